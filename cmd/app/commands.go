@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	createServiceAdminCommand = "create-service-admin"
+)
+
+type userDBCommand interface {
+	GetUserByEmail(ctx context.Context, email string) (*core.UserDB, error)
+}
+
+type credentialsDBCommand interface {
+	AddServiceAdmin(ctx context.Context, userId int) error
+}
+
+// runCommand runs maintenance command instead of http server, e.g.
+// "app create-service-admin -email admin@example.com"
+func runCommand(ctx context.Context, args []string, userDB userDBCommand, credentialsDB credentialsDBCommand) error {
+	switch args[0] {
+	case createServiceAdminCommand:
+		return createServiceAdmin(ctx, args[1:], userDB, credentialsDB)
+	default:
+		return fmt.Errorf("unknown command %q, available commands: %s", args[0], createServiceAdminCommand)
+	}
+}
+
+// createServiceAdmin gives service_admin credential to registered user, it is used for creating first service admin
+func createServiceAdmin(ctx context.Context, args []string, userDB userDBCommand, credentialsDB credentialsDBCommand) error {
+	logBase := logrus.Fields{
+		"module":   "main",
+		"file":     "commands",
+		"function": "createServiceAdmin",
+	}
+
+	flags := flag.NewFlagSet(createServiceAdminCommand, flag.ContinueOnError)
+	email := flags.String("email", "", "email of registered user which becomes service admin")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *email == "" {
+		return errors.New("email is required")
+	}
+
+	userData, err := userDB.GetUserByEmail(ctx, *email)
+	if err != nil {
+		return err
+	}
+
+	err = credentialsDB.AddServiceAdmin(ctx, userData.Id)
+	if err == moduleErrors.ErrorDatabaseCredentialAlreadyExists {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"userId": userData.Id,
+		}).Warning("user is already service admin")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"base":   logBase,
+		"userId": userData.Id,
+		"email":  *email,
+	}).Info("service admin created")
+
+	return nil
+}
@@ -2,16 +2,24 @@ package main
 
 import (
 	"context"
+	"github.com/Thing-repository/backend-server/internal/jobs"
 	"github.com/Thing-repository/backend-server/internal/service"
+	"github.com/Thing-repository/backend-server/internal/storage/cache"
+	"github.com/Thing-repository/backend-server/internal/storage/files"
 	"github.com/Thing-repository/backend-server/internal/storage/postgres"
 	"github.com/Thing-repository/backend-server/internal/transport/rest"
 	restHandler "github.com/Thing-repository/backend-server/internal/transport/rest/handler"
+	"github.com/Thing-repository/backend-server/pkg/core"
 	"github.com/Thing-repository/backend-server/pkg/generateToken"
+	"github.com/Thing-repository/backend-server/pkg/mailer"
+	"github.com/Thing-repository/backend-server/pkg/notify"
 	"github.com/Thing-repository/backend-server/pkg/userHash"
+	"github.com/Thing-repository/backend-server/pkg/webhook"
 	"github.com/joho/godotenv"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"os"
+	"time"
 )
 
 // @title Thing Repository API
@@ -34,8 +42,50 @@ var httpPort string
 // token data
 var tokenSecret string
 
+// credentials data
+var credentialsSource string
+var credentialsCacheTTL time.Duration
+
 // hash data
 var salt string
+var hashAlgorithm string
+var bcryptCost int
+
+// mail data
+var smtpCfg mailer.SMTPConfig
+var mailDir string
+var verifyEmailURL string
+var resetPasswordURL string
+var signUpURL string
+var requireVerifiedEmail bool
+
+// images data
+const (
+	defaultImagesLocalDir  = "data/images"
+	defaultImagesPublicURL = "/api/v1/files"
+	defaultImagesMaxSize   = 10 << 20
+)
+
+var imagesStorage string
+var imagesLocalDir string
+var imagesPublicURL string
+var imagesMaxSize int
+var s3Cfg files.S3Config
+
+// jobs data
+const (
+	defaultStockAlertsInterval = 10 * time.Minute
+	defaultUsagesInterval      = time.Minute
+	defaultBlocksInterval      = time.Minute
+	defaultTakeGracePeriod     = time.Hour
+	defaultWebhooksInterval    = 30 * time.Second
+)
+
+var stockAlertsInterval time.Duration
+var usagesInterval time.Duration
+var blocksInterval time.Duration
+var takeGracePeriod time.Duration
+var webhooksInterval time.Duration
 
 // db data
 var postgresCfg postgres.Config
@@ -70,19 +120,89 @@ func main() {
 	companyDb := postgres.NewCompanyDB(postgresDb, transaction)
 	departmentDB := postgres.NewDepartmentDB(postgresDb, transaction)
 	credentialsDB := postgres.NewCredentialsDB(postgresDb, transaction)
+	thingDB := postgres.NewThingDB(postgresDb, transaction)
+	thingUsageDB := postgres.NewThingUsageDB(postgresDb, transaction)
+	thingBlockDB := postgres.NewThingBlockDB(postgresDb, transaction)
+	sessionDB := postgres.NewSessionDB(postgresDb, transaction)
+	passwordResetDB := postgres.NewPasswordResetDB(postgresDb, transaction)
+	invitationDB := postgres.NewInvitationDB(postgresDb, transaction)
+	joinRequestDB := postgres.NewJoinRequestDB(postgresDb, transaction)
+	vacationDB := postgres.NewVacationDB(postgresDb, transaction)
+	stockDB := postgres.NewStockDB(postgresDb, transaction)
+	notificationDB := postgres.NewNotificationDB(postgresDb, transaction)
+	webhookDB := postgres.NewWebhookDB(postgresDb, transaction)
+	jobDB := postgres.NewJobDB(transaction)
+
+	if len(os.Args) > 1 {
+		if err := runCommand(ctx, os.Args[1:], userDb, credentialsDB); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"args":  os.Args[1:],
+				"error": err,
+			}).Fatal("error run command")
+		}
+		return
+	}
+
+	// services change credentials through cache, in database source mode middleware reads credentials from it
+	credentialsCache := cache.NewCredentials(credentialsDB, transaction, credentialsCacheTTL)
 
 	// helper modules
-	tokenGenerator := generateToken.NewToken([]byte(tokenSecret))
-	hashGenerator := userHash.NewHash(salt)
+	tokenGenerator := generateToken.NewToken([]byte(tokenSecret), credentialsSource == core.CredentialsSourceToken)
+	hashGenerator := newPasswordHash()
+	mailSender := newMailer()
+	fileStorage := newFileStorage()
 
 	// service modules
-	authService := service.NewAuth(tokenGenerator, userDb, hashGenerator, credentialsDB, transaction)
-	companyService := service.NewCompany(userDb, companyDb, departmentDB, credentialsDB, transaction)
-	userService := service.NewUser(userDb, departmentDB, credentialsDB, transaction)
-
-	httpHandler := restHandler.NewHandler(authService, companyService, tokenGenerator, userService, userDb)
+	notificationService := service.NewNotification(notificationDB, userDb, transaction, notify.NewEmail(mailSender),
+		notify.NewWebhook())
+	webhookService := service.NewWebhook(webhookDB, webhook.NewSender(), transaction)
+	invitationService := service.NewInvitation(invitationDB, userDb, departmentDB, companyDb, credentialsCache,
+		tokenGenerator, mailSender, notificationService, webhookService, transaction, signUpURL)
+	emailVerificationService := service.NewEmailVerification(userDb, tokenGenerator, mailSender, invitationService,
+		transaction, verifyEmailURL)
+	authService := service.NewAuth(tokenGenerator, userDb, hashGenerator, credentialsCache, sessionDB,
+		emailVerificationService, invitationService, transaction)
+	passwordService := service.NewPassword(userDb, passwordResetDB, sessionDB, tokenGenerator, hashGenerator,
+		mailSender, transaction, resetPasswordURL)
+	companyService := service.NewCompany(userDb, companyDb, departmentDB, credentialsCache, transaction,
+		requireVerifiedEmail)
+	departmentService := service.NewDepartment(departmentDB, credentialsCache, transaction)
+	credentialsService := service.NewCredentials(credentialsCache, departmentDB, userDb, transaction)
+	userService := service.NewUser(userDb, departmentDB, credentialsCache, thingUsageDB, webhookService, transaction)
+	profileService := service.NewProfile(userDb, credentialsCache, thingUsageDB, emailVerificationService, transaction)
+	joinRequestService := service.NewJoinRequest(joinRequestDB, userDb, departmentDB, credentialsCache, webhookService,
+		transaction)
+	thingService := service.NewThing(thingDB, departmentDB, userDb, stockDB, transaction)
+	thingUsageService := service.NewThingUsage(thingUsageDB, thingDB, thingBlockDB, departmentDB, vacationDB,
+		stockDB, notificationService, webhookService, transaction)
+	thingBlockService := service.NewThingBlock(thingBlockDB, thingDB, thingUsageDB, departmentDB, notificationService,
+		webhookService, transaction)
+	stockService := service.NewStock(stockDB, thingDB, transaction)
+	stockAlertService := service.NewStockAlert(stockDB, thingDB, departmentDB)
+	vacationService := service.NewVacation(vacationDB, departmentDB, transaction)
+	adminService := service.NewAdmin(userDb, credentialsCache, sessionDB, transaction)
+	imageService := service.NewImage(fileStorage, companyDb, departmentDB, thingDB, userDb, imagesPublicURL,
+		imagesMaxSize)
+
+	httpHandler := restHandler.NewHandler(authService, emailVerificationService, passwordService, companyService,
+		departmentService, credentialsService, tokenGenerator, userService, profileService,
+		invitationService, joinRequestService, thingService, thingUsageService, thingBlockService, stockService,
+		stockAlertService, vacationService, notificationService, webhookService, adminService, imageService, userDb,
+		credentialsCache, credentialsSource)
 	httpServer := rest.NewHttpServer()
 
+	jobRunner := jobs.NewRunner(jobDB, transaction,
+		jobs.Job{Name: "stock_alerts", Interval: stockAlertsInterval, Run: stockAlertService.EvaluateStock},
+		jobs.Job{Name: "overdue_usages", Interval: usagesInterval, Run: thingUsageService.MarkOverdueUsages},
+		jobs.Job{Name: "not_taken_usages", Interval: usagesInterval, Run: func(ctx context.Context) error {
+			return thingUsageService.CancelNotTakenUsages(ctx, takeGracePeriod)
+		}},
+		jobs.Job{Name: "expired_blocks", Interval: blocksInterval, Run: thingBlockService.ExpireBlocks},
+		jobs.Job{Name: "webhook_deliveries", Interval: webhooksInterval, Run: webhookService.ProcessDeliveries},
+	)
+	go jobRunner.Run(ctx)
+
 	if err := httpServer.Run(httpPort, httpHandler.InitRoutes()); err != nil {
 		logrus.WithFields(logrus.Fields{
 			"base":  logBase,
@@ -162,14 +282,18 @@ func initEnv() {
 		}).Warning("no salt, use value from env, for add use cmd")
 		salt = os.Getenv("THINGS_REPOSITORY_HASH_SALT")
 		if salt == "" {
+			// salt is used only for validation of legacy sha1 hashes
 			logrus.WithFields(logrus.Fields{
 				"base":         logBase,
-				"build_cmd":    "-X main.postgresPassword=(postgres password)",
+				"build_cmd":    "-X main.salt=(salt)",
 				"env variable": "THINGS_REPOSITORY_HASH_SALT",
-			}).Fatal("no salt, for add use cmd or env")
+			}).Warning("no salt, legacy password hashes can't be validated")
 		}
 	}
 
+	smtpCfg.Password = os.Getenv("THINGS_REPOSITORY_SMTP_PASSWORD")
+	s3Cfg.SecretKey = os.Getenv("THINGS_REPOSITORY_S3_SECRET_KEY")
+
 	httpPort = os.Getenv("THINGS_REPOSITORY_HTTP_PORT")
 	if httpPort == "" {
 		logrus.WithFields(logrus.Fields{
@@ -296,4 +420,147 @@ func initConfig() {
 			"postgresUsername": postgresCfg.Username,
 		}).Warning("use postgres username from config")
 	}
+
+	credentialsSource = viper.GetString("auth.credentials_source")
+	switch credentialsSource {
+	case "":
+		credentialsSource = core.CredentialsSourceToken
+	case core.CredentialsSourceToken, core.CredentialsSourceDatabase:
+	default:
+		logrus.WithFields(logrus.Fields{
+			"base":        logBase,
+			"config name": "auth.credentials_source",
+			"source":      credentialsSource,
+		}).Fatal("unknown credentials source, use token or database")
+	}
+	credentialsCacheTTL = viper.GetDuration("auth.credentials_cache_ttl")
+
+	hashAlgorithm = viper.GetString("password_hash.algorithm")
+	bcryptCost = viper.GetInt("password_hash.bcrypt_cost")
+
+	verifyEmailURL = viper.GetString("email.verify_url")
+	resetPasswordURL = viper.GetString("email.reset_password_url")
+	signUpURL = viper.GetString("email.sign_up_url")
+	requireVerifiedEmail = viper.GetBool("email.require_verified_for_company")
+	mailDir = viper.GetString("email.dir")
+	smtpCfg.Host = viper.GetString("email.smtp.host")
+	smtpCfg.Port = viper.GetString("email.smtp.port")
+	smtpCfg.Username = viper.GetString("email.smtp.username")
+	smtpCfg.From = viper.GetString("email.smtp.from")
+
+	imagesStorage = viper.GetString("images.storage")
+	imagesLocalDir = viper.GetString("images.local_dir")
+	if imagesLocalDir == "" {
+		imagesLocalDir = defaultImagesLocalDir
+	}
+	imagesPublicURL = viper.GetString("images.public_url")
+	if imagesPublicURL == "" {
+		imagesPublicURL = defaultImagesPublicURL
+	}
+	imagesMaxSize = viper.GetInt("images.max_size")
+	if imagesMaxSize <= 0 {
+		imagesMaxSize = defaultImagesMaxSize
+	}
+	s3Cfg.Endpoint = viper.GetString("images.s3.endpoint")
+	s3Cfg.Region = viper.GetString("images.s3.region")
+	s3Cfg.Bucket = viper.GetString("images.s3.bucket")
+	s3Cfg.AccessKey = viper.GetString("images.s3.access_key")
+
+	stockAlertsInterval = viper.GetDuration("jobs.stock_alerts_interval")
+	if stockAlertsInterval <= 0 {
+		stockAlertsInterval = defaultStockAlertsInterval
+	}
+	usagesInterval = viper.GetDuration("jobs.usages_interval")
+	if usagesInterval <= 0 {
+		usagesInterval = defaultUsagesInterval
+	}
+	blocksInterval = viper.GetDuration("jobs.blocks_interval")
+	if blocksInterval <= 0 {
+		blocksInterval = defaultBlocksInterval
+	}
+	takeGracePeriod = viper.GetDuration("jobs.take_grace_period")
+	if takeGracePeriod <= 0 {
+		takeGracePeriod = defaultTakeGracePeriod
+	}
+	webhooksInterval = viper.GetDuration("jobs.webhooks_interval")
+	if webhooksInterval <= 0 {
+		webhooksInterval = defaultWebhooksInterval
+	}
+}
+
+func newPasswordHash() *userHash.Hash {
+	logBase := logrus.Fields{
+		"module":   "main",
+		"file":     "main",
+		"function": "newPasswordHash",
+	}
+
+	argon2id := userHash.NewArgon2id(userHash.DefaultArgon2idParams)
+	bcrypt := userHash.NewBcrypt(bcryptCost)
+
+	var current, other userHash.Hasher
+	switch hashAlgorithm {
+	case "", "argon2id":
+		current, other = argon2id, bcrypt
+	case "bcrypt":
+		current, other = bcrypt, argon2id
+	default:
+		logrus.WithFields(logrus.Fields{
+			"base":        logBase,
+			"config name": "password_hash.algorithm",
+			"algorithm":   hashAlgorithm,
+		}).Fatal("unknown password hash algorithm, use argon2id or bcrypt")
+	}
+
+	if salt == "" {
+		return userHash.NewHash(current, other)
+	}
+	return userHash.NewHash(current, other, userHash.NewLegacySHA1(salt))
+}
+
+// newMailer returns smtp mailer if smtp host is set in config, else emails are written to log and mail dir
+func newMailer() mailer.Mailer {
+	if smtpCfg.Host == "" {
+		logrus.WithFields(logrus.Fields{
+			"module":   "main",
+			"file":     "main",
+			"function": "newMailer",
+			"mailDir":  mailDir,
+		}).Warning("no smtp host in config, emails will be written to log")
+		return mailer.NewLog(mailDir)
+	}
+	return mailer.NewSMTP(smtpCfg)
+}
+
+// newFileStorage returns storage of uploaded images, local directory is used by default
+func newFileStorage() files.Storage {
+	logBase := logrus.Fields{
+		"module":   "main",
+		"file":     "main",
+		"function": "newFileStorage",
+	}
+
+	var storage files.Storage
+	var err error
+	switch imagesStorage {
+	case "", "local":
+		storage, err = files.NewLocal(imagesLocalDir)
+	case "s3":
+		storage, err = files.NewS3(s3Cfg)
+	default:
+		logrus.WithFields(logrus.Fields{
+			"base":        logBase,
+			"config name": "images.storage",
+			"storage":     imagesStorage,
+		}).Fatal("unknown images storage, use local or s3")
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":    logBase,
+			"storage": imagesStorage,
+			"error":   err,
+		}).Fatal("error create images storage")
+	}
+
+	return storage
 }
@@ -0,0 +1,108 @@
+package jobs
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+type jobDB interface {
+	ClaimJobRun(ctx context.Context, jobName string, minInterval time.Duration) (bool, error)
+}
+
+type transactionDB interface {
+	InjectTx(ctx context.Context) (context.Context, error)
+	CommitTx(ctx context.Context) error
+	RollbackTx(ctx context.Context) error
+	RollbackTxDefer(ctx context.Context)
+}
+
+// Job is periodic background task, it runs on start and then once per interval
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Runner runs background jobs until context is done. Every replica of server runs the same jobs, job is run
+// in transaction under database lock, so one run of job per interval is done by only one replica.
+type Runner struct {
+	jobDB         jobDB
+	transactionDB transactionDB
+	jobs          []Job
+}
+
+func NewRunner(jobDB jobDB, transactionDB transactionDB, jobs ...Job) *Runner {
+	return &Runner{
+		jobDB:         jobDB,
+		transactionDB: transactionDB,
+		jobs:          jobs,
+	}
+}
+
+// Run starts all jobs and blocks until context is done and running jobs are finished
+func (R *Runner) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for _, job := range R.jobs {
+		wg.Add(1)
+		go func(job Job) {
+			defer wg.Done()
+			R.runJob(ctx, job)
+		}(job)
+	}
+
+	wg.Wait()
+}
+
+func (R *Runner) runJob(ctx context.Context, job Job) {
+	logBase := logrus.Fields{
+		"module":   "jobs",
+		"function": "runJob",
+		"job":      job.Name,
+	}
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := R.runOnce(ctx, job); err != nil && ctx.Err() == nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Error("job error")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce runs job if it isn't run by other replica and wasn't run recently. Half of interval is allowed
+// between runs, so ticks of replicas which are slightly late don't skip the run.
+func (R *Runner) runOnce(ctx context.Context, job Job) error {
+	ctx, err := R.transactionDB.InjectTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer R.transactionDB.RollbackTxDefer(ctx)
+
+	claimed, err := R.jobDB.ClaimJobRun(ctx, job.Name, job.Interval/2)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	if err = job.Run(ctx); err != nil {
+		return err
+	}
+
+	return R.transactionDB.CommitTx(ctx)
+}
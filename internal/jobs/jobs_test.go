@@ -0,0 +1,174 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type txKey struct{}
+
+// fakeDB imitates advisory lock and job runs table shared by replicas
+type fakeDB struct {
+	mu      sync.Mutex
+	locked  map[string]bool
+	lastRun map[string]time.Time
+}
+
+type fakeTx struct {
+	jobName string
+	lastRun time.Time
+	claimed bool
+}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{locked: map[string]bool{}, lastRun: map[string]time.Time{}}
+}
+
+func (F *fakeDB) InjectTx(ctx context.Context) (context.Context, error) {
+	return context.WithValue(ctx, txKey{}, &fakeTx{}), nil
+}
+
+func (F *fakeDB) ClaimJobRun(ctx context.Context, jobName string, minInterval time.Duration) (bool, error) {
+	F.mu.Lock()
+	defer F.mu.Unlock()
+
+	if F.locked[jobName] {
+		return false, nil
+	}
+	tx := ctx.Value(txKey{}).(*fakeTx)
+	tx.jobName = jobName
+	F.locked[jobName] = true
+
+	if time.Since(F.lastRun[jobName]) < minInterval {
+		return false, nil
+	}
+	tx.lastRun, tx.claimed = F.lastRun[jobName], true
+	F.lastRun[jobName] = time.Now()
+	return true, nil
+}
+
+func (F *fakeDB) CommitTx(ctx context.Context) error {
+	F.mu.Lock()
+	defer F.mu.Unlock()
+
+	tx := ctx.Value(txKey{}).(*fakeTx)
+	delete(F.locked, tx.jobName)
+	tx.jobName, tx.claimed = "", false
+	return nil
+}
+
+func (F *fakeDB) RollbackTx(ctx context.Context) error {
+	F.mu.Lock()
+	defer F.mu.Unlock()
+
+	tx := ctx.Value(txKey{}).(*fakeTx)
+	if tx.jobName == "" {
+		return nil
+	}
+	// run isn't recorded if job failed
+	if tx.claimed {
+		F.lastRun[tx.jobName] = tx.lastRun
+	}
+	delete(F.locked, tx.jobName)
+	tx.jobName, tx.claimed = "", false
+	return nil
+}
+
+func (F *fakeDB) RollbackTxDefer(ctx context.Context) {
+	_ = F.RollbackTx(ctx)
+}
+
+func runFor(t *testing.T, duration time.Duration, runners ...*Runner) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var wg sync.WaitGroup
+	for _, runner := range runners {
+		wg.Add(1)
+		go func(runner *Runner) {
+			defer wg.Done()
+			runner.Run(ctx)
+		}(runner)
+	}
+
+	time.Sleep(duration)
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("runner didn't stop after context cancel")
+	}
+}
+
+func TestRunner(t *testing.T) {
+	var okRuns, failedRuns int32
+	db := newFakeDB()
+	runner := NewRunner(db, db,
+		Job{
+			Name:     "ok",
+			Interval: 10 * time.Millisecond,
+			Run: func(ctx context.Context) error {
+				atomic.AddInt32(&okRuns, 1)
+				return nil
+			},
+		},
+		Job{
+			Name:     "failed",
+			Interval: 10 * time.Millisecond,
+			Run: func(ctx context.Context) error {
+				atomic.AddInt32(&failedRuns, 1)
+				return errors.New("job error")
+			},
+		},
+	)
+
+	runFor(t, 100*time.Millisecond, runner)
+
+	// failed job must be retried on next interval
+	if atomic.LoadInt32(&okRuns) < 2 || atomic.LoadInt32(&failedRuns) < 2 {
+		t.Errorf("runs = %d, %d, want at least 2 runs of each job", okRuns, failedRuns)
+	}
+}
+
+func TestRunnerReplicas(t *testing.T) {
+	var runs, running, concurrent int32
+	job := Job{
+		Name:     "job",
+		Interval: 40 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			if atomic.AddInt32(&running, 1) > 1 {
+				atomic.StoreInt32(&concurrent, 1)
+			}
+			atomic.AddInt32(&runs, 1)
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return nil
+		},
+	}
+
+	db := newFakeDB()
+	replicas := make([]*Runner, 0, 3)
+	for i := 0; i < 3; i++ {
+		replicas = append(replicas, NewRunner(db, db, job))
+	}
+
+	runFor(t, 190*time.Millisecond, replicas...)
+
+	if atomic.LoadInt32(&concurrent) != 0 {
+		t.Error("job was run by several replicas at once")
+	}
+	// ticks at 0, 40, 80, 120, 160 ms, each interval is run once whatever number of replicas
+	if got := atomic.LoadInt32(&runs); got < 3 || got > 6 {
+		t.Errorf("runs = %d, want one run per interval", got)
+	}
+}
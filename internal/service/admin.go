@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/authz"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=admin.go -destination=mock/adminMock.go
+type userDBAdmin interface {
+	GetUser(ctx context.Context, userId int) (*core.UserDB, error)
+	SetUserLocked(ctx context.Context, userId int, locked bool) error
+}
+
+type credentialsDBAdmin interface {
+	EnsureCredential(ctx context.Context, credentials *core.AddCredentials) error
+	GetCredentialUsers(ctx context.Context, credentialType string, objectId int) ([]core.User, error)
+	DeleteCredential(ctx context.Context, credentials *core.AddCredentials) error
+}
+
+type sessionDBAdmin interface {
+	RevokeUserSessions(ctx context.Context, userId int) error
+}
+
+type transactionDBAdmin interface {
+	InjectTx(ctx context.Context) (context.Context, error)
+	CommitTx(ctx context.Context) error
+	RollbackTx(ctx context.Context) error
+	RollbackTxDefer(ctx context.Context)
+}
+
+// Admin is service of platform level actions, which are allowed only for service admins
+type Admin struct {
+	userDB        userDBAdmin
+	credentialsDB credentialsDBAdmin
+	sessionDB     sessionDBAdmin
+	transactionDB transactionDBAdmin
+}
+
+func NewAdmin(userDB userDBAdmin, credentialsDB credentialsDBAdmin, sessionDB sessionDBAdmin,
+	transactionDB transactionDBAdmin) *Admin {
+	return &Admin{
+		userDB:        userDB,
+		credentialsDB: credentialsDB,
+		sessionDB:     sessionDB,
+		transactionDB: transactionDB,
+	}
+}
+
+// LockUser forbids sign in of user and revokes all user sessions
+func (A *Admin) LockUser(ctx context.Context, userId int) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "LockUser",
+		"userId":   userId,
+		"context":  *core.LogContext(ctx),
+	}
+
+	if err := authz.Authorize(ctx, authz.ActionLock, authz.User()); err != nil {
+		return err
+	}
+
+	currentUserId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return moduleErrors.ErrorServiceInvalidContext
+	}
+
+	if currentUserId == userId {
+		return moduleErrors.ErrorServiceSelfLock
+	}
+
+	ctx, err = A.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return err
+	}
+
+	defer A.transactionDB.RollbackTxDefer(ctx)
+
+	if err = A.setUserLocked(ctx, userId, true); err != nil {
+		return err
+	}
+
+	if err = A.sessionDB.RevokeUserSessions(ctx, userId); err != nil {
+		return err
+	}
+
+	if err = A.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"base": logBase,
+	}).Info("user locked")
+
+	return nil
+}
+
+func (A *Admin) UnlockUser(ctx context.Context, userId int) error {
+	if err := authz.Authorize(ctx, authz.ActionLock, authz.User()); err != nil {
+		return err
+	}
+
+	return A.setUserLocked(ctx, userId, false)
+}
+
+func (A *Admin) setUserLocked(ctx context.Context, userId int, locked bool) error {
+	err := A.userDB.SetUserLocked(ctx, userId, locked)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"module":   "service",
+			"function": "setUserLocked",
+			"userId":   userId,
+			"locked":   locked,
+			"error":    err.Error(),
+		}).Error("error set user lock")
+		if err == moduleErrors.ErrorDatabaseUserNotFound {
+			return moduleErrors.ErrorServiceUserNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// TransferCompany makes user the only admin of company, user must be a member of company
+func (A *Admin) TransferCompany(ctx context.Context, companyId int, userId int) error {
+	logBase := logrus.Fields{
+		"module":    "service",
+		"function":  "TransferCompany",
+		"companyId": companyId,
+		"userId":    userId,
+		"context":   *core.LogContext(ctx),
+	}
+
+	if err := authz.Authorize(ctx, authz.ActionTransferOwnership, authz.Company(companyId)); err != nil {
+		return err
+	}
+
+	ctx, err := A.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return err
+	}
+
+	defer A.transactionDB.RollbackTxDefer(ctx)
+
+	userData, err := A.userDB.GetUser(ctx, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user data")
+		if err == moduleErrors.ErrorDatabaseUserNotFound {
+			return moduleErrors.ErrorServiceUserNotFound
+		}
+		return moduleErrors.ErrorServiceGetUserData
+	}
+
+	if userData.CompanyId == nil || *userData.CompanyId != companyId {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error(moduleErrors.ErrorServiceUserNotInCompany.Error())
+		return moduleErrors.ErrorServiceUserNotInCompany
+	}
+
+	admins, err := A.credentialsDB.GetCredentialUsers(ctx, core.CredentialTypeCompanyAdmin, companyId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get company admins")
+		return err
+	}
+
+	for _, admin := range admins {
+		if admin.Id == userId {
+			continue
+		}
+		err = A.credentialsDB.DeleteCredential(ctx, newCredential(companyId, admin.Id, core.CredentialTypeCompanyAdmin))
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":    logBase,
+				"adminId": admin.Id,
+				"error":   err.Error(),
+			}).Error("error delete company admin credential")
+			return err
+		}
+	}
+
+	// new owner is often company admin already
+	err = A.credentialsDB.EnsureCredential(ctx, newCredential(companyId, userId, core.CredentialTypeCompanyAdmin))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error add company admin credential")
+		return err
+	}
+
+	if err = A.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"base": logBase,
+	}).Info("company transferred")
+
+	return nil
+}
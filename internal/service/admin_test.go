@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	mockService "github.com/Thing-repository/backend-server/internal/service/mock"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/golang/mock/gomock"
+	"github.com/openlyinc/pointy"
+	"testing"
+)
+
+const (
+	testAdminCompanyId = 1
+	testAdminOwnerId   = 2
+	testAdminUserId    = 3
+)
+
+type adminMocks struct {
+	userDB        *mockService.MockuserDBAdmin
+	credentialsDB *mockService.MockcredentialsDBAdmin
+	sessionDB     *mockService.MocksessionDBAdmin
+	transactionDB *mockService.MocktransactionDBAdmin
+}
+
+func newTestAdmin(c *gomock.Controller) (*Admin, *adminMocks) {
+	m := &adminMocks{
+		userDB:        mockService.NewMockuserDBAdmin(c),
+		credentialsDB: mockService.NewMockcredentialsDBAdmin(c),
+		sessionDB:     mockService.NewMocksessionDBAdmin(c),
+		transactionDB: mockService.NewMocktransactionDBAdmin(c),
+	}
+
+	m.transactionDB.EXPECT().InjectTx(gomock.Any()).DoAndReturn(
+		func(ctx context.Context) (context.Context, error) { return ctx, nil }).AnyTimes()
+	m.transactionDB.EXPECT().RollbackTxDefer(gomock.Any()).AnyTimes()
+	m.transactionDB.EXPECT().CommitTx(gomock.Any()).Return(nil).AnyTimes()
+
+	return NewAdmin(m.userDB, m.credentialsDB, m.sessionDB, m.transactionDB), m
+}
+
+func TestTransferCompany(t *testing.T) {
+	type mockBehavior func(m *adminMocks)
+
+	testTable := []struct {
+		name         string
+		mockBehavior mockBehavior
+		wantError    error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(m *adminMocks) {
+				m.userDB.EXPECT().GetUser(gomock.Any(), testAdminUserId).Return(&core.UserDB{User: core.User{
+					Id: testAdminUserId, CompanyId: pointy.Int(testAdminCompanyId)}}, nil)
+				m.credentialsDB.EXPECT().GetCredentialUsers(gomock.Any(), core.CredentialTypeCompanyAdmin,
+					testAdminCompanyId).Return([]core.User{{Id: testAdminOwnerId}}, nil)
+				m.credentialsDB.EXPECT().DeleteCredential(gomock.Any(), newCredential(testAdminCompanyId,
+					testAdminOwnerId, core.CredentialTypeCompanyAdmin)).Return(nil)
+				m.credentialsDB.EXPECT().EnsureCredential(gomock.Any(), newCredential(testAdminCompanyId,
+					testAdminUserId, core.CredentialTypeCompanyAdmin)).Return(nil)
+			},
+		},
+		{
+			// credential of new owner is kept, transfer doesn't fail on existing credential
+			name: "User is company admin already",
+			mockBehavior: func(m *adminMocks) {
+				m.userDB.EXPECT().GetUser(gomock.Any(), testAdminUserId).Return(&core.UserDB{User: core.User{
+					Id: testAdminUserId, CompanyId: pointy.Int(testAdminCompanyId)}}, nil)
+				m.credentialsDB.EXPECT().GetCredentialUsers(gomock.Any(), core.CredentialTypeCompanyAdmin,
+					testAdminCompanyId).Return([]core.User{{Id: testAdminOwnerId}, {Id: testAdminUserId}}, nil)
+				m.credentialsDB.EXPECT().DeleteCredential(gomock.Any(), newCredential(testAdminCompanyId,
+					testAdminOwnerId, core.CredentialTypeCompanyAdmin)).Return(nil)
+				m.credentialsDB.EXPECT().EnsureCredential(gomock.Any(), newCredential(testAdminCompanyId,
+					testAdminUserId, core.CredentialTypeCompanyAdmin)).Return(nil)
+			},
+		},
+		{
+			name: "User from other company",
+			mockBehavior: func(m *adminMocks) {
+				m.userDB.EXPECT().GetUser(gomock.Any(), testAdminUserId).Return(&core.UserDB{User: core.User{
+					Id: testAdminUserId, CompanyId: pointy.Int(testAdminCompanyId + 1)}}, nil)
+			},
+			wantError: moduleErrors.ErrorServiceUserNotInCompany,
+		},
+	}
+
+	ctx := core.ContextWithUser(context.Background(), 1, []core.Credentials{
+		{CredentialType: core.CredentialTypeServiceAdmin},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service, m := newTestAdmin(c)
+			testCase.mockBehavior(m)
+
+			if err := service.TransferCompany(ctx, testAdminCompanyId, testAdminUserId); err != testCase.wantError {
+				t.Errorf("error = %v, want %v", err, testCase.wantError)
+			}
+		})
+	}
+}
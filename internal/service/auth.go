@@ -5,17 +5,23 @@ import (
 	"github.com/Thing-repository/backend-server/pkg/core"
 	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
 	"github.com/sirupsen/logrus"
+	"time"
 )
 
+const refreshTokenTTL = time.Hour * 24 * 30
+
 //go:generate mockgen -source=auth.go -destination=mock/authMock.go
 type token interface {
 	GenerateToken(userId int, credentials []core.Credentials) (string, error)
+	GenerateRandomToken() (string, error)
+	HashRandomToken(token string) string
 }
 
 //go:generate mockgen -source=auth.go -destination=mock/authMock.go
 type hash interface {
 	GenerateHash(password string) (string, error)
 	ValidateHash(hash string, password string) error
+	NeedsRehash(hash string) bool
 }
 
 //go:generate mockgen -source=auth.go -destination=mock/authMock.go
@@ -23,6 +29,7 @@ type userDB interface {
 	GetUserByEmail(ctx context.Context, email string) (*core.UserDB, error)
 	GetUser(ctx context.Context, userId int) (*core.UserDB, error)
 	AddUser(ctx context.Context, user *core.AddUserDB) (*core.UserDB, error)
+	PathUser(ctx context.Context, user *core.UserDB, userId int) error
 }
 
 //go:generate mockgen -source=auth.go -destination=mock/authMock.go
@@ -30,6 +37,25 @@ type credentialDBAuth interface {
 	GetUserCredential(ctx context.Context, userId int) ([]core.Credentials, error)
 }
 
+//go:generate mockgen -source=auth.go -destination=mock/authMock.go
+type sessionDBAuth interface {
+	AddSession(ctx context.Context, session *core.SessionAdd) (*core.Session, error)
+	GetSessionForUpdate(ctx context.Context, refreshTokenHash string) (*core.Session, error)
+	SetSessionUsed(ctx context.Context, sessionId int) error
+	RevokeSessionFamily(ctx context.Context, familyId string) error
+	RevokeUserSessions(ctx context.Context, userId int) error
+}
+
+//go:generate mockgen -source=auth.go -destination=mock/authMock.go
+type emailVerificationAuth interface {
+	SendVerification(ctx context.Context, userId int) error
+}
+
+//go:generate mockgen -source=auth.go -destination=mock/authMock.go
+type invitationAuth interface {
+	AcceptTokenInvitation(ctx context.Context, userId int, email string, token string) (*core.Department, error)
+}
+
 type transactionDBAuth interface {
 	InjectTx(ctx context.Context) (context.Context, error)
 	CommitTx(ctx context.Context) error
@@ -38,20 +64,27 @@ type transactionDBAuth interface {
 }
 
 type AuthService struct {
-	token         token
-	db            userDB
-	hash          hash
-	credentialDB  credentialDBAuth
-	transactionDB transactionDBAuth
+	token             token
+	db                userDB
+	hash              hash
+	credentialDB      credentialDBAuth
+	sessionDB         sessionDBAuth
+	emailVerification emailVerificationAuth
+	invitation        invitationAuth
+	transactionDB     transactionDBAuth
 }
 
-func NewAuth(token token, db userDB, hash hash, credentialDB credentialDBAuth, transactionDB transactionDBCompany) *AuthService {
+func NewAuth(token token, db userDB, hash hash, credentialDB credentialDBAuth, sessionDB sessionDBAuth,
+	emailVerification emailVerificationAuth, invitation invitationAuth, transactionDB transactionDBCompany) *AuthService {
 	return &AuthService{
-		token:         token,
-		db:            db,
-		hash:          hash,
-		credentialDB:  credentialDB,
-		transactionDB: transactionDB,
+		token:             token,
+		db:                db,
+		hash:              hash,
+		credentialDB:      credentialDB,
+		sessionDB:         sessionDB,
+		emailVerification: emailVerification,
+		invitation:        invitation,
+		transactionDB:     transactionDB,
 	}
 }
 
@@ -95,18 +128,33 @@ func (a *AuthService) SignIn(authData *core.UserSignInData) (*core.SignInRespons
 		}
 	}
 
-	//generate token
-	token, err := a.generateTokenForUser(ctx, userData.Id)
+	if userData.LockedTime != 0 {
+		logrus.WithFields(logrus.Fields{
+			"base":       logBase,
+			"lockedTime": userData.LockedTime,
+		}).Warning(moduleErrors.ErrorServiceUserLocked.Error())
+		return nil, moduleErrors.ErrorServiceUserLocked
+	}
+
+	// hashes of legacy algorithm or with outdated params are replaced after successful sign in
+	if a.hash.NeedsRehash(*userData.PasswordHash) {
+		a.rehashPassword(ctx, userData.Id, authData.UserPassword)
+	}
+
+	//generate tokens of new session
+	tokens, err := a.issueTokens(ctx, userData.Id, nil)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"base":  logBase,
 			"error": err.Error(),
 		}).Error("error generate token")
+		return nil, err
 	}
 
 	return &core.SignInResponse{
-		User:  userData.User,
-		Token: token,
+		User:         userData.User,
+		Token:        tokens.Token,
+		RefreshToken: tokens.RefreshToken,
 	}, nil
 }
 
@@ -163,17 +211,37 @@ func (a *AuthService) SignUp(authData *core.UserSignUpData) (*core.SignInRespons
 		}
 	}
 
-	token, err := a.generateTokenForUser(ctx, userData.Id)
+	// user signed up by link from invitation email joins company at once, so credentials get to token.
+	// Other invitations to email are accepted after its verification.
+	if authData.InvitationToken != "" {
+		departmentData, err := a.invitation.AcceptTokenInvitation(ctx, userData.Id, *authData.Email,
+			authData.InvitationToken)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Error("error accept invitation")
+			return nil, err
+		}
+		if departmentData != nil {
+			userData.CompanyId = departmentData.CompanyId
+			userData.DepartmentId = departmentData.Id
+		}
+	}
+
+	tokens, err := a.issueTokens(ctx, userData.Id, nil)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"base":  logBase,
 			"error": err.Error(),
 		}).Error("error generate token")
+		return nil, err
 	}
 
 	response := core.SignInResponse{
-		User:  userData.User,
-		Token: token,
+		User:         userData.User,
+		Token:        tokens.Token,
+		RefreshToken: tokens.RefreshToken,
 	}
 
 	if err = a.transactionDB.CommitTx(ctx); err != nil {
@@ -184,9 +252,257 @@ func (a *AuthService) SignUp(authData *core.UserSignUpData) (*core.SignInRespons
 		return nil, err
 	}
 
+	// user is already registered, so failed email can be resent later
+	if err = a.emailVerification.SendVerification(context.TODO(), userData.Id); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"userId": userData.Id,
+			"error":  err.Error(),
+		}).Error("error send verification email")
+	}
+
 	return &response, nil
 }
 
+// Refresh rotates refresh token: it can be used only once and is replaced by new token of the same session family.
+// Reuse of rotated token means that token was stolen, so whole family is revoked.
+func (a *AuthService) Refresh(refreshToken string) (*core.TokenResponse, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "Refresh",
+	}
+
+	ctx, err := a.transactionDB.InjectTx(context.TODO())
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return nil, err
+	}
+
+	defer a.transactionDB.RollbackTxDefer(ctx)
+
+	session, err := a.getSession(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.UsedTime != 0 && session.RevokedTime == 0 {
+		logrus.WithFields(logrus.Fields{
+			"base":     logBase,
+			"userId":   session.UserId,
+			"familyId": session.FamilyId,
+		}).Warning("refresh token reused, revoke session family")
+
+		if err = a.sessionDB.RevokeSessionFamily(ctx, session.FamilyId); err != nil {
+			return nil, err
+		}
+		if err = a.transactionDB.CommitTx(ctx); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Error("error commit transaction")
+			return nil, err
+		}
+		return nil, moduleErrors.ErrorServiceRefreshTokenReused
+	}
+
+	if session.RevokedTime != 0 || session.ExpiresTime < uint32(time.Now().Unix()) {
+		logrus.WithFields(logrus.Fields{
+			"base":    logBase,
+			"session": session,
+		}).Warning("refresh token revoked or expired")
+		return nil, moduleErrors.ErrorServiceInvalidRefreshToken
+	}
+
+	// locked user can't prolong sessions which were opened before lock
+	userData, err := a.db.GetUser(ctx, session.UserId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"userId": session.UserId,
+			"error":  err.Error(),
+		}).Error("error get user data")
+		if err == moduleErrors.ErrorDatabaseUserNotFound {
+			return nil, moduleErrors.ErrorServiceInvalidRefreshToken
+		}
+		return nil, err
+	}
+	if userData.LockedTime != 0 {
+		logrus.WithFields(logrus.Fields{
+			"base":       logBase,
+			"userId":     session.UserId,
+			"lockedTime": userData.LockedTime,
+		}).Warning(moduleErrors.ErrorServiceUserLocked.Error())
+		return nil, moduleErrors.ErrorServiceUserLocked
+	}
+
+	if err = a.sessionDB.SetSessionUsed(ctx, session.Id); err != nil {
+		return nil, err
+	}
+
+	// new access token gets actual user credentials
+	tokens, err := a.issueTokens(ctx, session.UserId, &session.FamilyId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error generate token")
+		return nil, err
+	}
+
+	if err = a.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// SignOut revokes session of refresh token
+func (a *AuthService) SignOut(refreshToken string) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "SignOut",
+	}
+
+	ctx, err := a.transactionDB.InjectTx(context.TODO())
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return err
+	}
+
+	defer a.transactionDB.RollbackTxDefer(ctx)
+
+	session, err := a.getSession(ctx, refreshToken)
+	if err != nil {
+		return err
+	}
+
+	if err = a.sessionDB.RevokeSessionFamily(ctx, session.FamilyId); err != nil {
+		return err
+	}
+
+	if err = a.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// SignOutAll revokes all sessions of user from context
+func (a *AuthService) SignOutAll(ctx context.Context) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "SignOutAll",
+		"context":  *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return moduleErrors.ErrorServiceInvalidContext
+	}
+
+	return a.sessionDB.RevokeUserSessions(ctx, userId)
+}
+
+func (a *AuthService) getSession(ctx context.Context, refreshToken string) (*core.Session, error) {
+	session, err := a.sessionDB.GetSessionForUpdate(ctx, a.token.HashRandomToken(refreshToken))
+	if err != nil {
+		if err == moduleErrors.ErrorDatabaseSessionNotFound {
+			return nil, moduleErrors.ErrorServiceInvalidRefreshToken
+		}
+		return nil, err
+	}
+	return session, nil
+}
+
+// issueTokens generates access token and refresh token of session family,
+// new family is created if familyId is nil
+func (a *AuthService) issueTokens(ctx context.Context, userId int, familyId *string) (*core.TokenResponse, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "issueTokens",
+		"userId":   userId,
+	}
+
+	token, err := a.generateTokenForUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := a.token.GenerateRandomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = a.sessionDB.AddSession(ctx, &core.SessionAdd{
+		UserId:           userId,
+		FamilyId:         familyId,
+		RefreshTokenHash: a.token.HashRandomToken(refreshToken),
+		ExpiresTime:      uint32(time.Now().Add(refreshTokenTTL).Unix()),
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error add session")
+		return nil, err
+	}
+
+	return &core.TokenResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// rehashPassword saves password hash generated by current algorithm,
+// errors are only logged because user already signed in with old hash
+func (a *AuthService) rehashPassword(ctx context.Context, userId int, password string) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "rehashPassword",
+		"userId":   userId,
+	}
+
+	hash, err := a.hash.GenerateHash(password)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error generate password hash")
+		return
+	}
+
+	err = a.db.PathUser(ctx, &core.UserDB{PasswordHash: &hash}, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error save password hash")
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"base": logBase,
+	}).Info("password hash upgraded")
+}
+
 func (a *AuthService) generateTokenForUser(ctx context.Context, userId int) (string, error) {
 	logBase := logrus.Fields{
 		"module":   "service",
@@ -1,5 +1,237 @@
 package service
 
+import (
+	"context"
+	mockService "github.com/Thing-repository/backend-server/internal/service/mock"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/golang/mock/gomock"
+	"github.com/openlyinc/pointy"
+	"testing"
+	"time"
+)
+
+func TestRefresh(t *testing.T) {
+	type mockBehavior func(token *mockService.Mocktoken, userDB *mockService.MockuserDB,
+		credentialDB *mockService.MockcredentialDBAuth, sessionDB *mockService.MocksessionDBAuth)
+
+	const (
+		refreshToken    = "refresh_token"
+		newRefreshToken = "new_refresh_token"
+		familyId        = "family"
+		userId          = 1
+	)
+
+	expiresTime := uint32(time.Now().Add(time.Hour).Unix())
+	testSession := func() *core.Session {
+		return &core.Session{Id: 3, UserId: userId, FamilyId: familyId, ExpiresTime: expiresTime}
+	}
+
+	testTable := []struct {
+		name           string
+		mockBehavior   mockBehavior
+		outputResponse *core.TokenResponse
+		outputError    error
+	}{
+		{
+			name: "Rotation",
+			mockBehavior: func(token *mockService.Mocktoken, userDB *mockService.MockuserDB,
+				credentialDB *mockService.MockcredentialDBAuth, sessionDB *mockService.MocksessionDBAuth) {
+				token.EXPECT().HashRandomToken(refreshToken).Return("hash")
+				sessionDB.EXPECT().GetSessionForUpdate(gomock.Any(), "hash").Return(testSession(), nil)
+				userDB.EXPECT().GetUser(gomock.Any(), userId).Return(&core.UserDB{User: core.User{Id: userId}}, nil)
+				sessionDB.EXPECT().SetSessionUsed(gomock.Any(), 3).Return(nil)
+				credentialDB.EXPECT().GetUserCredential(gomock.Any(), userId).Return(nil, nil)
+				token.EXPECT().GenerateToken(userId, nil).Return("access_token", nil)
+				token.EXPECT().GenerateRandomToken().Return(newRefreshToken, nil)
+				token.EXPECT().HashRandomToken(newRefreshToken).Return("new_hash")
+				// new refresh token stays in family of used one
+				sessionDB.EXPECT().AddSession(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, session *core.SessionAdd) (*core.Session, error) {
+						if session.FamilyId == nil || *session.FamilyId != familyId ||
+							session.RefreshTokenHash != "new_hash" || session.UserId != userId {
+							t.Errorf("unexpected session %+v", session)
+						}
+						return &core.Session{Id: 4}, nil
+					})
+			},
+			outputResponse: &core.TokenResponse{Token: "access_token", RefreshToken: newRefreshToken},
+		},
+		{
+			name: "Reused token revokes family",
+			mockBehavior: func(token *mockService.Mocktoken, userDB *mockService.MockuserDB,
+				credentialDB *mockService.MockcredentialDBAuth, sessionDB *mockService.MocksessionDBAuth) {
+				session := testSession()
+				session.UsedTime = uint32(time.Now().Unix())
+				token.EXPECT().HashRandomToken(refreshToken).Return("hash")
+				sessionDB.EXPECT().GetSessionForUpdate(gomock.Any(), "hash").Return(session, nil)
+				sessionDB.EXPECT().RevokeSessionFamily(gomock.Any(), familyId).Return(nil)
+			},
+			outputError: moduleErrors.ErrorServiceRefreshTokenReused,
+		},
+		{
+			name: "Revoked session",
+			mockBehavior: func(token *mockService.Mocktoken, userDB *mockService.MockuserDB,
+				credentialDB *mockService.MockcredentialDBAuth, sessionDB *mockService.MocksessionDBAuth) {
+				session := testSession()
+				session.UsedTime = uint32(time.Now().Unix())
+				session.RevokedTime = session.UsedTime
+				token.EXPECT().HashRandomToken(refreshToken).Return("hash")
+				sessionDB.EXPECT().GetSessionForUpdate(gomock.Any(), "hash").Return(session, nil)
+			},
+			outputError: moduleErrors.ErrorServiceInvalidRefreshToken,
+		},
+		{
+			name: "Expired session",
+			mockBehavior: func(token *mockService.Mocktoken, userDB *mockService.MockuserDB,
+				credentialDB *mockService.MockcredentialDBAuth, sessionDB *mockService.MocksessionDBAuth) {
+				session := testSession()
+				session.ExpiresTime = uint32(time.Now().Add(-time.Hour).Unix())
+				token.EXPECT().HashRandomToken(refreshToken).Return("hash")
+				sessionDB.EXPECT().GetSessionForUpdate(gomock.Any(), "hash").Return(session, nil)
+			},
+			outputError: moduleErrors.ErrorServiceInvalidRefreshToken,
+		},
+		{
+			name: "Unknown token",
+			mockBehavior: func(token *mockService.Mocktoken, userDB *mockService.MockuserDB,
+				credentialDB *mockService.MockcredentialDBAuth, sessionDB *mockService.MocksessionDBAuth) {
+				token.EXPECT().HashRandomToken(refreshToken).Return("hash")
+				sessionDB.EXPECT().GetSessionForUpdate(gomock.Any(), "hash").
+					Return(nil, moduleErrors.ErrorDatabaseSessionNotFound)
+			},
+			outputError: moduleErrors.ErrorServiceInvalidRefreshToken,
+		},
+		{
+			name: "Locked user",
+			mockBehavior: func(token *mockService.Mocktoken, userDB *mockService.MockuserDB,
+				credentialDB *mockService.MockcredentialDBAuth, sessionDB *mockService.MocksessionDBAuth) {
+				token.EXPECT().HashRandomToken(refreshToken).Return("hash")
+				sessionDB.EXPECT().GetSessionForUpdate(gomock.Any(), "hash").Return(testSession(), nil)
+				userDB.EXPECT().GetUser(gomock.Any(), userId).Return(&core.UserDB{User: core.User{Id: userId},
+					LockedTime: uint32(time.Now().Unix())}, nil)
+			},
+			outputError: moduleErrors.ErrorServiceUserLocked,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			token := mockService.NewMocktoken(c)
+			userDB := mockService.NewMockuserDB(c)
+			credentialDB := mockService.NewMockcredentialDBAuth(c)
+			sessionDB := mockService.NewMocksessionDBAuth(c)
+			transactionDB := mockService.NewMocktransactionDBAuth(c)
+			transactionDB.EXPECT().InjectTx(gomock.Any()).DoAndReturn(
+				func(ctx context.Context) (context.Context, error) { return ctx, nil })
+			transactionDB.EXPECT().RollbackTxDefer(gomock.Any())
+			transactionDB.EXPECT().CommitTx(gomock.Any()).Return(nil).AnyTimes()
+			testCase.mockBehavior(token, userDB, credentialDB, sessionDB)
+
+			auth := NewAuth(token, userDB, nil, credentialDB, sessionDB, nil, nil, transactionDB)
+
+			response, err := auth.Refresh(refreshToken)
+			if err != testCase.outputError {
+				t.Fatalf("error = %v, want %v", err, testCase.outputError)
+			}
+			if testCase.outputResponse != nil && *response != *testCase.outputResponse {
+				t.Errorf("response = %+v, want %+v", *response, *testCase.outputResponse)
+			}
+		})
+	}
+}
+
+func TestSignUp(t *testing.T) {
+	type mockBehavior func(userDB *mockService.MockuserDB, invitation *mockService.MockinvitationAuth)
+
+	const (
+		userId       = 1
+		companyId    = 2
+		departmentId = 3
+		email        = "invited@example.com"
+	)
+
+	testTable := []struct {
+		name            string
+		invitationToken string
+		mockBehavior    mockBehavior
+		wantCompanyId   *int
+	}{
+		{
+			// email isn't verified, so invitations sent to it aren't accepted by person who registered it
+			name: "Without invitation token",
+			mockBehavior: func(userDB *mockService.MockuserDB, invitation *mockService.MockinvitationAuth) {
+			},
+		},
+		{
+			name:            "With invitation token",
+			invitationToken: "invitation_token",
+			mockBehavior: func(userDB *mockService.MockuserDB, invitation *mockService.MockinvitationAuth) {
+				invitation.EXPECT().AcceptTokenInvitation(gomock.Any(), userId, email, "invitation_token").
+					Return(&core.Department{Id: pointy.Int(departmentId),
+						DepartmentBase: core.DepartmentBase{CompanyId: pointy.Int(companyId)}}, nil)
+			},
+			wantCompanyId: pointy.Int(companyId),
+		},
+		{
+			name:            "With unknown invitation token",
+			invitationToken: "other_token",
+			mockBehavior: func(userDB *mockService.MockuserDB, invitation *mockService.MockinvitationAuth) {
+				invitation.EXPECT().AcceptTokenInvitation(gomock.Any(), userId, email, "other_token").Return(nil, nil)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			token := mockService.NewMocktoken(c)
+			userDB := mockService.NewMockuserDB(c)
+			hash := mockService.NewMockhash(c)
+			credentialDB := mockService.NewMockcredentialDBAuth(c)
+			sessionDB := mockService.NewMocksessionDBAuth(c)
+			emailVerification := mockService.NewMockemailVerificationAuth(c)
+			invitation := mockService.NewMockinvitationAuth(c)
+			transactionDB := mockService.NewMocktransactionDBAuth(c)
+			transactionDB.EXPECT().InjectTx(gomock.Any()).DoAndReturn(
+				func(ctx context.Context) (context.Context, error) { return ctx, nil })
+			transactionDB.EXPECT().RollbackTxDefer(gomock.Any())
+			transactionDB.EXPECT().CommitTx(gomock.Any()).Return(nil)
+
+			hash.EXPECT().GenerateHash("password").Return("hash", nil)
+			userDB.EXPECT().AddUser(gomock.Any(), gomock.Any()).Return(&core.UserDB{User: core.User{Id: userId,
+				UserBaseData: core.UserBaseData{Email: pointy.String(email)}}}, nil)
+			testCase.mockBehavior(userDB, invitation)
+			credentialDB.EXPECT().GetUserCredential(gomock.Any(), userId).Return(nil, nil)
+			token.EXPECT().GenerateToken(userId, nil).Return("access_token", nil)
+			token.EXPECT().GenerateRandomToken().Return("refresh_token", nil)
+			token.EXPECT().HashRandomToken("refresh_token").Return("refresh_hash")
+			sessionDB.EXPECT().AddSession(gomock.Any(), gomock.Any()).Return(&core.Session{Id: 1}, nil)
+			emailVerification.EXPECT().SendVerification(gomock.Any(), userId).Return(nil)
+
+			auth := NewAuth(token, userDB, hash, credentialDB, sessionDB, emailVerification, invitation, transactionDB)
+
+			response, err := auth.SignUp(&core.UserSignUpData{
+				UserBaseData:    core.UserBaseData{Email: pointy.String(email)},
+				Password:        "password",
+				InvitationToken: testCase.invitationToken,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if (response.User.CompanyId == nil) != (testCase.wantCompanyId == nil) ||
+				testCase.wantCompanyId != nil && *response.User.CompanyId != *testCase.wantCompanyId {
+				t.Errorf("company id = %v, want %v", response.User.CompanyId, testCase.wantCompanyId)
+			}
+		})
+	}
+}
+
 //
 //import (
 //	mockService "github.com/Thing-repository/backend-server/internal/service/mock"
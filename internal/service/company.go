@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"github.com/Thing-repository/backend-server/pkg/authz"
 	"github.com/Thing-repository/backend-server/pkg/core"
 	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
 	"github.com/openlyinc/pointy"
@@ -10,7 +11,7 @@ import (
 
 const departmentHeadName = "Head"
 
-//go:generate mockgen -source=auth.go -destination=mock/authMock.go
+//go:generate mockgen -source=company.go -destination=mock/companyMock.go
 type userDBCompany interface {
 	PathUser(ctx context.Context, user *core.UserDB, userId int) error
 	GetUser(ctx context.Context, userId int) (*core.UserDB, error)
@@ -21,14 +22,17 @@ type companyDBCompany interface {
 	GetCompany(ctx context.Context, companyId int) (*core.Company, error)
 	UpdateCompany(ctx context.Context, company core.CompanyUpdate, companyId int) error
 	DeleteCompany(ctx context.Context, companyId int) error
+	FindCompanies(ctx context.Context, filter string, limit int, offset int) ([]core.Company, error)
 }
 
 type departmentDBCompany interface {
 	AddDepartment(ctx context.Context, departmentBase *core.DepartmentBase) (*core.Department, error)
+	GetDepartmentsByCompany(ctx context.Context, companyId int) ([]core.Department, error)
 }
 
 type credentialsDBCompany interface {
 	CreateCredential(ctx context.Context, credentials *core.AddCredentials) (int, error)
+	InvalidateAllCredentials(ctx context.Context)
 }
 
 type transactionDBCompany interface {
@@ -44,14 +48,16 @@ type Company struct {
 	departmentDB  departmentDBCompany
 	credentialsDB credentialsDBCompany
 	transactionDB transactionDBCompany
+	// requireVerifiedEmail forbids company creation for users with not verified email
+	requireVerifiedEmail bool
 }
 
 func NewCompany(userDB userDBCompany, companyDB companyDBCompany,
 	departmentDB departmentDBCompany, credentialsDB credentialsDBCompany,
-	transactionDB transactionDBCompany) *Company {
+	transactionDB transactionDBCompany, requireVerifiedEmail bool) *Company {
 	return &Company{userDB: userDB, companyDB: companyDB,
 		departmentDB: departmentDB, credentialsDB: credentialsDB,
-		transactionDB: transactionDB}
+		transactionDB: transactionDB, requireVerifiedEmail: requireVerifiedEmail}
 }
 
 func (C *Company) AddCompany(ctx context.Context, companyAdd *core.CompanyBase) (*core.Company, error) {
@@ -99,6 +105,14 @@ func (C *Company) AddCompany(ctx context.Context, companyAdd *core.CompanyBase)
 		return nil, moduleErrors.ErrorServiceUserAlreadyHasCompany
 	}
 
+	if C.requireVerifiedEmail && (userData.EmailIsValidated == nil || !*userData.EmailIsValidated) {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"userId": userId,
+		}).Error(moduleErrors.ErrorServiceEmailNotVerified.Error())
+		return nil, moduleErrors.ErrorServiceEmailNotVerified
+	}
+
 	companyData, err := C.companyDB.AddCompany(ctx, companyAdd)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
@@ -203,22 +217,8 @@ func (C *Company) GetCompany(ctx context.Context, companyId int) (*core.Company,
 		"context":  *core.LogContext(ctx),
 	}
 
-	credentials, err := core.ContextGetUserCredentials(ctx)
-	if err != nil {
-		logrus.WithFields(logrus.Fields{
-			"base":  logBase,
-			"error": err.Error(),
-		}).Error("error get user credentials from context")
-		return nil, moduleErrors.ErrorServiceInvalidContext
-	}
-
-	// TODO: add check rights for service admin, load and check permission form context
-	if !core.CheckCredential(credentials, core.CredentialTypeCompanyUser, companyId) {
-		logrus.WithFields(logrus.Fields{
-			"base":      logBase,
-			"companyId": companyId,
-		}).Error("user has not credentials")
-		return nil, moduleErrors.ErrorServiceBadPermissions
+	if err := authz.Authorize(ctx, authz.ActionRead, authz.Company(companyId)); err != nil {
+		return nil, err
 	}
 
 	companyData, err := C.companyDB.GetCompany(ctx, companyId)
@@ -234,35 +234,57 @@ func (C *Company) GetCompany(ctx context.Context, companyId int) (*core.Company,
 	return companyData, nil
 }
 
-func (C *Company) UpdateCompany(ctx context.Context, companyBase core.CompanyBase, companyId int) (*core.Company, error) {
+// FindCompanies is search of companies by name for join requests, it is available to any user
+func (C *Company) FindCompanies(ctx context.Context, filter string, limit int, offset int) ([]core.CompanySearchResult, error) {
 	logBase := logrus.Fields{
 		"module":   "service",
-		"function": "UpdateCompany",
+		"function": "FindCompanies",
+		"filter":   filter,
 		"context":  *core.LogContext(ctx),
 	}
 
-	credentials, err := core.ContextGetUserCredentials(ctx)
+	companies, err := C.companyDB.FindCompanies(ctx, filter, limit, offset)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"base":  logBase,
 			"error": err.Error(),
-		}).Error("error get user credentials from context")
-		return nil, moduleErrors.ErrorServiceInvalidContext
+		}).Error("error find companies in database")
+		return nil, err
 	}
 
-	if !core.CheckCredential(credentials, core.CredentialTypeCompanyAdmin, companyId) {
-		logrus.WithFields(logrus.Fields{
-			"base":      logBase,
-			"companyId": companyId,
-		}).Error("user has not credentials")
-		return nil, moduleErrors.ErrorServiceBadPermissions
+	ret := make([]core.CompanySearchResult, 0, len(companies))
+	for _, companyData := range companies {
+		departments, err := C.departmentDB.GetDepartmentsByCompany(ctx, companyData.Id)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":      logBase,
+				"companyId": companyData.Id,
+				"error":     err.Error(),
+			}).Error("error get departments of company from database")
+			return nil, err
+		}
+		ret = append(ret, core.CompanySearchResult{Company: companyData, Departments: departments})
+	}
+
+	return ret, nil
+}
+
+func (C *Company) UpdateCompany(ctx context.Context, companyBase core.CompanyBase, companyId int) (*core.Company, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "UpdateCompany",
+		"context":  *core.LogContext(ctx),
+	}
+
+	if err := authz.Authorize(ctx, authz.ActionUpdate, authz.Company(companyId)); err != nil {
+		return nil, err
 	}
 
 	company := core.CompanyUpdate{
 		CompanyBase: companyBase,
 	}
 
-	err = C.companyDB.UpdateCompany(ctx, company, companyId)
+	err := C.companyDB.UpdateCompany(ctx, company, companyId)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"base":        logBase,
@@ -282,24 +304,11 @@ func (C *Company) DeleteCompany(ctx context.Context, companyId int) error {
 		"function": "UpdateCompany",
 	}
 
-	credentials, err := core.ContextGetUserCredentials(ctx)
-	if err != nil {
-		logrus.WithFields(logrus.Fields{
-			"base":  logBase,
-			"error": err.Error(),
-		}).Error("error get user credentials from context")
-		return moduleErrors.ErrorServiceInvalidContext
-	}
-
-	if !core.CheckCredential(credentials, core.CredentialTypeCompanyAdmin, companyId) {
-		logrus.WithFields(logrus.Fields{
-			"base":      logBase,
-			"companyId": companyId,
-		}).Error("user has not credentials")
-		return moduleErrors.ErrorServiceBadPermissions
+	if err := authz.Authorize(ctx, authz.ActionDelete, authz.Company(companyId)); err != nil {
+		return err
 	}
 
-	err = C.companyDB.DeleteCompany(ctx, companyId)
+	err := C.companyDB.DeleteCompany(ctx, companyId)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"base":      logBase,
@@ -309,5 +318,8 @@ func (C *Company) DeleteCompany(ctx context.Context, companyId int) error {
 		return err
 	}
 
+	// credentials of company are deleted by cascade
+	C.credentialsDB.InvalidateAllCredentials(ctx)
+
 	return nil
 }
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	mockService "github.com/Thing-repository/backend-server/internal/service/mock"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/golang/mock/gomock"
+	"github.com/openlyinc/pointy"
+	"testing"
+)
+
+const (
+	testCompanyUserId       = 1
+	testCompanyId           = 2
+	testCompanyDepartmentId = 3
+)
+
+type companyMocks struct {
+	userDB        *mockService.MockuserDBCompany
+	companyDB     *mockService.MockcompanyDBCompany
+	departmentDB  *mockService.MockdepartmentDBCompany
+	credentialsDB *mockService.MockcredentialsDBCompany
+	transactionDB *mockService.MocktransactionDBCompany
+}
+
+func newTestCompany(c *gomock.Controller, requireVerifiedEmail bool) (*Company, *companyMocks) {
+	m := &companyMocks{
+		userDB:        mockService.NewMockuserDBCompany(c),
+		companyDB:     mockService.NewMockcompanyDBCompany(c),
+		departmentDB:  mockService.NewMockdepartmentDBCompany(c),
+		credentialsDB: mockService.NewMockcredentialsDBCompany(c),
+		transactionDB: mockService.NewMocktransactionDBCompany(c),
+	}
+
+	m.transactionDB.EXPECT().InjectTx(gomock.Any()).DoAndReturn(
+		func(ctx context.Context) (context.Context, error) { return ctx, nil }).AnyTimes()
+	m.transactionDB.EXPECT().RollbackTxDefer(gomock.Any()).AnyTimes()
+	m.transactionDB.EXPECT().CommitTx(gomock.Any()).Return(nil).AnyTimes()
+
+	return NewCompany(m.userDB, m.companyDB, m.departmentDB, m.credentialsDB, m.transactionDB,
+		requireVerifiedEmail), m
+}
+
+func TestAddCompany(t *testing.T) {
+	expectCompanyAdded := func(m *companyMocks, companyAdd *core.CompanyBase) {
+		m.companyDB.EXPECT().AddCompany(gomock.Any(), companyAdd).Return(&core.Company{Id: testCompanyId}, nil)
+		m.departmentDB.EXPECT().AddDepartment(gomock.Any(), &core.DepartmentBase{
+			DepartmentName: pointy.String(departmentHeadName), CompanyId: pointy.Int(testCompanyId),
+		}).Return(&core.Department{Id: pointy.Int(testCompanyDepartmentId)}, nil)
+		m.credentialsDB.EXPECT().CreateCredential(gomock.Any(), gomock.Any()).Return(1, nil).Times(4)
+		m.userDB.EXPECT().PathUser(gomock.Any(), gomock.Any(), testCompanyUserId).Return(nil)
+	}
+
+	testTable := []struct {
+		name                 string
+		requireVerifiedEmail bool
+		emailIsValidated     *bool
+		wantError            error
+	}{
+		{
+			name:                 "Verified email",
+			requireVerifiedEmail: true,
+			emailIsValidated:     pointy.Bool(true),
+		},
+		{
+			name:                 "Not verified email",
+			requireVerifiedEmail: true,
+			emailIsValidated:     pointy.Bool(false),
+			wantError:            moduleErrors.ErrorServiceEmailNotVerified,
+		},
+		{
+			name:                 "Not verified email isn't required",
+			requireVerifiedEmail: false,
+			emailIsValidated:     pointy.Bool(false),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service, m := newTestCompany(c, testCase.requireVerifiedEmail)
+
+			companyAdd := &core.CompanyBase{CompanyName: pointy.String("Company")}
+			userData := &core.UserDB{User: core.User{Id: testCompanyUserId}}
+			userData.EmailIsValidated = testCase.emailIsValidated
+			m.userDB.EXPECT().GetUser(gomock.Any(), testCompanyUserId).Return(userData, nil)
+			if testCase.wantError == nil {
+				expectCompanyAdded(m, companyAdd)
+			}
+
+			ctx := core.ContextWithUser(context.Background(), testCompanyUserId, nil)
+			if _, err := service.AddCompany(ctx, companyAdd); err != testCase.wantError {
+				t.Errorf("error = %v, want %v", err, testCase.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/authz"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
+)
+
+//go:generate mockgen -source=credentials.go -destination=mock/credentialsMock.go
+type credentialsDBCredentials interface {
+	CreateCredential(ctx context.Context, credentials *core.AddCredentials) (int, error)
+	GetCredentialUsers(ctx context.Context, credentialType string, objectId int) ([]core.User, error)
+	GetUserCredential(ctx context.Context, userId int) ([]core.Credentials, error)
+	CountCredentialsForUpdate(ctx context.Context, credentialType string, objectId int) (int, error)
+	DeleteCredential(ctx context.Context, credentials *core.AddCredentials) error
+}
+
+type departmentDBCredentials interface {
+	GetDepartment(ctx context.Context, departmentId int) (*core.Department, error)
+}
+
+type userDBCredentials interface {
+	GetUser(ctx context.Context, userId int) (*core.UserDB, error)
+}
+
+type transactionDBCredentials interface {
+	InjectTx(ctx context.Context) (context.Context, error)
+	CommitTx(ctx context.Context) error
+	RollbackTx(ctx context.Context) error
+	RollbackTxDefer(ctx context.Context)
+}
+
+type Credentials struct {
+	credentialsDB credentialsDBCredentials
+	departmentDB  departmentDBCredentials
+	userDB        userDBCredentials
+	transactionDB transactionDBCredentials
+}
+
+func NewCredentials(credentialsDB credentialsDBCredentials, departmentDB departmentDBCredentials,
+	userDB userDBCredentials, transactionDB transactionDBCredentials) *Credentials {
+	return &Credentials{
+		credentialsDB: credentialsDB,
+		departmentDB:  departmentDB,
+		userDB:        userDB,
+		transactionDB: transactionDB,
+	}
+}
+
+// managedCredentials is list of credentials which can be granted and revoked by admins,
+// company_user and department_user are managed by company membership
+var managedCredentials = []string{
+	core.CredentialTypeCompanyAdmin,
+	core.CredentialTypeDepartmentAdmin,
+	core.CredentialTypeDepartmentMaintainer,
+}
+
+// credentialResource returns object of credential for authorization. Company admins are managed by
+// company admins, department admins and maintainers are managed by admins of company and department.
+func credentialResource(credentialType string, companyId int, objectId int) authz.Resource {
+	if slices.Index(core.CompanyCredential, credentialType) != -1 {
+		return authz.Company(companyId)
+	}
+	return authz.Department(companyId, objectId)
+}
+
+// credentialCompany returns company id of credential object
+func (C *Credentials) credentialCompany(ctx context.Context, credentialType string, objectId int) (int, error) {
+	if slices.Index(core.CompanyCredential, credentialType) != -1 {
+		return objectId, nil
+	}
+
+	departmentData, err := C.departmentDB.GetDepartment(ctx, objectId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"module":       "service",
+			"function":     "credentialCompany",
+			"departmentId": objectId,
+			"error":        err.Error(),
+		}).Error("error get department from database")
+		return 0, departmentDBError(err)
+	}
+
+	return *departmentData.CompanyId, nil
+}
+
+func (C *Credentials) GetCredentialUsers(ctx context.Context, credentialType string, objectId int) ([]core.User, error) {
+	logBase := logrus.Fields{
+		"module":         "service",
+		"function":       "GetCredentialUsers",
+		"credentialType": credentialType,
+		"objectId":       objectId,
+		"context":        *core.LogContext(ctx),
+	}
+
+	if slices.Index(managedCredentials, credentialType) == -1 {
+		return nil, moduleErrors.ErrorServiceInvalidCredentialType
+	}
+
+	companyId, err := C.credentialCompany(ctx, credentialType, objectId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = authz.Authorize(ctx, authz.ActionRead, credentialResource(credentialType, companyId, objectId))
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := C.credentialsDB.GetCredentialUsers(ctx, credentialType, objectId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get credential users from database")
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (C *Credentials) GrantCredential(ctx context.Context, credentialType string, objectId int, userId int) error {
+	logBase := logrus.Fields{
+		"module":         "service",
+		"function":       "GrantCredential",
+		"credentialType": credentialType,
+		"objectId":       objectId,
+		"userId":         userId,
+		"context":        *core.LogContext(ctx),
+	}
+
+	if slices.Index(managedCredentials, credentialType) == -1 {
+		return moduleErrors.ErrorServiceInvalidCredentialType
+	}
+
+	companyId, err := C.credentialCompany(ctx, credentialType, objectId)
+	if err != nil {
+		return err
+	}
+
+	err = authz.Authorize(ctx, authz.ActionManageCredentials, credentialResource(credentialType, companyId, objectId))
+	if err != nil {
+		return err
+	}
+
+	userData, err := C.userDB.GetUser(ctx, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user data")
+		if err == moduleErrors.ErrorDatabaseUserNotFound {
+			return moduleErrors.ErrorServiceUserNotFound
+		}
+		return moduleErrors.ErrorServiceGetUserData
+	}
+
+	if userData.CompanyId == nil || *userData.CompanyId != companyId {
+		logrus.WithFields(logrus.Fields{
+			"base":      logBase,
+			"companyId": companyId,
+		}).Error(moduleErrors.ErrorServiceUserNotInCompany.Error())
+		return moduleErrors.ErrorServiceUserNotInCompany
+	}
+
+	_, err = C.credentialsDB.CreateCredential(ctx, newCredential(objectId, userId, credentialType))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error add credential to database")
+		if err == moduleErrors.ErrorDatabaseCredentialAlreadyExists {
+			return moduleErrors.ErrorServiceCredentialAlreadyExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (C *Credentials) RevokeCredential(ctx context.Context, credentialType string, objectId int, userId int) error {
+	logBase := logrus.Fields{
+		"module":         "service",
+		"function":       "RevokeCredential",
+		"credentialType": credentialType,
+		"objectId":       objectId,
+		"userId":         userId,
+		"context":        *core.LogContext(ctx),
+	}
+
+	if slices.Index(managedCredentials, credentialType) == -1 {
+		return moduleErrors.ErrorServiceInvalidCredentialType
+	}
+
+	companyId, err := C.credentialCompany(ctx, credentialType, objectId)
+	if err != nil {
+		return err
+	}
+
+	err = authz.Authorize(ctx, authz.ActionManageCredentials, credentialResource(credentialType, companyId, objectId))
+	if err != nil {
+		return err
+	}
+
+	ctx, err = C.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return err
+	}
+
+	defer C.transactionDB.RollbackTxDefer(ctx)
+
+	if credentialType == core.CredentialTypeCompanyAdmin {
+		if err = checkLastCompanyAdmin(ctx, C.credentialsDB, userId, companyId); err != nil {
+			return err
+		}
+	}
+
+	err = C.credentialsDB.DeleteCredential(ctx, newCredential(objectId, userId, credentialType))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error delete credential from database")
+		if err == moduleErrors.ErrorDatabaseCredentialNotFound {
+			return moduleErrors.ErrorServiceCredentialNotFound
+		}
+		return err
+	}
+
+	if err = C.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	mockService "github.com/Thing-repository/backend-server/internal/service/mock"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/golang/mock/gomock"
+	"github.com/openlyinc/pointy"
+	"testing"
+)
+
+const (
+	testCredentialsCompanyId    = 1
+	testCredentialsDepartmentId = 10
+	testCredentialsAdminId      = 2
+	testCredentialsUserId       = 3
+)
+
+type credentialsMocks struct {
+	credentialsDB *mockService.MockcredentialsDBCredentials
+	departmentDB  *mockService.MockdepartmentDBCredentials
+	userDB        *mockService.MockuserDBCredentials
+	transactionDB *mockService.MocktransactionDBCredentials
+}
+
+func newTestCredentials(c *gomock.Controller) (*Credentials, *credentialsMocks) {
+	m := &credentialsMocks{
+		credentialsDB: mockService.NewMockcredentialsDBCredentials(c),
+		departmentDB:  mockService.NewMockdepartmentDBCredentials(c),
+		userDB:        mockService.NewMockuserDBCredentials(c),
+		transactionDB: mockService.NewMocktransactionDBCredentials(c),
+	}
+
+	m.transactionDB.EXPECT().InjectTx(gomock.Any()).DoAndReturn(
+		func(ctx context.Context) (context.Context, error) { return ctx, nil }).AnyTimes()
+	m.transactionDB.EXPECT().RollbackTxDefer(gomock.Any()).AnyTimes()
+	m.transactionDB.EXPECT().CommitTx(gomock.Any()).Return(nil).AnyTimes()
+
+	m.departmentDB.EXPECT().GetDepartment(gomock.Any(), testCredentialsDepartmentId).Return(&core.Department{
+		Id:             pointy.Int(testCredentialsDepartmentId),
+		DepartmentBase: core.DepartmentBase{CompanyId: pointy.Int(testCredentialsCompanyId)},
+	}, nil).AnyTimes()
+
+	return NewCredentials(m.credentialsDB, m.departmentDB, m.userDB, m.transactionDB), m
+}
+
+func testCompanyAdminCredentials() []core.Credentials {
+	return []core.Credentials{{CredentialType: core.CredentialTypeCompanyAdmin, ObjectId: testCredentialsCompanyId}}
+}
+
+func TestGrantCredential(t *testing.T) {
+	departmentAdmin := []core.Credentials{
+		{CredentialType: core.CredentialTypeDepartmentAdmin, ObjectId: testCredentialsDepartmentId},
+	}
+	companyAdmin := testCompanyAdminCredentials()
+
+	testTable := []struct {
+		name           string
+		credentials    []core.Credentials
+		credentialType string
+		objectId       int
+		wantError      error
+	}{
+		{name: "Company admin grants company admin", credentials: companyAdmin,
+			credentialType: core.CredentialTypeCompanyAdmin, objectId: testCredentialsCompanyId},
+		{name: "Company admin grants department admin", credentials: companyAdmin,
+			credentialType: core.CredentialTypeDepartmentAdmin, objectId: testCredentialsDepartmentId},
+		{name: "Department admin grants maintainer", credentials: departmentAdmin,
+			credentialType: core.CredentialTypeDepartmentMaintainer, objectId: testCredentialsDepartmentId},
+		{name: "Department admin grants company admin", credentials: departmentAdmin,
+			credentialType: core.CredentialTypeCompanyAdmin, objectId: testCredentialsCompanyId,
+			wantError: moduleErrors.ErrorServiceBadPermissions},
+		{name: "Maintainer grants maintainer", credentials: []core.Credentials{
+			{CredentialType: core.CredentialTypeDepartmentMaintainer, ObjectId: testCredentialsDepartmentId}},
+			credentialType: core.CredentialTypeDepartmentMaintainer, objectId: testCredentialsDepartmentId,
+			wantError: moduleErrors.ErrorServiceBadPermissions},
+		{name: "Company user", credentials: companyAdmin, credentialType: core.CredentialTypeCompanyUser,
+			objectId: testCredentialsCompanyId, wantError: moduleErrors.ErrorServiceInvalidCredentialType},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service, m := newTestCredentials(c)
+
+			if testCase.wantError == nil {
+				m.userDB.EXPECT().GetUser(gomock.Any(), testCredentialsUserId).Return(&core.UserDB{User: core.User{
+					Id: testCredentialsUserId, CompanyId: pointy.Int(testCredentialsCompanyId)}}, nil)
+				m.credentialsDB.EXPECT().CreateCredential(gomock.Any(),
+					newCredential(testCase.objectId, testCredentialsUserId, testCase.credentialType)).Return(1, nil)
+			}
+
+			ctx := core.ContextWithUser(context.Background(), testCredentialsAdminId, testCase.credentials)
+			err := service.GrantCredential(ctx, testCase.credentialType, testCase.objectId, testCredentialsUserId)
+			if err != testCase.wantError {
+				t.Errorf("error = %v, want %v", err, testCase.wantError)
+			}
+		})
+	}
+}
+
+func TestRevokeCredential(t *testing.T) {
+	type mockBehavior func(m *credentialsMocks)
+
+	testTable := []struct {
+		name           string
+		credentialType string
+		objectId       int
+		mockBehavior   mockBehavior
+		wantError      error
+	}{
+		{
+			name:           "Last company admin",
+			credentialType: core.CredentialTypeCompanyAdmin,
+			objectId:       testCredentialsCompanyId,
+			mockBehavior: func(m *credentialsMocks) {
+				m.credentialsDB.EXPECT().GetUserCredential(gomock.Any(), testCredentialsAdminId).
+					Return(testCompanyAdminCredentials(), nil)
+				m.credentialsDB.EXPECT().CountCredentialsForUpdate(gomock.Any(), core.CredentialTypeCompanyAdmin,
+					testCredentialsCompanyId).Return(1, nil)
+			},
+			wantError: moduleErrors.ErrorServiceLastCompanyAdmin,
+		},
+		{
+			name:           "One of company admins",
+			credentialType: core.CredentialTypeCompanyAdmin,
+			objectId:       testCredentialsCompanyId,
+			mockBehavior: func(m *credentialsMocks) {
+				m.credentialsDB.EXPECT().GetUserCredential(gomock.Any(), testCredentialsAdminId).
+					Return(testCompanyAdminCredentials(), nil)
+				m.credentialsDB.EXPECT().CountCredentialsForUpdate(gomock.Any(), core.CredentialTypeCompanyAdmin,
+					testCredentialsCompanyId).Return(2, nil)
+				m.credentialsDB.EXPECT().DeleteCredential(gomock.Any(), newCredential(testCredentialsCompanyId,
+					testCredentialsAdminId, core.CredentialTypeCompanyAdmin)).Return(nil)
+			},
+		},
+		{
+			name:           "Not company admin",
+			credentialType: core.CredentialTypeCompanyAdmin,
+			objectId:       testCredentialsCompanyId,
+			mockBehavior: func(m *credentialsMocks) {
+				m.credentialsDB.EXPECT().GetUserCredential(gomock.Any(), testCredentialsAdminId).Return(nil, nil)
+				m.credentialsDB.EXPECT().DeleteCredential(gomock.Any(), newCredential(testCredentialsCompanyId,
+					testCredentialsAdminId, core.CredentialTypeCompanyAdmin)).
+					Return(moduleErrors.ErrorDatabaseCredentialNotFound)
+			},
+			wantError: moduleErrors.ErrorServiceCredentialNotFound,
+		},
+		{
+			name:           "Department admin",
+			credentialType: core.CredentialTypeDepartmentAdmin,
+			objectId:       testCredentialsDepartmentId,
+			mockBehavior: func(m *credentialsMocks) {
+				m.credentialsDB.EXPECT().DeleteCredential(gomock.Any(), newCredential(testCredentialsDepartmentId,
+					testCredentialsAdminId, core.CredentialTypeDepartmentAdmin)).Return(nil)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service, m := newTestCredentials(c)
+			testCase.mockBehavior(m)
+
+			ctx := core.ContextWithUser(context.Background(), testCredentialsAdminId, testCompanyAdminCredentials())
+			err := service.RevokeCredential(ctx, testCase.credentialType, testCase.objectId, testCredentialsAdminId)
+			if err != testCase.wantError {
+				t.Errorf("error = %v, want %v", err, testCase.wantError)
+			}
+		})
+	}
+}
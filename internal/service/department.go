@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/authz"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=department.go -destination=mock/departmentMock.go
+type departmentDBDepartment interface {
+	AddDepartment(ctx context.Context, departmentBase *core.DepartmentBase) (*core.Department, error)
+	GetDepartment(ctx context.Context, departmentId int) (*core.Department, error)
+	GetDepartmentByName(ctx context.Context, companyId int, departmentName string) (*core.Department, error)
+	GetDepartmentsByCompany(ctx context.Context, companyId int) ([]core.Department, error)
+	UpdateDepartment(ctx context.Context, department *core.DepartmentUpdate, departmentId int) error
+	MoveDepartmentMembers(ctx context.Context, departmentId int, targetDepartmentId int) error
+	DeleteDepartment(ctx context.Context, departmentId int) error
+}
+
+type credentialsDBDepartment interface {
+	CreateCredential(ctx context.Context, credentials *core.AddCredentials) (int, error)
+	InvalidateAllCredentials(ctx context.Context)
+}
+
+type transactionDBDepartment interface {
+	InjectTx(ctx context.Context) (context.Context, error)
+	CommitTx(ctx context.Context) error
+	RollbackTx(ctx context.Context) error
+	RollbackTxDefer(ctx context.Context)
+}
+
+type Department struct {
+	departmentDB  departmentDBDepartment
+	credentialsDB credentialsDBDepartment
+	transactionDB transactionDBDepartment
+}
+
+func NewDepartment(departmentDB departmentDBDepartment, credentialsDB credentialsDBDepartment,
+	transactionDB transactionDBDepartment) *Department {
+	return &Department{
+		departmentDB:  departmentDB,
+		credentialsDB: credentialsDB,
+		transactionDB: transactionDB,
+	}
+}
+
+func departmentDBError(err error) error {
+	switch err {
+	case moduleErrors.ErrorDatabaseDepartmentNotFound:
+		return moduleErrors.ErrorServiceDepartmentNotFound
+	case moduleErrors.ErrorDatabaseDepartmentAlreadyExists:
+		return moduleErrors.ErrorServiceDepartmentAlreadyExists
+	case moduleErrors.ErrorDataBaseHasNotDataToChange:
+		return moduleErrors.ErrorAllNoFields
+	default:
+		return err
+	}
+}
+
+func isHeadDepartment(department *core.Department) bool {
+	return department.DepartmentName != nil && *department.DepartmentName == departmentHeadName
+}
+
+func (D *Department) AddDepartment(ctx context.Context, departmentBase *core.DepartmentBase) (*core.Department, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "AddDepartment",
+		"context":  *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	if err = authz.Authorize(ctx, authz.ActionCreate, authz.Department(*departmentBase.CompanyId, 0)); err != nil {
+		return nil, err
+	}
+
+	ctx, err = D.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return nil, err
+	}
+
+	defer D.transactionDB.RollbackTxDefer(ctx)
+
+	departmentData, err := D.departmentDB.AddDepartment(ctx, departmentBase)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":           logBase,
+			"departmentBase": departmentBase,
+			"error":          err.Error(),
+		}).Error("error add department to database")
+		return nil, departmentDBError(err)
+	}
+
+	// company admin who created department becomes its admin, as for head department
+	_, err = D.credentialsDB.CreateCredential(ctx, newCredential(*departmentData.Id, userId, core.CredentialTypeDepartmentAdmin))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":         logBase,
+			"departmentId": *departmentData.Id,
+			"userId":       userId,
+			"error":        err.Error(),
+		}).Error("error add department admin to database")
+		return nil, err
+	}
+
+	if err = D.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return nil, err
+	}
+
+	return departmentData, nil
+}
+
+func (D *Department) GetDepartment(ctx context.Context, departmentId int) (*core.Department, error) {
+	departmentData, err := D.getDepartment(ctx, departmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = authz.Authorize(ctx, authz.ActionRead, authz.Department(*departmentData.CompanyId, departmentId)); err != nil {
+		return nil, err
+	}
+
+	return departmentData, nil
+}
+
+func (D *Department) GetDepartments(ctx context.Context, companyId int) ([]core.Department, error) {
+	logBase := logrus.Fields{
+		"module":    "service",
+		"function":  "GetDepartments",
+		"companyId": companyId,
+		"context":   *core.LogContext(ctx),
+	}
+
+	if err := authz.Authorize(ctx, authz.ActionRead, authz.Department(companyId, 0)); err != nil {
+		return nil, err
+	}
+
+	departments, err := D.departmentDB.GetDepartmentsByCompany(ctx, companyId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get company departments from db")
+		return nil, err
+	}
+
+	return departments, nil
+}
+
+func (D *Department) UpdateDepartment(ctx context.Context, department *core.DepartmentUpdate, departmentId int) (*core.Department, error) {
+	logBase := logrus.Fields{
+		"module":       "service",
+		"function":     "UpdateDepartment",
+		"departmentId": departmentId,
+		"context":      *core.LogContext(ctx),
+	}
+
+	departmentData, err := D.getDepartment(ctx, departmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = authz.Authorize(ctx, authz.ActionUpdate, authz.Department(*departmentData.CompanyId, departmentId)); err != nil {
+		return nil, err
+	}
+
+	// head department is found by name, so it can't be renamed
+	if department.DepartmentName != nil && isHeadDepartment(departmentData) &&
+		*department.DepartmentName != departmentHeadName {
+		logrus.WithFields(logrus.Fields{
+			"base":       logBase,
+			"department": department,
+		}).Error(moduleErrors.ErrorServiceHeadDepartmentProtected.Error())
+		return nil, moduleErrors.ErrorServiceHeadDepartmentProtected
+	}
+
+	err = D.departmentDB.UpdateDepartment(ctx, department, departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":       logBase,
+			"department": department,
+			"error":      err.Error(),
+		}).Error("error update department in database")
+		return nil, departmentDBError(err)
+	}
+
+	return D.getDepartment(ctx, departmentId)
+}
+
+// DeleteDepartment deletes department and moves its users and things to target department.
+// If target department not set, head department of company is used.
+func (D *Department) DeleteDepartment(ctx context.Context, departmentId int, targetDepartmentId *int) error {
+	logBase := logrus.Fields{
+		"module":             "service",
+		"function":           "DeleteDepartment",
+		"departmentId":       departmentId,
+		"targetDepartmentId": targetDepartmentId,
+		"context":            *core.LogContext(ctx),
+	}
+
+	ctx, err := D.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return err
+	}
+
+	defer D.transactionDB.RollbackTxDefer(ctx)
+
+	departmentData, err := D.getDepartment(ctx, departmentId)
+	if err != nil {
+		return err
+	}
+
+	if err = authz.Authorize(ctx, authz.ActionDelete, authz.Department(*departmentData.CompanyId, departmentId)); err != nil {
+		return err
+	}
+
+	if isHeadDepartment(departmentData) {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error(moduleErrors.ErrorServiceHeadDepartmentProtected.Error())
+		return moduleErrors.ErrorServiceHeadDepartmentProtected
+	}
+
+	var targetData *core.Department
+	if targetDepartmentId != nil {
+		targetData, err = D.departmentDB.GetDepartment(ctx, *targetDepartmentId)
+	} else {
+		targetData, err = D.departmentDB.GetDepartmentByName(ctx, *departmentData.CompanyId, departmentHeadName)
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get target department from database")
+		if err == moduleErrors.ErrorDatabaseDepartmentNotFound {
+			return moduleErrors.ErrorServiceInvalidTargetDepartment
+		}
+		return err
+	}
+
+	if *targetData.Id == departmentId || *targetData.CompanyId != *departmentData.CompanyId {
+		logrus.WithFields(logrus.Fields{
+			"base":       logBase,
+			"targetData": targetData,
+		}).Error(moduleErrors.ErrorServiceInvalidTargetDepartment.Error())
+		return moduleErrors.ErrorServiceInvalidTargetDepartment
+	}
+
+	err = D.departmentDB.MoveDepartmentMembers(ctx, departmentId, *targetData.Id)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error move department members")
+		return err
+	}
+
+	err = D.departmentDB.DeleteDepartment(ctx, departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error delete department from database")
+		return departmentDBError(err)
+	}
+
+	// credentials of department members are moved and deleted by department database
+	D.credentialsDB.InvalidateAllCredentials(ctx)
+
+	if err = D.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+func (D *Department) getDepartment(ctx context.Context, departmentId int) (*core.Department, error) {
+	departmentData, err := D.departmentDB.GetDepartment(ctx, departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"module":       "service",
+			"function":     "getDepartment",
+			"departmentId": departmentId,
+			"error":        err.Error(),
+		}).Error("error get department from database")
+		return nil, departmentDBError(err)
+	}
+	return departmentData, nil
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	mockService "github.com/Thing-repository/backend-server/internal/service/mock"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/golang/mock/gomock"
+	"github.com/openlyinc/pointy"
+	"testing"
+)
+
+const (
+	testDepartmentCompanyId = 1
+	testDepartmentId        = 10
+	testDepartmentHeadId    = 11
+	testDepartmentTargetId  = 12
+)
+
+type departmentMocks struct {
+	departmentDB  *mockService.MockdepartmentDBDepartment
+	credentialsDB *mockService.MockcredentialsDBDepartment
+	transactionDB *mockService.MocktransactionDBDepartment
+}
+
+func newTestDepartment(c *gomock.Controller) (*Department, *departmentMocks) {
+	m := &departmentMocks{
+		departmentDB:  mockService.NewMockdepartmentDBDepartment(c),
+		credentialsDB: mockService.NewMockcredentialsDBDepartment(c),
+		transactionDB: mockService.NewMocktransactionDBDepartment(c),
+	}
+
+	m.transactionDB.EXPECT().InjectTx(gomock.Any()).DoAndReturn(
+		func(ctx context.Context) (context.Context, error) { return ctx, nil }).AnyTimes()
+	m.transactionDB.EXPECT().RollbackTxDefer(gomock.Any()).AnyTimes()
+	m.transactionDB.EXPECT().CommitTx(gomock.Any()).Return(nil).AnyTimes()
+
+	return NewDepartment(m.departmentDB, m.credentialsDB, m.transactionDB), m
+}
+
+func testDepartment(departmentId int, companyId int, name string) *core.Department {
+	return &core.Department{
+		Id: pointy.Int(departmentId),
+		DepartmentBase: core.DepartmentBase{
+			DepartmentName: pointy.String(name),
+			CompanyId:      pointy.Int(companyId),
+		},
+	}
+}
+
+func TestDeleteDepartment(t *testing.T) {
+	type mockBehavior func(m *departmentMocks)
+
+	expectDeleted := func(m *departmentMocks, targetDepartmentId int) {
+		m.departmentDB.EXPECT().MoveDepartmentMembers(gomock.Any(), testDepartmentId, targetDepartmentId).Return(nil)
+		m.departmentDB.EXPECT().DeleteDepartment(gomock.Any(), testDepartmentId).Return(nil)
+		m.credentialsDB.EXPECT().InvalidateAllCredentials(gomock.Any())
+	}
+
+	testTable := []struct {
+		name               string
+		departmentId       int
+		targetDepartmentId *int
+		mockBehavior       mockBehavior
+		wantError          error
+	}{
+		{
+			name:               "Ok",
+			departmentId:       testDepartmentId,
+			targetDepartmentId: pointy.Int(testDepartmentTargetId),
+			mockBehavior: func(m *departmentMocks) {
+				m.departmentDB.EXPECT().GetDepartment(gomock.Any(), testDepartmentId).
+					Return(testDepartment(testDepartmentId, testDepartmentCompanyId, "Sales"), nil)
+				m.departmentDB.EXPECT().GetDepartment(gomock.Any(), testDepartmentTargetId).
+					Return(testDepartment(testDepartmentTargetId, testDepartmentCompanyId, "Support"), nil)
+				expectDeleted(m, testDepartmentTargetId)
+			},
+		},
+		{
+			// members of deleted department are moved to Head by default
+			name:         "Default target",
+			departmentId: testDepartmentId,
+			mockBehavior: func(m *departmentMocks) {
+				m.departmentDB.EXPECT().GetDepartment(gomock.Any(), testDepartmentId).
+					Return(testDepartment(testDepartmentId, testDepartmentCompanyId, "Sales"), nil)
+				m.departmentDB.EXPECT().GetDepartmentByName(gomock.Any(), testDepartmentCompanyId, departmentHeadName).
+					Return(testDepartment(testDepartmentHeadId, testDepartmentCompanyId, departmentHeadName), nil)
+				expectDeleted(m, testDepartmentHeadId)
+			},
+		},
+		{
+			name:               "Head department",
+			departmentId:       testDepartmentHeadId,
+			targetDepartmentId: pointy.Int(testDepartmentTargetId),
+			mockBehavior: func(m *departmentMocks) {
+				m.departmentDB.EXPECT().GetDepartment(gomock.Any(), testDepartmentHeadId).
+					Return(testDepartment(testDepartmentHeadId, testDepartmentCompanyId, departmentHeadName), nil)
+			},
+			wantError: moduleErrors.ErrorServiceHeadDepartmentProtected,
+		},
+		{
+			name:               "Target in other company",
+			departmentId:       testDepartmentId,
+			targetDepartmentId: pointy.Int(testDepartmentTargetId),
+			mockBehavior: func(m *departmentMocks) {
+				m.departmentDB.EXPECT().GetDepartment(gomock.Any(), testDepartmentId).
+					Return(testDepartment(testDepartmentId, testDepartmentCompanyId, "Sales"), nil)
+				m.departmentDB.EXPECT().GetDepartment(gomock.Any(), testDepartmentTargetId).
+					Return(testDepartment(testDepartmentTargetId, testDepartmentCompanyId+1, "Support"), nil)
+			},
+			wantError: moduleErrors.ErrorServiceInvalidTargetDepartment,
+		},
+		{
+			name:               "Target is same department",
+			departmentId:       testDepartmentId,
+			targetDepartmentId: pointy.Int(testDepartmentId),
+			mockBehavior: func(m *departmentMocks) {
+				m.departmentDB.EXPECT().GetDepartment(gomock.Any(), testDepartmentId).
+					Return(testDepartment(testDepartmentId, testDepartmentCompanyId, "Sales"), nil).Times(2)
+			},
+			wantError: moduleErrors.ErrorServiceInvalidTargetDepartment,
+		},
+		{
+			name:               "Target not found",
+			departmentId:       testDepartmentId,
+			targetDepartmentId: pointy.Int(testDepartmentTargetId),
+			mockBehavior: func(m *departmentMocks) {
+				m.departmentDB.EXPECT().GetDepartment(gomock.Any(), testDepartmentId).
+					Return(testDepartment(testDepartmentId, testDepartmentCompanyId, "Sales"), nil)
+				m.departmentDB.EXPECT().GetDepartment(gomock.Any(), testDepartmentTargetId).
+					Return(nil, moduleErrors.ErrorDatabaseDepartmentNotFound)
+			},
+			wantError: moduleErrors.ErrorServiceInvalidTargetDepartment,
+		},
+	}
+
+	ctx := core.ContextWithUser(context.Background(), 1, []core.Credentials{
+		{CredentialType: core.CredentialTypeCompanyAdmin, ObjectId: testDepartmentCompanyId},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service, m := newTestDepartment(c)
+			testCase.mockBehavior(m)
+
+			err := service.DeleteDepartment(ctx, testCase.departmentId, testCase.targetDepartmentId)
+			if err != testCase.wantError {
+				t.Errorf("error = %v, want %v", err, testCase.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const (
+	emailTokenTTL         = time.Hour * 24
+	emailResendInterval   = time.Minute * 2
+	emailVerifySubject    = "Thing repository email verification"
+	emailVerifyBodyFormat = "Hello, %s!\n\nTo verify your email open link:\n%s?token=%s\n\nLink is valid for 24 hours."
+)
+
+//go:generate mockgen -source=email.go -destination=mock/emailMock.go
+type userDBEmail interface {
+	GetUser(ctx context.Context, userId int) (*core.UserDB, error)
+	SetEmailValidationToken(ctx context.Context, userId int, tokenHash string) error
+	ConfirmEmail(ctx context.Context, tokenHash string, sentAfter uint32) (int, error)
+}
+
+type tokenEmail interface {
+	GenerateRandomToken() (string, error)
+	HashRandomToken(token string) string
+}
+
+type mailerEmail interface {
+	Send(ctx context.Context, message *core.MailMessage) error
+}
+
+type invitationEmail interface {
+	AcceptEmailInvitations(ctx context.Context, userId int, email string) (*core.Department, error)
+}
+
+type transactionDBEmail interface {
+	InjectTx(ctx context.Context) (context.Context, error)
+	CommitTx(ctx context.Context) error
+	RollbackTx(ctx context.Context) error
+	RollbackTxDefer(ctx context.Context)
+}
+
+type EmailVerification struct {
+	userDB        userDBEmail
+	token         tokenEmail
+	mailer        mailerEmail
+	invitation    invitationEmail
+	transactionDB transactionDBEmail
+	verifyURL     string
+}
+
+// NewEmailVerification creates service, verifyURL is frontend page which sends token to /auth/verify-email
+func NewEmailVerification(userDB userDBEmail, token tokenEmail, mailer mailerEmail, invitation invitationEmail,
+	transactionDB transactionDBEmail, verifyURL string) *EmailVerification {
+	return &EmailVerification{
+		userDB:        userDB,
+		token:         token,
+		mailer:        mailer,
+		invitation:    invitation,
+		transactionDB: transactionDB,
+		verifyURL:     verifyURL,
+	}
+}
+
+// SendVerification generates new email validation token and sends it to user email
+func (E *EmailVerification) SendVerification(ctx context.Context, userId int) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "SendVerification",
+		"userId":   userId,
+	}
+
+	userData, err := E.userDB.GetUser(ctx, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user data")
+		if err == moduleErrors.ErrorDatabaseUserNotFound {
+			return moduleErrors.ErrorServiceUserNotFound
+		}
+		return moduleErrors.ErrorServiceGetUserData
+	}
+
+	if userData.EmailIsValidated != nil && *userData.EmailIsValidated {
+		return moduleErrors.ErrorServiceEmailAlreadyVerified
+	}
+
+	if userData.EmailValidationSentTime != 0 &&
+		time.Since(time.Unix(int64(userData.EmailValidationSentTime), 0)) < emailResendInterval {
+		logrus.WithFields(logrus.Fields{
+			"base":     logBase,
+			"sentTime": userData.EmailValidationSentTime,
+		}).Warning(moduleErrors.ErrorServiceEmailResendTooSoon.Error())
+		return moduleErrors.ErrorServiceEmailResendTooSoon
+	}
+
+	return E.sendVerification(ctx, userData)
+}
+
+// SendNewEmailVerification sends verification to changed email of user, resend interval isn't checked
+// because previous email was sent to old address. Token of old address becomes invalid.
+func (E *EmailVerification) SendNewEmailVerification(ctx context.Context, userId int) error {
+	userData, err := E.userDB.GetUser(ctx, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"module":   "service",
+			"function": "SendNewEmailVerification",
+			"userId":   userId,
+			"error":    err.Error(),
+		}).Error("error get user data")
+		if err == moduleErrors.ErrorDatabaseUserNotFound {
+			return moduleErrors.ErrorServiceUserNotFound
+		}
+		return moduleErrors.ErrorServiceGetUserData
+	}
+
+	return E.sendVerification(ctx, userData)
+}
+
+func (E *EmailVerification) sendVerification(ctx context.Context, userData *core.UserDB) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "sendVerification",
+		"userId":   userData.Id,
+	}
+
+	token, err := E.token.GenerateRandomToken()
+	if err != nil {
+		return err
+	}
+
+	if err = E.userDB.SetEmailValidationToken(ctx, userData.Id, E.token.HashRandomToken(token)); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error save email validation token")
+		return err
+	}
+
+	message := &core.MailMessage{
+		To:      *userData.Email,
+		Subject: emailVerifySubject,
+		Body:    fmt.Sprintf(emailVerifyBodyFormat, *userData.FirstName, E.verifyURL, token),
+	}
+
+	if err = E.mailer.Send(ctx, message); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error send verification email")
+		return err
+	}
+
+	return nil
+}
+
+// ResendVerification sends new verification email to user from context
+func (E *EmailVerification) ResendVerification(ctx context.Context) error {
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"module":   "service",
+			"function": "ResendVerification",
+			"error":    err.Error(),
+		}).Error("error get user id from context")
+		return moduleErrors.ErrorServiceInvalidContext
+	}
+
+	return E.SendVerification(ctx, userId)
+}
+
+// VerifyEmail confirms email of user with token, invitations sent to verified email are accepted
+func (E *EmailVerification) VerifyEmail(ctx context.Context, token string) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "VerifyEmail",
+	}
+
+	sentAfter := uint32(time.Now().Add(-emailTokenTTL).Unix())
+
+	ctx, err := E.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return err
+	}
+
+	defer E.transactionDB.RollbackTxDefer(ctx)
+
+	userId, err := E.userDB.ConfirmEmail(ctx, E.token.HashRandomToken(token), sentAfter)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error confirm email")
+		if err == moduleErrors.ErrorDatabaseUserNotFound {
+			return moduleErrors.ErrorServiceInvalidEmailToken
+		}
+		return err
+	}
+
+	userData, err := E.userDB.GetUser(ctx, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"userId": userId,
+			"error":  err.Error(),
+		}).Error("error get user data")
+		return moduleErrors.ErrorServiceGetUserData
+	}
+
+	if _, err = E.invitation.AcceptEmailInvitations(ctx, userId, *userData.Email); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"userId": userId,
+			"error":  err.Error(),
+		}).Error("error accept email invitations")
+		return err
+	}
+
+	if err = E.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"base":   logBase,
+		"userId": userId,
+	}).Info("email verified")
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	mockService "github.com/Thing-repository/backend-server/internal/service/mock"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/golang/mock/gomock"
+	"github.com/openlyinc/pointy"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testEmailUserId = 1
+	testEmail       = "user@example.com"
+)
+
+type emailMocks struct {
+	userDB        *mockService.MockuserDBEmail
+	token         *mockService.MocktokenEmail
+	mailer        *mockService.MockmailerEmail
+	invitation    *mockService.MockinvitationEmail
+	transactionDB *mockService.MocktransactionDBEmail
+}
+
+func newTestEmailVerification(c *gomock.Controller) (*EmailVerification, *emailMocks) {
+	m := &emailMocks{
+		userDB:        mockService.NewMockuserDBEmail(c),
+		token:         mockService.NewMocktokenEmail(c),
+		mailer:        mockService.NewMockmailerEmail(c),
+		invitation:    mockService.NewMockinvitationEmail(c),
+		transactionDB: mockService.NewMocktransactionDBEmail(c),
+	}
+
+	m.transactionDB.EXPECT().InjectTx(gomock.Any()).DoAndReturn(
+		func(ctx context.Context) (context.Context, error) { return ctx, nil }).AnyTimes()
+	m.transactionDB.EXPECT().RollbackTxDefer(gomock.Any()).AnyTimes()
+	m.transactionDB.EXPECT().CommitTx(gomock.Any()).Return(nil).AnyTimes()
+
+	return NewEmailVerification(m.userDB, m.token, m.mailer, m.invitation, m.transactionDB,
+		"https://example.com/verify"), m
+}
+
+func testEmailUser() *core.UserDB {
+	return &core.UserDB{User: core.User{Id: testEmailUserId, UserBaseData: core.UserBaseData{
+		FirstName: pointy.String("Name"), Email: pointy.String(testEmail)}}}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	testTable := []struct {
+		name         string
+		mockBehavior func(m *emailMocks)
+		wantError    error
+	}{
+		{
+			// invitations to email are accepted only after its owner is verified
+			name: "Ok",
+			mockBehavior: func(m *emailMocks) {
+				m.userDB.EXPECT().ConfirmEmail(gomock.Any(), "hash", gomock.Any()).Return(testEmailUserId, nil)
+				m.userDB.EXPECT().GetUser(gomock.Any(), testEmailUserId).Return(testEmailUser(), nil)
+				m.invitation.EXPECT().AcceptEmailInvitations(gomock.Any(), testEmailUserId, testEmail).
+					Return(&core.Department{Id: pointy.Int(3),
+						DepartmentBase: core.DepartmentBase{CompanyId: pointy.Int(2)}}, nil)
+			},
+		},
+		{
+			name: "Invalid token",
+			mockBehavior: func(m *emailMocks) {
+				m.userDB.EXPECT().ConfirmEmail(gomock.Any(), "hash", gomock.Any()).
+					Return(0, moduleErrors.ErrorDatabaseUserNotFound)
+			},
+			wantError: moduleErrors.ErrorServiceInvalidEmailToken,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service, m := newTestEmailVerification(c)
+			m.token.EXPECT().HashRandomToken("token").Return("hash")
+			testCase.mockBehavior(m)
+
+			if err := service.VerifyEmail(context.Background(), "token"); err != testCase.wantError {
+				t.Errorf("error = %v, want %v", err, testCase.wantError)
+			}
+		})
+	}
+}
+
+func TestSendVerification(t *testing.T) {
+	now := uint32(time.Now().Unix())
+
+	testTable := []struct {
+		name         string
+		userData     func() *core.UserDB
+		mockBehavior func(m *emailMocks)
+		wantError    error
+	}{
+		{
+			name: "Ok",
+			userData: func() *core.UserDB {
+				userData := testEmailUser()
+				userData.EmailValidationSentTime = now - uint32(emailResendInterval.Seconds()) - 1
+				return userData
+			},
+			mockBehavior: func(m *emailMocks) {
+				m.token.EXPECT().GenerateRandomToken().Return("token", nil)
+				m.token.EXPECT().HashRandomToken("token").Return("hash")
+				m.userDB.EXPECT().SetEmailValidationToken(gomock.Any(), testEmailUserId, "hash").Return(nil)
+				m.mailer.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, message *core.MailMessage) error {
+						if message.To != testEmail || !strings.Contains(message.Body, "token") {
+							t.Errorf("unexpected message %+v", message)
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name: "Resend too soon",
+			userData: func() *core.UserDB {
+				userData := testEmailUser()
+				userData.EmailValidationSentTime = now
+				return userData
+			},
+			mockBehavior: func(m *emailMocks) {},
+			wantError:    moduleErrors.ErrorServiceEmailResendTooSoon,
+		},
+		{
+			name: "Already verified",
+			userData: func() *core.UserDB {
+				userData := testEmailUser()
+				userData.EmailIsValidated = pointy.Bool(true)
+				return userData
+			},
+			mockBehavior: func(m *emailMocks) {},
+			wantError:    moduleErrors.ErrorServiceEmailAlreadyVerified,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service, m := newTestEmailVerification(c)
+			m.userDB.EXPECT().GetUser(gomock.Any(), testEmailUserId).Return(testCase.userData(), nil)
+			testCase.mockBehavior(m)
+
+			if err := service.SendVerification(context.Background(), testEmailUserId); err != testCase.wantError {
+				t.Errorf("error = %v, want %v", err, testCase.wantError)
+			}
+		})
+	}
+}
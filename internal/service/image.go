@@ -0,0 +1,397 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/Thing-repository/backend-server/pkg/authz"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/Thing-repository/backend-server/pkg/images"
+	"github.com/sirupsen/logrus"
+	"io"
+	"path"
+	"strings"
+)
+
+const (
+	imageThumbnailSize   = 256
+	imageThumbnailSuffix = "_thumb"
+)
+
+type fileStorageImage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type companyDBImage interface {
+	GetCompany(ctx context.Context, companyId int) (*core.Company, error)
+	UpdateCompany(ctx context.Context, company core.CompanyUpdate, companyId int) error
+}
+
+type departmentDBImage interface {
+	GetDepartment(ctx context.Context, departmentId int) (*core.Department, error)
+	UpdateDepartment(ctx context.Context, department *core.DepartmentUpdate, departmentId int) error
+}
+
+type thingDBImage interface {
+	GetThing(ctx context.Context, thingId int) (*core.Thing, error)
+	UpdateThing(ctx context.Context, thing *core.ThingUpdate, thingId int) error
+}
+
+type userDBImage interface {
+	GetUser(ctx context.Context, userId int) (*core.UserDB, error)
+	PathUser(ctx context.Context, user *core.UserDB, userId int) error
+}
+
+// Image is service of uploaded images. Original image and its thumbnail are saved in file storage,
+// url of original image is saved to object.
+type Image struct {
+	storage      fileStorageImage
+	companyDB    companyDBImage
+	departmentDB departmentDBImage
+	thingDB      thingDBImage
+	userDB       userDBImage
+	publicURL    string
+	maxSize      int
+}
+
+// NewImage creates image service, publicURL is prefix of urls which serve stored files
+func NewImage(storage fileStorageImage, companyDB companyDBImage, departmentDB departmentDBImage, thingDB thingDBImage,
+	userDB userDBImage, publicURL string, maxSize int) *Image {
+	return &Image{
+		storage:      storage,
+		companyDB:    companyDB,
+		departmentDB: departmentDB,
+		thingDB:      thingDB,
+		userDB:       userDB,
+		publicURL:    strings.TrimSuffix(publicURL, "/"),
+		maxSize:      maxSize,
+	}
+}
+
+func (I *Image) LoadCompanyImage(ctx context.Context, companyId int, upload *core.ImageUpload) (*core.Image, error) {
+	logBase := logrus.Fields{
+		"module":    "service",
+		"function":  "LoadCompanyImage",
+		"companyId": companyId,
+		"context":   *core.LogContext(ctx),
+	}
+
+	if err := authz.Authorize(ctx, authz.ActionUpdate, authz.Company(companyId)); err != nil {
+		return nil, err
+	}
+
+	companyData, err := I.companyDB.GetCompany(ctx, companyId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get company from database")
+		return nil, err
+	}
+
+	key, err := I.saveImage(ctx, fmt.Sprintf("companies/%d", companyId), upload)
+	if err != nil {
+		return nil, err
+	}
+
+	imageURL := I.fileURL(key)
+	err = I.companyDB.UpdateCompany(ctx, core.CompanyUpdate{ImageURL: &imageURL}, companyId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error update company image in database")
+		I.deleteImage(ctx, key)
+		return nil, err
+	}
+
+	I.deleteOldImage(ctx, companyData.ImageURL)
+
+	return I.image(key), nil
+}
+
+func (I *Image) LoadDepartmentImage(ctx context.Context, departmentId int, upload *core.ImageUpload) (*core.Image, error) {
+	logBase := logrus.Fields{
+		"module":       "service",
+		"function":     "LoadDepartmentImage",
+		"departmentId": departmentId,
+		"context":      *core.LogContext(ctx),
+	}
+
+	departmentData, err := I.departmentDB.GetDepartment(ctx, departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get department from database")
+		return nil, departmentDBError(err)
+	}
+
+	err = authz.Authorize(ctx, authz.ActionUpdate, authz.Department(*departmentData.CompanyId, departmentId))
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := I.saveImage(ctx, fmt.Sprintf("departments/%d", departmentId), upload)
+	if err != nil {
+		return nil, err
+	}
+
+	imageURL := I.fileURL(key)
+	err = I.departmentDB.UpdateDepartment(ctx, &core.DepartmentUpdate{ImageURL: &imageURL}, departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error update department image in database")
+		I.deleteImage(ctx, key)
+		return nil, departmentDBError(err)
+	}
+
+	I.deleteOldImage(ctx, departmentData.ImageURL)
+
+	return I.image(key), nil
+}
+
+func (I *Image) LoadThingImage(ctx context.Context, thingId int, upload *core.ImageUpload) (*core.Image, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "LoadThingImage",
+		"thingId":  thingId,
+		"context":  *core.LogContext(ctx),
+	}
+
+	thingData, err := I.thingDB.GetThing(ctx, thingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get thing from database")
+		if err == moduleErrors.ErrorDatabaseThingNotFound {
+			return nil, moduleErrors.ErrorServiceThingNotFound
+		}
+		return nil, err
+	}
+
+	err = authz.Authorize(ctx, authz.ActionUpdate, authz.Thing(thingData.CompanyId, thingData.DepartmentId))
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := I.saveImage(ctx, fmt.Sprintf("things/%d", thingId), upload)
+	if err != nil {
+		return nil, err
+	}
+
+	imageURL := I.fileURL(key)
+	err = I.thingDB.UpdateThing(ctx, &core.ThingUpdate{ImageURL: &imageURL}, thingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error update thing image in database")
+		I.deleteImage(ctx, key)
+		if err == moduleErrors.ErrorDatabaseThingNotFound {
+			return nil, moduleErrors.ErrorServiceThingNotFound
+		}
+		return nil, err
+	}
+
+	oldImageURL := thingData.ImageURL
+	I.deleteOldImage(ctx, &oldImageURL)
+
+	return I.image(key), nil
+}
+
+func (I *Image) LoadCurrentUserImage(ctx context.Context, upload *core.ImageUpload) (*core.Image, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "LoadCurrentUserImage",
+		"context":  *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	userData, err := I.userDB.GetUser(ctx, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user data from db")
+		if err == moduleErrors.ErrorDatabaseUserNotFound {
+			return nil, moduleErrors.ErrorServiceUserNotFound
+		}
+		return nil, moduleErrors.ErrorServiceGetUserData
+	}
+
+	key, err := I.saveImage(ctx, fmt.Sprintf("users/%d", userId), upload)
+	if err != nil {
+		return nil, err
+	}
+
+	imageURL := I.fileURL(key)
+	newUserData := core.UserDB{User: core.User{ImageURL: &imageURL}}
+	if err = I.userDB.PathUser(ctx, &newUserData, userId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error update user image in db")
+		I.deleteImage(ctx, key)
+		return nil, err
+	}
+
+	I.deleteOldImage(ctx, userData.ImageURL)
+
+	return I.image(key), nil
+}
+
+// GetFile returns stored file and its content type
+func (I *Image) GetFile(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	reader, contentType, err := I.storage.Get(ctx, key)
+	if err != nil {
+		switch err {
+		case moduleErrors.ErrorStorageFileNotFound, moduleErrors.ErrorStorageInvalidKey:
+			return nil, "", moduleErrors.ErrorServiceFileNotFound
+		default:
+			logrus.WithFields(logrus.Fields{
+				"module":   "service",
+				"function": "GetFile",
+				"key":      key,
+				"error":    err.Error(),
+			}).Error("error get file from storage")
+			return nil, "", err
+		}
+	}
+
+	return reader, contentType, nil
+}
+
+// saveImage validates upload, stores image and its thumbnail under new random key in prefix and returns key
+// of original image. Content type is detected from data, declared content type must be one of image types.
+func (I *Image) saveImage(ctx context.Context, prefix string, upload *core.ImageUpload) (string, error) {
+	logBase := logrus.Fields{
+		"module":      "service",
+		"function":    "saveImage",
+		"prefix":      prefix,
+		"contentType": upload.ContentType,
+		"size":        len(upload.Data),
+	}
+
+	if len(upload.Data) > I.maxSize {
+		return "", moduleErrors.ErrorServiceImageTooLarge
+	}
+	if !images.Supported(upload.ContentType) {
+		return "", moduleErrors.ErrorServiceUnsupportedImageType
+	}
+
+	img, err := images.Decode(upload.Data)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Warning("error decode image")
+		switch err {
+		case images.ErrorUnsupportedType:
+			return "", moduleErrors.ErrorServiceUnsupportedImageType
+		case images.ErrorImageTooLarge:
+			return "", moduleErrors.ErrorServiceImageTooLarge
+		default:
+			return "", moduleErrors.ErrorServiceInvalidImage
+		}
+	}
+
+	thumbnail := images.Thumbnail(img, imageThumbnailSize)
+	thumbnailData, err := images.Encode(thumbnail)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error encode thumbnail")
+		return "", err
+	}
+
+	name := make([]byte, 16)
+	if _, err = rand.Read(name); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error generate file name")
+		return "", err
+	}
+	key := prefix + "/" + hex.EncodeToString(name) + img.Extension()
+
+	if err = I.storage.Put(ctx, key, upload.Data, img.ContentType); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"key":   key,
+			"error": err.Error(),
+		}).Error("error put image to storage")
+		return "", err
+	}
+
+	if err = I.storage.Put(ctx, thumbnailKey(key), thumbnailData, thumbnail.ContentType); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"key":   key,
+			"error": err.Error(),
+		}).Error("error put thumbnail to storage")
+		I.deleteImage(ctx, key)
+		return "", err
+	}
+
+	return key, nil
+}
+
+// deleteImage removes image and its thumbnail, errors are only logged because image is already unused
+func (I *Image) deleteImage(ctx context.Context, key string) {
+	for _, fileKey := range []string{key, thumbnailKey(key)} {
+		if err := I.storage.Delete(ctx, fileKey); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"module":   "service",
+				"function": "deleteImage",
+				"key":      fileKey,
+				"error":    err.Error(),
+			}).Error("error delete file from storage")
+		}
+	}
+}
+
+// deleteOldImage removes replaced image, images which aren't stored by service are skipped
+func (I *Image) deleteOldImage(ctx context.Context, imageURL *string) {
+	if imageURL == nil || !strings.HasPrefix(*imageURL, I.publicURL+"/") {
+		return
+	}
+	I.deleteImage(ctx, strings.TrimPrefix(*imageURL, I.publicURL+"/"))
+}
+
+func (I *Image) fileURL(key string) string {
+	return I.publicURL + "/" + key
+}
+
+func (I *Image) image(key string) *core.Image {
+	return &core.Image{
+		ImageURL:     I.fileURL(key),
+		ThumbnailURL: I.fileURL(thumbnailKey(key)),
+	}
+}
+
+// thumbnailKey returns key of image thumbnail, gif thumbnails are saved as png
+func thumbnailKey(key string) string {
+	extension := path.Ext(key)
+	base := strings.TrimSuffix(key, extension)
+	if extension == ".gif" {
+		extension = ".png"
+	}
+	return base + imageThumbnailSuffix + extension
+}
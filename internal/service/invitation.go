@@ -0,0 +1,518 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/Thing-repository/backend-server/pkg/authz"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const (
+	invitationTTL        = time.Hour * 24 * 7
+	invitationSubject    = "Thing repository invitation"
+	invitationBodyFormat = "Hello!\n\nYou are invited to department %s of company %s.\n" +
+		"To accept invitation sign up with this email by link:\n%s?invitation=%s\n\nInvitation is valid for 7 days."
+	invitationNotificationTitle  = "Invitation to department"
+	invitationNotificationFormat = "You are invited to department %s. Invitation is valid for 7 days."
+)
+
+type invitationDBInvitation interface {
+	AddInvitation(ctx context.Context, invitation *core.InvitationAdd, invitedBy int, tokenHash string,
+		expiresTime uint32) (*core.Invitation, error)
+	GetInvitationForUpdate(ctx context.Context, invitationId int) (*core.Invitation, error)
+	GetUserInvitations(ctx context.Context, userId int) ([]core.Invitation, error)
+	AttachEmailInvitations(ctx context.Context, email string, userId int) ([]core.Invitation, error)
+	AttachTokenInvitation(ctx context.Context, tokenHash string, email string, userId int) (*core.Invitation, error)
+	SetInvitationStatus(ctx context.Context, invitationId int, status string) error
+}
+
+type userDBInvitation interface {
+	GetUser(ctx context.Context, userId int) (*core.UserDB, error)
+	GetUserByEmail(ctx context.Context, email string) (*core.UserDB, error)
+	PathUser(ctx context.Context, user *core.UserDB, userId int) error
+}
+
+type departmentDBInvitation interface {
+	GetDepartment(ctx context.Context, departmentId int) (*core.Department, error)
+}
+
+type companyDBInvitation interface {
+	GetCompany(ctx context.Context, companyId int) (*core.Company, error)
+}
+
+type credentialsDBInvitation interface {
+	CreateCredential(ctx context.Context, credentials *core.AddCredentials) (int, error)
+}
+
+type tokenInvitation interface {
+	GenerateRandomToken() (string, error)
+	HashRandomToken(token string) string
+}
+
+type mailerInvitation interface {
+	Send(ctx context.Context, message *core.MailMessage) error
+}
+
+type notifierInvitation interface {
+	Notify(ctx context.Context, notification *core.NotificationAdd) error
+}
+
+type publisherInvitation interface {
+	Publish(ctx context.Context, companyId int, eventType string, data interface{}) error
+}
+
+type transactionDBInvitation interface {
+	InjectTx(ctx context.Context) (context.Context, error)
+	CommitTx(ctx context.Context) error
+	RollbackTx(ctx context.Context) error
+	RollbackTxDefer(ctx context.Context)
+}
+
+type Invitation struct {
+	invitationDB  invitationDBInvitation
+	userDB        userDBInvitation
+	departmentDB  departmentDBInvitation
+	companyDB     companyDBInvitation
+	credentialsDB credentialsDBInvitation
+	token         tokenInvitation
+	mailer        mailerInvitation
+	notifier      notifierInvitation
+	publisher     publisherInvitation
+	transactionDB transactionDBInvitation
+	signUpURL     string
+}
+
+// NewInvitation creates service, signUpURL is frontend page which is sent to invited not registered users
+func NewInvitation(invitationDB invitationDBInvitation, userDB userDBInvitation, departmentDB departmentDBInvitation,
+	companyDB companyDBInvitation, credentialsDB credentialsDBInvitation, token tokenInvitation,
+	mailer mailerInvitation, notifier notifierInvitation, publisher publisherInvitation,
+	transactionDB transactionDBInvitation, signUpURL string) *Invitation {
+	return &Invitation{
+		invitationDB:  invitationDB,
+		userDB:        userDB,
+		departmentDB:  departmentDB,
+		companyDB:     companyDB,
+		credentialsDB: credentialsDB,
+		token:         token,
+		mailer:        mailer,
+		notifier:      notifier,
+		publisher:     publisher,
+		transactionDB: transactionDB,
+		signUpURL:     signUpURL,
+	}
+}
+
+// Invite invites user without company to department. Invitation by verified email of registered user
+// is stored as invitation by user id and user is notified. Other user gets email with secret token, invitation
+// is accepted on sign up with token or after verification of email.
+func (I *Invitation) Invite(ctx context.Context, invitationAdd *core.InvitationAdd) (*core.Invitation, error) {
+	logBase := logrus.Fields{
+		"module":        "service",
+		"function":      "Invite",
+		"invitationAdd": invitationAdd,
+		"context":       *core.LogContext(ctx),
+	}
+
+	invitedBy, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	if (invitationAdd.UserId == nil) == (invitationAdd.Email == nil) {
+		return nil, moduleErrors.ErrorServiceInvalidInvitation
+	}
+
+	departmentData, err := I.departmentDB.GetDepartment(ctx, invitationAdd.DepartmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get department data from db")
+		return nil, departmentDBError(err)
+	}
+
+	err = authz.Authorize(ctx, authz.ActionAddMember, authz.Department(*departmentData.CompanyId, *departmentData.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := *invitationAdd
+	if invitation.Email != nil {
+		userData, err := I.userDB.GetUserByEmail(ctx, *invitation.Email)
+		switch err {
+		case nil:
+			// not verified email can be registered by other person
+			if userData.EmailIsValidated != nil && *userData.EmailIsValidated {
+				invitation.UserId = &userData.Id
+				invitation.Email = nil
+			}
+		case moduleErrors.ErrorDatabaseUserNotFound:
+		default:
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Error("error get user by email")
+			return nil, err
+		}
+	}
+
+	if invitation.UserId != nil {
+		userData, err := I.userDB.GetUser(ctx, *invitation.UserId)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Error("error get invited user")
+			if err == moduleErrors.ErrorDatabaseUserNotFound {
+				return nil, moduleErrors.ErrorServiceUserNotFound
+			}
+			return nil, err
+		}
+		if userData.CompanyId != nil {
+			return nil, moduleErrors.ErrorServiceUserAlreadyHasCompany
+		}
+	}
+
+	var token, tokenHash string
+	if invitation.Email != nil {
+		token, err = I.token.GenerateRandomToken()
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Error("error generate invitation token")
+			return nil, err
+		}
+		tokenHash = I.token.HashRandomToken(token)
+	}
+
+	expiresTime := uint32(time.Now().Add(invitationTTL).Unix())
+	invitationData, err := I.invitationDB.AddInvitation(ctx, &invitation, invitedBy, tokenHash, expiresTime)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error add invitation to db")
+		return nil, departmentDBError(err)
+	}
+
+	if invitation.Email != nil {
+		if err = I.sendInvitation(ctx, *invitation.Email, token, departmentData); err != nil {
+			return nil, err
+		}
+	} else {
+		err = I.notifier.Notify(ctx, &core.NotificationAdd{
+			UserId:   *invitation.UserId,
+			Type:     core.NotificationInvitationReceived,
+			Title:    invitationNotificationTitle,
+			Body:     fmt.Sprintf(invitationNotificationFormat, *departmentData.DepartmentName),
+			ObjectId: &invitationData.Id,
+		})
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Error("error notify invited user")
+			return nil, err
+		}
+	}
+
+	return invitationData, nil
+}
+
+// GetMyInvitations returns active invitations of user from context
+func (I *Invitation) GetMyInvitations(ctx context.Context) ([]core.Invitation, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "GetMyInvitations",
+		"context":  *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	invitations, err := I.invitationDB.GetUserInvitations(ctx, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get invitations from db")
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+// AcceptInvitation adds user from context to department of invitation
+func (I *Invitation) AcceptInvitation(ctx context.Context, invitationId int) error {
+	return I.answerInvitation(ctx, invitationId, core.InvitationStatusAccepted)
+}
+
+func (I *Invitation) DeclineInvitation(ctx context.Context, invitationId int) error {
+	return I.answerInvitation(ctx, invitationId, core.InvitationStatusDeclined)
+}
+
+func (I *Invitation) answerInvitation(ctx context.Context, invitationId int, status string) error {
+	logBase := logrus.Fields{
+		"module":       "service",
+		"function":     "answerInvitation",
+		"invitationId": invitationId,
+		"status":       status,
+		"context":      *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return moduleErrors.ErrorServiceInvalidContext
+	}
+
+	ctx, err = I.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return err
+	}
+
+	defer I.transactionDB.RollbackTxDefer(ctx)
+
+	invitation, err := I.invitationDB.GetInvitationForUpdate(ctx, invitationId)
+	if err != nil {
+		if err == moduleErrors.ErrorDatabaseInvitationNotFound {
+			return moduleErrors.ErrorServiceInvitationNotFound
+		}
+		return err
+	}
+
+	// other user can't find out that invitation exists
+	if invitation.UserId == nil || *invitation.UserId != userId {
+		logrus.WithFields(logrus.Fields{
+			"base":       logBase,
+			"invitation": invitation,
+		}).Warning("invitation of other user")
+		return moduleErrors.ErrorServiceInvitationNotFound
+	}
+
+	if invitation.Status != core.InvitationStatusPending || int64(invitation.ExpiresTime) < time.Now().Unix() {
+		logrus.WithFields(logrus.Fields{
+			"base":       logBase,
+			"invitation": invitation,
+		}).Error(moduleErrors.ErrorServiceInvitationNotActive.Error())
+		return moduleErrors.ErrorServiceInvitationNotActive
+	}
+
+	if status == core.InvitationStatusAccepted {
+		if err = I.join(ctx, userId, invitation); err != nil {
+			return err
+		}
+	}
+
+	if err = I.invitationDB.SetInvitationStatus(ctx, invitationId, status); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error set invitation status")
+		return err
+	}
+
+	if err = I.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// AcceptEmailInvitations is called after verification of user email in its transaction. Invitations sent
+// to email are bound to user and the newest one is accepted if user has no company. Department of accepted
+// invitation is returned, nil if invitation isn't accepted.
+func (I *Invitation) AcceptEmailInvitations(ctx context.Context, userId int, email string) (*core.Department, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "AcceptEmailInvitations",
+		"userId":   userId,
+	}
+
+	invitations, err := I.invitationDB.AttachEmailInvitations(ctx, email, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error attach email invitations")
+		return nil, err
+	}
+
+	if len(invitations) == 0 {
+		return nil, nil
+	}
+
+	userData, err := I.userDB.GetUser(ctx, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user data")
+		return nil, moduleErrors.ErrorServiceGetUserData
+	}
+
+	// bound invitations are answered by user after leaving company
+	if userData.CompanyId != nil {
+		return nil, nil
+	}
+
+	return I.acceptAttached(ctx, userId, &invitations[0])
+}
+
+// AcceptTokenInvitation is called on sign up in transaction of new user. Token from invitation email proves that
+// user received it, so invitation is accepted before verification of email. Department of accepted invitation is
+// returned, nil if invitation with token isn't found.
+func (I *Invitation) AcceptTokenInvitation(ctx context.Context, userId int, email string,
+	token string) (*core.Department, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "AcceptTokenInvitation",
+		"userId":   userId,
+	}
+
+	invitation, err := I.invitationDB.AttachTokenInvitation(ctx, I.token.HashRandomToken(token), email, userId)
+	if err != nil {
+		// sign up with expired or answered invitation isn't failed
+		if err == moduleErrors.ErrorDatabaseInvitationNotFound {
+			return nil, nil
+		}
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error attach token invitation")
+		return nil, err
+	}
+
+	return I.acceptAttached(ctx, userId, invitation)
+}
+
+// acceptAttached adds user without company to department of invitation bound to the user
+func (I *Invitation) acceptAttached(ctx context.Context, userId int,
+	invitation *core.Invitation) (*core.Department, error) {
+	logBase := logrus.Fields{
+		"module":       "service",
+		"function":     "acceptAttached",
+		"userId":       userId,
+		"invitationId": invitation.Id,
+	}
+
+	departmentData, err := I.departmentDB.GetDepartment(ctx, invitation.DepartmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get department data from db")
+		return nil, departmentDBError(err)
+	}
+
+	if err = addMember(ctx, I.userDB, I.credentialsDB, I.publisher, userId, departmentData); err != nil {
+		return nil, err
+	}
+
+	if err = I.invitationDB.SetInvitationStatus(ctx, invitation.Id, core.InvitationStatusAccepted); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error set invitation status")
+		return nil, err
+	}
+
+	return departmentData, nil
+}
+
+// join adds user to department of invitation if user has no company
+func (I *Invitation) join(ctx context.Context, userId int, invitation *core.Invitation) error {
+	logBase := logrus.Fields{
+		"module":       "service",
+		"function":     "join",
+		"userId":       userId,
+		"invitationId": invitation.Id,
+	}
+
+	userData, err := I.userDB.GetUser(ctx, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user data")
+		return moduleErrors.ErrorServiceGetUserData
+	}
+
+	if userData.CompanyId != nil {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error(moduleErrors.ErrorServiceUserAlreadyHasCompany.Error())
+		return moduleErrors.ErrorServiceUserAlreadyHasCompany
+	}
+
+	departmentData, err := I.departmentDB.GetDepartment(ctx, invitation.DepartmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get department data from db")
+		return departmentDBError(err)
+	}
+
+	return addMember(ctx, I.userDB, I.credentialsDB, I.publisher, userId, departmentData)
+}
+
+func (I *Invitation) sendInvitation(ctx context.Context, email string, token string,
+	departmentData *core.Department) error {
+	logBase := logrus.Fields{
+		"module":       "service",
+		"function":     "sendInvitation",
+		"departmentId": *departmentData.Id,
+	}
+
+	companyData, err := I.companyDB.GetCompany(ctx, *departmentData.CompanyId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get company data")
+		return err
+	}
+
+	message := &core.MailMessage{
+		To:      email,
+		Subject: invitationSubject,
+		Body: fmt.Sprintf(invitationBodyFormat, *departmentData.DepartmentName, *companyData.CompanyName,
+			I.signUpURL, token),
+	}
+
+	if err = I.mailer.Send(ctx, message); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error send invitation email")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,411 @@
+package service
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/authz"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=join_request.go -destination=mock/joinRequestMock.go
+type joinRequestDBJoinRequest interface {
+	AddJoinRequest(ctx context.Context, joinRequest *core.JoinRequestAdd, userId int) (*core.JoinRequest, error)
+	GetJoinRequestForUpdate(ctx context.Context, joinRequestId int) (*core.JoinRequest, error)
+	GetUserJoinRequests(ctx context.Context, userId int) ([]core.JoinRequest, error)
+	GetDepartmentJoinRequests(ctx context.Context, departmentId int) ([]core.JoinRequest, error)
+	GetCompanyJoinRequests(ctx context.Context, companyId int) ([]core.JoinRequest, error)
+	SetJoinRequestStatus(ctx context.Context, joinRequestId int, status string, answeredBy *int) error
+	CancelUserJoinRequests(ctx context.Context, userId int) error
+}
+
+type userDBJoinRequest interface {
+	GetUser(ctx context.Context, userId int) (*core.UserDB, error)
+	PathUser(ctx context.Context, user *core.UserDB, userId int) error
+}
+
+type departmentDBJoinRequest interface {
+	GetDepartment(ctx context.Context, departmentId int) (*core.Department, error)
+}
+
+type credentialsDBJoinRequest interface {
+	CreateCredential(ctx context.Context, credentials *core.AddCredentials) (int, error)
+}
+
+type publisherJoinRequest interface {
+	Publish(ctx context.Context, companyId int, eventType string, data interface{}) error
+}
+
+type transactionDBJoinRequest interface {
+	InjectTx(ctx context.Context) (context.Context, error)
+	CommitTx(ctx context.Context) error
+	RollbackTx(ctx context.Context) error
+	RollbackTxDefer(ctx context.Context)
+}
+
+type JoinRequest struct {
+	joinRequestDB joinRequestDBJoinRequest
+	userDB        userDBJoinRequest
+	departmentDB  departmentDBJoinRequest
+	credentialsDB credentialsDBJoinRequest
+	publisher     publisherJoinRequest
+	transactionDB transactionDBJoinRequest
+}
+
+func NewJoinRequest(joinRequestDB joinRequestDBJoinRequest, userDB userDBJoinRequest,
+	departmentDB departmentDBJoinRequest, credentialsDB credentialsDBJoinRequest, publisher publisherJoinRequest,
+	transactionDB transactionDBJoinRequest) *JoinRequest {
+	return &JoinRequest{
+		joinRequestDB: joinRequestDB,
+		userDB:        userDB,
+		departmentDB:  departmentDB,
+		credentialsDB: credentialsDB,
+		publisher:     publisher,
+		transactionDB: transactionDB,
+	}
+}
+
+// AddJoinRequest creates request of user from context to join department, user must have no company
+func (J *JoinRequest) AddJoinRequest(ctx context.Context, joinRequestAdd *core.JoinRequestAdd) (*core.JoinRequest, error) {
+	logBase := logrus.Fields{
+		"module":         "service",
+		"function":       "AddJoinRequest",
+		"joinRequestAdd": joinRequestAdd,
+		"context":        *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	userData, err := J.userDB.GetUser(ctx, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user data")
+		return nil, moduleErrors.ErrorServiceGetUserData
+	}
+
+	if userData.CompanyId != nil {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error(moduleErrors.ErrorServiceUserAlreadyHasCompany.Error())
+		return nil, moduleErrors.ErrorServiceUserAlreadyHasCompany
+	}
+
+	joinRequest, err := J.joinRequestDB.AddJoinRequest(ctx, joinRequestAdd, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error add join request to db")
+		if err == moduleErrors.ErrorDatabaseJoinRequestExists {
+			return nil, moduleErrors.ErrorServiceJoinRequestExists
+		}
+		return nil, departmentDBError(err)
+	}
+
+	return joinRequest, nil
+}
+
+// GetMyJoinRequests returns all join requests of user from context
+func (J *JoinRequest) GetMyJoinRequests(ctx context.Context) ([]core.JoinRequest, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "GetMyJoinRequests",
+		"context":  *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	joinRequests, err := J.joinRequestDB.GetUserJoinRequests(ctx, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get join requests from db")
+		return nil, err
+	}
+
+	return joinRequests, nil
+}
+
+// GetDepartmentJoinRequests returns pending join requests to department for its admins
+func (J *JoinRequest) GetDepartmentJoinRequests(ctx context.Context, departmentId int) ([]core.JoinRequest, error) {
+	logBase := logrus.Fields{
+		"module":       "service",
+		"function":     "GetDepartmentJoinRequests",
+		"departmentId": departmentId,
+		"context":      *core.LogContext(ctx),
+	}
+
+	departmentData, err := J.departmentDB.GetDepartment(ctx, departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get department data from db")
+		return nil, departmentDBError(err)
+	}
+
+	err = authz.Authorize(ctx, authz.ActionAddMember, authz.Department(*departmentData.CompanyId, departmentId))
+	if err != nil {
+		return nil, err
+	}
+
+	joinRequests, err := J.joinRequestDB.GetDepartmentJoinRequests(ctx, departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get join requests from db")
+		return nil, err
+	}
+
+	return joinRequests, nil
+}
+
+// GetCompanyJoinRequests returns pending join requests to all departments of company for company admins
+func (J *JoinRequest) GetCompanyJoinRequests(ctx context.Context, companyId int) ([]core.JoinRequest, error) {
+	logBase := logrus.Fields{
+		"module":    "service",
+		"function":  "GetCompanyJoinRequests",
+		"companyId": companyId,
+		"context":   *core.LogContext(ctx),
+	}
+
+	if err := authz.Authorize(ctx, authz.ActionAddMember, authz.Company(companyId)); err != nil {
+		return nil, err
+	}
+
+	joinRequests, err := J.joinRequestDB.GetCompanyJoinRequests(ctx, companyId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get join requests from db")
+		return nil, err
+	}
+
+	return joinRequests, nil
+}
+
+// CancelJoinRequest cancels pending join request of user from context
+func (J *JoinRequest) CancelJoinRequest(ctx context.Context, joinRequestId int) error {
+	logBase := logrus.Fields{
+		"module":        "service",
+		"function":      "CancelJoinRequest",
+		"joinRequestId": joinRequestId,
+		"context":       *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return moduleErrors.ErrorServiceInvalidContext
+	}
+
+	ctx, err = J.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return err
+	}
+
+	defer J.transactionDB.RollbackTxDefer(ctx)
+
+	joinRequest, err := J.getPendingJoinRequest(ctx, joinRequestId)
+	if err != nil {
+		return err
+	}
+
+	// other user can't find out that join request exists
+	if joinRequest.UserId != userId {
+		logrus.WithFields(logrus.Fields{
+			"base":        logBase,
+			"joinRequest": joinRequest,
+		}).Warning("join request of other user")
+		return moduleErrors.ErrorServiceJoinRequestNotFound
+	}
+
+	if err = J.joinRequestDB.SetJoinRequestStatus(ctx, joinRequestId, core.JoinRequestStatusCancelled, nil); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error set join request status")
+		return err
+	}
+
+	if err = J.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// ApproveJoinRequest adds user to department of join request the same way as User.AddUserToCompany,
+// other pending join requests of user are cancelled
+func (J *JoinRequest) ApproveJoinRequest(ctx context.Context, joinRequestId int) error {
+	return J.answerJoinRequest(ctx, joinRequestId, core.JoinRequestStatusApproved)
+}
+
+func (J *JoinRequest) RejectJoinRequest(ctx context.Context, joinRequestId int) error {
+	return J.answerJoinRequest(ctx, joinRequestId, core.JoinRequestStatusRejected)
+}
+
+func (J *JoinRequest) answerJoinRequest(ctx context.Context, joinRequestId int, status string) error {
+	logBase := logrus.Fields{
+		"module":        "service",
+		"function":      "answerJoinRequest",
+		"joinRequestId": joinRequestId,
+		"status":        status,
+		"context":       *core.LogContext(ctx),
+	}
+
+	adminId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return moduleErrors.ErrorServiceInvalidContext
+	}
+
+	ctx, err = J.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return err
+	}
+
+	defer J.transactionDB.RollbackTxDefer(ctx)
+
+	joinRequest, err := J.getPendingJoinRequest(ctx, joinRequestId)
+	if err != nil {
+		return err
+	}
+
+	err = authz.Authorize(ctx, authz.ActionAddMember, authz.Department(joinRequest.CompanyId, joinRequest.DepartmentId))
+	if err != nil {
+		return err
+	}
+
+	if status == core.JoinRequestStatusApproved {
+		if err = J.join(ctx, joinRequest); err != nil {
+			return err
+		}
+	}
+
+	if err = J.joinRequestDB.SetJoinRequestStatus(ctx, joinRequestId, status, &adminId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error set join request status")
+		return err
+	}
+
+	if err = J.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// join adds user of join request to its department, user must still have no company
+func (J *JoinRequest) join(ctx context.Context, joinRequest *core.JoinRequest) error {
+	logBase := logrus.Fields{
+		"module":        "service",
+		"function":      "join",
+		"userId":        joinRequest.UserId,
+		"joinRequestId": joinRequest.Id,
+	}
+
+	userData, err := J.userDB.GetUser(ctx, joinRequest.UserId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user data")
+		return moduleErrors.ErrorServiceGetUserData
+	}
+
+	if userData.CompanyId != nil {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error(moduleErrors.ErrorServiceUserAlreadyHasCompany.Error())
+		return moduleErrors.ErrorServiceUserAlreadyHasCompany
+	}
+
+	departmentData, err := J.departmentDB.GetDepartment(ctx, joinRequest.DepartmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get department data from db")
+		return departmentDBError(err)
+	}
+
+	if err = addMember(ctx, J.userDB, J.credentialsDB, J.publisher, joinRequest.UserId, departmentData); err != nil {
+		return err
+	}
+
+	if err = J.joinRequestDB.CancelUserJoinRequests(ctx, joinRequest.UserId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error cancel other join requests of user")
+		return err
+	}
+
+	return nil
+}
+
+// getPendingJoinRequest locks join request, it must be called in transaction
+func (J *JoinRequest) getPendingJoinRequest(ctx context.Context, joinRequestId int) (*core.JoinRequest, error) {
+	joinRequest, err := J.joinRequestDB.GetJoinRequestForUpdate(ctx, joinRequestId)
+	if err != nil {
+		if err == moduleErrors.ErrorDatabaseJoinRequestNotFound {
+			return nil, moduleErrors.ErrorServiceJoinRequestNotFound
+		}
+		return nil, err
+	}
+
+	if joinRequest.Status != core.JoinRequestStatusPending {
+		logrus.WithFields(logrus.Fields{
+			"module":      "service",
+			"function":    "getPendingJoinRequest",
+			"joinRequest": joinRequest,
+		}).Error(moduleErrors.ErrorServiceJoinRequestNotActive.Error())
+		return nil, moduleErrors.ErrorServiceJoinRequestNotActive
+	}
+
+	return joinRequest, nil
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	mockService "github.com/Thing-repository/backend-server/internal/service/mock"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/golang/mock/gomock"
+	"github.com/openlyinc/pointy"
+	"testing"
+)
+
+const (
+	testJoinRequestId           = 1
+	testJoinRequestCompanyId    = 2
+	testJoinRequestDepartmentId = 3
+	testJoinRequestUserId       = 4
+	testJoinRequestAdminId      = 5
+)
+
+type joinRequestMocks struct {
+	joinRequestDB *mockService.MockjoinRequestDBJoinRequest
+	userDB        *mockService.MockuserDBJoinRequest
+	departmentDB  *mockService.MockdepartmentDBJoinRequest
+	credentialsDB *mockService.MockcredentialsDBJoinRequest
+	publisher     *mockService.MockpublisherJoinRequest
+	transactionDB *mockService.MocktransactionDBJoinRequest
+}
+
+func newTestJoinRequest(c *gomock.Controller) (*JoinRequest, *joinRequestMocks) {
+	m := &joinRequestMocks{
+		joinRequestDB: mockService.NewMockjoinRequestDBJoinRequest(c),
+		userDB:        mockService.NewMockuserDBJoinRequest(c),
+		departmentDB:  mockService.NewMockdepartmentDBJoinRequest(c),
+		credentialsDB: mockService.NewMockcredentialsDBJoinRequest(c),
+		publisher:     mockService.NewMockpublisherJoinRequest(c),
+		transactionDB: mockService.NewMocktransactionDBJoinRequest(c),
+	}
+
+	m.transactionDB.EXPECT().InjectTx(gomock.Any()).DoAndReturn(
+		func(ctx context.Context) (context.Context, error) { return ctx, nil }).AnyTimes()
+	m.transactionDB.EXPECT().RollbackTxDefer(gomock.Any()).AnyTimes()
+	m.transactionDB.EXPECT().CommitTx(gomock.Any()).Return(nil).AnyTimes()
+
+	return NewJoinRequest(m.joinRequestDB, m.userDB, m.departmentDB, m.credentialsDB, m.publisher,
+		m.transactionDB), m
+}
+
+func testJoinRequest(status string) *core.JoinRequest {
+	return &core.JoinRequest{
+		JoinRequestAdd: core.JoinRequestAdd{DepartmentId: testJoinRequestDepartmentId},
+		Id:             testJoinRequestId,
+		CompanyId:      testJoinRequestCompanyId,
+		UserId:         testJoinRequestUserId,
+		Status:         status,
+	}
+}
+
+func TestApproveJoinRequest(t *testing.T) {
+	departmentAdmin := []core.Credentials{
+		{CredentialType: core.CredentialTypeDepartmentAdmin, ObjectId: testJoinRequestDepartmentId},
+	}
+
+	testTable := []struct {
+		name         string
+		credentials  []core.Credentials
+		mockBehavior func(m *joinRequestMocks)
+		wantError    error
+	}{
+		{
+			// user is added the same way as by admin, other requests of user are cancelled
+			name:        "Department admin",
+			credentials: departmentAdmin,
+			mockBehavior: func(m *joinRequestMocks) {
+				m.joinRequestDB.EXPECT().GetJoinRequestForUpdate(gomock.Any(), testJoinRequestId).
+					Return(testJoinRequest(core.JoinRequestStatusPending), nil)
+				m.userDB.EXPECT().GetUser(gomock.Any(), testJoinRequestUserId).
+					Return(&core.UserDB{User: core.User{Id: testJoinRequestUserId}}, nil)
+				department := &core.Department{Id: pointy.Int(testJoinRequestDepartmentId),
+					DepartmentBase: core.DepartmentBase{CompanyId: pointy.Int(testJoinRequestCompanyId)}}
+				m.departmentDB.EXPECT().GetDepartment(gomock.Any(), testJoinRequestDepartmentId).
+					Return(department, nil)
+				m.userDB.EXPECT().PathUser(gomock.Any(), &core.UserDB{User: core.User{
+					CompanyId: department.CompanyId, DepartmentId: department.Id}}, testJoinRequestUserId).
+					Return(nil)
+				m.credentialsDB.EXPECT().CreateCredential(gomock.Any(), newCredential(testJoinRequestDepartmentId,
+					testJoinRequestUserId, core.CredentialTypeDepartmentUser)).Return(1, nil)
+				m.credentialsDB.EXPECT().CreateCredential(gomock.Any(), newCredential(testJoinRequestCompanyId,
+					testJoinRequestUserId, core.CredentialTypeCompanyUser)).Return(2, nil)
+				m.publisher.EXPECT().Publish(gomock.Any(), testJoinRequestCompanyId, core.WebhookEventUserJoined,
+					gomock.Any()).Return(nil)
+				m.joinRequestDB.EXPECT().CancelUserJoinRequests(gomock.Any(), testJoinRequestUserId).Return(nil)
+				m.joinRequestDB.EXPECT().SetJoinRequestStatus(gomock.Any(), testJoinRequestId,
+					core.JoinRequestStatusApproved, pointy.Int(testJoinRequestAdminId)).Return(nil)
+			},
+		},
+		{
+			name: "Department user",
+			credentials: []core.Credentials{
+				{CredentialType: core.CredentialTypeDepartmentUser, ObjectId: testJoinRequestDepartmentId},
+			},
+			mockBehavior: func(m *joinRequestMocks) {
+				m.joinRequestDB.EXPECT().GetJoinRequestForUpdate(gomock.Any(), testJoinRequestId).
+					Return(testJoinRequest(core.JoinRequestStatusPending), nil)
+			},
+			wantError: moduleErrors.ErrorServiceBadPermissions,
+		},
+		{
+			name:        "Answered request",
+			credentials: departmentAdmin,
+			mockBehavior: func(m *joinRequestMocks) {
+				m.joinRequestDB.EXPECT().GetJoinRequestForUpdate(gomock.Any(), testJoinRequestId).
+					Return(testJoinRequest(core.JoinRequestStatusCancelled), nil)
+			},
+			wantError: moduleErrors.ErrorServiceJoinRequestNotActive,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service, m := newTestJoinRequest(c)
+			testCase.mockBehavior(m)
+
+			ctx := core.ContextWithUser(context.Background(), testJoinRequestAdminId, testCase.credentials)
+			if err := service.ApproveJoinRequest(ctx, testJoinRequestId); err != testCase.wantError {
+				t.Errorf("error = %v, want %v", err, testCase.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: admin.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/Thing-repository/backend-server/pkg/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockuserDBAdmin is a mock of userDBAdmin interface.
+type MockuserDBAdmin struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserDBAdminMockRecorder
+}
+
+// MockuserDBAdminMockRecorder is the mock recorder for MockuserDBAdmin.
+type MockuserDBAdminMockRecorder struct {
+	mock *MockuserDBAdmin
+}
+
+// NewMockuserDBAdmin creates a new mock instance.
+func NewMockuserDBAdmin(ctrl *gomock.Controller) *MockuserDBAdmin {
+	mock := &MockuserDBAdmin{ctrl: ctrl}
+	mock.recorder = &MockuserDBAdminMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserDBAdmin) EXPECT() *MockuserDBAdminMockRecorder {
+	return m.recorder
+}
+
+// GetUser mocks base method.
+func (m *MockuserDBAdmin) GetUser(ctx context.Context, userId int) (*core.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userId)
+	ret0, _ := ret[0].(*core.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockuserDBAdminMockRecorder) GetUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockuserDBAdmin)(nil).GetUser), ctx, userId)
+}
+
+// SetUserLocked mocks base method.
+func (m *MockuserDBAdmin) SetUserLocked(ctx context.Context, userId int, locked bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserLocked", ctx, userId, locked)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserLocked indicates an expected call of SetUserLocked.
+func (mr *MockuserDBAdminMockRecorder) SetUserLocked(ctx, userId, locked interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserLocked", reflect.TypeOf((*MockuserDBAdmin)(nil).SetUserLocked), ctx, userId, locked)
+}
+
+// MockcredentialsDBAdmin is a mock of credentialsDBAdmin interface.
+type MockcredentialsDBAdmin struct {
+	ctrl     *gomock.Controller
+	recorder *MockcredentialsDBAdminMockRecorder
+}
+
+// MockcredentialsDBAdminMockRecorder is the mock recorder for MockcredentialsDBAdmin.
+type MockcredentialsDBAdminMockRecorder struct {
+	mock *MockcredentialsDBAdmin
+}
+
+// NewMockcredentialsDBAdmin creates a new mock instance.
+func NewMockcredentialsDBAdmin(ctrl *gomock.Controller) *MockcredentialsDBAdmin {
+	mock := &MockcredentialsDBAdmin{ctrl: ctrl}
+	mock.recorder = &MockcredentialsDBAdminMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcredentialsDBAdmin) EXPECT() *MockcredentialsDBAdminMockRecorder {
+	return m.recorder
+}
+
+// DeleteCredential mocks base method.
+func (m *MockcredentialsDBAdmin) DeleteCredential(ctx context.Context, credentials *core.AddCredentials) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCredential", ctx, credentials)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCredential indicates an expected call of DeleteCredential.
+func (mr *MockcredentialsDBAdminMockRecorder) DeleteCredential(ctx, credentials interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCredential", reflect.TypeOf((*MockcredentialsDBAdmin)(nil).DeleteCredential), ctx, credentials)
+}
+
+// EnsureCredential mocks base method.
+func (m *MockcredentialsDBAdmin) EnsureCredential(ctx context.Context, credentials *core.AddCredentials) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureCredential", ctx, credentials)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureCredential indicates an expected call of EnsureCredential.
+func (mr *MockcredentialsDBAdminMockRecorder) EnsureCredential(ctx, credentials interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureCredential", reflect.TypeOf((*MockcredentialsDBAdmin)(nil).EnsureCredential), ctx, credentials)
+}
+
+// GetCredentialUsers mocks base method.
+func (m *MockcredentialsDBAdmin) GetCredentialUsers(ctx context.Context, credentialType string, objectId int) ([]core.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCredentialUsers", ctx, credentialType, objectId)
+	ret0, _ := ret[0].([]core.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCredentialUsers indicates an expected call of GetCredentialUsers.
+func (mr *MockcredentialsDBAdminMockRecorder) GetCredentialUsers(ctx, credentialType, objectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentialUsers", reflect.TypeOf((*MockcredentialsDBAdmin)(nil).GetCredentialUsers), ctx, credentialType, objectId)
+}
+
+// MocksessionDBAdmin is a mock of sessionDBAdmin interface.
+type MocksessionDBAdmin struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionDBAdminMockRecorder
+}
+
+// MocksessionDBAdminMockRecorder is the mock recorder for MocksessionDBAdmin.
+type MocksessionDBAdminMockRecorder struct {
+	mock *MocksessionDBAdmin
+}
+
+// NewMocksessionDBAdmin creates a new mock instance.
+func NewMocksessionDBAdmin(ctrl *gomock.Controller) *MocksessionDBAdmin {
+	mock := &MocksessionDBAdmin{ctrl: ctrl}
+	mock.recorder = &MocksessionDBAdminMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionDBAdmin) EXPECT() *MocksessionDBAdminMockRecorder {
+	return m.recorder
+}
+
+// RevokeUserSessions mocks base method.
+func (m *MocksessionDBAdmin) RevokeUserSessions(ctx context.Context, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessions", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MocksessionDBAdminMockRecorder) RevokeUserSessions(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MocksessionDBAdmin)(nil).RevokeUserSessions), ctx, userId)
+}
+
+// MocktransactionDBAdmin is a mock of transactionDBAdmin interface.
+type MocktransactionDBAdmin struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactionDBAdminMockRecorder
+}
+
+// MocktransactionDBAdminMockRecorder is the mock recorder for MocktransactionDBAdmin.
+type MocktransactionDBAdminMockRecorder struct {
+	mock *MocktransactionDBAdmin
+}
+
+// NewMocktransactionDBAdmin creates a new mock instance.
+func NewMocktransactionDBAdmin(ctrl *gomock.Controller) *MocktransactionDBAdmin {
+	mock := &MocktransactionDBAdmin{ctrl: ctrl}
+	mock.recorder = &MocktransactionDBAdminMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktransactionDBAdmin) EXPECT() *MocktransactionDBAdminMockRecorder {
+	return m.recorder
+}
+
+// CommitTx mocks base method.
+func (m *MocktransactionDBAdmin) CommitTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitTx indicates an expected call of CommitTx.
+func (mr *MocktransactionDBAdminMockRecorder) CommitTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTx", reflect.TypeOf((*MocktransactionDBAdmin)(nil).CommitTx), ctx)
+}
+
+// InjectTx mocks base method.
+func (m *MocktransactionDBAdmin) InjectTx(ctx context.Context) (context.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectTx", ctx)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InjectTx indicates an expected call of InjectTx.
+func (mr *MocktransactionDBAdminMockRecorder) InjectTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectTx", reflect.TypeOf((*MocktransactionDBAdmin)(nil).InjectTx), ctx)
+}
+
+// RollbackTx mocks base method.
+func (m *MocktransactionDBAdmin) RollbackTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollbackTx indicates an expected call of RollbackTx.
+func (mr *MocktransactionDBAdminMockRecorder) RollbackTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTx", reflect.TypeOf((*MocktransactionDBAdmin)(nil).RollbackTx), ctx)
+}
+
+// RollbackTxDefer mocks base method.
+func (m *MocktransactionDBAdmin) RollbackTxDefer(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RollbackTxDefer", ctx)
+}
+
+// RollbackTxDefer indicates an expected call of RollbackTxDefer.
+func (mr *MocktransactionDBAdminMockRecorder) RollbackTxDefer(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTxDefer", reflect.TypeOf((*MocktransactionDBAdmin)(nil).RollbackTxDefer), ctx)
+}
@@ -35,19 +35,48 @@ func (m *Mocktoken) EXPECT() *MocktokenMockRecorder {
 	return m.recorder
 }
 
+// GenerateRandomToken mocks base method.
+func (m *Mocktoken) GenerateRandomToken() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateRandomToken")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateRandomToken indicates an expected call of GenerateRandomToken.
+func (mr *MocktokenMockRecorder) GenerateRandomToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateRandomToken", reflect.TypeOf((*Mocktoken)(nil).GenerateRandomToken))
+}
+
 // GenerateToken mocks base method.
-func (m *Mocktoken) GenerateToken(userId int) (string, error) {
+func (m *Mocktoken) GenerateToken(userId int, credentials []core.Credentials) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateToken", userId)
+	ret := m.ctrl.Call(m, "GenerateToken", userId, credentials)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GenerateToken indicates an expected call of GenerateToken.
-func (mr *MocktokenMockRecorder) GenerateToken(userId interface{}) *gomock.Call {
+func (mr *MocktokenMockRecorder) GenerateToken(userId, credentials interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*Mocktoken)(nil).GenerateToken), userId, credentials)
+}
+
+// HashRandomToken mocks base method.
+func (m *Mocktoken) HashRandomToken(token string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashRandomToken", token)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// HashRandomToken indicates an expected call of HashRandomToken.
+func (mr *MocktokenMockRecorder) HashRandomToken(token interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*Mocktoken)(nil).GenerateToken), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashRandomToken", reflect.TypeOf((*Mocktoken)(nil).HashRandomToken), token)
 }
 
 // Mockhash is a mock of hash interface.
@@ -88,6 +117,20 @@ func (mr *MockhashMockRecorder) GenerateHash(password interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateHash", reflect.TypeOf((*Mockhash)(nil).GenerateHash), password)
 }
 
+// NeedsRehash mocks base method.
+func (m *Mockhash) NeedsRehash(hash string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NeedsRehash", hash)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NeedsRehash indicates an expected call of NeedsRehash.
+func (mr *MockhashMockRecorder) NeedsRehash(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsRehash", reflect.TypeOf((*Mockhash)(nil).NeedsRehash), hash)
+}
+
 // ValidateHash mocks base method.
 func (m *Mockhash) ValidateHash(hash, password string) error {
 	m.ctrl.T.Helper()
@@ -169,3 +212,303 @@ func (mr *MockuserDBMockRecorder) GetUserByEmail(ctx, email interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockuserDB)(nil).GetUserByEmail), ctx, email)
 }
+
+// PathUser mocks base method.
+func (m *MockuserDB) PathUser(ctx context.Context, user *core.UserDB, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PathUser", ctx, user, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PathUser indicates an expected call of PathUser.
+func (mr *MockuserDBMockRecorder) PathUser(ctx, user, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PathUser", reflect.TypeOf((*MockuserDB)(nil).PathUser), ctx, user, userId)
+}
+
+// MockcredentialDBAuth is a mock of credentialDBAuth interface.
+type MockcredentialDBAuth struct {
+	ctrl     *gomock.Controller
+	recorder *MockcredentialDBAuthMockRecorder
+}
+
+// MockcredentialDBAuthMockRecorder is the mock recorder for MockcredentialDBAuth.
+type MockcredentialDBAuthMockRecorder struct {
+	mock *MockcredentialDBAuth
+}
+
+// NewMockcredentialDBAuth creates a new mock instance.
+func NewMockcredentialDBAuth(ctrl *gomock.Controller) *MockcredentialDBAuth {
+	mock := &MockcredentialDBAuth{ctrl: ctrl}
+	mock.recorder = &MockcredentialDBAuthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcredentialDBAuth) EXPECT() *MockcredentialDBAuthMockRecorder {
+	return m.recorder
+}
+
+// GetUserCredential mocks base method.
+func (m *MockcredentialDBAuth) GetUserCredential(ctx context.Context, userId int) ([]core.Credentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCredential", ctx, userId)
+	ret0, _ := ret[0].([]core.Credentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCredential indicates an expected call of GetUserCredential.
+func (mr *MockcredentialDBAuthMockRecorder) GetUserCredential(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCredential", reflect.TypeOf((*MockcredentialDBAuth)(nil).GetUserCredential), ctx, userId)
+}
+
+// MocksessionDBAuth is a mock of sessionDBAuth interface.
+type MocksessionDBAuth struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionDBAuthMockRecorder
+}
+
+// MocksessionDBAuthMockRecorder is the mock recorder for MocksessionDBAuth.
+type MocksessionDBAuthMockRecorder struct {
+	mock *MocksessionDBAuth
+}
+
+// NewMocksessionDBAuth creates a new mock instance.
+func NewMocksessionDBAuth(ctrl *gomock.Controller) *MocksessionDBAuth {
+	mock := &MocksessionDBAuth{ctrl: ctrl}
+	mock.recorder = &MocksessionDBAuthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionDBAuth) EXPECT() *MocksessionDBAuthMockRecorder {
+	return m.recorder
+}
+
+// AddSession mocks base method.
+func (m *MocksessionDBAuth) AddSession(ctx context.Context, session *core.SessionAdd) (*core.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSession", ctx, session)
+	ret0, _ := ret[0].(*core.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSession indicates an expected call of AddSession.
+func (mr *MocksessionDBAuthMockRecorder) AddSession(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSession", reflect.TypeOf((*MocksessionDBAuth)(nil).AddSession), ctx, session)
+}
+
+// GetSessionForUpdate mocks base method.
+func (m *MocksessionDBAuth) GetSessionForUpdate(ctx context.Context, refreshTokenHash string) (*core.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionForUpdate", ctx, refreshTokenHash)
+	ret0, _ := ret[0].(*core.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionForUpdate indicates an expected call of GetSessionForUpdate.
+func (mr *MocksessionDBAuthMockRecorder) GetSessionForUpdate(ctx, refreshTokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionForUpdate", reflect.TypeOf((*MocksessionDBAuth)(nil).GetSessionForUpdate), ctx, refreshTokenHash)
+}
+
+// RevokeSessionFamily mocks base method.
+func (m *MocksessionDBAuth) RevokeSessionFamily(ctx context.Context, familyId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessionFamily", ctx, familyId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSessionFamily indicates an expected call of RevokeSessionFamily.
+func (mr *MocksessionDBAuthMockRecorder) RevokeSessionFamily(ctx, familyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessionFamily", reflect.TypeOf((*MocksessionDBAuth)(nil).RevokeSessionFamily), ctx, familyId)
+}
+
+// RevokeUserSessions mocks base method.
+func (m *MocksessionDBAuth) RevokeUserSessions(ctx context.Context, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessions", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MocksessionDBAuthMockRecorder) RevokeUserSessions(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MocksessionDBAuth)(nil).RevokeUserSessions), ctx, userId)
+}
+
+// SetSessionUsed mocks base method.
+func (m *MocksessionDBAuth) SetSessionUsed(ctx context.Context, sessionId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSessionUsed", ctx, sessionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSessionUsed indicates an expected call of SetSessionUsed.
+func (mr *MocksessionDBAuthMockRecorder) SetSessionUsed(ctx, sessionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSessionUsed", reflect.TypeOf((*MocksessionDBAuth)(nil).SetSessionUsed), ctx, sessionId)
+}
+
+// MockemailVerificationAuth is a mock of emailVerificationAuth interface.
+type MockemailVerificationAuth struct {
+	ctrl     *gomock.Controller
+	recorder *MockemailVerificationAuthMockRecorder
+}
+
+// MockemailVerificationAuthMockRecorder is the mock recorder for MockemailVerificationAuth.
+type MockemailVerificationAuthMockRecorder struct {
+	mock *MockemailVerificationAuth
+}
+
+// NewMockemailVerificationAuth creates a new mock instance.
+func NewMockemailVerificationAuth(ctrl *gomock.Controller) *MockemailVerificationAuth {
+	mock := &MockemailVerificationAuth{ctrl: ctrl}
+	mock.recorder = &MockemailVerificationAuthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockemailVerificationAuth) EXPECT() *MockemailVerificationAuthMockRecorder {
+	return m.recorder
+}
+
+// SendVerification mocks base method.
+func (m *MockemailVerificationAuth) SendVerification(ctx context.Context, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendVerification", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendVerification indicates an expected call of SendVerification.
+func (mr *MockemailVerificationAuthMockRecorder) SendVerification(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerification", reflect.TypeOf((*MockemailVerificationAuth)(nil).SendVerification), ctx, userId)
+}
+
+// MockinvitationAuth is a mock of invitationAuth interface.
+type MockinvitationAuth struct {
+	ctrl     *gomock.Controller
+	recorder *MockinvitationAuthMockRecorder
+}
+
+// MockinvitationAuthMockRecorder is the mock recorder for MockinvitationAuth.
+type MockinvitationAuthMockRecorder struct {
+	mock *MockinvitationAuth
+}
+
+// NewMockinvitationAuth creates a new mock instance.
+func NewMockinvitationAuth(ctrl *gomock.Controller) *MockinvitationAuth {
+	mock := &MockinvitationAuth{ctrl: ctrl}
+	mock.recorder = &MockinvitationAuthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockinvitationAuth) EXPECT() *MockinvitationAuthMockRecorder {
+	return m.recorder
+}
+
+// AcceptTokenInvitation mocks base method.
+func (m *MockinvitationAuth) AcceptTokenInvitation(ctx context.Context, userId int, email, token string) (*core.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptTokenInvitation", ctx, userId, email, token)
+	ret0, _ := ret[0].(*core.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptTokenInvitation indicates an expected call of AcceptTokenInvitation.
+func (mr *MockinvitationAuthMockRecorder) AcceptTokenInvitation(ctx, userId, email, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptTokenInvitation", reflect.TypeOf((*MockinvitationAuth)(nil).AcceptTokenInvitation), ctx, userId, email, token)
+}
+
+// MocktransactionDBAuth is a mock of transactionDBAuth interface.
+type MocktransactionDBAuth struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactionDBAuthMockRecorder
+}
+
+// MocktransactionDBAuthMockRecorder is the mock recorder for MocktransactionDBAuth.
+type MocktransactionDBAuthMockRecorder struct {
+	mock *MocktransactionDBAuth
+}
+
+// NewMocktransactionDBAuth creates a new mock instance.
+func NewMocktransactionDBAuth(ctrl *gomock.Controller) *MocktransactionDBAuth {
+	mock := &MocktransactionDBAuth{ctrl: ctrl}
+	mock.recorder = &MocktransactionDBAuthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktransactionDBAuth) EXPECT() *MocktransactionDBAuthMockRecorder {
+	return m.recorder
+}
+
+// CommitTx mocks base method.
+func (m *MocktransactionDBAuth) CommitTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitTx indicates an expected call of CommitTx.
+func (mr *MocktransactionDBAuthMockRecorder) CommitTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTx", reflect.TypeOf((*MocktransactionDBAuth)(nil).CommitTx), ctx)
+}
+
+// InjectTx mocks base method.
+func (m *MocktransactionDBAuth) InjectTx(ctx context.Context) (context.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectTx", ctx)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InjectTx indicates an expected call of InjectTx.
+func (mr *MocktransactionDBAuthMockRecorder) InjectTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectTx", reflect.TypeOf((*MocktransactionDBAuth)(nil).InjectTx), ctx)
+}
+
+// RollbackTx mocks base method.
+func (m *MocktransactionDBAuth) RollbackTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollbackTx indicates an expected call of RollbackTx.
+func (mr *MocktransactionDBAuthMockRecorder) RollbackTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTx", reflect.TypeOf((*MocktransactionDBAuth)(nil).RollbackTx), ctx)
+}
+
+// RollbackTxDefer mocks base method.
+func (m *MocktransactionDBAuth) RollbackTxDefer(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RollbackTxDefer", ctx)
+}
+
+// RollbackTxDefer indicates an expected call of RollbackTxDefer.
+func (mr *MocktransactionDBAuthMockRecorder) RollbackTxDefer(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTxDefer", reflect.TypeOf((*MocktransactionDBAuth)(nil).RollbackTxDefer), ctx)
+}
@@ -0,0 +1,342 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: company.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/Thing-repository/backend-server/pkg/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockuserDBCompany is a mock of userDBCompany interface.
+type MockuserDBCompany struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserDBCompanyMockRecorder
+}
+
+// MockuserDBCompanyMockRecorder is the mock recorder for MockuserDBCompany.
+type MockuserDBCompanyMockRecorder struct {
+	mock *MockuserDBCompany
+}
+
+// NewMockuserDBCompany creates a new mock instance.
+func NewMockuserDBCompany(ctrl *gomock.Controller) *MockuserDBCompany {
+	mock := &MockuserDBCompany{ctrl: ctrl}
+	mock.recorder = &MockuserDBCompanyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserDBCompany) EXPECT() *MockuserDBCompanyMockRecorder {
+	return m.recorder
+}
+
+// GetUser mocks base method.
+func (m *MockuserDBCompany) GetUser(ctx context.Context, userId int) (*core.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userId)
+	ret0, _ := ret[0].(*core.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockuserDBCompanyMockRecorder) GetUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockuserDBCompany)(nil).GetUser), ctx, userId)
+}
+
+// PathUser mocks base method.
+func (m *MockuserDBCompany) PathUser(ctx context.Context, user *core.UserDB, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PathUser", ctx, user, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PathUser indicates an expected call of PathUser.
+func (mr *MockuserDBCompanyMockRecorder) PathUser(ctx, user, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PathUser", reflect.TypeOf((*MockuserDBCompany)(nil).PathUser), ctx, user, userId)
+}
+
+// MockcompanyDBCompany is a mock of companyDBCompany interface.
+type MockcompanyDBCompany struct {
+	ctrl     *gomock.Controller
+	recorder *MockcompanyDBCompanyMockRecorder
+}
+
+// MockcompanyDBCompanyMockRecorder is the mock recorder for MockcompanyDBCompany.
+type MockcompanyDBCompanyMockRecorder struct {
+	mock *MockcompanyDBCompany
+}
+
+// NewMockcompanyDBCompany creates a new mock instance.
+func NewMockcompanyDBCompany(ctrl *gomock.Controller) *MockcompanyDBCompany {
+	mock := &MockcompanyDBCompany{ctrl: ctrl}
+	mock.recorder = &MockcompanyDBCompanyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcompanyDBCompany) EXPECT() *MockcompanyDBCompanyMockRecorder {
+	return m.recorder
+}
+
+// AddCompany mocks base method.
+func (m *MockcompanyDBCompany) AddCompany(ctx context.Context, companyBase *core.CompanyBase) (*core.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCompany", ctx, companyBase)
+	ret0, _ := ret[0].(*core.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCompany indicates an expected call of AddCompany.
+func (mr *MockcompanyDBCompanyMockRecorder) AddCompany(ctx, companyBase interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCompany", reflect.TypeOf((*MockcompanyDBCompany)(nil).AddCompany), ctx, companyBase)
+}
+
+// DeleteCompany mocks base method.
+func (m *MockcompanyDBCompany) DeleteCompany(ctx context.Context, companyId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCompany", ctx, companyId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCompany indicates an expected call of DeleteCompany.
+func (mr *MockcompanyDBCompanyMockRecorder) DeleteCompany(ctx, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCompany", reflect.TypeOf((*MockcompanyDBCompany)(nil).DeleteCompany), ctx, companyId)
+}
+
+// FindCompanies mocks base method.
+func (m *MockcompanyDBCompany) FindCompanies(ctx context.Context, filter string, limit, offset int) ([]core.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCompanies", ctx, filter, limit, offset)
+	ret0, _ := ret[0].([]core.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCompanies indicates an expected call of FindCompanies.
+func (mr *MockcompanyDBCompanyMockRecorder) FindCompanies(ctx, filter, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCompanies", reflect.TypeOf((*MockcompanyDBCompany)(nil).FindCompanies), ctx, filter, limit, offset)
+}
+
+// GetCompany mocks base method.
+func (m *MockcompanyDBCompany) GetCompany(ctx context.Context, companyId int) (*core.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompany", ctx, companyId)
+	ret0, _ := ret[0].(*core.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompany indicates an expected call of GetCompany.
+func (mr *MockcompanyDBCompanyMockRecorder) GetCompany(ctx, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompany", reflect.TypeOf((*MockcompanyDBCompany)(nil).GetCompany), ctx, companyId)
+}
+
+// UpdateCompany mocks base method.
+func (m *MockcompanyDBCompany) UpdateCompany(ctx context.Context, company core.CompanyUpdate, companyId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCompany", ctx, company, companyId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCompany indicates an expected call of UpdateCompany.
+func (mr *MockcompanyDBCompanyMockRecorder) UpdateCompany(ctx, company, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCompany", reflect.TypeOf((*MockcompanyDBCompany)(nil).UpdateCompany), ctx, company, companyId)
+}
+
+// MockdepartmentDBCompany is a mock of departmentDBCompany interface.
+type MockdepartmentDBCompany struct {
+	ctrl     *gomock.Controller
+	recorder *MockdepartmentDBCompanyMockRecorder
+}
+
+// MockdepartmentDBCompanyMockRecorder is the mock recorder for MockdepartmentDBCompany.
+type MockdepartmentDBCompanyMockRecorder struct {
+	mock *MockdepartmentDBCompany
+}
+
+// NewMockdepartmentDBCompany creates a new mock instance.
+func NewMockdepartmentDBCompany(ctrl *gomock.Controller) *MockdepartmentDBCompany {
+	mock := &MockdepartmentDBCompany{ctrl: ctrl}
+	mock.recorder = &MockdepartmentDBCompanyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdepartmentDBCompany) EXPECT() *MockdepartmentDBCompanyMockRecorder {
+	return m.recorder
+}
+
+// AddDepartment mocks base method.
+func (m *MockdepartmentDBCompany) AddDepartment(ctx context.Context, departmentBase *core.DepartmentBase) (*core.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDepartment", ctx, departmentBase)
+	ret0, _ := ret[0].(*core.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDepartment indicates an expected call of AddDepartment.
+func (mr *MockdepartmentDBCompanyMockRecorder) AddDepartment(ctx, departmentBase interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDepartment", reflect.TypeOf((*MockdepartmentDBCompany)(nil).AddDepartment), ctx, departmentBase)
+}
+
+// GetDepartmentsByCompany mocks base method.
+func (m *MockdepartmentDBCompany) GetDepartmentsByCompany(ctx context.Context, companyId int) ([]core.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartmentsByCompany", ctx, companyId)
+	ret0, _ := ret[0].([]core.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartmentsByCompany indicates an expected call of GetDepartmentsByCompany.
+func (mr *MockdepartmentDBCompanyMockRecorder) GetDepartmentsByCompany(ctx, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartmentsByCompany", reflect.TypeOf((*MockdepartmentDBCompany)(nil).GetDepartmentsByCompany), ctx, companyId)
+}
+
+// MockcredentialsDBCompany is a mock of credentialsDBCompany interface.
+type MockcredentialsDBCompany struct {
+	ctrl     *gomock.Controller
+	recorder *MockcredentialsDBCompanyMockRecorder
+}
+
+// MockcredentialsDBCompanyMockRecorder is the mock recorder for MockcredentialsDBCompany.
+type MockcredentialsDBCompanyMockRecorder struct {
+	mock *MockcredentialsDBCompany
+}
+
+// NewMockcredentialsDBCompany creates a new mock instance.
+func NewMockcredentialsDBCompany(ctrl *gomock.Controller) *MockcredentialsDBCompany {
+	mock := &MockcredentialsDBCompany{ctrl: ctrl}
+	mock.recorder = &MockcredentialsDBCompanyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcredentialsDBCompany) EXPECT() *MockcredentialsDBCompanyMockRecorder {
+	return m.recorder
+}
+
+// CreateCredential mocks base method.
+func (m *MockcredentialsDBCompany) CreateCredential(ctx context.Context, credentials *core.AddCredentials) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCredential", ctx, credentials)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCredential indicates an expected call of CreateCredential.
+func (mr *MockcredentialsDBCompanyMockRecorder) CreateCredential(ctx, credentials interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCredential", reflect.TypeOf((*MockcredentialsDBCompany)(nil).CreateCredential), ctx, credentials)
+}
+
+// InvalidateAllCredentials mocks base method.
+func (m *MockcredentialsDBCompany) InvalidateAllCredentials(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "InvalidateAllCredentials", ctx)
+}
+
+// InvalidateAllCredentials indicates an expected call of InvalidateAllCredentials.
+func (mr *MockcredentialsDBCompanyMockRecorder) InvalidateAllCredentials(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateAllCredentials", reflect.TypeOf((*MockcredentialsDBCompany)(nil).InvalidateAllCredentials), ctx)
+}
+
+// MocktransactionDBCompany is a mock of transactionDBCompany interface.
+type MocktransactionDBCompany struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactionDBCompanyMockRecorder
+}
+
+// MocktransactionDBCompanyMockRecorder is the mock recorder for MocktransactionDBCompany.
+type MocktransactionDBCompanyMockRecorder struct {
+	mock *MocktransactionDBCompany
+}
+
+// NewMocktransactionDBCompany creates a new mock instance.
+func NewMocktransactionDBCompany(ctrl *gomock.Controller) *MocktransactionDBCompany {
+	mock := &MocktransactionDBCompany{ctrl: ctrl}
+	mock.recorder = &MocktransactionDBCompanyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktransactionDBCompany) EXPECT() *MocktransactionDBCompanyMockRecorder {
+	return m.recorder
+}
+
+// CommitTx mocks base method.
+func (m *MocktransactionDBCompany) CommitTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitTx indicates an expected call of CommitTx.
+func (mr *MocktransactionDBCompanyMockRecorder) CommitTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTx", reflect.TypeOf((*MocktransactionDBCompany)(nil).CommitTx), ctx)
+}
+
+// InjectTx mocks base method.
+func (m *MocktransactionDBCompany) InjectTx(ctx context.Context) (context.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectTx", ctx)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InjectTx indicates an expected call of InjectTx.
+func (mr *MocktransactionDBCompanyMockRecorder) InjectTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectTx", reflect.TypeOf((*MocktransactionDBCompany)(nil).InjectTx), ctx)
+}
+
+// RollbackTx mocks base method.
+func (m *MocktransactionDBCompany) RollbackTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollbackTx indicates an expected call of RollbackTx.
+func (mr *MocktransactionDBCompanyMockRecorder) RollbackTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTx", reflect.TypeOf((*MocktransactionDBCompany)(nil).RollbackTx), ctx)
+}
+
+// RollbackTxDefer mocks base method.
+func (m *MocktransactionDBCompany) RollbackTxDefer(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RollbackTxDefer", ctx)
+}
+
+// RollbackTxDefer indicates an expected call of RollbackTxDefer.
+func (mr *MocktransactionDBCompanyMockRecorder) RollbackTxDefer(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTxDefer", reflect.TypeOf((*MocktransactionDBCompany)(nil).RollbackTxDefer), ctx)
+}
@@ -0,0 +1,264 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: credentials.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/Thing-repository/backend-server/pkg/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockcredentialsDBCredentials is a mock of credentialsDBCredentials interface.
+type MockcredentialsDBCredentials struct {
+	ctrl     *gomock.Controller
+	recorder *MockcredentialsDBCredentialsMockRecorder
+}
+
+// MockcredentialsDBCredentialsMockRecorder is the mock recorder for MockcredentialsDBCredentials.
+type MockcredentialsDBCredentialsMockRecorder struct {
+	mock *MockcredentialsDBCredentials
+}
+
+// NewMockcredentialsDBCredentials creates a new mock instance.
+func NewMockcredentialsDBCredentials(ctrl *gomock.Controller) *MockcredentialsDBCredentials {
+	mock := &MockcredentialsDBCredentials{ctrl: ctrl}
+	mock.recorder = &MockcredentialsDBCredentialsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcredentialsDBCredentials) EXPECT() *MockcredentialsDBCredentialsMockRecorder {
+	return m.recorder
+}
+
+// CountCredentialsForUpdate mocks base method.
+func (m *MockcredentialsDBCredentials) CountCredentialsForUpdate(ctx context.Context, credentialType string, objectId int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCredentialsForUpdate", ctx, credentialType, objectId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCredentialsForUpdate indicates an expected call of CountCredentialsForUpdate.
+func (mr *MockcredentialsDBCredentialsMockRecorder) CountCredentialsForUpdate(ctx, credentialType, objectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCredentialsForUpdate", reflect.TypeOf((*MockcredentialsDBCredentials)(nil).CountCredentialsForUpdate), ctx, credentialType, objectId)
+}
+
+// CreateCredential mocks base method.
+func (m *MockcredentialsDBCredentials) CreateCredential(ctx context.Context, credentials *core.AddCredentials) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCredential", ctx, credentials)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCredential indicates an expected call of CreateCredential.
+func (mr *MockcredentialsDBCredentialsMockRecorder) CreateCredential(ctx, credentials interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCredential", reflect.TypeOf((*MockcredentialsDBCredentials)(nil).CreateCredential), ctx, credentials)
+}
+
+// DeleteCredential mocks base method.
+func (m *MockcredentialsDBCredentials) DeleteCredential(ctx context.Context, credentials *core.AddCredentials) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCredential", ctx, credentials)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCredential indicates an expected call of DeleteCredential.
+func (mr *MockcredentialsDBCredentialsMockRecorder) DeleteCredential(ctx, credentials interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCredential", reflect.TypeOf((*MockcredentialsDBCredentials)(nil).DeleteCredential), ctx, credentials)
+}
+
+// GetCredentialUsers mocks base method.
+func (m *MockcredentialsDBCredentials) GetCredentialUsers(ctx context.Context, credentialType string, objectId int) ([]core.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCredentialUsers", ctx, credentialType, objectId)
+	ret0, _ := ret[0].([]core.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCredentialUsers indicates an expected call of GetCredentialUsers.
+func (mr *MockcredentialsDBCredentialsMockRecorder) GetCredentialUsers(ctx, credentialType, objectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentialUsers", reflect.TypeOf((*MockcredentialsDBCredentials)(nil).GetCredentialUsers), ctx, credentialType, objectId)
+}
+
+// GetUserCredential mocks base method.
+func (m *MockcredentialsDBCredentials) GetUserCredential(ctx context.Context, userId int) ([]core.Credentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCredential", ctx, userId)
+	ret0, _ := ret[0].([]core.Credentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCredential indicates an expected call of GetUserCredential.
+func (mr *MockcredentialsDBCredentialsMockRecorder) GetUserCredential(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCredential", reflect.TypeOf((*MockcredentialsDBCredentials)(nil).GetUserCredential), ctx, userId)
+}
+
+// MockdepartmentDBCredentials is a mock of departmentDBCredentials interface.
+type MockdepartmentDBCredentials struct {
+	ctrl     *gomock.Controller
+	recorder *MockdepartmentDBCredentialsMockRecorder
+}
+
+// MockdepartmentDBCredentialsMockRecorder is the mock recorder for MockdepartmentDBCredentials.
+type MockdepartmentDBCredentialsMockRecorder struct {
+	mock *MockdepartmentDBCredentials
+}
+
+// NewMockdepartmentDBCredentials creates a new mock instance.
+func NewMockdepartmentDBCredentials(ctrl *gomock.Controller) *MockdepartmentDBCredentials {
+	mock := &MockdepartmentDBCredentials{ctrl: ctrl}
+	mock.recorder = &MockdepartmentDBCredentialsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdepartmentDBCredentials) EXPECT() *MockdepartmentDBCredentialsMockRecorder {
+	return m.recorder
+}
+
+// GetDepartment mocks base method.
+func (m *MockdepartmentDBCredentials) GetDepartment(ctx context.Context, departmentId int) (*core.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartment", ctx, departmentId)
+	ret0, _ := ret[0].(*core.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartment indicates an expected call of GetDepartment.
+func (mr *MockdepartmentDBCredentialsMockRecorder) GetDepartment(ctx, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartment", reflect.TypeOf((*MockdepartmentDBCredentials)(nil).GetDepartment), ctx, departmentId)
+}
+
+// MockuserDBCredentials is a mock of userDBCredentials interface.
+type MockuserDBCredentials struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserDBCredentialsMockRecorder
+}
+
+// MockuserDBCredentialsMockRecorder is the mock recorder for MockuserDBCredentials.
+type MockuserDBCredentialsMockRecorder struct {
+	mock *MockuserDBCredentials
+}
+
+// NewMockuserDBCredentials creates a new mock instance.
+func NewMockuserDBCredentials(ctrl *gomock.Controller) *MockuserDBCredentials {
+	mock := &MockuserDBCredentials{ctrl: ctrl}
+	mock.recorder = &MockuserDBCredentialsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserDBCredentials) EXPECT() *MockuserDBCredentialsMockRecorder {
+	return m.recorder
+}
+
+// GetUser mocks base method.
+func (m *MockuserDBCredentials) GetUser(ctx context.Context, userId int) (*core.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userId)
+	ret0, _ := ret[0].(*core.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockuserDBCredentialsMockRecorder) GetUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockuserDBCredentials)(nil).GetUser), ctx, userId)
+}
+
+// MocktransactionDBCredentials is a mock of transactionDBCredentials interface.
+type MocktransactionDBCredentials struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactionDBCredentialsMockRecorder
+}
+
+// MocktransactionDBCredentialsMockRecorder is the mock recorder for MocktransactionDBCredentials.
+type MocktransactionDBCredentialsMockRecorder struct {
+	mock *MocktransactionDBCredentials
+}
+
+// NewMocktransactionDBCredentials creates a new mock instance.
+func NewMocktransactionDBCredentials(ctrl *gomock.Controller) *MocktransactionDBCredentials {
+	mock := &MocktransactionDBCredentials{ctrl: ctrl}
+	mock.recorder = &MocktransactionDBCredentialsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktransactionDBCredentials) EXPECT() *MocktransactionDBCredentialsMockRecorder {
+	return m.recorder
+}
+
+// CommitTx mocks base method.
+func (m *MocktransactionDBCredentials) CommitTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitTx indicates an expected call of CommitTx.
+func (mr *MocktransactionDBCredentialsMockRecorder) CommitTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTx", reflect.TypeOf((*MocktransactionDBCredentials)(nil).CommitTx), ctx)
+}
+
+// InjectTx mocks base method.
+func (m *MocktransactionDBCredentials) InjectTx(ctx context.Context) (context.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectTx", ctx)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InjectTx indicates an expected call of InjectTx.
+func (mr *MocktransactionDBCredentialsMockRecorder) InjectTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectTx", reflect.TypeOf((*MocktransactionDBCredentials)(nil).InjectTx), ctx)
+}
+
+// RollbackTx mocks base method.
+func (m *MocktransactionDBCredentials) RollbackTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollbackTx indicates an expected call of RollbackTx.
+func (mr *MocktransactionDBCredentialsMockRecorder) RollbackTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTx", reflect.TypeOf((*MocktransactionDBCredentials)(nil).RollbackTx), ctx)
+}
+
+// RollbackTxDefer mocks base method.
+func (m *MocktransactionDBCredentials) RollbackTxDefer(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RollbackTxDefer", ctx)
+}
+
+// RollbackTxDefer indicates an expected call of RollbackTxDefer.
+func (mr *MocktransactionDBCredentialsMockRecorder) RollbackTxDefer(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTxDefer", reflect.TypeOf((*MocktransactionDBCredentials)(nil).RollbackTxDefer), ctx)
+}
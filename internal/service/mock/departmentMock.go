@@ -0,0 +1,266 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: department.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/Thing-repository/backend-server/pkg/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockdepartmentDBDepartment is a mock of departmentDBDepartment interface.
+type MockdepartmentDBDepartment struct {
+	ctrl     *gomock.Controller
+	recorder *MockdepartmentDBDepartmentMockRecorder
+}
+
+// MockdepartmentDBDepartmentMockRecorder is the mock recorder for MockdepartmentDBDepartment.
+type MockdepartmentDBDepartmentMockRecorder struct {
+	mock *MockdepartmentDBDepartment
+}
+
+// NewMockdepartmentDBDepartment creates a new mock instance.
+func NewMockdepartmentDBDepartment(ctrl *gomock.Controller) *MockdepartmentDBDepartment {
+	mock := &MockdepartmentDBDepartment{ctrl: ctrl}
+	mock.recorder = &MockdepartmentDBDepartmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdepartmentDBDepartment) EXPECT() *MockdepartmentDBDepartmentMockRecorder {
+	return m.recorder
+}
+
+// AddDepartment mocks base method.
+func (m *MockdepartmentDBDepartment) AddDepartment(ctx context.Context, departmentBase *core.DepartmentBase) (*core.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDepartment", ctx, departmentBase)
+	ret0, _ := ret[0].(*core.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDepartment indicates an expected call of AddDepartment.
+func (mr *MockdepartmentDBDepartmentMockRecorder) AddDepartment(ctx, departmentBase interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDepartment", reflect.TypeOf((*MockdepartmentDBDepartment)(nil).AddDepartment), ctx, departmentBase)
+}
+
+// DeleteDepartment mocks base method.
+func (m *MockdepartmentDBDepartment) DeleteDepartment(ctx context.Context, departmentId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDepartment", ctx, departmentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDepartment indicates an expected call of DeleteDepartment.
+func (mr *MockdepartmentDBDepartmentMockRecorder) DeleteDepartment(ctx, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDepartment", reflect.TypeOf((*MockdepartmentDBDepartment)(nil).DeleteDepartment), ctx, departmentId)
+}
+
+// GetDepartment mocks base method.
+func (m *MockdepartmentDBDepartment) GetDepartment(ctx context.Context, departmentId int) (*core.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartment", ctx, departmentId)
+	ret0, _ := ret[0].(*core.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartment indicates an expected call of GetDepartment.
+func (mr *MockdepartmentDBDepartmentMockRecorder) GetDepartment(ctx, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartment", reflect.TypeOf((*MockdepartmentDBDepartment)(nil).GetDepartment), ctx, departmentId)
+}
+
+// GetDepartmentByName mocks base method.
+func (m *MockdepartmentDBDepartment) GetDepartmentByName(ctx context.Context, companyId int, departmentName string) (*core.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartmentByName", ctx, companyId, departmentName)
+	ret0, _ := ret[0].(*core.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartmentByName indicates an expected call of GetDepartmentByName.
+func (mr *MockdepartmentDBDepartmentMockRecorder) GetDepartmentByName(ctx, companyId, departmentName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartmentByName", reflect.TypeOf((*MockdepartmentDBDepartment)(nil).GetDepartmentByName), ctx, companyId, departmentName)
+}
+
+// GetDepartmentsByCompany mocks base method.
+func (m *MockdepartmentDBDepartment) GetDepartmentsByCompany(ctx context.Context, companyId int) ([]core.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartmentsByCompany", ctx, companyId)
+	ret0, _ := ret[0].([]core.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartmentsByCompany indicates an expected call of GetDepartmentsByCompany.
+func (mr *MockdepartmentDBDepartmentMockRecorder) GetDepartmentsByCompany(ctx, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartmentsByCompany", reflect.TypeOf((*MockdepartmentDBDepartment)(nil).GetDepartmentsByCompany), ctx, companyId)
+}
+
+// MoveDepartmentMembers mocks base method.
+func (m *MockdepartmentDBDepartment) MoveDepartmentMembers(ctx context.Context, departmentId, targetDepartmentId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveDepartmentMembers", ctx, departmentId, targetDepartmentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveDepartmentMembers indicates an expected call of MoveDepartmentMembers.
+func (mr *MockdepartmentDBDepartmentMockRecorder) MoveDepartmentMembers(ctx, departmentId, targetDepartmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveDepartmentMembers", reflect.TypeOf((*MockdepartmentDBDepartment)(nil).MoveDepartmentMembers), ctx, departmentId, targetDepartmentId)
+}
+
+// UpdateDepartment mocks base method.
+func (m *MockdepartmentDBDepartment) UpdateDepartment(ctx context.Context, department *core.DepartmentUpdate, departmentId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDepartment", ctx, department, departmentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDepartment indicates an expected call of UpdateDepartment.
+func (mr *MockdepartmentDBDepartmentMockRecorder) UpdateDepartment(ctx, department, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDepartment", reflect.TypeOf((*MockdepartmentDBDepartment)(nil).UpdateDepartment), ctx, department, departmentId)
+}
+
+// MockcredentialsDBDepartment is a mock of credentialsDBDepartment interface.
+type MockcredentialsDBDepartment struct {
+	ctrl     *gomock.Controller
+	recorder *MockcredentialsDBDepartmentMockRecorder
+}
+
+// MockcredentialsDBDepartmentMockRecorder is the mock recorder for MockcredentialsDBDepartment.
+type MockcredentialsDBDepartmentMockRecorder struct {
+	mock *MockcredentialsDBDepartment
+}
+
+// NewMockcredentialsDBDepartment creates a new mock instance.
+func NewMockcredentialsDBDepartment(ctrl *gomock.Controller) *MockcredentialsDBDepartment {
+	mock := &MockcredentialsDBDepartment{ctrl: ctrl}
+	mock.recorder = &MockcredentialsDBDepartmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcredentialsDBDepartment) EXPECT() *MockcredentialsDBDepartmentMockRecorder {
+	return m.recorder
+}
+
+// CreateCredential mocks base method.
+func (m *MockcredentialsDBDepartment) CreateCredential(ctx context.Context, credentials *core.AddCredentials) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCredential", ctx, credentials)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCredential indicates an expected call of CreateCredential.
+func (mr *MockcredentialsDBDepartmentMockRecorder) CreateCredential(ctx, credentials interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCredential", reflect.TypeOf((*MockcredentialsDBDepartment)(nil).CreateCredential), ctx, credentials)
+}
+
+// InvalidateAllCredentials mocks base method.
+func (m *MockcredentialsDBDepartment) InvalidateAllCredentials(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "InvalidateAllCredentials", ctx)
+}
+
+// InvalidateAllCredentials indicates an expected call of InvalidateAllCredentials.
+func (mr *MockcredentialsDBDepartmentMockRecorder) InvalidateAllCredentials(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateAllCredentials", reflect.TypeOf((*MockcredentialsDBDepartment)(nil).InvalidateAllCredentials), ctx)
+}
+
+// MocktransactionDBDepartment is a mock of transactionDBDepartment interface.
+type MocktransactionDBDepartment struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactionDBDepartmentMockRecorder
+}
+
+// MocktransactionDBDepartmentMockRecorder is the mock recorder for MocktransactionDBDepartment.
+type MocktransactionDBDepartmentMockRecorder struct {
+	mock *MocktransactionDBDepartment
+}
+
+// NewMocktransactionDBDepartment creates a new mock instance.
+func NewMocktransactionDBDepartment(ctrl *gomock.Controller) *MocktransactionDBDepartment {
+	mock := &MocktransactionDBDepartment{ctrl: ctrl}
+	mock.recorder = &MocktransactionDBDepartmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktransactionDBDepartment) EXPECT() *MocktransactionDBDepartmentMockRecorder {
+	return m.recorder
+}
+
+// CommitTx mocks base method.
+func (m *MocktransactionDBDepartment) CommitTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitTx indicates an expected call of CommitTx.
+func (mr *MocktransactionDBDepartmentMockRecorder) CommitTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTx", reflect.TypeOf((*MocktransactionDBDepartment)(nil).CommitTx), ctx)
+}
+
+// InjectTx mocks base method.
+func (m *MocktransactionDBDepartment) InjectTx(ctx context.Context) (context.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectTx", ctx)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InjectTx indicates an expected call of InjectTx.
+func (mr *MocktransactionDBDepartmentMockRecorder) InjectTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectTx", reflect.TypeOf((*MocktransactionDBDepartment)(nil).InjectTx), ctx)
+}
+
+// RollbackTx mocks base method.
+func (m *MocktransactionDBDepartment) RollbackTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollbackTx indicates an expected call of RollbackTx.
+func (mr *MocktransactionDBDepartmentMockRecorder) RollbackTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTx", reflect.TypeOf((*MocktransactionDBDepartment)(nil).RollbackTx), ctx)
+}
+
+// RollbackTxDefer mocks base method.
+func (m *MocktransactionDBDepartment) RollbackTxDefer(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RollbackTxDefer", ctx)
+}
+
+// RollbackTxDefer indicates an expected call of RollbackTxDefer.
+func (mr *MocktransactionDBDepartmentMockRecorder) RollbackTxDefer(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTxDefer", reflect.TypeOf((*MocktransactionDBDepartment)(nil).RollbackTxDefer), ctx)
+}
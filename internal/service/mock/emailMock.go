@@ -0,0 +1,285 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: email.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/Thing-repository/backend-server/pkg/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockuserDBEmail is a mock of userDBEmail interface.
+type MockuserDBEmail struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserDBEmailMockRecorder
+}
+
+// MockuserDBEmailMockRecorder is the mock recorder for MockuserDBEmail.
+type MockuserDBEmailMockRecorder struct {
+	mock *MockuserDBEmail
+}
+
+// NewMockuserDBEmail creates a new mock instance.
+func NewMockuserDBEmail(ctrl *gomock.Controller) *MockuserDBEmail {
+	mock := &MockuserDBEmail{ctrl: ctrl}
+	mock.recorder = &MockuserDBEmailMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserDBEmail) EXPECT() *MockuserDBEmailMockRecorder {
+	return m.recorder
+}
+
+// ConfirmEmail mocks base method.
+func (m *MockuserDBEmail) ConfirmEmail(ctx context.Context, tokenHash string, sentAfter uint32) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEmail", ctx, tokenHash, sentAfter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmEmail indicates an expected call of ConfirmEmail.
+func (mr *MockuserDBEmailMockRecorder) ConfirmEmail(ctx, tokenHash, sentAfter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmail", reflect.TypeOf((*MockuserDBEmail)(nil).ConfirmEmail), ctx, tokenHash, sentAfter)
+}
+
+// GetUser mocks base method.
+func (m *MockuserDBEmail) GetUser(ctx context.Context, userId int) (*core.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userId)
+	ret0, _ := ret[0].(*core.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockuserDBEmailMockRecorder) GetUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockuserDBEmail)(nil).GetUser), ctx, userId)
+}
+
+// SetEmailValidationToken mocks base method.
+func (m *MockuserDBEmail) SetEmailValidationToken(ctx context.Context, userId int, tokenHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEmailValidationToken", ctx, userId, tokenHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEmailValidationToken indicates an expected call of SetEmailValidationToken.
+func (mr *MockuserDBEmailMockRecorder) SetEmailValidationToken(ctx, userId, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEmailValidationToken", reflect.TypeOf((*MockuserDBEmail)(nil).SetEmailValidationToken), ctx, userId, tokenHash)
+}
+
+// MocktokenEmail is a mock of tokenEmail interface.
+type MocktokenEmail struct {
+	ctrl     *gomock.Controller
+	recorder *MocktokenEmailMockRecorder
+}
+
+// MocktokenEmailMockRecorder is the mock recorder for MocktokenEmail.
+type MocktokenEmailMockRecorder struct {
+	mock *MocktokenEmail
+}
+
+// NewMocktokenEmail creates a new mock instance.
+func NewMocktokenEmail(ctrl *gomock.Controller) *MocktokenEmail {
+	mock := &MocktokenEmail{ctrl: ctrl}
+	mock.recorder = &MocktokenEmailMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktokenEmail) EXPECT() *MocktokenEmailMockRecorder {
+	return m.recorder
+}
+
+// GenerateRandomToken mocks base method.
+func (m *MocktokenEmail) GenerateRandomToken() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateRandomToken")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateRandomToken indicates an expected call of GenerateRandomToken.
+func (mr *MocktokenEmailMockRecorder) GenerateRandomToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateRandomToken", reflect.TypeOf((*MocktokenEmail)(nil).GenerateRandomToken))
+}
+
+// HashRandomToken mocks base method.
+func (m *MocktokenEmail) HashRandomToken(token string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashRandomToken", token)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// HashRandomToken indicates an expected call of HashRandomToken.
+func (mr *MocktokenEmailMockRecorder) HashRandomToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashRandomToken", reflect.TypeOf((*MocktokenEmail)(nil).HashRandomToken), token)
+}
+
+// MockmailerEmail is a mock of mailerEmail interface.
+type MockmailerEmail struct {
+	ctrl     *gomock.Controller
+	recorder *MockmailerEmailMockRecorder
+}
+
+// MockmailerEmailMockRecorder is the mock recorder for MockmailerEmail.
+type MockmailerEmailMockRecorder struct {
+	mock *MockmailerEmail
+}
+
+// NewMockmailerEmail creates a new mock instance.
+func NewMockmailerEmail(ctrl *gomock.Controller) *MockmailerEmail {
+	mock := &MockmailerEmail{ctrl: ctrl}
+	mock.recorder = &MockmailerEmailMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmailerEmail) EXPECT() *MockmailerEmailMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockmailerEmail) Send(ctx context.Context, message *core.MailMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockmailerEmailMockRecorder) Send(ctx, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockmailerEmail)(nil).Send), ctx, message)
+}
+
+// MockinvitationEmail is a mock of invitationEmail interface.
+type MockinvitationEmail struct {
+	ctrl     *gomock.Controller
+	recorder *MockinvitationEmailMockRecorder
+}
+
+// MockinvitationEmailMockRecorder is the mock recorder for MockinvitationEmail.
+type MockinvitationEmailMockRecorder struct {
+	mock *MockinvitationEmail
+}
+
+// NewMockinvitationEmail creates a new mock instance.
+func NewMockinvitationEmail(ctrl *gomock.Controller) *MockinvitationEmail {
+	mock := &MockinvitationEmail{ctrl: ctrl}
+	mock.recorder = &MockinvitationEmailMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockinvitationEmail) EXPECT() *MockinvitationEmailMockRecorder {
+	return m.recorder
+}
+
+// AcceptEmailInvitations mocks base method.
+func (m *MockinvitationEmail) AcceptEmailInvitations(ctx context.Context, userId int, email string) (*core.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptEmailInvitations", ctx, userId, email)
+	ret0, _ := ret[0].(*core.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptEmailInvitations indicates an expected call of AcceptEmailInvitations.
+func (mr *MockinvitationEmailMockRecorder) AcceptEmailInvitations(ctx, userId, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptEmailInvitations", reflect.TypeOf((*MockinvitationEmail)(nil).AcceptEmailInvitations), ctx, userId, email)
+}
+
+// MocktransactionDBEmail is a mock of transactionDBEmail interface.
+type MocktransactionDBEmail struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactionDBEmailMockRecorder
+}
+
+// MocktransactionDBEmailMockRecorder is the mock recorder for MocktransactionDBEmail.
+type MocktransactionDBEmailMockRecorder struct {
+	mock *MocktransactionDBEmail
+}
+
+// NewMocktransactionDBEmail creates a new mock instance.
+func NewMocktransactionDBEmail(ctrl *gomock.Controller) *MocktransactionDBEmail {
+	mock := &MocktransactionDBEmail{ctrl: ctrl}
+	mock.recorder = &MocktransactionDBEmailMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktransactionDBEmail) EXPECT() *MocktransactionDBEmailMockRecorder {
+	return m.recorder
+}
+
+// CommitTx mocks base method.
+func (m *MocktransactionDBEmail) CommitTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitTx indicates an expected call of CommitTx.
+func (mr *MocktransactionDBEmailMockRecorder) CommitTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTx", reflect.TypeOf((*MocktransactionDBEmail)(nil).CommitTx), ctx)
+}
+
+// InjectTx mocks base method.
+func (m *MocktransactionDBEmail) InjectTx(ctx context.Context) (context.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectTx", ctx)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InjectTx indicates an expected call of InjectTx.
+func (mr *MocktransactionDBEmailMockRecorder) InjectTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectTx", reflect.TypeOf((*MocktransactionDBEmail)(nil).InjectTx), ctx)
+}
+
+// RollbackTx mocks base method.
+func (m *MocktransactionDBEmail) RollbackTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollbackTx indicates an expected call of RollbackTx.
+func (mr *MocktransactionDBEmailMockRecorder) RollbackTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTx", reflect.TypeOf((*MocktransactionDBEmail)(nil).RollbackTx), ctx)
+}
+
+// RollbackTxDefer mocks base method.
+func (m *MocktransactionDBEmail) RollbackTxDefer(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RollbackTxDefer", ctx)
+}
+
+// RollbackTxDefer indicates an expected call of RollbackTxDefer.
+func (mr *MocktransactionDBEmailMockRecorder) RollbackTxDefer(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTxDefer", reflect.TypeOf((*MocktransactionDBEmail)(nil).RollbackTxDefer), ctx)
+}
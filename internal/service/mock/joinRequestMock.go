@@ -0,0 +1,382 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: join_request.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/Thing-repository/backend-server/pkg/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockjoinRequestDBJoinRequest is a mock of joinRequestDBJoinRequest interface.
+type MockjoinRequestDBJoinRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockjoinRequestDBJoinRequestMockRecorder
+}
+
+// MockjoinRequestDBJoinRequestMockRecorder is the mock recorder for MockjoinRequestDBJoinRequest.
+type MockjoinRequestDBJoinRequestMockRecorder struct {
+	mock *MockjoinRequestDBJoinRequest
+}
+
+// NewMockjoinRequestDBJoinRequest creates a new mock instance.
+func NewMockjoinRequestDBJoinRequest(ctrl *gomock.Controller) *MockjoinRequestDBJoinRequest {
+	mock := &MockjoinRequestDBJoinRequest{ctrl: ctrl}
+	mock.recorder = &MockjoinRequestDBJoinRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockjoinRequestDBJoinRequest) EXPECT() *MockjoinRequestDBJoinRequestMockRecorder {
+	return m.recorder
+}
+
+// AddJoinRequest mocks base method.
+func (m *MockjoinRequestDBJoinRequest) AddJoinRequest(ctx context.Context, joinRequest *core.JoinRequestAdd, userId int) (*core.JoinRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddJoinRequest", ctx, joinRequest, userId)
+	ret0, _ := ret[0].(*core.JoinRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddJoinRequest indicates an expected call of AddJoinRequest.
+func (mr *MockjoinRequestDBJoinRequestMockRecorder) AddJoinRequest(ctx, joinRequest, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddJoinRequest", reflect.TypeOf((*MockjoinRequestDBJoinRequest)(nil).AddJoinRequest), ctx, joinRequest, userId)
+}
+
+// CancelUserJoinRequests mocks base method.
+func (m *MockjoinRequestDBJoinRequest) CancelUserJoinRequests(ctx context.Context, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelUserJoinRequests", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelUserJoinRequests indicates an expected call of CancelUserJoinRequests.
+func (mr *MockjoinRequestDBJoinRequestMockRecorder) CancelUserJoinRequests(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUserJoinRequests", reflect.TypeOf((*MockjoinRequestDBJoinRequest)(nil).CancelUserJoinRequests), ctx, userId)
+}
+
+// GetCompanyJoinRequests mocks base method.
+func (m *MockjoinRequestDBJoinRequest) GetCompanyJoinRequests(ctx context.Context, companyId int) ([]core.JoinRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanyJoinRequests", ctx, companyId)
+	ret0, _ := ret[0].([]core.JoinRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanyJoinRequests indicates an expected call of GetCompanyJoinRequests.
+func (mr *MockjoinRequestDBJoinRequestMockRecorder) GetCompanyJoinRequests(ctx, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyJoinRequests", reflect.TypeOf((*MockjoinRequestDBJoinRequest)(nil).GetCompanyJoinRequests), ctx, companyId)
+}
+
+// GetDepartmentJoinRequests mocks base method.
+func (m *MockjoinRequestDBJoinRequest) GetDepartmentJoinRequests(ctx context.Context, departmentId int) ([]core.JoinRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartmentJoinRequests", ctx, departmentId)
+	ret0, _ := ret[0].([]core.JoinRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartmentJoinRequests indicates an expected call of GetDepartmentJoinRequests.
+func (mr *MockjoinRequestDBJoinRequestMockRecorder) GetDepartmentJoinRequests(ctx, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartmentJoinRequests", reflect.TypeOf((*MockjoinRequestDBJoinRequest)(nil).GetDepartmentJoinRequests), ctx, departmentId)
+}
+
+// GetJoinRequestForUpdate mocks base method.
+func (m *MockjoinRequestDBJoinRequest) GetJoinRequestForUpdate(ctx context.Context, joinRequestId int) (*core.JoinRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJoinRequestForUpdate", ctx, joinRequestId)
+	ret0, _ := ret[0].(*core.JoinRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJoinRequestForUpdate indicates an expected call of GetJoinRequestForUpdate.
+func (mr *MockjoinRequestDBJoinRequestMockRecorder) GetJoinRequestForUpdate(ctx, joinRequestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJoinRequestForUpdate", reflect.TypeOf((*MockjoinRequestDBJoinRequest)(nil).GetJoinRequestForUpdate), ctx, joinRequestId)
+}
+
+// GetUserJoinRequests mocks base method.
+func (m *MockjoinRequestDBJoinRequest) GetUserJoinRequests(ctx context.Context, userId int) ([]core.JoinRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserJoinRequests", ctx, userId)
+	ret0, _ := ret[0].([]core.JoinRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserJoinRequests indicates an expected call of GetUserJoinRequests.
+func (mr *MockjoinRequestDBJoinRequestMockRecorder) GetUserJoinRequests(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserJoinRequests", reflect.TypeOf((*MockjoinRequestDBJoinRequest)(nil).GetUserJoinRequests), ctx, userId)
+}
+
+// SetJoinRequestStatus mocks base method.
+func (m *MockjoinRequestDBJoinRequest) SetJoinRequestStatus(ctx context.Context, joinRequestId int, status string, answeredBy *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetJoinRequestStatus", ctx, joinRequestId, status, answeredBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetJoinRequestStatus indicates an expected call of SetJoinRequestStatus.
+func (mr *MockjoinRequestDBJoinRequestMockRecorder) SetJoinRequestStatus(ctx, joinRequestId, status, answeredBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetJoinRequestStatus", reflect.TypeOf((*MockjoinRequestDBJoinRequest)(nil).SetJoinRequestStatus), ctx, joinRequestId, status, answeredBy)
+}
+
+// MockuserDBJoinRequest is a mock of userDBJoinRequest interface.
+type MockuserDBJoinRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserDBJoinRequestMockRecorder
+}
+
+// MockuserDBJoinRequestMockRecorder is the mock recorder for MockuserDBJoinRequest.
+type MockuserDBJoinRequestMockRecorder struct {
+	mock *MockuserDBJoinRequest
+}
+
+// NewMockuserDBJoinRequest creates a new mock instance.
+func NewMockuserDBJoinRequest(ctrl *gomock.Controller) *MockuserDBJoinRequest {
+	mock := &MockuserDBJoinRequest{ctrl: ctrl}
+	mock.recorder = &MockuserDBJoinRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserDBJoinRequest) EXPECT() *MockuserDBJoinRequestMockRecorder {
+	return m.recorder
+}
+
+// GetUser mocks base method.
+func (m *MockuserDBJoinRequest) GetUser(ctx context.Context, userId int) (*core.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userId)
+	ret0, _ := ret[0].(*core.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockuserDBJoinRequestMockRecorder) GetUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockuserDBJoinRequest)(nil).GetUser), ctx, userId)
+}
+
+// PathUser mocks base method.
+func (m *MockuserDBJoinRequest) PathUser(ctx context.Context, user *core.UserDB, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PathUser", ctx, user, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PathUser indicates an expected call of PathUser.
+func (mr *MockuserDBJoinRequestMockRecorder) PathUser(ctx, user, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PathUser", reflect.TypeOf((*MockuserDBJoinRequest)(nil).PathUser), ctx, user, userId)
+}
+
+// MockdepartmentDBJoinRequest is a mock of departmentDBJoinRequest interface.
+type MockdepartmentDBJoinRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockdepartmentDBJoinRequestMockRecorder
+}
+
+// MockdepartmentDBJoinRequestMockRecorder is the mock recorder for MockdepartmentDBJoinRequest.
+type MockdepartmentDBJoinRequestMockRecorder struct {
+	mock *MockdepartmentDBJoinRequest
+}
+
+// NewMockdepartmentDBJoinRequest creates a new mock instance.
+func NewMockdepartmentDBJoinRequest(ctrl *gomock.Controller) *MockdepartmentDBJoinRequest {
+	mock := &MockdepartmentDBJoinRequest{ctrl: ctrl}
+	mock.recorder = &MockdepartmentDBJoinRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdepartmentDBJoinRequest) EXPECT() *MockdepartmentDBJoinRequestMockRecorder {
+	return m.recorder
+}
+
+// GetDepartment mocks base method.
+func (m *MockdepartmentDBJoinRequest) GetDepartment(ctx context.Context, departmentId int) (*core.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartment", ctx, departmentId)
+	ret0, _ := ret[0].(*core.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartment indicates an expected call of GetDepartment.
+func (mr *MockdepartmentDBJoinRequestMockRecorder) GetDepartment(ctx, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartment", reflect.TypeOf((*MockdepartmentDBJoinRequest)(nil).GetDepartment), ctx, departmentId)
+}
+
+// MockcredentialsDBJoinRequest is a mock of credentialsDBJoinRequest interface.
+type MockcredentialsDBJoinRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockcredentialsDBJoinRequestMockRecorder
+}
+
+// MockcredentialsDBJoinRequestMockRecorder is the mock recorder for MockcredentialsDBJoinRequest.
+type MockcredentialsDBJoinRequestMockRecorder struct {
+	mock *MockcredentialsDBJoinRequest
+}
+
+// NewMockcredentialsDBJoinRequest creates a new mock instance.
+func NewMockcredentialsDBJoinRequest(ctrl *gomock.Controller) *MockcredentialsDBJoinRequest {
+	mock := &MockcredentialsDBJoinRequest{ctrl: ctrl}
+	mock.recorder = &MockcredentialsDBJoinRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcredentialsDBJoinRequest) EXPECT() *MockcredentialsDBJoinRequestMockRecorder {
+	return m.recorder
+}
+
+// CreateCredential mocks base method.
+func (m *MockcredentialsDBJoinRequest) CreateCredential(ctx context.Context, credentials *core.AddCredentials) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCredential", ctx, credentials)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCredential indicates an expected call of CreateCredential.
+func (mr *MockcredentialsDBJoinRequestMockRecorder) CreateCredential(ctx, credentials interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCredential", reflect.TypeOf((*MockcredentialsDBJoinRequest)(nil).CreateCredential), ctx, credentials)
+}
+
+// MockpublisherJoinRequest is a mock of publisherJoinRequest interface.
+type MockpublisherJoinRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockpublisherJoinRequestMockRecorder
+}
+
+// MockpublisherJoinRequestMockRecorder is the mock recorder for MockpublisherJoinRequest.
+type MockpublisherJoinRequestMockRecorder struct {
+	mock *MockpublisherJoinRequest
+}
+
+// NewMockpublisherJoinRequest creates a new mock instance.
+func NewMockpublisherJoinRequest(ctrl *gomock.Controller) *MockpublisherJoinRequest {
+	mock := &MockpublisherJoinRequest{ctrl: ctrl}
+	mock.recorder = &MockpublisherJoinRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpublisherJoinRequest) EXPECT() *MockpublisherJoinRequestMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockpublisherJoinRequest) Publish(ctx context.Context, companyId int, eventType string, data interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, companyId, eventType, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockpublisherJoinRequestMockRecorder) Publish(ctx, companyId, eventType, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockpublisherJoinRequest)(nil).Publish), ctx, companyId, eventType, data)
+}
+
+// MocktransactionDBJoinRequest is a mock of transactionDBJoinRequest interface.
+type MocktransactionDBJoinRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactionDBJoinRequestMockRecorder
+}
+
+// MocktransactionDBJoinRequestMockRecorder is the mock recorder for MocktransactionDBJoinRequest.
+type MocktransactionDBJoinRequestMockRecorder struct {
+	mock *MocktransactionDBJoinRequest
+}
+
+// NewMocktransactionDBJoinRequest creates a new mock instance.
+func NewMocktransactionDBJoinRequest(ctrl *gomock.Controller) *MocktransactionDBJoinRequest {
+	mock := &MocktransactionDBJoinRequest{ctrl: ctrl}
+	mock.recorder = &MocktransactionDBJoinRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktransactionDBJoinRequest) EXPECT() *MocktransactionDBJoinRequestMockRecorder {
+	return m.recorder
+}
+
+// CommitTx mocks base method.
+func (m *MocktransactionDBJoinRequest) CommitTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitTx indicates an expected call of CommitTx.
+func (mr *MocktransactionDBJoinRequestMockRecorder) CommitTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTx", reflect.TypeOf((*MocktransactionDBJoinRequest)(nil).CommitTx), ctx)
+}
+
+// InjectTx mocks base method.
+func (m *MocktransactionDBJoinRequest) InjectTx(ctx context.Context) (context.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectTx", ctx)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InjectTx indicates an expected call of InjectTx.
+func (mr *MocktransactionDBJoinRequestMockRecorder) InjectTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectTx", reflect.TypeOf((*MocktransactionDBJoinRequest)(nil).InjectTx), ctx)
+}
+
+// RollbackTx mocks base method.
+func (m *MocktransactionDBJoinRequest) RollbackTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollbackTx indicates an expected call of RollbackTx.
+func (mr *MocktransactionDBJoinRequestMockRecorder) RollbackTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTx", reflect.TypeOf((*MocktransactionDBJoinRequest)(nil).RollbackTx), ctx)
+}
+
+// RollbackTxDefer mocks base method.
+func (m *MocktransactionDBJoinRequest) RollbackTxDefer(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RollbackTxDefer", ctx)
+}
+
+// RollbackTxDefer indicates an expected call of RollbackTxDefer.
+func (mr *MocktransactionDBJoinRequestMockRecorder) RollbackTxDefer(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTxDefer", reflect.TypeOf((*MocktransactionDBJoinRequest)(nil).RollbackTxDefer), ctx)
+}
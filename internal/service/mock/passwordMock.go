@@ -0,0 +1,417 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: password.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/Thing-repository/backend-server/pkg/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockuserDBPassword is a mock of userDBPassword interface.
+type MockuserDBPassword struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserDBPasswordMockRecorder
+}
+
+// MockuserDBPasswordMockRecorder is the mock recorder for MockuserDBPassword.
+type MockuserDBPasswordMockRecorder struct {
+	mock *MockuserDBPassword
+}
+
+// NewMockuserDBPassword creates a new mock instance.
+func NewMockuserDBPassword(ctrl *gomock.Controller) *MockuserDBPassword {
+	mock := &MockuserDBPassword{ctrl: ctrl}
+	mock.recorder = &MockuserDBPasswordMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserDBPassword) EXPECT() *MockuserDBPasswordMockRecorder {
+	return m.recorder
+}
+
+// GetUser mocks base method.
+func (m *MockuserDBPassword) GetUser(ctx context.Context, userId int) (*core.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userId)
+	ret0, _ := ret[0].(*core.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockuserDBPasswordMockRecorder) GetUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockuserDBPassword)(nil).GetUser), ctx, userId)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockuserDBPassword) GetUserByEmail(ctx context.Context, email string) (*core.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(*core.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockuserDBPasswordMockRecorder) GetUserByEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockuserDBPassword)(nil).GetUserByEmail), ctx, email)
+}
+
+// PathUser mocks base method.
+func (m *MockuserDBPassword) PathUser(ctx context.Context, user *core.UserDB, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PathUser", ctx, user, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PathUser indicates an expected call of PathUser.
+func (mr *MockuserDBPasswordMockRecorder) PathUser(ctx, user, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PathUser", reflect.TypeOf((*MockuserDBPassword)(nil).PathUser), ctx, user, userId)
+}
+
+// MockpasswordResetDBPassword is a mock of passwordResetDBPassword interface.
+type MockpasswordResetDBPassword struct {
+	ctrl     *gomock.Controller
+	recorder *MockpasswordResetDBPasswordMockRecorder
+}
+
+// MockpasswordResetDBPasswordMockRecorder is the mock recorder for MockpasswordResetDBPassword.
+type MockpasswordResetDBPasswordMockRecorder struct {
+	mock *MockpasswordResetDBPassword
+}
+
+// NewMockpasswordResetDBPassword creates a new mock instance.
+func NewMockpasswordResetDBPassword(ctrl *gomock.Controller) *MockpasswordResetDBPassword {
+	mock := &MockpasswordResetDBPassword{ctrl: ctrl}
+	mock.recorder = &MockpasswordResetDBPasswordMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpasswordResetDBPassword) EXPECT() *MockpasswordResetDBPasswordMockRecorder {
+	return m.recorder
+}
+
+// AddPasswordReset mocks base method.
+func (m *MockpasswordResetDBPassword) AddPasswordReset(ctx context.Context, userId int, tokenHash string, expiresTime uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPasswordReset", ctx, userId, tokenHash, expiresTime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPasswordReset indicates an expected call of AddPasswordReset.
+func (mr *MockpasswordResetDBPasswordMockRecorder) AddPasswordReset(ctx, userId, tokenHash, expiresTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPasswordReset", reflect.TypeOf((*MockpasswordResetDBPassword)(nil).AddPasswordReset), ctx, userId, tokenHash, expiresTime)
+}
+
+// CountPasswordResets mocks base method.
+func (m *MockpasswordResetDBPassword) CountPasswordResets(ctx context.Context, userId int, since uint32) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPasswordResets", ctx, userId, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPasswordResets indicates an expected call of CountPasswordResets.
+func (mr *MockpasswordResetDBPasswordMockRecorder) CountPasswordResets(ctx, userId, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPasswordResets", reflect.TypeOf((*MockpasswordResetDBPassword)(nil).CountPasswordResets), ctx, userId, since)
+}
+
+// RevokeUserPasswordResets mocks base method.
+func (m *MockpasswordResetDBPassword) RevokeUserPasswordResets(ctx context.Context, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserPasswordResets", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserPasswordResets indicates an expected call of RevokeUserPasswordResets.
+func (mr *MockpasswordResetDBPasswordMockRecorder) RevokeUserPasswordResets(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserPasswordResets", reflect.TypeOf((*MockpasswordResetDBPassword)(nil).RevokeUserPasswordResets), ctx, userId)
+}
+
+// UsePasswordReset mocks base method.
+func (m *MockpasswordResetDBPassword) UsePasswordReset(ctx context.Context, tokenHash string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsePasswordReset", ctx, tokenHash)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsePasswordReset indicates an expected call of UsePasswordReset.
+func (mr *MockpasswordResetDBPasswordMockRecorder) UsePasswordReset(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsePasswordReset", reflect.TypeOf((*MockpasswordResetDBPassword)(nil).UsePasswordReset), ctx, tokenHash)
+}
+
+// MocksessionDBPassword is a mock of sessionDBPassword interface.
+type MocksessionDBPassword struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionDBPasswordMockRecorder
+}
+
+// MocksessionDBPasswordMockRecorder is the mock recorder for MocksessionDBPassword.
+type MocksessionDBPasswordMockRecorder struct {
+	mock *MocksessionDBPassword
+}
+
+// NewMocksessionDBPassword creates a new mock instance.
+func NewMocksessionDBPassword(ctrl *gomock.Controller) *MocksessionDBPassword {
+	mock := &MocksessionDBPassword{ctrl: ctrl}
+	mock.recorder = &MocksessionDBPasswordMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionDBPassword) EXPECT() *MocksessionDBPasswordMockRecorder {
+	return m.recorder
+}
+
+// RevokeUserSessions mocks base method.
+func (m *MocksessionDBPassword) RevokeUserSessions(ctx context.Context, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessions", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MocksessionDBPasswordMockRecorder) RevokeUserSessions(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MocksessionDBPassword)(nil).RevokeUserSessions), ctx, userId)
+}
+
+// MocktokenPassword is a mock of tokenPassword interface.
+type MocktokenPassword struct {
+	ctrl     *gomock.Controller
+	recorder *MocktokenPasswordMockRecorder
+}
+
+// MocktokenPasswordMockRecorder is the mock recorder for MocktokenPassword.
+type MocktokenPasswordMockRecorder struct {
+	mock *MocktokenPassword
+}
+
+// NewMocktokenPassword creates a new mock instance.
+func NewMocktokenPassword(ctrl *gomock.Controller) *MocktokenPassword {
+	mock := &MocktokenPassword{ctrl: ctrl}
+	mock.recorder = &MocktokenPasswordMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktokenPassword) EXPECT() *MocktokenPasswordMockRecorder {
+	return m.recorder
+}
+
+// GenerateRandomToken mocks base method.
+func (m *MocktokenPassword) GenerateRandomToken() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateRandomToken")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateRandomToken indicates an expected call of GenerateRandomToken.
+func (mr *MocktokenPasswordMockRecorder) GenerateRandomToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateRandomToken", reflect.TypeOf((*MocktokenPassword)(nil).GenerateRandomToken))
+}
+
+// HashRandomToken mocks base method.
+func (m *MocktokenPassword) HashRandomToken(token string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashRandomToken", token)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// HashRandomToken indicates an expected call of HashRandomToken.
+func (mr *MocktokenPasswordMockRecorder) HashRandomToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashRandomToken", reflect.TypeOf((*MocktokenPassword)(nil).HashRandomToken), token)
+}
+
+// MockhashPassword is a mock of hashPassword interface.
+type MockhashPassword struct {
+	ctrl     *gomock.Controller
+	recorder *MockhashPasswordMockRecorder
+}
+
+// MockhashPasswordMockRecorder is the mock recorder for MockhashPassword.
+type MockhashPasswordMockRecorder struct {
+	mock *MockhashPassword
+}
+
+// NewMockhashPassword creates a new mock instance.
+func NewMockhashPassword(ctrl *gomock.Controller) *MockhashPassword {
+	mock := &MockhashPassword{ctrl: ctrl}
+	mock.recorder = &MockhashPasswordMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhashPassword) EXPECT() *MockhashPasswordMockRecorder {
+	return m.recorder
+}
+
+// GenerateHash mocks base method.
+func (m *MockhashPassword) GenerateHash(password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateHash", password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateHash indicates an expected call of GenerateHash.
+func (mr *MockhashPasswordMockRecorder) GenerateHash(password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateHash", reflect.TypeOf((*MockhashPassword)(nil).GenerateHash), password)
+}
+
+// ValidateHash mocks base method.
+func (m *MockhashPassword) ValidateHash(hash, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateHash", hash, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateHash indicates an expected call of ValidateHash.
+func (mr *MockhashPasswordMockRecorder) ValidateHash(hash, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateHash", reflect.TypeOf((*MockhashPassword)(nil).ValidateHash), hash, password)
+}
+
+// MockmailerPassword is a mock of mailerPassword interface.
+type MockmailerPassword struct {
+	ctrl     *gomock.Controller
+	recorder *MockmailerPasswordMockRecorder
+}
+
+// MockmailerPasswordMockRecorder is the mock recorder for MockmailerPassword.
+type MockmailerPasswordMockRecorder struct {
+	mock *MockmailerPassword
+}
+
+// NewMockmailerPassword creates a new mock instance.
+func NewMockmailerPassword(ctrl *gomock.Controller) *MockmailerPassword {
+	mock := &MockmailerPassword{ctrl: ctrl}
+	mock.recorder = &MockmailerPasswordMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmailerPassword) EXPECT() *MockmailerPasswordMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockmailerPassword) Send(ctx context.Context, message *core.MailMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockmailerPasswordMockRecorder) Send(ctx, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockmailerPassword)(nil).Send), ctx, message)
+}
+
+// MocktransactionDBPassword is a mock of transactionDBPassword interface.
+type MocktransactionDBPassword struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactionDBPasswordMockRecorder
+}
+
+// MocktransactionDBPasswordMockRecorder is the mock recorder for MocktransactionDBPassword.
+type MocktransactionDBPasswordMockRecorder struct {
+	mock *MocktransactionDBPassword
+}
+
+// NewMocktransactionDBPassword creates a new mock instance.
+func NewMocktransactionDBPassword(ctrl *gomock.Controller) *MocktransactionDBPassword {
+	mock := &MocktransactionDBPassword{ctrl: ctrl}
+	mock.recorder = &MocktransactionDBPasswordMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktransactionDBPassword) EXPECT() *MocktransactionDBPasswordMockRecorder {
+	return m.recorder
+}
+
+// CommitTx mocks base method.
+func (m *MocktransactionDBPassword) CommitTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitTx indicates an expected call of CommitTx.
+func (mr *MocktransactionDBPasswordMockRecorder) CommitTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTx", reflect.TypeOf((*MocktransactionDBPassword)(nil).CommitTx), ctx)
+}
+
+// InjectTx mocks base method.
+func (m *MocktransactionDBPassword) InjectTx(ctx context.Context) (context.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectTx", ctx)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InjectTx indicates an expected call of InjectTx.
+func (mr *MocktransactionDBPasswordMockRecorder) InjectTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectTx", reflect.TypeOf((*MocktransactionDBPassword)(nil).InjectTx), ctx)
+}
+
+// RollbackTx mocks base method.
+func (m *MocktransactionDBPassword) RollbackTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollbackTx indicates an expected call of RollbackTx.
+func (mr *MocktransactionDBPasswordMockRecorder) RollbackTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTx", reflect.TypeOf((*MocktransactionDBPassword)(nil).RollbackTx), ctx)
+}
+
+// RollbackTxDefer mocks base method.
+func (m *MocktransactionDBPassword) RollbackTxDefer(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RollbackTxDefer", ctx)
+}
+
+// RollbackTxDefer indicates an expected call of RollbackTxDefer.
+func (mr *MocktransactionDBPasswordMockRecorder) RollbackTxDefer(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTxDefer", reflect.TypeOf((*MocktransactionDBPassword)(nil).RollbackTxDefer), ctx)
+}
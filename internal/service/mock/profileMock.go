@@ -0,0 +1,297 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: profile.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/Thing-repository/backend-server/pkg/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockuserDBProfile is a mock of userDBProfile interface.
+type MockuserDBProfile struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserDBProfileMockRecorder
+}
+
+// MockuserDBProfileMockRecorder is the mock recorder for MockuserDBProfile.
+type MockuserDBProfileMockRecorder struct {
+	mock *MockuserDBProfile
+}
+
+// NewMockuserDBProfile creates a new mock instance.
+func NewMockuserDBProfile(ctrl *gomock.Controller) *MockuserDBProfile {
+	mock := &MockuserDBProfile{ctrl: ctrl}
+	mock.recorder = &MockuserDBProfileMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserDBProfile) EXPECT() *MockuserDBProfileMockRecorder {
+	return m.recorder
+}
+
+// DeleteUser mocks base method.
+func (m *MockuserDBProfile) DeleteUser(ctx context.Context, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockuserDBProfileMockRecorder) DeleteUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockuserDBProfile)(nil).DeleteUser), ctx, userId)
+}
+
+// GetUser mocks base method.
+func (m *MockuserDBProfile) GetUser(ctx context.Context, userId int) (*core.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userId)
+	ret0, _ := ret[0].(*core.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockuserDBProfileMockRecorder) GetUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockuserDBProfile)(nil).GetUser), ctx, userId)
+}
+
+// PathUser mocks base method.
+func (m *MockuserDBProfile) PathUser(ctx context.Context, user *core.UserDB, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PathUser", ctx, user, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PathUser indicates an expected call of PathUser.
+func (mr *MockuserDBProfileMockRecorder) PathUser(ctx, user, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PathUser", reflect.TypeOf((*MockuserDBProfile)(nil).PathUser), ctx, user, userId)
+}
+
+// MockcredentialsDBProfile is a mock of credentialsDBProfile interface.
+type MockcredentialsDBProfile struct {
+	ctrl     *gomock.Controller
+	recorder *MockcredentialsDBProfileMockRecorder
+}
+
+// MockcredentialsDBProfileMockRecorder is the mock recorder for MockcredentialsDBProfile.
+type MockcredentialsDBProfileMockRecorder struct {
+	mock *MockcredentialsDBProfile
+}
+
+// NewMockcredentialsDBProfile creates a new mock instance.
+func NewMockcredentialsDBProfile(ctrl *gomock.Controller) *MockcredentialsDBProfile {
+	mock := &MockcredentialsDBProfile{ctrl: ctrl}
+	mock.recorder = &MockcredentialsDBProfileMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcredentialsDBProfile) EXPECT() *MockcredentialsDBProfileMockRecorder {
+	return m.recorder
+}
+
+// CountCredentialsForUpdate mocks base method.
+func (m *MockcredentialsDBProfile) CountCredentialsForUpdate(ctx context.Context, credentialType string, objectId int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCredentialsForUpdate", ctx, credentialType, objectId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCredentialsForUpdate indicates an expected call of CountCredentialsForUpdate.
+func (mr *MockcredentialsDBProfileMockRecorder) CountCredentialsForUpdate(ctx, credentialType, objectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCredentialsForUpdate", reflect.TypeOf((*MockcredentialsDBProfile)(nil).CountCredentialsForUpdate), ctx, credentialType, objectId)
+}
+
+// GetUserCredential mocks base method.
+func (m *MockcredentialsDBProfile) GetUserCredential(ctx context.Context, userId int) ([]core.Credentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCredential", ctx, userId)
+	ret0, _ := ret[0].([]core.Credentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCredential indicates an expected call of GetUserCredential.
+func (mr *MockcredentialsDBProfileMockRecorder) GetUserCredential(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCredential", reflect.TypeOf((*MockcredentialsDBProfile)(nil).GetUserCredential), ctx, userId)
+}
+
+// InvalidateAllCredentials mocks base method.
+func (m *MockcredentialsDBProfile) InvalidateAllCredentials(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "InvalidateAllCredentials", ctx)
+}
+
+// InvalidateAllCredentials indicates an expected call of InvalidateAllCredentials.
+func (mr *MockcredentialsDBProfileMockRecorder) InvalidateAllCredentials(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateAllCredentials", reflect.TypeOf((*MockcredentialsDBProfile)(nil).InvalidateAllCredentials), ctx)
+}
+
+// MockthingUsageDBProfile is a mock of thingUsageDBProfile interface.
+type MockthingUsageDBProfile struct {
+	ctrl     *gomock.Controller
+	recorder *MockthingUsageDBProfileMockRecorder
+}
+
+// MockthingUsageDBProfileMockRecorder is the mock recorder for MockthingUsageDBProfile.
+type MockthingUsageDBProfileMockRecorder struct {
+	mock *MockthingUsageDBProfile
+}
+
+// NewMockthingUsageDBProfile creates a new mock instance.
+func NewMockthingUsageDBProfile(ctrl *gomock.Controller) *MockthingUsageDBProfile {
+	mock := &MockthingUsageDBProfile{ctrl: ctrl}
+	mock.recorder = &MockthingUsageDBProfileMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockthingUsageDBProfile) EXPECT() *MockthingUsageDBProfileMockRecorder {
+	return m.recorder
+}
+
+// CountUserTakenUsages mocks base method.
+func (m *MockthingUsageDBProfile) CountUserTakenUsages(ctx context.Context, userId, companyId, departmentId int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserTakenUsages", ctx, userId, companyId, departmentId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserTakenUsages indicates an expected call of CountUserTakenUsages.
+func (mr *MockthingUsageDBProfileMockRecorder) CountUserTakenUsages(ctx, userId, companyId, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserTakenUsages", reflect.TypeOf((*MockthingUsageDBProfile)(nil).CountUserTakenUsages), ctx, userId, companyId, departmentId)
+}
+
+// MockemailVerificationProfile is a mock of emailVerificationProfile interface.
+type MockemailVerificationProfile struct {
+	ctrl     *gomock.Controller
+	recorder *MockemailVerificationProfileMockRecorder
+}
+
+// MockemailVerificationProfileMockRecorder is the mock recorder for MockemailVerificationProfile.
+type MockemailVerificationProfileMockRecorder struct {
+	mock *MockemailVerificationProfile
+}
+
+// NewMockemailVerificationProfile creates a new mock instance.
+func NewMockemailVerificationProfile(ctrl *gomock.Controller) *MockemailVerificationProfile {
+	mock := &MockemailVerificationProfile{ctrl: ctrl}
+	mock.recorder = &MockemailVerificationProfileMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockemailVerificationProfile) EXPECT() *MockemailVerificationProfileMockRecorder {
+	return m.recorder
+}
+
+// SendNewEmailVerification mocks base method.
+func (m *MockemailVerificationProfile) SendNewEmailVerification(ctx context.Context, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendNewEmailVerification", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendNewEmailVerification indicates an expected call of SendNewEmailVerification.
+func (mr *MockemailVerificationProfileMockRecorder) SendNewEmailVerification(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendNewEmailVerification", reflect.TypeOf((*MockemailVerificationProfile)(nil).SendNewEmailVerification), ctx, userId)
+}
+
+// MocktransactionDBProfile is a mock of transactionDBProfile interface.
+type MocktransactionDBProfile struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactionDBProfileMockRecorder
+}
+
+// MocktransactionDBProfileMockRecorder is the mock recorder for MocktransactionDBProfile.
+type MocktransactionDBProfileMockRecorder struct {
+	mock *MocktransactionDBProfile
+}
+
+// NewMocktransactionDBProfile creates a new mock instance.
+func NewMocktransactionDBProfile(ctrl *gomock.Controller) *MocktransactionDBProfile {
+	mock := &MocktransactionDBProfile{ctrl: ctrl}
+	mock.recorder = &MocktransactionDBProfileMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktransactionDBProfile) EXPECT() *MocktransactionDBProfileMockRecorder {
+	return m.recorder
+}
+
+// CommitTx mocks base method.
+func (m *MocktransactionDBProfile) CommitTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitTx indicates an expected call of CommitTx.
+func (mr *MocktransactionDBProfileMockRecorder) CommitTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTx", reflect.TypeOf((*MocktransactionDBProfile)(nil).CommitTx), ctx)
+}
+
+// InjectTx mocks base method.
+func (m *MocktransactionDBProfile) InjectTx(ctx context.Context) (context.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectTx", ctx)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InjectTx indicates an expected call of InjectTx.
+func (mr *MocktransactionDBProfileMockRecorder) InjectTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectTx", reflect.TypeOf((*MocktransactionDBProfile)(nil).InjectTx), ctx)
+}
+
+// RollbackTx mocks base method.
+func (m *MocktransactionDBProfile) RollbackTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollbackTx indicates an expected call of RollbackTx.
+func (mr *MocktransactionDBProfileMockRecorder) RollbackTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTx", reflect.TypeOf((*MocktransactionDBProfile)(nil).RollbackTx), ctx)
+}
+
+// RollbackTxDefer mocks base method.
+func (m *MocktransactionDBProfile) RollbackTxDefer(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RollbackTxDefer", ctx)
+}
+
+// RollbackTxDefer indicates an expected call of RollbackTxDefer.
+func (mr *MocktransactionDBProfileMockRecorder) RollbackTxDefer(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTxDefer", reflect.TypeOf((*MocktransactionDBProfile)(nil).RollbackTxDefer), ctx)
+}
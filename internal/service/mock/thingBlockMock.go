@@ -0,0 +1,462 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: thing_block.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/Thing-repository/backend-server/pkg/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockthingBlockDBThingBlock is a mock of thingBlockDBThingBlock interface.
+type MockthingBlockDBThingBlock struct {
+	ctrl     *gomock.Controller
+	recorder *MockthingBlockDBThingBlockMockRecorder
+}
+
+// MockthingBlockDBThingBlockMockRecorder is the mock recorder for MockthingBlockDBThingBlock.
+type MockthingBlockDBThingBlockMockRecorder struct {
+	mock *MockthingBlockDBThingBlock
+}
+
+// NewMockthingBlockDBThingBlock creates a new mock instance.
+func NewMockthingBlockDBThingBlock(ctrl *gomock.Controller) *MockthingBlockDBThingBlock {
+	mock := &MockthingBlockDBThingBlock{ctrl: ctrl}
+	mock.recorder = &MockthingBlockDBThingBlockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockthingBlockDBThingBlock) EXPECT() *MockthingBlockDBThingBlockMockRecorder {
+	return m.recorder
+}
+
+// AddBlock mocks base method.
+func (m *MockthingBlockDBThingBlock) AddBlock(ctx context.Context, block *core.ThingBlockAdd) (*core.ThingBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBlock", ctx, block)
+	ret0, _ := ret[0].(*core.ThingBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddBlock indicates an expected call of AddBlock.
+func (mr *MockthingBlockDBThingBlockMockRecorder) AddBlock(ctx, block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBlock", reflect.TypeOf((*MockthingBlockDBThingBlock)(nil).AddBlock), ctx, block)
+}
+
+// AddUsageCancellation mocks base method.
+func (m *MockthingBlockDBThingBlock) AddUsageCancellation(ctx context.Context, usage *core.ThingUsage, blockId int, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUsageCancellation", ctx, usage, blockId, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUsageCancellation indicates an expected call of AddUsageCancellation.
+func (mr *MockthingBlockDBThingBlockMockRecorder) AddUsageCancellation(ctx, usage, blockId, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUsageCancellation", reflect.TypeOf((*MockthingBlockDBThingBlock)(nil).AddUsageCancellation), ctx, usage, blockId, reason)
+}
+
+// DeleteBlock mocks base method.
+func (m *MockthingBlockDBThingBlock) DeleteBlock(ctx context.Context, blockId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlock", ctx, blockId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBlock indicates an expected call of DeleteBlock.
+func (mr *MockthingBlockDBThingBlockMockRecorder) DeleteBlock(ctx, blockId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlock", reflect.TypeOf((*MockthingBlockDBThingBlock)(nil).DeleteBlock), ctx, blockId)
+}
+
+// GetBlock mocks base method.
+func (m *MockthingBlockDBThingBlock) GetBlock(ctx context.Context, blockId int) (*core.ThingBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlock", ctx, blockId)
+	ret0, _ := ret[0].(*core.ThingBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlock indicates an expected call of GetBlock.
+func (mr *MockthingBlockDBThingBlockMockRecorder) GetBlock(ctx, blockId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlock", reflect.TypeOf((*MockthingBlockDBThingBlock)(nil).GetBlock), ctx, blockId)
+}
+
+// GetBlocksByCompany mocks base method.
+func (m *MockthingBlockDBThingBlock) GetBlocksByCompany(ctx context.Context, companyId int) ([]core.ThingBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksByCompany", ctx, companyId)
+	ret0, _ := ret[0].([]core.ThingBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksByCompany indicates an expected call of GetBlocksByCompany.
+func (mr *MockthingBlockDBThingBlockMockRecorder) GetBlocksByCompany(ctx, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksByCompany", reflect.TypeOf((*MockthingBlockDBThingBlock)(nil).GetBlocksByCompany), ctx, companyId)
+}
+
+// GetBlocksByDepartment mocks base method.
+func (m *MockthingBlockDBThingBlock) GetBlocksByDepartment(ctx context.Context, departmentId int) ([]core.ThingBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksByDepartment", ctx, departmentId)
+	ret0, _ := ret[0].([]core.ThingBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksByDepartment indicates an expected call of GetBlocksByDepartment.
+func (mr *MockthingBlockDBThingBlockMockRecorder) GetBlocksByDepartment(ctx, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksByDepartment", reflect.TypeOf((*MockthingBlockDBThingBlock)(nil).GetBlocksByDepartment), ctx, departmentId)
+}
+
+// UpdateBlock mocks base method.
+func (m *MockthingBlockDBThingBlock) UpdateBlock(ctx context.Context, block *core.ThingBlockAdd, blockId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBlock", ctx, block, blockId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBlock indicates an expected call of UpdateBlock.
+func (mr *MockthingBlockDBThingBlockMockRecorder) UpdateBlock(ctx, block, blockId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBlock", reflect.TypeOf((*MockthingBlockDBThingBlock)(nil).UpdateBlock), ctx, block, blockId)
+}
+
+// MockthingDBThingBlock is a mock of thingDBThingBlock interface.
+type MockthingDBThingBlock struct {
+	ctrl     *gomock.Controller
+	recorder *MockthingDBThingBlockMockRecorder
+}
+
+// MockthingDBThingBlockMockRecorder is the mock recorder for MockthingDBThingBlock.
+type MockthingDBThingBlockMockRecorder struct {
+	mock *MockthingDBThingBlock
+}
+
+// NewMockthingDBThingBlock creates a new mock instance.
+func NewMockthingDBThingBlock(ctrl *gomock.Controller) *MockthingDBThingBlock {
+	mock := &MockthingDBThingBlock{ctrl: ctrl}
+	mock.recorder = &MockthingDBThingBlockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockthingDBThingBlock) EXPECT() *MockthingDBThingBlockMockRecorder {
+	return m.recorder
+}
+
+// GetThing mocks base method.
+func (m *MockthingDBThingBlock) GetThing(ctx context.Context, thingId int) (*core.Thing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetThing", ctx, thingId)
+	ret0, _ := ret[0].(*core.Thing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetThing indicates an expected call of GetThing.
+func (mr *MockthingDBThingBlockMockRecorder) GetThing(ctx, thingId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThing", reflect.TypeOf((*MockthingDBThingBlock)(nil).GetThing), ctx, thingId)
+}
+
+// GetThingForUpdate mocks base method.
+func (m *MockthingDBThingBlock) GetThingForUpdate(ctx context.Context, thingId int) (*core.Thing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetThingForUpdate", ctx, thingId)
+	ret0, _ := ret[0].(*core.Thing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetThingForUpdate indicates an expected call of GetThingForUpdate.
+func (mr *MockthingDBThingBlockMockRecorder) GetThingForUpdate(ctx, thingId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThingForUpdate", reflect.TypeOf((*MockthingDBThingBlock)(nil).GetThingForUpdate), ctx, thingId)
+}
+
+// RefreshExpiredBlocking mocks base method.
+func (m *MockthingDBThingBlock) RefreshExpiredBlocking(ctx context.Context) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshExpiredBlocking", ctx)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshExpiredBlocking indicates an expected call of RefreshExpiredBlocking.
+func (mr *MockthingDBThingBlockMockRecorder) RefreshExpiredBlocking(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshExpiredBlocking", reflect.TypeOf((*MockthingDBThingBlock)(nil).RefreshExpiredBlocking), ctx)
+}
+
+// RefreshThingBlocking mocks base method.
+func (m *MockthingDBThingBlock) RefreshThingBlocking(ctx context.Context, thingId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshThingBlocking", ctx, thingId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshThingBlocking indicates an expected call of RefreshThingBlocking.
+func (mr *MockthingDBThingBlockMockRecorder) RefreshThingBlocking(ctx, thingId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshThingBlocking", reflect.TypeOf((*MockthingDBThingBlock)(nil).RefreshThingBlocking), ctx, thingId)
+}
+
+// MockthingUsageDBThingBlock is a mock of thingUsageDBThingBlock interface.
+type MockthingUsageDBThingBlock struct {
+	ctrl     *gomock.Controller
+	recorder *MockthingUsageDBThingBlockMockRecorder
+}
+
+// MockthingUsageDBThingBlockMockRecorder is the mock recorder for MockthingUsageDBThingBlock.
+type MockthingUsageDBThingBlockMockRecorder struct {
+	mock *MockthingUsageDBThingBlock
+}
+
+// NewMockthingUsageDBThingBlock creates a new mock instance.
+func NewMockthingUsageDBThingBlock(ctrl *gomock.Controller) *MockthingUsageDBThingBlock {
+	mock := &MockthingUsageDBThingBlock{ctrl: ctrl}
+	mock.recorder = &MockthingUsageDBThingBlockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockthingUsageDBThingBlock) EXPECT() *MockthingUsageDBThingBlockMockRecorder {
+	return m.recorder
+}
+
+// GetOverlappingUsages mocks base method.
+func (m *MockthingUsageDBThingBlock) GetOverlappingUsages(ctx context.Context, thingId int, startTime, endTime uint32) ([]core.ThingUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverlappingUsages", ctx, thingId, startTime, endTime)
+	ret0, _ := ret[0].([]core.ThingUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverlappingUsages indicates an expected call of GetOverlappingUsages.
+func (mr *MockthingUsageDBThingBlockMockRecorder) GetOverlappingUsages(ctx, thingId, startTime, endTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverlappingUsages", reflect.TypeOf((*MockthingUsageDBThingBlock)(nil).GetOverlappingUsages), ctx, thingId, startTime, endTime)
+}
+
+// SetUsageStatus mocks base method.
+func (m *MockthingUsageDBThingBlock) SetUsageStatus(ctx context.Context, usageId int, currentStatus, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUsageStatus", ctx, usageId, currentStatus, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUsageStatus indicates an expected call of SetUsageStatus.
+func (mr *MockthingUsageDBThingBlockMockRecorder) SetUsageStatus(ctx, usageId, currentStatus, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUsageStatus", reflect.TypeOf((*MockthingUsageDBThingBlock)(nil).SetUsageStatus), ctx, usageId, currentStatus, status)
+}
+
+// MockdepartmentDBThingBlock is a mock of departmentDBThingBlock interface.
+type MockdepartmentDBThingBlock struct {
+	ctrl     *gomock.Controller
+	recorder *MockdepartmentDBThingBlockMockRecorder
+}
+
+// MockdepartmentDBThingBlockMockRecorder is the mock recorder for MockdepartmentDBThingBlock.
+type MockdepartmentDBThingBlockMockRecorder struct {
+	mock *MockdepartmentDBThingBlock
+}
+
+// NewMockdepartmentDBThingBlock creates a new mock instance.
+func NewMockdepartmentDBThingBlock(ctrl *gomock.Controller) *MockdepartmentDBThingBlock {
+	mock := &MockdepartmentDBThingBlock{ctrl: ctrl}
+	mock.recorder = &MockdepartmentDBThingBlockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdepartmentDBThingBlock) EXPECT() *MockdepartmentDBThingBlockMockRecorder {
+	return m.recorder
+}
+
+// GetDepartment mocks base method.
+func (m *MockdepartmentDBThingBlock) GetDepartment(ctx context.Context, departmentId int) (*core.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartment", ctx, departmentId)
+	ret0, _ := ret[0].(*core.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartment indicates an expected call of GetDepartment.
+func (mr *MockdepartmentDBThingBlockMockRecorder) GetDepartment(ctx, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartment", reflect.TypeOf((*MockdepartmentDBThingBlock)(nil).GetDepartment), ctx, departmentId)
+}
+
+// MocknotifierThingBlock is a mock of notifierThingBlock interface.
+type MocknotifierThingBlock struct {
+	ctrl     *gomock.Controller
+	recorder *MocknotifierThingBlockMockRecorder
+}
+
+// MocknotifierThingBlockMockRecorder is the mock recorder for MocknotifierThingBlock.
+type MocknotifierThingBlockMockRecorder struct {
+	mock *MocknotifierThingBlock
+}
+
+// NewMocknotifierThingBlock creates a new mock instance.
+func NewMocknotifierThingBlock(ctrl *gomock.Controller) *MocknotifierThingBlock {
+	mock := &MocknotifierThingBlock{ctrl: ctrl}
+	mock.recorder = &MocknotifierThingBlockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocknotifierThingBlock) EXPECT() *MocknotifierThingBlockMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MocknotifierThingBlock) Notify(ctx context.Context, notification *core.NotificationAdd) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MocknotifierThingBlockMockRecorder) Notify(ctx, notification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MocknotifierThingBlock)(nil).Notify), ctx, notification)
+}
+
+// MockpublisherThingBlock is a mock of publisherThingBlock interface.
+type MockpublisherThingBlock struct {
+	ctrl     *gomock.Controller
+	recorder *MockpublisherThingBlockMockRecorder
+}
+
+// MockpublisherThingBlockMockRecorder is the mock recorder for MockpublisherThingBlock.
+type MockpublisherThingBlockMockRecorder struct {
+	mock *MockpublisherThingBlock
+}
+
+// NewMockpublisherThingBlock creates a new mock instance.
+func NewMockpublisherThingBlock(ctrl *gomock.Controller) *MockpublisherThingBlock {
+	mock := &MockpublisherThingBlock{ctrl: ctrl}
+	mock.recorder = &MockpublisherThingBlockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpublisherThingBlock) EXPECT() *MockpublisherThingBlockMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockpublisherThingBlock) Publish(ctx context.Context, companyId int, eventType string, data interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, companyId, eventType, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockpublisherThingBlockMockRecorder) Publish(ctx, companyId, eventType, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockpublisherThingBlock)(nil).Publish), ctx, companyId, eventType, data)
+}
+
+// MocktransactionDBThingBlock is a mock of transactionDBThingBlock interface.
+type MocktransactionDBThingBlock struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactionDBThingBlockMockRecorder
+}
+
+// MocktransactionDBThingBlockMockRecorder is the mock recorder for MocktransactionDBThingBlock.
+type MocktransactionDBThingBlockMockRecorder struct {
+	mock *MocktransactionDBThingBlock
+}
+
+// NewMocktransactionDBThingBlock creates a new mock instance.
+func NewMocktransactionDBThingBlock(ctrl *gomock.Controller) *MocktransactionDBThingBlock {
+	mock := &MocktransactionDBThingBlock{ctrl: ctrl}
+	mock.recorder = &MocktransactionDBThingBlockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktransactionDBThingBlock) EXPECT() *MocktransactionDBThingBlockMockRecorder {
+	return m.recorder
+}
+
+// CommitTx mocks base method.
+func (m *MocktransactionDBThingBlock) CommitTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitTx indicates an expected call of CommitTx.
+func (mr *MocktransactionDBThingBlockMockRecorder) CommitTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTx", reflect.TypeOf((*MocktransactionDBThingBlock)(nil).CommitTx), ctx)
+}
+
+// InjectTx mocks base method.
+func (m *MocktransactionDBThingBlock) InjectTx(ctx context.Context) (context.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectTx", ctx)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InjectTx indicates an expected call of InjectTx.
+func (mr *MocktransactionDBThingBlockMockRecorder) InjectTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectTx", reflect.TypeOf((*MocktransactionDBThingBlock)(nil).InjectTx), ctx)
+}
+
+// RollbackTx mocks base method.
+func (m *MocktransactionDBThingBlock) RollbackTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollbackTx indicates an expected call of RollbackTx.
+func (mr *MocktransactionDBThingBlockMockRecorder) RollbackTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTx", reflect.TypeOf((*MocktransactionDBThingBlock)(nil).RollbackTx), ctx)
+}
+
+// RollbackTxDefer mocks base method.
+func (m *MocktransactionDBThingBlock) RollbackTxDefer(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RollbackTxDefer", ctx)
+}
+
+// RollbackTxDefer indicates an expected call of RollbackTxDefer.
+func (mr *MocktransactionDBThingBlockMockRecorder) RollbackTxDefer(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTxDefer", reflect.TypeOf((*MocktransactionDBThingBlock)(nil).RollbackTxDefer), ctx)
+}
@@ -0,0 +1,588 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: thing_usage.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	core "github.com/Thing-repository/backend-server/pkg/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockthingUsageDBThingUsage is a mock of thingUsageDBThingUsage interface.
+type MockthingUsageDBThingUsage struct {
+	ctrl     *gomock.Controller
+	recorder *MockthingUsageDBThingUsageMockRecorder
+}
+
+// MockthingUsageDBThingUsageMockRecorder is the mock recorder for MockthingUsageDBThingUsage.
+type MockthingUsageDBThingUsageMockRecorder struct {
+	mock *MockthingUsageDBThingUsage
+}
+
+// NewMockthingUsageDBThingUsage creates a new mock instance.
+func NewMockthingUsageDBThingUsage(ctrl *gomock.Controller) *MockthingUsageDBThingUsage {
+	mock := &MockthingUsageDBThingUsage{ctrl: ctrl}
+	mock.recorder = &MockthingUsageDBThingUsageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockthingUsageDBThingUsage) EXPECT() *MockthingUsageDBThingUsageMockRecorder {
+	return m.recorder
+}
+
+// AddUsage mocks base method.
+func (m *MockthingUsageDBThingUsage) AddUsage(ctx context.Context, usage *core.ThingUsageAdd, status string) (*core.ThingUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUsage", ctx, usage, status)
+	ret0, _ := ret[0].(*core.ThingUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddUsage indicates an expected call of AddUsage.
+func (mr *MockthingUsageDBThingUsageMockRecorder) AddUsage(ctx, usage, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUsage", reflect.TypeOf((*MockthingUsageDBThingUsage)(nil).AddUsage), ctx, usage, status)
+}
+
+// CancelNotTakenUsages mocks base method.
+func (m *MockthingUsageDBThingUsage) CancelNotTakenUsages(ctx context.Context, gracePeriod time.Duration) ([]core.ThingUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelNotTakenUsages", ctx, gracePeriod)
+	ret0, _ := ret[0].([]core.ThingUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelNotTakenUsages indicates an expected call of CancelNotTakenUsages.
+func (mr *MockthingUsageDBThingUsageMockRecorder) CancelNotTakenUsages(ctx, gracePeriod interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelNotTakenUsages", reflect.TypeOf((*MockthingUsageDBThingUsage)(nil).CancelNotTakenUsages), ctx, gracePeriod)
+}
+
+// CountOverlappingUsages mocks base method.
+func (m *MockthingUsageDBThingUsage) CountOverlappingUsages(ctx context.Context, thingId int, startTime, endTime uint32) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOverlappingUsages", ctx, thingId, startTime, endTime)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOverlappingUsages indicates an expected call of CountOverlappingUsages.
+func (mr *MockthingUsageDBThingUsageMockRecorder) CountOverlappingUsages(ctx, thingId, startTime, endTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOverlappingUsages", reflect.TypeOf((*MockthingUsageDBThingUsage)(nil).CountOverlappingUsages), ctx, thingId, startTime, endTime)
+}
+
+// GetRequestedUsagesByDepartments mocks base method.
+func (m *MockthingUsageDBThingUsage) GetRequestedUsagesByDepartments(ctx context.Context, departmentIds []int) ([]core.ThingUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRequestedUsagesByDepartments", ctx, departmentIds)
+	ret0, _ := ret[0].([]core.ThingUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRequestedUsagesByDepartments indicates an expected call of GetRequestedUsagesByDepartments.
+func (mr *MockthingUsageDBThingUsageMockRecorder) GetRequestedUsagesByDepartments(ctx, departmentIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRequestedUsagesByDepartments", reflect.TypeOf((*MockthingUsageDBThingUsage)(nil).GetRequestedUsagesByDepartments), ctx, departmentIds)
+}
+
+// GetUsage mocks base method.
+func (m *MockthingUsageDBThingUsage) GetUsage(ctx context.Context, usageId int) (*core.ThingUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsage", ctx, usageId)
+	ret0, _ := ret[0].(*core.ThingUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockthingUsageDBThingUsageMockRecorder) GetUsage(ctx, usageId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockthingUsageDBThingUsage)(nil).GetUsage), ctx, usageId)
+}
+
+// GetUsagesByCompany mocks base method.
+func (m *MockthingUsageDBThingUsage) GetUsagesByCompany(ctx context.Context, companyId int) ([]core.ThingUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsagesByCompany", ctx, companyId)
+	ret0, _ := ret[0].([]core.ThingUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsagesByCompany indicates an expected call of GetUsagesByCompany.
+func (mr *MockthingUsageDBThingUsageMockRecorder) GetUsagesByCompany(ctx, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsagesByCompany", reflect.TypeOf((*MockthingUsageDBThingUsage)(nil).GetUsagesByCompany), ctx, companyId)
+}
+
+// GetUsagesByDepartment mocks base method.
+func (m *MockthingUsageDBThingUsage) GetUsagesByDepartment(ctx context.Context, departmentId int) ([]core.ThingUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsagesByDepartment", ctx, departmentId)
+	ret0, _ := ret[0].([]core.ThingUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsagesByDepartment indicates an expected call of GetUsagesByDepartment.
+func (mr *MockthingUsageDBThingUsageMockRecorder) GetUsagesByDepartment(ctx, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsagesByDepartment", reflect.TypeOf((*MockthingUsageDBThingUsage)(nil).GetUsagesByDepartment), ctx, departmentId)
+}
+
+// GetUsagesByThing mocks base method.
+func (m *MockthingUsageDBThingUsage) GetUsagesByThing(ctx context.Context, thingId int) ([]core.ThingUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsagesByThing", ctx, thingId)
+	ret0, _ := ret[0].([]core.ThingUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsagesByThing indicates an expected call of GetUsagesByThing.
+func (mr *MockthingUsageDBThingUsageMockRecorder) GetUsagesByThing(ctx, thingId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsagesByThing", reflect.TypeOf((*MockthingUsageDBThingUsage)(nil).GetUsagesByThing), ctx, thingId)
+}
+
+// GetUsagesByUser mocks base method.
+func (m *MockthingUsageDBThingUsage) GetUsagesByUser(ctx context.Context, userId int) ([]core.ThingUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsagesByUser", ctx, userId)
+	ret0, _ := ret[0].([]core.ThingUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsagesByUser indicates an expected call of GetUsagesByUser.
+func (mr *MockthingUsageDBThingUsageMockRecorder) GetUsagesByUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsagesByUser", reflect.TypeOf((*MockthingUsageDBThingUsage)(nil).GetUsagesByUser), ctx, userId)
+}
+
+// MarkOverdueUsages mocks base method.
+func (m *MockthingUsageDBThingUsage) MarkOverdueUsages(ctx context.Context) ([]core.ThingUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOverdueUsages", ctx)
+	ret0, _ := ret[0].([]core.ThingUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOverdueUsages indicates an expected call of MarkOverdueUsages.
+func (mr *MockthingUsageDBThingUsageMockRecorder) MarkOverdueUsages(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOverdueUsages", reflect.TypeOf((*MockthingUsageDBThingUsage)(nil).MarkOverdueUsages), ctx)
+}
+
+// SetUsageStatus mocks base method.
+func (m *MockthingUsageDBThingUsage) SetUsageStatus(ctx context.Context, usageId int, currentStatus, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUsageStatus", ctx, usageId, currentStatus, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUsageStatus indicates an expected call of SetUsageStatus.
+func (mr *MockthingUsageDBThingUsageMockRecorder) SetUsageStatus(ctx, usageId, currentStatus, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUsageStatus", reflect.TypeOf((*MockthingUsageDBThingUsage)(nil).SetUsageStatus), ctx, usageId, currentStatus, status)
+}
+
+// MockthingDBThingUsage is a mock of thingDBThingUsage interface.
+type MockthingDBThingUsage struct {
+	ctrl     *gomock.Controller
+	recorder *MockthingDBThingUsageMockRecorder
+}
+
+// MockthingDBThingUsageMockRecorder is the mock recorder for MockthingDBThingUsage.
+type MockthingDBThingUsageMockRecorder struct {
+	mock *MockthingDBThingUsage
+}
+
+// NewMockthingDBThingUsage creates a new mock instance.
+func NewMockthingDBThingUsage(ctrl *gomock.Controller) *MockthingDBThingUsage {
+	mock := &MockthingDBThingUsage{ctrl: ctrl}
+	mock.recorder = &MockthingDBThingUsageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockthingDBThingUsage) EXPECT() *MockthingDBThingUsageMockRecorder {
+	return m.recorder
+}
+
+// GetThing mocks base method.
+func (m *MockthingDBThingUsage) GetThing(ctx context.Context, thingId int) (*core.Thing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetThing", ctx, thingId)
+	ret0, _ := ret[0].(*core.Thing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetThing indicates an expected call of GetThing.
+func (mr *MockthingDBThingUsageMockRecorder) GetThing(ctx, thingId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThing", reflect.TypeOf((*MockthingDBThingUsage)(nil).GetThing), ctx, thingId)
+}
+
+// GetThingForUpdate mocks base method.
+func (m *MockthingDBThingUsage) GetThingForUpdate(ctx context.Context, thingId int) (*core.Thing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetThingForUpdate", ctx, thingId)
+	ret0, _ := ret[0].(*core.Thing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetThingForUpdate indicates an expected call of GetThingForUpdate.
+func (mr *MockthingDBThingUsageMockRecorder) GetThingForUpdate(ctx, thingId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThingForUpdate", reflect.TypeOf((*MockthingDBThingUsage)(nil).GetThingForUpdate), ctx, thingId)
+}
+
+// MockthingBlockDBThingUsage is a mock of thingBlockDBThingUsage interface.
+type MockthingBlockDBThingUsage struct {
+	ctrl     *gomock.Controller
+	recorder *MockthingBlockDBThingUsageMockRecorder
+}
+
+// MockthingBlockDBThingUsageMockRecorder is the mock recorder for MockthingBlockDBThingUsage.
+type MockthingBlockDBThingUsageMockRecorder struct {
+	mock *MockthingBlockDBThingUsage
+}
+
+// NewMockthingBlockDBThingUsage creates a new mock instance.
+func NewMockthingBlockDBThingUsage(ctrl *gomock.Controller) *MockthingBlockDBThingUsage {
+	mock := &MockthingBlockDBThingUsage{ctrl: ctrl}
+	mock.recorder = &MockthingBlockDBThingUsageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockthingBlockDBThingUsage) EXPECT() *MockthingBlockDBThingUsageMockRecorder {
+	return m.recorder
+}
+
+// GetOverlappingBlocks mocks base method.
+func (m *MockthingBlockDBThingUsage) GetOverlappingBlocks(ctx context.Context, thingId int, startTime, endTime uint32, excludeBlockId int) ([]core.ThingBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverlappingBlocks", ctx, thingId, startTime, endTime, excludeBlockId)
+	ret0, _ := ret[0].([]core.ThingBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverlappingBlocks indicates an expected call of GetOverlappingBlocks.
+func (mr *MockthingBlockDBThingUsageMockRecorder) GetOverlappingBlocks(ctx, thingId, startTime, endTime, excludeBlockId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverlappingBlocks", reflect.TypeOf((*MockthingBlockDBThingUsage)(nil).GetOverlappingBlocks), ctx, thingId, startTime, endTime, excludeBlockId)
+}
+
+// MockdepartmentDBThingUsage is a mock of departmentDBThingUsage interface.
+type MockdepartmentDBThingUsage struct {
+	ctrl     *gomock.Controller
+	recorder *MockdepartmentDBThingUsageMockRecorder
+}
+
+// MockdepartmentDBThingUsageMockRecorder is the mock recorder for MockdepartmentDBThingUsage.
+type MockdepartmentDBThingUsageMockRecorder struct {
+	mock *MockdepartmentDBThingUsage
+}
+
+// NewMockdepartmentDBThingUsage creates a new mock instance.
+func NewMockdepartmentDBThingUsage(ctrl *gomock.Controller) *MockdepartmentDBThingUsage {
+	mock := &MockdepartmentDBThingUsage{ctrl: ctrl}
+	mock.recorder = &MockdepartmentDBThingUsageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdepartmentDBThingUsage) EXPECT() *MockdepartmentDBThingUsageMockRecorder {
+	return m.recorder
+}
+
+// GetDepartment mocks base method.
+func (m *MockdepartmentDBThingUsage) GetDepartment(ctx context.Context, departmentId int) (*core.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartment", ctx, departmentId)
+	ret0, _ := ret[0].(*core.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartment indicates an expected call of GetDepartment.
+func (mr *MockdepartmentDBThingUsageMockRecorder) GetDepartment(ctx, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartment", reflect.TypeOf((*MockdepartmentDBThingUsage)(nil).GetDepartment), ctx, departmentId)
+}
+
+// GetDepartmentsByCompany mocks base method.
+func (m *MockdepartmentDBThingUsage) GetDepartmentsByCompany(ctx context.Context, companyId int) ([]core.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartmentsByCompany", ctx, companyId)
+	ret0, _ := ret[0].([]core.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartmentsByCompany indicates an expected call of GetDepartmentsByCompany.
+func (mr *MockdepartmentDBThingUsageMockRecorder) GetDepartmentsByCompany(ctx, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartmentsByCompany", reflect.TypeOf((*MockdepartmentDBThingUsage)(nil).GetDepartmentsByCompany), ctx, companyId)
+}
+
+// MockvacationDBThingUsage is a mock of vacationDBThingUsage interface.
+type MockvacationDBThingUsage struct {
+	ctrl     *gomock.Controller
+	recorder *MockvacationDBThingUsageMockRecorder
+}
+
+// MockvacationDBThingUsageMockRecorder is the mock recorder for MockvacationDBThingUsage.
+type MockvacationDBThingUsageMockRecorder struct {
+	mock *MockvacationDBThingUsage
+}
+
+// NewMockvacationDBThingUsage creates a new mock instance.
+func NewMockvacationDBThingUsage(ctrl *gomock.Controller) *MockvacationDBThingUsage {
+	mock := &MockvacationDBThingUsage{ctrl: ctrl}
+	mock.recorder = &MockvacationDBThingUsageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockvacationDBThingUsage) EXPECT() *MockvacationDBThingUsageMockRecorder {
+	return m.recorder
+}
+
+// CountOverlappingVacations mocks base method.
+func (m *MockvacationDBThingUsage) CountOverlappingVacations(ctx context.Context, userId int, startTime, endTime uint32, excludeVacationId int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOverlappingVacations", ctx, userId, startTime, endTime, excludeVacationId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOverlappingVacations indicates an expected call of CountOverlappingVacations.
+func (mr *MockvacationDBThingUsageMockRecorder) CountOverlappingVacations(ctx, userId, startTime, endTime, excludeVacationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOverlappingVacations", reflect.TypeOf((*MockvacationDBThingUsage)(nil).CountOverlappingVacations), ctx, userId, startTime, endTime, excludeVacationId)
+}
+
+// GetUsagesWithApproversAway mocks base method.
+func (m *MockvacationDBThingUsage) GetUsagesWithApproversAway(ctx context.Context, usageIds []int, at uint32) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsagesWithApproversAway", ctx, usageIds, at)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsagesWithApproversAway indicates an expected call of GetUsagesWithApproversAway.
+func (mr *MockvacationDBThingUsageMockRecorder) GetUsagesWithApproversAway(ctx, usageIds, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsagesWithApproversAway", reflect.TypeOf((*MockvacationDBThingUsage)(nil).GetUsagesWithApproversAway), ctx, usageIds, at)
+}
+
+// MockstockDBThingUsage is a mock of stockDBThingUsage interface.
+type MockstockDBThingUsage struct {
+	ctrl     *gomock.Controller
+	recorder *MockstockDBThingUsageMockRecorder
+}
+
+// MockstockDBThingUsageMockRecorder is the mock recorder for MockstockDBThingUsage.
+type MockstockDBThingUsageMockRecorder struct {
+	mock *MockstockDBThingUsage
+}
+
+// NewMockstockDBThingUsage creates a new mock instance.
+func NewMockstockDBThingUsage(ctrl *gomock.Controller) *MockstockDBThingUsage {
+	mock := &MockstockDBThingUsage{ctrl: ctrl}
+	mock.recorder = &MockstockDBThingUsageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstockDBThingUsage) EXPECT() *MockstockDBThingUsageMockRecorder {
+	return m.recorder
+}
+
+// AddStockMovement mocks base method.
+func (m *MockstockDBThingUsage) AddStockMovement(ctx context.Context, movement *core.StockMovement) (*core.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddStockMovement", ctx, movement)
+	ret0, _ := ret[0].(*core.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddStockMovement indicates an expected call of AddStockMovement.
+func (mr *MockstockDBThingUsageMockRecorder) AddStockMovement(ctx, movement interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStockMovement", reflect.TypeOf((*MockstockDBThingUsage)(nil).AddStockMovement), ctx, movement)
+}
+
+// MocknotifierThingUsage is a mock of notifierThingUsage interface.
+type MocknotifierThingUsage struct {
+	ctrl     *gomock.Controller
+	recorder *MocknotifierThingUsageMockRecorder
+}
+
+// MocknotifierThingUsageMockRecorder is the mock recorder for MocknotifierThingUsage.
+type MocknotifierThingUsageMockRecorder struct {
+	mock *MocknotifierThingUsage
+}
+
+// NewMocknotifierThingUsage creates a new mock instance.
+func NewMocknotifierThingUsage(ctrl *gomock.Controller) *MocknotifierThingUsage {
+	mock := &MocknotifierThingUsage{ctrl: ctrl}
+	mock.recorder = &MocknotifierThingUsageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocknotifierThingUsage) EXPECT() *MocknotifierThingUsageMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MocknotifierThingUsage) Notify(ctx context.Context, notification *core.NotificationAdd) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MocknotifierThingUsageMockRecorder) Notify(ctx, notification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MocknotifierThingUsage)(nil).Notify), ctx, notification)
+}
+
+// MockpublisherThingUsage is a mock of publisherThingUsage interface.
+type MockpublisherThingUsage struct {
+	ctrl     *gomock.Controller
+	recorder *MockpublisherThingUsageMockRecorder
+}
+
+// MockpublisherThingUsageMockRecorder is the mock recorder for MockpublisherThingUsage.
+type MockpublisherThingUsageMockRecorder struct {
+	mock *MockpublisherThingUsage
+}
+
+// NewMockpublisherThingUsage creates a new mock instance.
+func NewMockpublisherThingUsage(ctrl *gomock.Controller) *MockpublisherThingUsage {
+	mock := &MockpublisherThingUsage{ctrl: ctrl}
+	mock.recorder = &MockpublisherThingUsageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpublisherThingUsage) EXPECT() *MockpublisherThingUsageMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockpublisherThingUsage) Publish(ctx context.Context, companyId int, eventType string, data interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, companyId, eventType, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockpublisherThingUsageMockRecorder) Publish(ctx, companyId, eventType, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockpublisherThingUsage)(nil).Publish), ctx, companyId, eventType, data)
+}
+
+// MocktransactionDBThingUsage is a mock of transactionDBThingUsage interface.
+type MocktransactionDBThingUsage struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactionDBThingUsageMockRecorder
+}
+
+// MocktransactionDBThingUsageMockRecorder is the mock recorder for MocktransactionDBThingUsage.
+type MocktransactionDBThingUsageMockRecorder struct {
+	mock *MocktransactionDBThingUsage
+}
+
+// NewMocktransactionDBThingUsage creates a new mock instance.
+func NewMocktransactionDBThingUsage(ctrl *gomock.Controller) *MocktransactionDBThingUsage {
+	mock := &MocktransactionDBThingUsage{ctrl: ctrl}
+	mock.recorder = &MocktransactionDBThingUsageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktransactionDBThingUsage) EXPECT() *MocktransactionDBThingUsageMockRecorder {
+	return m.recorder
+}
+
+// CommitTx mocks base method.
+func (m *MocktransactionDBThingUsage) CommitTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitTx indicates an expected call of CommitTx.
+func (mr *MocktransactionDBThingUsageMockRecorder) CommitTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTx", reflect.TypeOf((*MocktransactionDBThingUsage)(nil).CommitTx), ctx)
+}
+
+// InjectTx mocks base method.
+func (m *MocktransactionDBThingUsage) InjectTx(ctx context.Context) (context.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectTx", ctx)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InjectTx indicates an expected call of InjectTx.
+func (mr *MocktransactionDBThingUsageMockRecorder) InjectTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectTx", reflect.TypeOf((*MocktransactionDBThingUsage)(nil).InjectTx), ctx)
+}
+
+// RollbackTx mocks base method.
+func (m *MocktransactionDBThingUsage) RollbackTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollbackTx indicates an expected call of RollbackTx.
+func (mr *MocktransactionDBThingUsageMockRecorder) RollbackTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTx", reflect.TypeOf((*MocktransactionDBThingUsage)(nil).RollbackTx), ctx)
+}
+
+// RollbackTxDefer mocks base method.
+func (m *MocktransactionDBThingUsage) RollbackTxDefer(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RollbackTxDefer", ctx)
+}
+
+// RollbackTxDefer indicates an expected call of RollbackTxDefer.
+func (mr *MocktransactionDBThingUsageMockRecorder) RollbackTxDefer(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTxDefer", reflect.TypeOf((*MocktransactionDBThingUsage)(nil).RollbackTxDefer), ctx)
+}
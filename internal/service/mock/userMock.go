@@ -0,0 +1,642 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/Thing-repository/backend-server/pkg/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockuserDBUser is a mock of userDBUser interface.
+type MockuserDBUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserDBUserMockRecorder
+}
+
+// MockuserDBUserMockRecorder is the mock recorder for MockuserDBUser.
+type MockuserDBUserMockRecorder struct {
+	mock *MockuserDBUser
+}
+
+// NewMockuserDBUser creates a new mock instance.
+func NewMockuserDBUser(ctrl *gomock.Controller) *MockuserDBUser {
+	mock := &MockuserDBUser{ctrl: ctrl}
+	mock.recorder = &MockuserDBUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserDBUser) EXPECT() *MockuserDBUserMockRecorder {
+	return m.recorder
+}
+
+// CountCompanyUsers mocks base method.
+func (m *MockuserDBUser) CountCompanyUsers(ctx context.Context, companyId int, filter *core.UserDirectoryFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCompanyUsers", ctx, companyId, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCompanyUsers indicates an expected call of CountCompanyUsers.
+func (mr *MockuserDBUserMockRecorder) CountCompanyUsers(ctx, companyId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCompanyUsers", reflect.TypeOf((*MockuserDBUser)(nil).CountCompanyUsers), ctx, companyId, filter)
+}
+
+// GetCompanyUsers mocks base method.
+func (m *MockuserDBUser) GetCompanyUsers(ctx context.Context, companyId int, filter *core.UserDirectoryFilter, cursor *core.UserDirectoryCursor, limit int) ([]core.UserDirectoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanyUsers", ctx, companyId, filter, cursor, limit)
+	ret0, _ := ret[0].([]core.UserDirectoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanyUsers indicates an expected call of GetCompanyUsers.
+func (mr *MockuserDBUserMockRecorder) GetCompanyUsers(ctx, companyId, filter, cursor, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyUsers", reflect.TypeOf((*MockuserDBUser)(nil).GetCompanyUsers), ctx, companyId, filter, cursor, limit)
+}
+
+// GetUser mocks base method.
+func (m *MockuserDBUser) GetUser(ctx context.Context, userId int) (*core.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userId)
+	ret0, _ := ret[0].(*core.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockuserDBUserMockRecorder) GetUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockuserDBUser)(nil).GetUser), ctx, userId)
+}
+
+// GetUsersFilter mocks base method.
+func (m *MockuserDBUser) GetUsersFilter(ctx context.Context, filter string, limit, offset int) ([]core.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersFilter", ctx, filter, limit, offset)
+	ret0, _ := ret[0].([]core.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersFilter indicates an expected call of GetUsersFilter.
+func (mr *MockuserDBUserMockRecorder) GetUsersFilter(ctx, filter, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersFilter", reflect.TypeOf((*MockuserDBUser)(nil).GetUsersFilter), ctx, filter, limit, offset)
+}
+
+// PathUser mocks base method.
+func (m *MockuserDBUser) PathUser(ctx context.Context, user *core.UserDB, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PathUser", ctx, user, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PathUser indicates an expected call of PathUser.
+func (mr *MockuserDBUserMockRecorder) PathUser(ctx, user, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PathUser", reflect.TypeOf((*MockuserDBUser)(nil).PathUser), ctx, user, userId)
+}
+
+// RemoveUserCompany mocks base method.
+func (m *MockuserDBUser) RemoveUserCompany(ctx context.Context, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUserCompany", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveUserCompany indicates an expected call of RemoveUserCompany.
+func (mr *MockuserDBUserMockRecorder) RemoveUserCompany(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserCompany", reflect.TypeOf((*MockuserDBUser)(nil).RemoveUserCompany), ctx, userId)
+}
+
+// MockdepartmentDBUser is a mock of departmentDBUser interface.
+type MockdepartmentDBUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockdepartmentDBUserMockRecorder
+}
+
+// MockdepartmentDBUserMockRecorder is the mock recorder for MockdepartmentDBUser.
+type MockdepartmentDBUserMockRecorder struct {
+	mock *MockdepartmentDBUser
+}
+
+// NewMockdepartmentDBUser creates a new mock instance.
+func NewMockdepartmentDBUser(ctrl *gomock.Controller) *MockdepartmentDBUser {
+	mock := &MockdepartmentDBUser{ctrl: ctrl}
+	mock.recorder = &MockdepartmentDBUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdepartmentDBUser) EXPECT() *MockdepartmentDBUserMockRecorder {
+	return m.recorder
+}
+
+// GetDepartment mocks base method.
+func (m *MockdepartmentDBUser) GetDepartment(ctx context.Context, departmentId int) (*core.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartment", ctx, departmentId)
+	ret0, _ := ret[0].(*core.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartment indicates an expected call of GetDepartment.
+func (mr *MockdepartmentDBUserMockRecorder) GetDepartment(ctx, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartment", reflect.TypeOf((*MockdepartmentDBUser)(nil).GetDepartment), ctx, departmentId)
+}
+
+// MockcredentialsDBUser is a mock of credentialsDBUser interface.
+type MockcredentialsDBUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockcredentialsDBUserMockRecorder
+}
+
+// MockcredentialsDBUserMockRecorder is the mock recorder for MockcredentialsDBUser.
+type MockcredentialsDBUserMockRecorder struct {
+	mock *MockcredentialsDBUser
+}
+
+// NewMockcredentialsDBUser creates a new mock instance.
+func NewMockcredentialsDBUser(ctrl *gomock.Controller) *MockcredentialsDBUser {
+	mock := &MockcredentialsDBUser{ctrl: ctrl}
+	mock.recorder = &MockcredentialsDBUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcredentialsDBUser) EXPECT() *MockcredentialsDBUserMockRecorder {
+	return m.recorder
+}
+
+// CountCredentialsForUpdate mocks base method.
+func (m *MockcredentialsDBUser) CountCredentialsForUpdate(ctx context.Context, credentialType string, objectId int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCredentialsForUpdate", ctx, credentialType, objectId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCredentialsForUpdate indicates an expected call of CountCredentialsForUpdate.
+func (mr *MockcredentialsDBUserMockRecorder) CountCredentialsForUpdate(ctx, credentialType, objectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCredentialsForUpdate", reflect.TypeOf((*MockcredentialsDBUser)(nil).CountCredentialsForUpdate), ctx, credentialType, objectId)
+}
+
+// CreateCredential mocks base method.
+func (m *MockcredentialsDBUser) CreateCredential(ctx context.Context, credentials *core.AddCredentials) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCredential", ctx, credentials)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCredential indicates an expected call of CreateCredential.
+func (mr *MockcredentialsDBUserMockRecorder) CreateCredential(ctx, credentials interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCredential", reflect.TypeOf((*MockcredentialsDBUser)(nil).CreateCredential), ctx, credentials)
+}
+
+// DeleteUserCompanyCredentials mocks base method.
+func (m *MockcredentialsDBUser) DeleteUserCompanyCredentials(ctx context.Context, userId, companyId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserCompanyCredentials", ctx, userId, companyId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserCompanyCredentials indicates an expected call of DeleteUserCompanyCredentials.
+func (mr *MockcredentialsDBUserMockRecorder) DeleteUserCompanyCredentials(ctx, userId, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserCompanyCredentials", reflect.TypeOf((*MockcredentialsDBUser)(nil).DeleteUserCompanyCredentials), ctx, userId, companyId)
+}
+
+// DeleteUserDepartmentCredentials mocks base method.
+func (m *MockcredentialsDBUser) DeleteUserDepartmentCredentials(ctx context.Context, userId, departmentId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserDepartmentCredentials", ctx, userId, departmentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserDepartmentCredentials indicates an expected call of DeleteUserDepartmentCredentials.
+func (mr *MockcredentialsDBUserMockRecorder) DeleteUserDepartmentCredentials(ctx, userId, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserDepartmentCredentials", reflect.TypeOf((*MockcredentialsDBUser)(nil).DeleteUserDepartmentCredentials), ctx, userId, departmentId)
+}
+
+// EnsureCredential mocks base method.
+func (m *MockcredentialsDBUser) EnsureCredential(ctx context.Context, credentials *core.AddCredentials) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureCredential", ctx, credentials)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureCredential indicates an expected call of EnsureCredential.
+func (mr *MockcredentialsDBUserMockRecorder) EnsureCredential(ctx, credentials interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureCredential", reflect.TypeOf((*MockcredentialsDBUser)(nil).EnsureCredential), ctx, credentials)
+}
+
+// GetUserCredential mocks base method.
+func (m *MockcredentialsDBUser) GetUserCredential(ctx context.Context, userId int) ([]core.Credentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCredential", ctx, userId)
+	ret0, _ := ret[0].([]core.Credentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCredential indicates an expected call of GetUserCredential.
+func (mr *MockcredentialsDBUserMockRecorder) GetUserCredential(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCredential", reflect.TypeOf((*MockcredentialsDBUser)(nil).GetUserCredential), ctx, userId)
+}
+
+// MockuserDBMember is a mock of userDBMember interface.
+type MockuserDBMember struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserDBMemberMockRecorder
+}
+
+// MockuserDBMemberMockRecorder is the mock recorder for MockuserDBMember.
+type MockuserDBMemberMockRecorder struct {
+	mock *MockuserDBMember
+}
+
+// NewMockuserDBMember creates a new mock instance.
+func NewMockuserDBMember(ctrl *gomock.Controller) *MockuserDBMember {
+	mock := &MockuserDBMember{ctrl: ctrl}
+	mock.recorder = &MockuserDBMemberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserDBMember) EXPECT() *MockuserDBMemberMockRecorder {
+	return m.recorder
+}
+
+// PathUser mocks base method.
+func (m *MockuserDBMember) PathUser(ctx context.Context, user *core.UserDB, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PathUser", ctx, user, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PathUser indicates an expected call of PathUser.
+func (mr *MockuserDBMemberMockRecorder) PathUser(ctx, user, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PathUser", reflect.TypeOf((*MockuserDBMember)(nil).PathUser), ctx, user, userId)
+}
+
+// MockcredentialsDBMember is a mock of credentialsDBMember interface.
+type MockcredentialsDBMember struct {
+	ctrl     *gomock.Controller
+	recorder *MockcredentialsDBMemberMockRecorder
+}
+
+// MockcredentialsDBMemberMockRecorder is the mock recorder for MockcredentialsDBMember.
+type MockcredentialsDBMemberMockRecorder struct {
+	mock *MockcredentialsDBMember
+}
+
+// NewMockcredentialsDBMember creates a new mock instance.
+func NewMockcredentialsDBMember(ctrl *gomock.Controller) *MockcredentialsDBMember {
+	mock := &MockcredentialsDBMember{ctrl: ctrl}
+	mock.recorder = &MockcredentialsDBMemberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcredentialsDBMember) EXPECT() *MockcredentialsDBMemberMockRecorder {
+	return m.recorder
+}
+
+// CreateCredential mocks base method.
+func (m *MockcredentialsDBMember) CreateCredential(ctx context.Context, credentials *core.AddCredentials) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCredential", ctx, credentials)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCredential indicates an expected call of CreateCredential.
+func (mr *MockcredentialsDBMemberMockRecorder) CreateCredential(ctx, credentials interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCredential", reflect.TypeOf((*MockcredentialsDBMember)(nil).CreateCredential), ctx, credentials)
+}
+
+// MockpublisherMember is a mock of publisherMember interface.
+type MockpublisherMember struct {
+	ctrl     *gomock.Controller
+	recorder *MockpublisherMemberMockRecorder
+}
+
+// MockpublisherMemberMockRecorder is the mock recorder for MockpublisherMember.
+type MockpublisherMemberMockRecorder struct {
+	mock *MockpublisherMember
+}
+
+// NewMockpublisherMember creates a new mock instance.
+func NewMockpublisherMember(ctrl *gomock.Controller) *MockpublisherMember {
+	mock := &MockpublisherMember{ctrl: ctrl}
+	mock.recorder = &MockpublisherMemberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpublisherMember) EXPECT() *MockpublisherMemberMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockpublisherMember) Publish(ctx context.Context, companyId int, eventType string, data interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, companyId, eventType, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockpublisherMemberMockRecorder) Publish(ctx, companyId, eventType, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockpublisherMember)(nil).Publish), ctx, companyId, eventType, data)
+}
+
+// MockcredentialsDBAdminCheck is a mock of credentialsDBAdminCheck interface.
+type MockcredentialsDBAdminCheck struct {
+	ctrl     *gomock.Controller
+	recorder *MockcredentialsDBAdminCheckMockRecorder
+}
+
+// MockcredentialsDBAdminCheckMockRecorder is the mock recorder for MockcredentialsDBAdminCheck.
+type MockcredentialsDBAdminCheckMockRecorder struct {
+	mock *MockcredentialsDBAdminCheck
+}
+
+// NewMockcredentialsDBAdminCheck creates a new mock instance.
+func NewMockcredentialsDBAdminCheck(ctrl *gomock.Controller) *MockcredentialsDBAdminCheck {
+	mock := &MockcredentialsDBAdminCheck{ctrl: ctrl}
+	mock.recorder = &MockcredentialsDBAdminCheckMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcredentialsDBAdminCheck) EXPECT() *MockcredentialsDBAdminCheckMockRecorder {
+	return m.recorder
+}
+
+// CountCredentialsForUpdate mocks base method.
+func (m *MockcredentialsDBAdminCheck) CountCredentialsForUpdate(ctx context.Context, credentialType string, objectId int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCredentialsForUpdate", ctx, credentialType, objectId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCredentialsForUpdate indicates an expected call of CountCredentialsForUpdate.
+func (mr *MockcredentialsDBAdminCheckMockRecorder) CountCredentialsForUpdate(ctx, credentialType, objectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCredentialsForUpdate", reflect.TypeOf((*MockcredentialsDBAdminCheck)(nil).CountCredentialsForUpdate), ctx, credentialType, objectId)
+}
+
+// GetUserCredential mocks base method.
+func (m *MockcredentialsDBAdminCheck) GetUserCredential(ctx context.Context, userId int) ([]core.Credentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCredential", ctx, userId)
+	ret0, _ := ret[0].([]core.Credentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCredential indicates an expected call of GetUserCredential.
+func (mr *MockcredentialsDBAdminCheckMockRecorder) GetUserCredential(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCredential", reflect.TypeOf((*MockcredentialsDBAdminCheck)(nil).GetUserCredential), ctx, userId)
+}
+
+// MockthingUsageDBTaken is a mock of thingUsageDBTaken interface.
+type MockthingUsageDBTaken struct {
+	ctrl     *gomock.Controller
+	recorder *MockthingUsageDBTakenMockRecorder
+}
+
+// MockthingUsageDBTakenMockRecorder is the mock recorder for MockthingUsageDBTaken.
+type MockthingUsageDBTakenMockRecorder struct {
+	mock *MockthingUsageDBTaken
+}
+
+// NewMockthingUsageDBTaken creates a new mock instance.
+func NewMockthingUsageDBTaken(ctrl *gomock.Controller) *MockthingUsageDBTaken {
+	mock := &MockthingUsageDBTaken{ctrl: ctrl}
+	mock.recorder = &MockthingUsageDBTakenMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockthingUsageDBTaken) EXPECT() *MockthingUsageDBTakenMockRecorder {
+	return m.recorder
+}
+
+// CountUserTakenUsages mocks base method.
+func (m *MockthingUsageDBTaken) CountUserTakenUsages(ctx context.Context, userId, companyId, departmentId int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserTakenUsages", ctx, userId, companyId, departmentId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserTakenUsages indicates an expected call of CountUserTakenUsages.
+func (mr *MockthingUsageDBTakenMockRecorder) CountUserTakenUsages(ctx, userId, companyId, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserTakenUsages", reflect.TypeOf((*MockthingUsageDBTaken)(nil).CountUserTakenUsages), ctx, userId, companyId, departmentId)
+}
+
+// MockthingUsageDBUser is a mock of thingUsageDBUser interface.
+type MockthingUsageDBUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockthingUsageDBUserMockRecorder
+}
+
+// MockthingUsageDBUserMockRecorder is the mock recorder for MockthingUsageDBUser.
+type MockthingUsageDBUserMockRecorder struct {
+	mock *MockthingUsageDBUser
+}
+
+// NewMockthingUsageDBUser creates a new mock instance.
+func NewMockthingUsageDBUser(ctrl *gomock.Controller) *MockthingUsageDBUser {
+	mock := &MockthingUsageDBUser{ctrl: ctrl}
+	mock.recorder = &MockthingUsageDBUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockthingUsageDBUser) EXPECT() *MockthingUsageDBUserMockRecorder {
+	return m.recorder
+}
+
+// CancelUserUsages mocks base method.
+func (m *MockthingUsageDBUser) CancelUserUsages(ctx context.Context, userId, companyId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelUserUsages", ctx, userId, companyId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelUserUsages indicates an expected call of CancelUserUsages.
+func (mr *MockthingUsageDBUserMockRecorder) CancelUserUsages(ctx, userId, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUserUsages", reflect.TypeOf((*MockthingUsageDBUser)(nil).CancelUserUsages), ctx, userId, companyId)
+}
+
+// CountUserTakenUsages mocks base method.
+func (m *MockthingUsageDBUser) CountUserTakenUsages(ctx context.Context, userId, companyId, departmentId int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserTakenUsages", ctx, userId, companyId, departmentId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserTakenUsages indicates an expected call of CountUserTakenUsages.
+func (mr *MockthingUsageDBUserMockRecorder) CountUserTakenUsages(ctx, userId, companyId, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserTakenUsages", reflect.TypeOf((*MockthingUsageDBUser)(nil).CountUserTakenUsages), ctx, userId, companyId, departmentId)
+}
+
+// MockpublisherUser is a mock of publisherUser interface.
+type MockpublisherUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockpublisherUserMockRecorder
+}
+
+// MockpublisherUserMockRecorder is the mock recorder for MockpublisherUser.
+type MockpublisherUserMockRecorder struct {
+	mock *MockpublisherUser
+}
+
+// NewMockpublisherUser creates a new mock instance.
+func NewMockpublisherUser(ctrl *gomock.Controller) *MockpublisherUser {
+	mock := &MockpublisherUser{ctrl: ctrl}
+	mock.recorder = &MockpublisherUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpublisherUser) EXPECT() *MockpublisherUserMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockpublisherUser) Publish(ctx context.Context, companyId int, eventType string, data interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, companyId, eventType, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockpublisherUserMockRecorder) Publish(ctx, companyId, eventType, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockpublisherUser)(nil).Publish), ctx, companyId, eventType, data)
+}
+
+// MocktransactionDBUser is a mock of transactionDBUser interface.
+type MocktransactionDBUser struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactionDBUserMockRecorder
+}
+
+// MocktransactionDBUserMockRecorder is the mock recorder for MocktransactionDBUser.
+type MocktransactionDBUserMockRecorder struct {
+	mock *MocktransactionDBUser
+}
+
+// NewMocktransactionDBUser creates a new mock instance.
+func NewMocktransactionDBUser(ctrl *gomock.Controller) *MocktransactionDBUser {
+	mock := &MocktransactionDBUser{ctrl: ctrl}
+	mock.recorder = &MocktransactionDBUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktransactionDBUser) EXPECT() *MocktransactionDBUserMockRecorder {
+	return m.recorder
+}
+
+// CommitTx mocks base method.
+func (m *MocktransactionDBUser) CommitTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitTx indicates an expected call of CommitTx.
+func (mr *MocktransactionDBUserMockRecorder) CommitTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTx", reflect.TypeOf((*MocktransactionDBUser)(nil).CommitTx), ctx)
+}
+
+// InjectTx mocks base method.
+func (m *MocktransactionDBUser) InjectTx(ctx context.Context) (context.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectTx", ctx)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InjectTx indicates an expected call of InjectTx.
+func (mr *MocktransactionDBUserMockRecorder) InjectTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectTx", reflect.TypeOf((*MocktransactionDBUser)(nil).InjectTx), ctx)
+}
+
+// RollbackTx mocks base method.
+func (m *MocktransactionDBUser) RollbackTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollbackTx indicates an expected call of RollbackTx.
+func (mr *MocktransactionDBUserMockRecorder) RollbackTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTx", reflect.TypeOf((*MocktransactionDBUser)(nil).RollbackTx), ctx)
+}
+
+// RollbackTxDefer mocks base method.
+func (m *MocktransactionDBUser) RollbackTxDefer(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RollbackTxDefer", ctx)
+}
+
+// RollbackTxDefer indicates an expected call of RollbackTxDefer.
+func (mr *MocktransactionDBUserMockRecorder) RollbackTxDefer(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTxDefer", reflect.TypeOf((*MocktransactionDBUser)(nil).RollbackTxDefer), ctx)
+}
@@ -0,0 +1,300 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/Thing-repository/backend-server/pkg/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockwebhookDBWebhook is a mock of webhookDBWebhook interface.
+type MockwebhookDBWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockwebhookDBWebhookMockRecorder
+}
+
+// MockwebhookDBWebhookMockRecorder is the mock recorder for MockwebhookDBWebhook.
+type MockwebhookDBWebhookMockRecorder struct {
+	mock *MockwebhookDBWebhook
+}
+
+// NewMockwebhookDBWebhook creates a new mock instance.
+func NewMockwebhookDBWebhook(ctrl *gomock.Controller) *MockwebhookDBWebhook {
+	mock := &MockwebhookDBWebhook{ctrl: ctrl}
+	mock.recorder = &MockwebhookDBWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockwebhookDBWebhook) EXPECT() *MockwebhookDBWebhookMockRecorder {
+	return m.recorder
+}
+
+// AddWebhook mocks base method.
+func (m *MockwebhookDBWebhook) AddWebhook(ctx context.Context, companyId int, webhook *core.WebhookAdd) (*core.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWebhook", ctx, companyId, webhook)
+	ret0, _ := ret[0].(*core.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWebhook indicates an expected call of AddWebhook.
+func (mr *MockwebhookDBWebhookMockRecorder) AddWebhook(ctx, companyId, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWebhook", reflect.TypeOf((*MockwebhookDBWebhook)(nil).AddWebhook), ctx, companyId, webhook)
+}
+
+// ClaimWebhookDeliveries mocks base method.
+func (m *MockwebhookDBWebhook) ClaimWebhookDeliveries(ctx context.Context, limit, lease int) ([]core.WebhookDispatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWebhookDeliveries", ctx, limit, lease)
+	ret0, _ := ret[0].([]core.WebhookDispatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWebhookDeliveries indicates an expected call of ClaimWebhookDeliveries.
+func (mr *MockwebhookDBWebhookMockRecorder) ClaimWebhookDeliveries(ctx, limit, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWebhookDeliveries", reflect.TypeOf((*MockwebhookDBWebhook)(nil).ClaimWebhookDeliveries), ctx, limit, lease)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockwebhookDBWebhook) DeleteWebhook(ctx context.Context, webhookId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, webhookId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockwebhookDBWebhookMockRecorder) DeleteWebhook(ctx, webhookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockwebhookDBWebhook)(nil).DeleteWebhook), ctx, webhookId)
+}
+
+// EnqueueWebhookDeliveries mocks base method.
+func (m *MockwebhookDBWebhook) EnqueueWebhookDeliveries(ctx context.Context, companyId int, eventType string, payload []byte) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueWebhookDeliveries", ctx, companyId, eventType, payload)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueWebhookDeliveries indicates an expected call of EnqueueWebhookDeliveries.
+func (mr *MockwebhookDBWebhookMockRecorder) EnqueueWebhookDeliveries(ctx, companyId, eventType, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueWebhookDeliveries", reflect.TypeOf((*MockwebhookDBWebhook)(nil).EnqueueWebhookDeliveries), ctx, companyId, eventType, payload)
+}
+
+// GetCompanyWebhooks mocks base method.
+func (m *MockwebhookDBWebhook) GetCompanyWebhooks(ctx context.Context, companyId int) ([]core.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanyWebhooks", ctx, companyId)
+	ret0, _ := ret[0].([]core.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanyWebhooks indicates an expected call of GetCompanyWebhooks.
+func (mr *MockwebhookDBWebhookMockRecorder) GetCompanyWebhooks(ctx, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyWebhooks", reflect.TypeOf((*MockwebhookDBWebhook)(nil).GetCompanyWebhooks), ctx, companyId)
+}
+
+// GetWebhook mocks base method.
+func (m *MockwebhookDBWebhook) GetWebhook(ctx context.Context, webhookId int) (*core.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", ctx, webhookId)
+	ret0, _ := ret[0].(*core.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockwebhookDBWebhookMockRecorder) GetWebhook(ctx, webhookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockwebhookDBWebhook)(nil).GetWebhook), ctx, webhookId)
+}
+
+// GetWebhookDeliveries mocks base method.
+func (m *MockwebhookDBWebhook) GetWebhookDeliveries(ctx context.Context, webhookId, limit int) ([]core.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveries", ctx, webhookId, limit)
+	ret0, _ := ret[0].([]core.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDeliveries indicates an expected call of GetWebhookDeliveries.
+func (mr *MockwebhookDBWebhookMockRecorder) GetWebhookDeliveries(ctx, webhookId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveries", reflect.TypeOf((*MockwebhookDBWebhook)(nil).GetWebhookDeliveries), ctx, webhookId, limit)
+}
+
+// RedeliverWebhookDelivery mocks base method.
+func (m *MockwebhookDBWebhook) RedeliverWebhookDelivery(ctx context.Context, deliveryId, webhookId int) (*core.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeliverWebhookDelivery", ctx, deliveryId, webhookId)
+	ret0, _ := ret[0].(*core.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeliverWebhookDelivery indicates an expected call of RedeliverWebhookDelivery.
+func (mr *MockwebhookDBWebhookMockRecorder) RedeliverWebhookDelivery(ctx, deliveryId, webhookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeliverWebhookDelivery", reflect.TypeOf((*MockwebhookDBWebhook)(nil).RedeliverWebhookDelivery), ctx, deliveryId, webhookId)
+}
+
+// SetWebhookDeliveryResult mocks base method.
+func (m *MockwebhookDBWebhook) SetWebhookDeliveryResult(ctx context.Context, deliveryId int, result *core.WebhookDeliveryResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWebhookDeliveryResult", ctx, deliveryId, result)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWebhookDeliveryResult indicates an expected call of SetWebhookDeliveryResult.
+func (mr *MockwebhookDBWebhookMockRecorder) SetWebhookDeliveryResult(ctx, deliveryId, result interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWebhookDeliveryResult", reflect.TypeOf((*MockwebhookDBWebhook)(nil).SetWebhookDeliveryResult), ctx, deliveryId, result)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockwebhookDBWebhook) UpdateWebhook(ctx context.Context, webhookId int, webhook *core.WebhookUpdate) (*core.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", ctx, webhookId, webhook)
+	ret0, _ := ret[0].(*core.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockwebhookDBWebhookMockRecorder) UpdateWebhook(ctx, webhookId, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockwebhookDBWebhook)(nil).UpdateWebhook), ctx, webhookId, webhook)
+}
+
+// MocksenderWebhook is a mock of senderWebhook interface.
+type MocksenderWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MocksenderWebhookMockRecorder
+}
+
+// MocksenderWebhookMockRecorder is the mock recorder for MocksenderWebhook.
+type MocksenderWebhookMockRecorder struct {
+	mock *MocksenderWebhook
+}
+
+// NewMocksenderWebhook creates a new mock instance.
+func NewMocksenderWebhook(ctrl *gomock.Controller) *MocksenderWebhook {
+	mock := &MocksenderWebhook{ctrl: ctrl}
+	mock.recorder = &MocksenderWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksenderWebhook) EXPECT() *MocksenderWebhookMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MocksenderWebhook) Send(ctx context.Context, dispatch *core.WebhookDispatch) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, dispatch)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MocksenderWebhookMockRecorder) Send(ctx, dispatch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MocksenderWebhook)(nil).Send), ctx, dispatch)
+}
+
+// MocktransactionDBWebhook is a mock of transactionDBWebhook interface.
+type MocktransactionDBWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactionDBWebhookMockRecorder
+}
+
+// MocktransactionDBWebhookMockRecorder is the mock recorder for MocktransactionDBWebhook.
+type MocktransactionDBWebhookMockRecorder struct {
+	mock *MocktransactionDBWebhook
+}
+
+// NewMocktransactionDBWebhook creates a new mock instance.
+func NewMocktransactionDBWebhook(ctrl *gomock.Controller) *MocktransactionDBWebhook {
+	mock := &MocktransactionDBWebhook{ctrl: ctrl}
+	mock.recorder = &MocktransactionDBWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktransactionDBWebhook) EXPECT() *MocktransactionDBWebhookMockRecorder {
+	return m.recorder
+}
+
+// CommitTx mocks base method.
+func (m *MocktransactionDBWebhook) CommitTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitTx indicates an expected call of CommitTx.
+func (mr *MocktransactionDBWebhookMockRecorder) CommitTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTx", reflect.TypeOf((*MocktransactionDBWebhook)(nil).CommitTx), ctx)
+}
+
+// InjectTx mocks base method.
+func (m *MocktransactionDBWebhook) InjectTx(ctx context.Context) (context.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectTx", ctx)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InjectTx indicates an expected call of InjectTx.
+func (mr *MocktransactionDBWebhookMockRecorder) InjectTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectTx", reflect.TypeOf((*MocktransactionDBWebhook)(nil).InjectTx), ctx)
+}
+
+// RollbackTx mocks base method.
+func (m *MocktransactionDBWebhook) RollbackTx(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackTx", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollbackTx indicates an expected call of RollbackTx.
+func (mr *MocktransactionDBWebhookMockRecorder) RollbackTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTx", reflect.TypeOf((*MocktransactionDBWebhook)(nil).RollbackTx), ctx)
+}
+
+// RollbackTxDefer mocks base method.
+func (m *MocktransactionDBWebhook) RollbackTxDefer(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RollbackTxDefer", ctx)
+}
+
+// RollbackTxDefer indicates an expected call of RollbackTxDefer.
+func (mr *MocktransactionDBWebhookMockRecorder) RollbackTxDefer(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTxDefer", reflect.TypeOf((*MocktransactionDBWebhook)(nil).RollbackTxDefer), ctx)
+}
@@ -0,0 +1,405 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/Thing-repository/backend-server/pkg/notify"
+	"github.com/Thing-repository/backend-server/pkg/utils"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	notificationsPageSize   = 50
+	notificationDeliveryTTL = time.Minute
+)
+
+var usageNotificationTitles = map[string]string{
+	core.NotificationUsageApproved:  "Usage approved",
+	core.NotificationUsageCancelled: "Usage cancelled",
+	core.NotificationUsageOverdue:   "Usage is overdue",
+	core.NotificationThingBlocked:   "Usage cancelled by block",
+}
+
+var usageNotificationFormats = map[string]string{
+	core.NotificationUsageApproved:  "Your usage of %s is approved.",
+	core.NotificationUsageCancelled: "Your usage of %s is cancelled.",
+	core.NotificationUsageOverdue:   "Return time of %s has passed, please return it.",
+	core.NotificationThingBlocked:   "Your usage of %s is cancelled because thing is blocked.",
+}
+
+// notifierUsage is used by services which notify users about their usages
+type notifierUsage interface {
+	Notify(ctx context.Context, notification *core.NotificationAdd) error
+}
+
+// notifyUsageOwner notifies owner of usage about its change, details are appended to body if they are set
+func notifyUsageOwner(ctx context.Context, notifier notifierUsage, notificationType string, usage *core.ThingUsage,
+	thingName string, details string) error {
+	body := fmt.Sprintf(usageNotificationFormats[notificationType], thingName)
+	if details != "" {
+		body += "\n" + details
+	}
+
+	usageId := usage.Id
+	return notifier.Notify(ctx, &core.NotificationAdd{
+		UserId:   usage.UserId,
+		Type:     notificationType,
+		Title:    usageNotificationTitles[notificationType],
+		Body:     body,
+		ObjectId: &usageId,
+	})
+}
+
+type notificationDBNotification interface {
+	AddNotification(ctx context.Context, notification *core.NotificationAdd) (*core.Notification, error)
+	GetUserNotifications(ctx context.Context, userId int, unreadOnly bool, beforeId int,
+		limit int) ([]core.Notification, error)
+	CountUnreadNotifications(ctx context.Context, userId int) (int, error)
+	MarkNotificationRead(ctx context.Context, notificationId int, userId int) error
+	MarkAllNotificationsRead(ctx context.Context, userId int) error
+	GetNotificationPreferences(ctx context.Context, userId int) ([]core.NotificationPreference, error)
+	SetNotificationPreference(ctx context.Context, userId int, preference *core.NotificationPreference) error
+}
+
+type userDBNotification interface {
+	GetUser(ctx context.Context, userId int) (*core.UserDB, error)
+}
+
+type transactionDBNotification interface {
+	AfterCommit(ctx context.Context, fn func())
+}
+
+// Notification is in-app inbox of user, notifications are also delivered by channels which user enabled
+type Notification struct {
+	notificationDB notificationDBNotification
+	userDB         userDBNotification
+	transactionDB  transactionDBNotification
+	channels       map[string]notify.Channel
+}
+
+func NewNotification(notificationDB notificationDBNotification, userDB userDBNotification,
+	transactionDB transactionDBNotification, channels ...notify.Channel) *Notification {
+	channelsByName := make(map[string]notify.Channel, len(channels))
+	for _, channel := range channels {
+		channelsByName[channel.Name()] = channel
+	}
+
+	return &Notification{
+		notificationDB: notificationDB,
+		userDB:         userDB,
+		transactionDB:  transactionDB,
+		channels:       channelsByName,
+	}
+}
+
+// Notify adds notification to inbox of user in transaction of caller. Delivery by channels starts after commit,
+// so rolled back changes aren't reported, and its errors don't affect caller.
+func (N *Notification) Notify(ctx context.Context, notification *core.NotificationAdd) error {
+	logBase := logrus.Fields{
+		"module":       "service",
+		"function":     "Notify",
+		"notification": notification,
+	}
+
+	notificationData, err := N.notificationDB.AddNotification(ctx, notification)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error add notification to database")
+		return err
+	}
+
+	if len(N.channels) == 0 {
+		return nil
+	}
+
+	N.transactionDB.AfterCommit(ctx, func() {
+		go N.deliver(notificationData)
+	})
+
+	return nil
+}
+
+func (N *Notification) deliver(notification *core.Notification) {
+	logBase := logrus.Fields{
+		"module":         "service",
+		"function":       "deliver",
+		"notificationId": notification.Id,
+		"userId":         notification.UserId,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), notificationDeliveryTTL)
+	defer cancel()
+
+	preferences, err := N.notificationDB.GetNotificationPreferences(ctx, notification.UserId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get notification preferences from database")
+		return
+	}
+
+	var recipient *core.NotificationRecipient
+	for i := range preferences {
+		channel, ok := N.channels[preferences[i].Channel]
+		if !ok || !preferences[i].Enabled {
+			continue
+		}
+
+		if recipient == nil {
+			userData, err := N.userDB.GetUser(ctx, notification.UserId)
+			if err != nil {
+				logrus.WithFields(logrus.Fields{
+					"base":  logBase,
+					"error": err.Error(),
+				}).Error("error get user from database")
+				return
+			}
+			recipient = &core.NotificationRecipient{UserId: userData.Id}
+			if userData.Email != nil {
+				recipient.Email = *userData.Email
+			}
+		}
+		recipient.WebhookURL = preferences[i].WebhookURL
+
+		if err = channel.Deliver(ctx, recipient, notification); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":    logBase,
+				"channel": preferences[i].Channel,
+				"error":   err.Error(),
+			}).Error("error deliver notification")
+		}
+	}
+}
+
+// GetNotifications returns page of notifications of user from context, newest first.
+// Next page is requested with id of last notification as beforeId.
+func (N *Notification) GetNotifications(ctx context.Context, unreadOnly bool, beforeId int) ([]core.Notification, error) {
+	logBase := logrus.Fields{
+		"module":     "service",
+		"function":   "GetNotifications",
+		"unreadOnly": unreadOnly,
+		"beforeId":   beforeId,
+		"context":    *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	notifications, err := N.notificationDB.GetUserNotifications(ctx, userId, unreadOnly, beforeId,
+		notificationsPageSize)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get notifications from database")
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (N *Notification) GetUnreadCount(ctx context.Context) (*core.NotificationUnreadCount, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "GetUnreadCount",
+		"context":  *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	count, err := N.notificationDB.CountUnreadNotifications(ctx, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error count unread notifications in database")
+		return nil, err
+	}
+
+	return &core.NotificationUnreadCount{Count: count}, nil
+}
+
+func (N *Notification) MarkRead(ctx context.Context, notificationId int) error {
+	logBase := logrus.Fields{
+		"module":         "service",
+		"function":       "MarkRead",
+		"notificationId": notificationId,
+		"context":        *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return moduleErrors.ErrorServiceInvalidContext
+	}
+
+	err = N.notificationDB.MarkNotificationRead(ctx, notificationId, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error mark notification read in database")
+		switch err {
+		case moduleErrors.ErrorDatabaseNotificationNotFound:
+			return moduleErrors.ErrorServiceNotificationNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (N *Notification) MarkAllRead(ctx context.Context) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "MarkAllRead",
+		"context":  *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return moduleErrors.ErrorServiceInvalidContext
+	}
+
+	if err = N.notificationDB.MarkAllNotificationsRead(ctx, userId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error mark notifications read in database")
+		return err
+	}
+
+	return nil
+}
+
+// GetPreferences returns preferences of user from context for all channels, channels aren't enabled by default
+func (N *Notification) GetPreferences(ctx context.Context) ([]core.NotificationPreference, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "GetPreferences",
+		"context":  *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	saved, err := N.notificationDB.GetNotificationPreferences(ctx, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get notification preferences from database")
+		return nil, err
+	}
+
+	preferences := make([]core.NotificationPreference, 0, len(core.NotificationChannels))
+	for _, channel := range core.NotificationChannels {
+		preference := core.NotificationPreference{Channel: channel}
+		if i := slices.IndexFunc(saved, func(p core.NotificationPreference) bool {
+			return p.Channel == channel
+		}); i != -1 {
+			preference = saved[i]
+		}
+		preferences = append(preferences, preference)
+	}
+
+	return preferences, nil
+}
+
+// SetPreference enables or disables delivery channel for user from context
+func (N *Notification) SetPreference(ctx context.Context, preference *core.NotificationPreference) error {
+	logBase := logrus.Fields{
+		"module":     "service",
+		"function":   "SetPreference",
+		"preference": preference,
+		"context":    *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return moduleErrors.ErrorServiceInvalidContext
+	}
+
+	if slices.Index(core.NotificationChannels, preference.Channel) == -1 {
+		return moduleErrors.ErrorServiceInvalidChannel
+	}
+
+	if preference.Channel != core.NotificationChannelWebhook {
+		preference.WebhookURL = ""
+	} else if preference.Enabled || preference.WebhookURL != "" {
+		if !validWebhookURL(preference.WebhookURL) {
+			return moduleErrors.ErrorServiceInvalidWebhookURL
+		}
+	}
+
+	if err = N.notificationDB.SetNotificationPreference(ctx, userId, preference); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error set notification preference in database")
+		return err
+	}
+
+	return nil
+}
+
+// validWebhookURL refuses urls of internal hosts early, hosts resolved to internal
+// addresses are refused by http client on delivery
+func validWebhookURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return false
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil && !utils.IsPublicIP(ip) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,29 @@
+package service
+
+import "testing"
+
+func TestValidWebhookURL(t *testing.T) {
+	testTable := []struct {
+		url  string
+		want bool
+	}{
+		{url: "https://example.com/hook", want: true},
+		{url: "http://93.184.216.34:8080/hook", want: true},
+		{url: "ftp://example.com/hook", want: false},
+		{url: "/hook", want: false},
+		{url: "http://localhost:8080/hook", want: false},
+		{url: "http://api.localhost./hook", want: false},
+		{url: "http://127.0.0.1/hook", want: false},
+		{url: "http://[::1]/hook", want: false},
+		{url: "http://10.0.0.5/hook", want: false},
+		{url: "http://169.254.169.254/latest/meta-data", want: false},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.url, func(t *testing.T) {
+			if got := validWebhookURL(testCase.url); got != testCase.want {
+				t.Errorf("validWebhookURL(%s) = %t, want %t", testCase.url, got, testCase.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const (
+	passwordResetTTL         = time.Hour
+	passwordResetLimit       = 3
+	passwordResetLimitPeriod = time.Hour
+	passwordResetSubject     = "Thing repository password reset"
+	passwordResetBodyFormat  = "Hello, %s!\n\nTo reset your password open link:\n%s?token=%s\n\n" +
+		"Link is valid for 1 hour. If you didn't request password reset, ignore this email."
+)
+
+//go:generate mockgen -source=password.go -destination=mock/passwordMock.go
+type userDBPassword interface {
+	GetUser(ctx context.Context, userId int) (*core.UserDB, error)
+	GetUserByEmail(ctx context.Context, email string) (*core.UserDB, error)
+	PathUser(ctx context.Context, user *core.UserDB, userId int) error
+}
+
+type passwordResetDBPassword interface {
+	AddPasswordReset(ctx context.Context, userId int, tokenHash string, expiresTime uint32) error
+	CountPasswordResets(ctx context.Context, userId int, since uint32) (int, error)
+	UsePasswordReset(ctx context.Context, tokenHash string) (int, error)
+	RevokeUserPasswordResets(ctx context.Context, userId int) error
+}
+
+type sessionDBPassword interface {
+	RevokeUserSessions(ctx context.Context, userId int) error
+}
+
+type tokenPassword interface {
+	GenerateRandomToken() (string, error)
+	HashRandomToken(token string) string
+}
+
+type hashPassword interface {
+	GenerateHash(password string) (string, error)
+	ValidateHash(hash string, password string) error
+}
+
+type mailerPassword interface {
+	Send(ctx context.Context, message *core.MailMessage) error
+}
+
+type transactionDBPassword interface {
+	InjectTx(ctx context.Context) (context.Context, error)
+	CommitTx(ctx context.Context) error
+	RollbackTx(ctx context.Context) error
+	RollbackTxDefer(ctx context.Context)
+}
+
+type Password struct {
+	userDB          userDBPassword
+	passwordResetDB passwordResetDBPassword
+	sessionDB       sessionDBPassword
+	token           tokenPassword
+	hash            hashPassword
+	mailer          mailerPassword
+	transactionDB   transactionDBPassword
+	resetURL        string
+}
+
+// NewPassword creates service, resetURL is frontend page which sends token and new password to /auth/password/reset
+func NewPassword(userDB userDBPassword, passwordResetDB passwordResetDBPassword, sessionDB sessionDBPassword,
+	token tokenPassword, hash hashPassword, mailer mailerPassword, transactionDB transactionDBPassword,
+	resetURL string) *Password {
+	return &Password{
+		userDB:          userDB,
+		passwordResetDB: passwordResetDB,
+		sessionDB:       sessionDB,
+		token:           token,
+		hash:            hash,
+		mailer:          mailer,
+		transactionDB:   transactionDB,
+		resetURL:        resetURL,
+	}
+}
+
+// ForgotPassword sends password reset token to email. It doesn't return error for unknown email,
+// so it can't be used for checking which emails are registered.
+func (P *Password) ForgotPassword(ctx context.Context, email string) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "ForgotPassword",
+	}
+
+	userData, err := P.userDB.GetUserByEmail(ctx, email)
+	if err != nil {
+		if err == moduleErrors.ErrorDatabaseUserNotFound {
+			return nil
+		}
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user data")
+		return err
+	}
+
+	since := uint32(time.Now().Add(-passwordResetLimitPeriod).Unix())
+	count, err := P.passwordResetDB.CountPasswordResets(ctx, userData.Id, since)
+	if err != nil {
+		return err
+	}
+	if count >= passwordResetLimit {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"userId": userData.Id,
+			"count":  count,
+		}).Warning("too many password reset requests")
+		return nil
+	}
+
+	token, err := P.token.GenerateRandomToken()
+	if err != nil {
+		return err
+	}
+
+	expiresTime := uint32(time.Now().Add(passwordResetTTL).Unix())
+	err = P.passwordResetDB.AddPasswordReset(ctx, userData.Id, P.token.HashRandomToken(token), expiresTime)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"userId": userData.Id,
+			"error":  err.Error(),
+		}).Error("error add password reset")
+		return err
+	}
+
+	message := &core.MailMessage{
+		To:      *userData.Email,
+		Subject: passwordResetSubject,
+		Body:    fmt.Sprintf(passwordResetBodyFormat, *userData.FirstName, P.resetURL, token),
+	}
+
+	if err = P.mailer.Send(ctx, message); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"userId": userData.Id,
+			"error":  err.Error(),
+		}).Error("error send password reset email")
+		return err
+	}
+
+	return nil
+}
+
+// ResetPassword sets new password by reset token, all reset tokens and sessions of user are revoked
+func (P *Password) ResetPassword(ctx context.Context, token string, password string) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "ResetPassword",
+	}
+
+	ctx, err := P.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return err
+	}
+
+	defer P.transactionDB.RollbackTxDefer(ctx)
+
+	userId, err := P.passwordResetDB.UsePasswordReset(ctx, P.token.HashRandomToken(token))
+	if err != nil {
+		if err == moduleErrors.ErrorDatabaseResetTokenNotFound {
+			return moduleErrors.ErrorServiceInvalidResetToken
+		}
+		return err
+	}
+
+	if err = P.setPassword(ctx, userId, password); err != nil {
+		return err
+	}
+
+	if err = P.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// ChangePassword changes password of user from context, all sessions of user are revoked
+func (P *Password) ChangePassword(ctx context.Context, oldPassword string, newPassword string) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "ChangePassword",
+		"context":  *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return moduleErrors.ErrorServiceInvalidContext
+	}
+
+	ctx, err = P.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return err
+	}
+
+	defer P.transactionDB.RollbackTxDefer(ctx)
+
+	userData, err := P.userDB.GetUser(ctx, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user data")
+		return moduleErrors.ErrorServiceGetUserData
+	}
+
+	err = P.hash.ValidateHash(*userData.PasswordHash, oldPassword)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error validation old password")
+		if err == moduleErrors.ErrorHashValidationPassword {
+			return moduleErrors.ErrorServiceInvalidPassword
+		}
+		return err
+	}
+
+	if err = P.setPassword(ctx, userId, newPassword); err != nil {
+		return err
+	}
+
+	if err = P.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// setPassword saves hash of new password and revokes reset tokens and sessions of user
+func (P *Password) setPassword(ctx context.Context, userId int, password string) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "setPassword",
+		"userId":   userId,
+	}
+
+	hash, err := P.hash.GenerateHash(password)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error generate password hash")
+		return err
+	}
+
+	if err = P.userDB.PathUser(ctx, &core.UserDB{PasswordHash: &hash}, userId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error save password hash")
+		return err
+	}
+
+	if err = P.passwordResetDB.RevokeUserPasswordResets(ctx, userId); err != nil {
+		return err
+	}
+
+	if err = P.sessionDB.RevokeUserSessions(ctx, userId); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	mockService "github.com/Thing-repository/backend-server/internal/service/mock"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/golang/mock/gomock"
+	"github.com/openlyinc/pointy"
+	"testing"
+	"time"
+)
+
+const testPasswordUserId = 1
+
+type passwordMocks struct {
+	userDB          *mockService.MockuserDBPassword
+	passwordResetDB *mockService.MockpasswordResetDBPassword
+	sessionDB       *mockService.MocksessionDBPassword
+	token           *mockService.MocktokenPassword
+	hash            *mockService.MockhashPassword
+	mailer          *mockService.MockmailerPassword
+	transactionDB   *mockService.MocktransactionDBPassword
+}
+
+func newTestPassword(c *gomock.Controller) (*Password, *passwordMocks) {
+	m := &passwordMocks{
+		userDB:          mockService.NewMockuserDBPassword(c),
+		passwordResetDB: mockService.NewMockpasswordResetDBPassword(c),
+		sessionDB:       mockService.NewMocksessionDBPassword(c),
+		token:           mockService.NewMocktokenPassword(c),
+		hash:            mockService.NewMockhashPassword(c),
+		mailer:          mockService.NewMockmailerPassword(c),
+		transactionDB:   mockService.NewMocktransactionDBPassword(c),
+	}
+
+	m.transactionDB.EXPECT().InjectTx(gomock.Any()).DoAndReturn(
+		func(ctx context.Context) (context.Context, error) { return ctx, nil }).AnyTimes()
+	m.transactionDB.EXPECT().RollbackTxDefer(gomock.Any()).AnyTimes()
+	m.transactionDB.EXPECT().CommitTx(gomock.Any()).Return(nil).AnyTimes()
+
+	return NewPassword(m.userDB, m.passwordResetDB, m.sessionDB, m.token, m.hash, m.mailer, m.transactionDB,
+		"https://example.com/reset"), m
+}
+
+// expectPasswordSet expects new password to be saved with revoking of reset tokens and sessions
+func expectPasswordSet(m *passwordMocks, password string) {
+	m.hash.EXPECT().GenerateHash(password).Return("newHash", nil)
+	m.userDB.EXPECT().PathUser(gomock.Any(), &core.UserDB{PasswordHash: pointy.String("newHash")},
+		testPasswordUserId).Return(nil)
+	m.passwordResetDB.EXPECT().RevokeUserPasswordResets(gomock.Any(), testPasswordUserId).Return(nil)
+	m.sessionDB.EXPECT().RevokeUserSessions(gomock.Any(), testPasswordUserId).Return(nil)
+}
+
+func TestForgotPassword(t *testing.T) {
+	testTable := []struct {
+		name         string
+		mockBehavior func(m *passwordMocks)
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(m *passwordMocks) {
+				m.userDB.EXPECT().GetUserByEmail(gomock.Any(), testEmail).Return(&core.UserDB{User: core.User{
+					Id: testPasswordUserId, UserBaseData: core.UserBaseData{
+						FirstName: pointy.String("Name"), Email: pointy.String(testEmail)}}}, nil)
+				m.passwordResetDB.EXPECT().CountPasswordResets(gomock.Any(), testPasswordUserId, gomock.Any()).
+					Return(0, nil)
+				m.token.EXPECT().GenerateRandomToken().Return("token", nil)
+				m.token.EXPECT().HashRandomToken("token").Return("hash")
+				m.passwordResetDB.EXPECT().AddPasswordReset(gomock.Any(), testPasswordUserId, "hash", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, userId int, tokenHash string, expiresTime uint32) error {
+						if ttl := time.Until(time.Unix(int64(expiresTime), 0)); ttl > passwordResetTTL {
+							t.Errorf("reset token expires in %s, want at most %s", ttl, passwordResetTTL)
+						}
+						return nil
+					})
+				m.mailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			// unknown email isn't reported and nothing is sent
+			name: "Unknown email",
+			mockBehavior: func(m *passwordMocks) {
+				m.userDB.EXPECT().GetUserByEmail(gomock.Any(), testEmail).
+					Return(nil, moduleErrors.ErrorDatabaseUserNotFound)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service, m := newTestPassword(c)
+			testCase.mockBehavior(m)
+
+			if err := service.ForgotPassword(context.Background(), testEmail); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	service, m := newTestPassword(c)
+
+	// database marks token as used, so second reset by the same token doesn't find it as well as expired one
+	m.token.EXPECT().HashRandomToken("token").Return("hash").Times(2)
+	gomock.InOrder(
+		m.passwordResetDB.EXPECT().UsePasswordReset(gomock.Any(), "hash").Return(testPasswordUserId, nil),
+		m.passwordResetDB.EXPECT().UsePasswordReset(gomock.Any(), "hash").
+			Return(0, moduleErrors.ErrorDatabaseResetTokenNotFound),
+	)
+	expectPasswordSet(m, "password")
+
+	if err := service.ResetPassword(context.Background(), "token", "password"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	err := service.ResetPassword(context.Background(), "token", "password")
+	if err != moduleErrors.ErrorServiceInvalidResetToken {
+		t.Errorf("error = %v, want %v", err, moduleErrors.ErrorServiceInvalidResetToken)
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	testTable := []struct {
+		name         string
+		mockBehavior func(m *passwordMocks)
+		wantError    error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(m *passwordMocks) {
+				m.hash.EXPECT().ValidateHash("oldHash", "old").Return(nil)
+				expectPasswordSet(m, "new")
+			},
+		},
+		{
+			name: "Wrong old password",
+			mockBehavior: func(m *passwordMocks) {
+				m.hash.EXPECT().ValidateHash("oldHash", "old").Return(moduleErrors.ErrorHashValidationPassword)
+			},
+			wantError: moduleErrors.ErrorServiceInvalidPassword,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service, m := newTestPassword(c)
+			m.userDB.EXPECT().GetUser(gomock.Any(), testPasswordUserId).
+				Return(&core.UserDB{PasswordHash: pointy.String("oldHash")}, nil)
+			testCase.mockBehavior(m)
+
+			ctx := core.ContextWithUser(context.Background(), testPasswordUserId, nil)
+			if err := service.ChangePassword(ctx, "old", "new"); err != testCase.wantError {
+				t.Errorf("error = %v, want %v", err, testCase.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/authz"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/openlyinc/pointy"
+	"github.com/sirupsen/logrus"
+	"strings"
+)
+
+//go:generate mockgen -source=profile.go -destination=mock/profileMock.go
+type userDBProfile interface {
+	GetUser(ctx context.Context, userId int) (*core.UserDB, error)
+	PathUser(ctx context.Context, user *core.UserDB, userId int) error
+	DeleteUser(ctx context.Context, userId int) error
+}
+
+type credentialsDBProfile interface {
+	GetUserCredential(ctx context.Context, userId int) ([]core.Credentials, error)
+	CountCredentialsForUpdate(ctx context.Context, credentialType string, objectId int) (int, error)
+	InvalidateAllCredentials(ctx context.Context)
+}
+
+type thingUsageDBProfile interface {
+	CountUserTakenUsages(ctx context.Context, userId int, companyId int, departmentId int) (int, error)
+}
+
+type emailVerificationProfile interface {
+	SendNewEmailVerification(ctx context.Context, userId int) error
+}
+
+type transactionDBProfile interface {
+	InjectTx(ctx context.Context) (context.Context, error)
+	CommitTx(ctx context.Context) error
+	RollbackTx(ctx context.Context) error
+	RollbackTxDefer(ctx context.Context)
+}
+
+// Profile is service of current user account
+type Profile struct {
+	userDB            userDBProfile
+	credentialsDB     credentialsDBProfile
+	thingUsageDB      thingUsageDBProfile
+	emailVerification emailVerificationProfile
+	transactionDB     transactionDBProfile
+}
+
+func NewProfile(userDB userDBProfile, credentialsDB credentialsDBProfile, thingUsageDB thingUsageDBProfile,
+	emailVerification emailVerificationProfile, transactionDB transactionDBProfile) *Profile {
+	return &Profile{
+		userDB:            userDB,
+		credentialsDB:     credentialsDB,
+		thingUsageDB:      thingUsageDB,
+		emailVerification: emailVerification,
+		transactionDB:     transactionDB,
+	}
+}
+
+func (P *Profile) GetCurrentUser(ctx context.Context) (*core.User, error) {
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"module":   "service",
+			"function": "GetCurrentUser",
+			"error":    err.Error(),
+		}).Error("error get user id from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	userData, err := P.getUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userData.User, nil
+}
+
+// GetUser returns user from the same company as current user, other users are not found
+func (P *Profile) GetUser(ctx context.Context, userId int) (*core.User, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "GetUser",
+		"userId":   userId,
+		"context":  *core.LogContext(ctx),
+	}
+
+	currentUserId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	if userId == currentUserId {
+		return P.GetCurrentUser(ctx)
+	}
+
+	credentials, err := core.ContextGetUserCredentials(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user credentials from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	userData, err := P.getUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// other users can't find out that user exists
+	if userData.CompanyId == nil || !authz.Can(credentials, authz.ActionRead, authz.Company(*userData.CompanyId)) {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Warning("user from other company")
+		return nil, moduleErrors.ErrorServiceUserNotFound
+	}
+
+	return &userData.User, nil
+}
+
+// UpdateCurrentUser changes profile of current user, changed email isn't verified until user opens link
+// from verification email
+func (P *Profile) UpdateCurrentUser(ctx context.Context, userUpdate *core.UserUpdate) (*core.User, error) {
+	logBase := logrus.Fields{
+		"module":     "service",
+		"function":   "UpdateCurrentUser",
+		"userUpdate": userUpdate,
+		"context":    *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	ctx, err = P.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return nil, err
+	}
+
+	defer P.transactionDB.RollbackTxDefer(ctx)
+
+	userData, err := P.getUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	newUserData := core.UserDB{
+		User: core.User{
+			UserBaseData: core.UserBaseData{
+				FirstName: userUpdate.FirstName,
+				LastName:  userUpdate.LastName,
+			},
+		},
+	}
+
+	emailChanged := userUpdate.Email != nil && !strings.EqualFold(*userUpdate.Email, *userData.Email)
+	if emailChanged {
+		newUserData.Email = userUpdate.Email
+		newUserData.EmailIsValidated = pointy.Bool(false)
+	}
+
+	if err = P.userDB.PathUser(ctx, &newUserData, userId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error update user in db")
+		switch err {
+		case moduleErrors.ErrorDataBaseUserAlreadyHas:
+			return nil, moduleErrors.ErrorServiceUserAlreadyHas
+		case moduleErrors.ErrorDataBaseHasNotDataToChange:
+			return nil, moduleErrors.ErrorAllNoFields
+		default:
+			return nil, err
+		}
+	}
+
+	// token is saved in transaction, so user isn't changed if email can't be sent
+	if emailChanged {
+		if err = P.emailVerification.SendNewEmailVerification(ctx, userId); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Error("error send verification of new email")
+			return nil, err
+		}
+	}
+
+	userData, err = P.getUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = P.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return nil, err
+	}
+
+	return &userData.User, nil
+}
+
+// DeleteCurrentUser deletes account of current user. The last company admin can't delete account,
+// taken things must be returned first.
+func (P *Profile) DeleteCurrentUser(ctx context.Context) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "DeleteCurrentUser",
+		"context":  *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return moduleErrors.ErrorServiceInvalidContext
+	}
+
+	ctx, err = P.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return err
+	}
+
+	defer P.transactionDB.RollbackTxDefer(ctx)
+
+	userData, err := P.getUser(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	if userData.CompanyId != nil {
+		if err = checkLastCompanyAdmin(ctx, P.credentialsDB, userId, *userData.CompanyId); err != nil {
+			return err
+		}
+		if err = checkTakenThings(ctx, P.thingUsageDB, userId, *userData.CompanyId, 0); err != nil {
+			return err
+		}
+	}
+
+	if err = P.userDB.DeleteUser(ctx, userId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error delete user from db")
+		return err
+	}
+
+	// credentials are deleted by cascade
+	P.credentialsDB.InvalidateAllCredentials(ctx)
+
+	if err = P.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+func (P *Profile) getUser(ctx context.Context, userId int) (*core.UserDB, error) {
+	userData, err := P.userDB.GetUser(ctx, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"module":   "service",
+			"function": "getUser",
+			"userId":   userId,
+			"error":    err.Error(),
+		}).Error("error get user data from db")
+		if err == moduleErrors.ErrorDatabaseUserNotFound {
+			return nil, moduleErrors.ErrorServiceUserNotFound
+		}
+		return nil, moduleErrors.ErrorServiceGetUserData
+	}
+
+	return userData, nil
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	mockService "github.com/Thing-repository/backend-server/internal/service/mock"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/golang/mock/gomock"
+	"github.com/openlyinc/pointy"
+	"testing"
+)
+
+const (
+	testProfileCompanyId = 1
+	testProfileUserId    = 2
+	testProfileOtherId   = 3
+)
+
+type profileMocks struct {
+	userDB            *mockService.MockuserDBProfile
+	credentialsDB     *mockService.MockcredentialsDBProfile
+	thingUsageDB      *mockService.MockthingUsageDBProfile
+	emailVerification *mockService.MockemailVerificationProfile
+	transactionDB     *mockService.MocktransactionDBProfile
+}
+
+func newTestProfile(c *gomock.Controller) (*Profile, *profileMocks) {
+	m := &profileMocks{
+		userDB:            mockService.NewMockuserDBProfile(c),
+		credentialsDB:     mockService.NewMockcredentialsDBProfile(c),
+		thingUsageDB:      mockService.NewMockthingUsageDBProfile(c),
+		emailVerification: mockService.NewMockemailVerificationProfile(c),
+		transactionDB:     mockService.NewMocktransactionDBProfile(c),
+	}
+
+	m.transactionDB.EXPECT().InjectTx(gomock.Any()).DoAndReturn(
+		func(ctx context.Context) (context.Context, error) { return ctx, nil }).AnyTimes()
+	m.transactionDB.EXPECT().RollbackTxDefer(gomock.Any()).AnyTimes()
+	m.transactionDB.EXPECT().CommitTx(gomock.Any()).Return(nil).AnyTimes()
+
+	return NewProfile(m.userDB, m.credentialsDB, m.thingUsageDB, m.emailVerification, m.transactionDB), m
+}
+
+func testProfileUser(userId int, companyId *int) *core.UserDB {
+	return &core.UserDB{User: core.User{Id: userId, CompanyId: companyId, UserBaseData: core.UserBaseData{
+		FirstName: pointy.String("Name"), Email: pointy.String(testEmail)}}}
+}
+
+func testProfileContext() context.Context {
+	return core.ContextWithUser(context.Background(), testProfileUserId, []core.Credentials{
+		{CredentialType: core.CredentialTypeCompanyUser, ObjectId: testProfileCompanyId},
+	})
+}
+
+func TestProfileGetUser(t *testing.T) {
+	testTable := []struct {
+		name      string
+		userData  *core.UserDB
+		wantError error
+	}{
+		{
+			name:     "Colleague",
+			userData: testProfileUser(testProfileOtherId, pointy.Int(testProfileCompanyId)),
+		},
+		{
+			name:      "User from other company",
+			userData:  testProfileUser(testProfileOtherId, pointy.Int(testProfileCompanyId+1)),
+			wantError: moduleErrors.ErrorServiceUserNotFound,
+		},
+		{
+			name:      "User without company",
+			userData:  testProfileUser(testProfileOtherId, nil),
+			wantError: moduleErrors.ErrorServiceUserNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service, m := newTestProfile(c)
+			m.userDB.EXPECT().GetUser(gomock.Any(), testProfileOtherId).Return(testCase.userData, nil)
+
+			userData, err := service.GetUser(testProfileContext(), testProfileOtherId)
+			if err != testCase.wantError {
+				t.Fatalf("error = %v, want %v", err, testCase.wantError)
+			}
+			if err == nil && userData.Id != testProfileOtherId {
+				t.Errorf("user id = %d, want %d", userData.Id, testProfileOtherId)
+			}
+		})
+	}
+}
+
+func TestUpdateCurrentUser(t *testing.T) {
+	testTable := []struct {
+		name         string
+		userUpdate   *core.UserUpdate
+		mockBehavior func(m *profileMocks, userUpdate *core.UserUpdate)
+	}{
+		{
+			// new email isn't verified until user opens link from verification email
+			name:       "Email changed",
+			userUpdate: &core.UserUpdate{Email: pointy.String("new@example.com")},
+			mockBehavior: func(m *profileMocks, userUpdate *core.UserUpdate) {
+				newUserData := &core.UserDB{}
+				newUserData.Email = userUpdate.Email
+				newUserData.EmailIsValidated = pointy.Bool(false)
+				m.userDB.EXPECT().PathUser(gomock.Any(), newUserData, testProfileUserId).Return(nil)
+				m.emailVerification.EXPECT().SendNewEmailVerification(gomock.Any(), testProfileUserId).Return(nil)
+			},
+		},
+		{
+			// email in other case is the same address
+			name:       "Email isn't changed",
+			userUpdate: &core.UserUpdate{FirstName: pointy.String("New"), Email: pointy.String("USER@example.com")},
+			mockBehavior: func(m *profileMocks, userUpdate *core.UserUpdate) {
+				newUserData := &core.UserDB{}
+				newUserData.FirstName = userUpdate.FirstName
+				m.userDB.EXPECT().PathUser(gomock.Any(), newUserData, testProfileUserId).Return(nil)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service, m := newTestProfile(c)
+			m.userDB.EXPECT().GetUser(gomock.Any(), testProfileUserId).
+				Return(testProfileUser(testProfileUserId, pointy.Int(testProfileCompanyId)), nil).Times(2)
+			testCase.mockBehavior(m, testCase.userUpdate)
+
+			if _, err := service.UpdateCurrentUser(testProfileContext(), testCase.userUpdate); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestDeleteCurrentUser(t *testing.T) {
+	adminCredentials := []core.Credentials{
+		{CredentialType: core.CredentialTypeCompanyAdmin, ObjectId: testProfileCompanyId},
+	}
+
+	testTable := []struct {
+		name         string
+		mockBehavior func(m *profileMocks)
+		wantError    error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(m *profileMocks) {
+				m.credentialsDB.EXPECT().GetUserCredential(gomock.Any(), testProfileUserId).
+					Return(adminCredentials, nil)
+				m.credentialsDB.EXPECT().CountCredentialsForUpdate(gomock.Any(), core.CredentialTypeCompanyAdmin,
+					testProfileCompanyId).Return(2, nil)
+				m.thingUsageDB.EXPECT().CountUserTakenUsages(gomock.Any(), testProfileUserId, testProfileCompanyId, 0).
+					Return(0, nil)
+				m.userDB.EXPECT().DeleteUser(gomock.Any(), testProfileUserId).Return(nil)
+				m.credentialsDB.EXPECT().InvalidateAllCredentials(gomock.Any())
+			},
+		},
+		{
+			name: "Last company admin",
+			mockBehavior: func(m *profileMocks) {
+				m.credentialsDB.EXPECT().GetUserCredential(gomock.Any(), testProfileUserId).
+					Return(adminCredentials, nil)
+				m.credentialsDB.EXPECT().CountCredentialsForUpdate(gomock.Any(), core.CredentialTypeCompanyAdmin,
+					testProfileCompanyId).Return(1, nil)
+			},
+			wantError: moduleErrors.ErrorServiceLastCompanyAdmin,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service, m := newTestProfile(c)
+			m.userDB.EXPECT().GetUser(gomock.Any(), testProfileUserId).
+				Return(testProfileUser(testProfileUserId, pointy.Int(testProfileCompanyId)), nil)
+			testCase.mockBehavior(m)
+
+			if err := service.DeleteCurrentUser(testProfileContext()); err != testCase.wantError {
+				t.Errorf("error = %v, want %v", err, testCase.wantError)
+			}
+		})
+	}
+}
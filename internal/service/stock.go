@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/authz"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
+)
+
+type stockDBStock interface {
+	AddStockMovement(ctx context.Context, movement *core.StockMovement) (*core.StockMovement, error)
+	GetStockMovements(ctx context.Context, thingId int) ([]core.StockMovement, error)
+}
+
+type thingDBStock interface {
+	GetThing(ctx context.Context, thingId int) (*core.Thing, error)
+	GetThingForUpdate(ctx context.Context, thingId int) (*core.Thing, error)
+}
+
+type transactionDBStock interface {
+	InjectTx(ctx context.Context) (context.Context, error)
+	CommitTx(ctx context.Context) error
+	RollbackTx(ctx context.Context) error
+	RollbackTxDefer(ctx context.Context)
+}
+
+// stockDBMovement is used by services which record stock movements of consumables
+type stockDBMovement interface {
+	AddStockMovement(ctx context.Context, movement *core.StockMovement) (*core.StockMovement, error)
+}
+
+// Stock is service of consumables stock ledger, remainder of consumable is changed only by stock movements
+type Stock struct {
+	stockDB       stockDBStock
+	thingDB       thingDBStock
+	transactionDB transactionDBStock
+}
+
+func NewStock(stockDB stockDBStock, thingDB thingDBStock, transactionDB transactionDBStock) *Stock {
+	return &Stock{
+		stockDB:       stockDB,
+		thingDB:       thingDB,
+		transactionDB: transactionDB,
+	}
+}
+
+func validateStockMovement(movement *core.StockMovementAdd) error {
+	if slices.Index(core.StockMovementTypes, movement.Type) == -1 {
+		return moduleErrors.ErrorServiceInvalidMovementType
+	}
+	// adjustment sets counted remainder, so it can be zero
+	if movement.Quantity < 0 || movement.Quantity == 0 && movement.Type != core.StockMovementAdjustment {
+		return moduleErrors.ErrorServiceInvalidQuantity
+	}
+	return nil
+}
+
+// addStockMovement records movement of consumable thing and changes its remainder. Thing must be locked
+// for update in transaction, so remainder can't change between check and record.
+func addStockMovement(ctx context.Context, stockDB stockDBMovement, thing *core.Thing, movementType string,
+	quantity float32, usageId *int, comment string) (*core.StockMovement, error) {
+	logBase := logrus.Fields{
+		"module":       "service",
+		"function":     "addStockMovement",
+		"thingId":      thing.Id,
+		"movementType": movementType,
+		"quantity":     quantity,
+		"usageId":      usageId,
+	}
+
+	if thing.Type != core.ThingTypeConsumables {
+		return nil, moduleErrors.ErrorServiceThingNotConsumable
+	}
+
+	delta := quantity
+	switch movementType {
+	case core.StockMovementConsumption, core.StockMovementWriteOff:
+		delta = -quantity
+	case core.StockMovementAdjustment:
+		delta = quantity - thing.Remainder
+	}
+
+	if thing.Remainder+delta < 0 {
+		logrus.WithFields(logrus.Fields{
+			"base":      logBase,
+			"remainder": thing.Remainder,
+		}).Warning(moduleErrors.ErrorServiceInsufficientStock.Error())
+		return nil, moduleErrors.ErrorServiceInsufficientStock
+	}
+
+	movement := &core.StockMovement{
+		ThingId:  thing.Id,
+		Type:     movementType,
+		Quantity: delta,
+		UsageId:  usageId,
+		Comment:  comment,
+	}
+	if userId, err := core.ContextGetUserId(ctx); err == nil {
+		movement.UserId = &userId
+	}
+
+	movementData, err := stockDB.AddStockMovement(ctx, movement)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error add stock movement to database")
+		switch err {
+		case moduleErrors.ErrorDatabaseThingNotFound:
+			return nil, moduleErrors.ErrorServiceThingNotFound
+		case moduleErrors.ErrorDatabaseInsufficientStock:
+			return nil, moduleErrors.ErrorServiceInsufficientStock
+		default:
+			return nil, err
+		}
+	}
+
+	thing.Remainder = movementData.Remainder
+
+	return movementData, nil
+}
+
+func (S *Stock) AddMovement(ctx context.Context, thingId int, movement *core.StockMovementAdd) (*core.StockMovement, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "AddMovement",
+		"thingId":  thingId,
+		"movement": movement,
+		"context":  *core.LogContext(ctx),
+	}
+
+	if err := validateStockMovement(movement); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("invalid stock movement")
+		return nil, err
+	}
+
+	ctx, err := S.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return nil, err
+	}
+
+	defer S.transactionDB.RollbackTxDefer(ctx)
+
+	thingData, err := S.thingDB.GetThingForUpdate(ctx, thingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get thing from database")
+		switch err {
+		case moduleErrors.ErrorDatabaseThingNotFound:
+			return nil, moduleErrors.ErrorServiceThingNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	err = authz.Authorize(ctx, authz.ActionCreate, authz.ThingStock(thingData.CompanyId, thingData.DepartmentId))
+	if err != nil {
+		return nil, err
+	}
+
+	movementData, err := addStockMovement(ctx, S.stockDB, thingData, movement.Type, movement.Quantity, nil,
+		movement.Comment)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = S.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return nil, err
+	}
+
+	return movementData, nil
+}
+
+func (S *Stock) GetMovements(ctx context.Context, thingId int) ([]core.StockMovement, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "GetMovements",
+		"thingId":  thingId,
+		"context":  *core.LogContext(ctx),
+	}
+
+	thingData, err := S.thingDB.GetThing(ctx, thingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get thing from database")
+		switch err {
+		case moduleErrors.ErrorDatabaseThingNotFound:
+			return nil, moduleErrors.ErrorServiceThingNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	err = authz.Authorize(ctx, authz.ActionRead, authz.ThingStock(thingData.CompanyId, thingData.DepartmentId))
+	if err != nil {
+		return nil, err
+	}
+
+	if thingData.Type != core.ThingTypeConsumables {
+		return nil, moduleErrors.ErrorServiceThingNotConsumable
+	}
+
+	movements, err := S.stockDB.GetStockMovements(ctx, thingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get stock movements from database")
+		return nil, err
+	}
+
+	return movements, nil
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/authz"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/sirupsen/logrus"
+)
+
+type stockDBStockAlert interface {
+	CreateStockAlerts(ctx context.Context) (int, error)
+	ResolveStockAlerts(ctx context.Context) (int, error)
+	GetDepartmentStockAlerts(ctx context.Context, departmentId int) ([]core.StockAlert, error)
+}
+
+type thingDBStockAlert interface {
+	GetLowStockThings(ctx context.Context, companyId int) ([]core.Thing, error)
+}
+
+type departmentDBStockAlert interface {
+	GetDepartment(ctx context.Context, departmentId int) (*core.Department, error)
+}
+
+// StockAlert is service of low stock alerts, alerts are produced by background job and are shown
+// to department maintainers until stock is replenished
+type StockAlert struct {
+	stockDB      stockDBStockAlert
+	thingDB      thingDBStockAlert
+	departmentDB departmentDBStockAlert
+}
+
+func NewStockAlert(stockDB stockDBStockAlert, thingDB thingDBStockAlert,
+	departmentDB departmentDBStockAlert) *StockAlert {
+	return &StockAlert{
+		stockDB:      stockDB,
+		thingDB:      thingDB,
+		departmentDB: departmentDB,
+	}
+}
+
+// EvaluateStock resolves alerts of replenished consumables and opens alerts for consumables below threshold
+func (S *StockAlert) EvaluateStock(ctx context.Context) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "EvaluateStock",
+	}
+
+	resolved, err := S.stockDB.ResolveStockAlerts(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error resolve stock alerts")
+		return err
+	}
+
+	created, err := S.stockDB.CreateStockAlerts(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create stock alerts")
+		return err
+	}
+
+	if resolved != 0 || created != 0 {
+		logrus.WithFields(logrus.Fields{
+			"base":     logBase,
+			"resolved": resolved,
+			"created":  created,
+		}).Info("stock alerts changed")
+	}
+
+	return nil
+}
+
+// GetLowStock returns consumables of company with remainder below threshold
+func (S *StockAlert) GetLowStock(ctx context.Context, companyId int) ([]core.Thing, error) {
+	logBase := logrus.Fields{
+		"module":    "service",
+		"function":  "GetLowStock",
+		"companyId": companyId,
+		"context":   *core.LogContext(ctx),
+	}
+
+	if err := authz.Authorize(ctx, authz.ActionRead, authz.ThingStock(companyId, 0)); err != nil {
+		return nil, err
+	}
+
+	things, err := S.thingDB.GetLowStockThings(ctx, companyId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get low stock things from database")
+		return nil, err
+	}
+
+	return things, nil
+}
+
+// GetDepartmentAlerts returns open low stock alerts of department, they are for users who replenish stock
+func (S *StockAlert) GetDepartmentAlerts(ctx context.Context, departmentId int) ([]core.StockAlert, error) {
+	logBase := logrus.Fields{
+		"module":       "service",
+		"function":     "GetDepartmentAlerts",
+		"departmentId": departmentId,
+		"context":      *core.LogContext(ctx),
+	}
+
+	departmentData, err := S.departmentDB.GetDepartment(ctx, departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get department from database")
+		return nil, departmentDBError(err)
+	}
+
+	err = authz.Authorize(ctx, authz.ActionCreate, authz.ThingStock(*departmentData.CompanyId, departmentId))
+	if err != nil {
+		return nil, err
+	}
+
+	alerts, err := S.stockDB.GetDepartmentStockAlerts(ctx, departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get stock alerts from database")
+		return nil, err
+	}
+
+	return alerts, nil
+}
@@ -0,0 +1,448 @@
+package service
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/authz"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
+)
+
+type thingDBThing interface {
+	AddThing(ctx context.Context, thingBase *core.ThingBase, companyId int, departmentId int) (*core.Thing, error)
+	GetThing(ctx context.Context, thingId int) (*core.Thing, error)
+	GetThingsByCompany(ctx context.Context, companyId int) ([]core.Thing, error)
+	GetThingsByDepartment(ctx context.Context, departmentId int) ([]core.Thing, error)
+	UpdateThing(ctx context.Context, thing *core.ThingUpdate, thingId int) error
+	DeleteThing(ctx context.Context, thingId int) error
+}
+
+type departmentDBThing interface {
+	GetDepartment(ctx context.Context, departmentId int) (*core.Department, error)
+}
+
+type userDBThing interface {
+	GetUser(ctx context.Context, userId int) (*core.UserDB, error)
+}
+
+type stockDBThing interface {
+	AddStockMovement(ctx context.Context, movement *core.StockMovement) (*core.StockMovement, error)
+}
+
+type transactionDBThing interface {
+	InjectTx(ctx context.Context) (context.Context, error)
+	CommitTx(ctx context.Context) error
+	RollbackTx(ctx context.Context) error
+	RollbackTxDefer(ctx context.Context)
+}
+
+type Thing struct {
+	thingDB       thingDBThing
+	departmentDB  departmentDBThing
+	userDB        userDBThing
+	stockDB       stockDBThing
+	transactionDB transactionDBThing
+}
+
+func NewThing(thingDB thingDBThing, departmentDB departmentDBThing, userDB userDBThing, stockDB stockDBThing,
+	transactionDB transactionDBThing) *Thing {
+	return &Thing{
+		thingDB:       thingDB,
+		departmentDB:  departmentDB,
+		userDB:        userDB,
+		stockDB:       stockDB,
+		transactionDB: transactionDB,
+	}
+}
+
+func validateThingTypes(thingType *string, remainderType *string) error {
+	if thingType != nil && slices.Index(core.ThingTypes, *thingType) == -1 {
+		return moduleErrors.ErrorServiceInvalidThingType
+	}
+	if remainderType != nil && *remainderType != "" && slices.Index(core.RemainderTypes, *remainderType) == -1 {
+		return moduleErrors.ErrorServiceInvalidRemainderType
+	}
+	return nil
+}
+
+// validateMinRemainder checks low stock threshold, only consumables can have it. Threshold is measured in
+// units of remainder type, so pieces are whole and percents are not above 100.
+func validateMinRemainder(minRemainder float32, thingType string, remainderType string) error {
+	if minRemainder == 0 {
+		return nil
+	}
+	if thingType != core.ThingTypeConsumables {
+		return moduleErrors.ErrorServiceThingNotConsumable
+	}
+	if minRemainder < 0 {
+		return moduleErrors.ErrorServiceInvalidMinRemainder
+	}
+	switch remainderType {
+	case core.RemainderTypePcs:
+		if minRemainder != float32(int(minRemainder)) {
+			return moduleErrors.ErrorServiceInvalidMinRemainder
+		}
+	case core.RemainderTypePercents:
+		if minRemainder > 100 {
+			return moduleErrors.ErrorServiceInvalidMinRemainder
+		}
+	}
+	return nil
+}
+
+func (T *Thing) AddThing(ctx context.Context, thingBase *core.ThingBase, departmentId *int) (*core.Thing, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "AddThing",
+		"context":  *core.LogContext(ctx),
+	}
+
+	err := validateThingTypes(&thingBase.Type, &thingBase.RemainderType)
+	if err == nil {
+		err = validateMinRemainder(thingBase.MinRemainder, thingBase.Type, thingBase.RemainderType)
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":      logBase,
+			"thingBase": thingBase,
+			"error":     err.Error(),
+		}).Error("invalid thing data")
+		return nil, err
+	}
+
+	// if department not set, thing will be added to user department
+	if departmentId == nil {
+		userId, err := core.ContextGetUserId(ctx)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Error("error get user id from context")
+			return nil, moduleErrors.ErrorServiceInvalidContext
+		}
+
+		userData, err := T.userDB.GetUser(ctx, userId)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":   logBase,
+				"userId": userId,
+				"error":  err.Error(),
+			}).Error("error get user data")
+			return nil, moduleErrors.ErrorServiceGetUserData
+		}
+		if userData.DepartmentId == nil {
+			logrus.WithFields(logrus.Fields{
+				"base":   logBase,
+				"userId": userId,
+			}).Error(moduleErrors.ErrorServiceUserHasNotDepartment.Error())
+			return nil, moduleErrors.ErrorServiceUserHasNotDepartment
+		}
+		departmentId = userData.DepartmentId
+	}
+
+	departmentData, err := T.departmentDB.GetDepartment(ctx, *departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":         logBase,
+			"departmentId": *departmentId,
+			"error":        err.Error(),
+		}).Error("error get department data from db")
+		return nil, err
+	}
+
+	if err = authz.Authorize(ctx, authz.ActionCreate, authz.Thing(*departmentData.CompanyId, *departmentId)); err != nil {
+		return nil, err
+	}
+
+	ctx, err = T.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return nil, err
+	}
+
+	defer T.transactionDB.RollbackTxDefer(ctx)
+
+	// initial remainder of consumable is recorded as receipt, so stock ledger starts from zero
+	initialStock := thingBase.Remainder
+	thingAdd := *thingBase
+	if thingAdd.Type == core.ThingTypeConsumables {
+		thingAdd.Remainder = 0
+	}
+
+	thingData, err := T.thingDB.AddThing(ctx, &thingAdd, *departmentData.CompanyId, *departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":      logBase,
+			"thingBase": thingBase,
+			"error":     err.Error(),
+		}).Error("error add thing to database")
+		return nil, err
+	}
+
+	if thingData.Type == core.ThingTypeConsumables && initialStock != 0 {
+		if initialStock < 0 {
+			return nil, moduleErrors.ErrorServiceInvalidQuantity
+		}
+		_, err = addStockMovement(ctx, T.stockDB, thingData, core.StockMovementReceipt, initialStock, nil,
+			"initial stock")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = T.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return nil, err
+	}
+
+	return thingData, nil
+}
+
+func (T *Thing) GetThing(ctx context.Context, thingId int) (*core.Thing, error) {
+	thingData, err := T.getThing(ctx, thingId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = authz.Authorize(ctx, authz.ActionRead, authz.Thing(thingData.CompanyId, thingData.DepartmentId))
+	if err != nil {
+		return nil, err
+	}
+
+	return thingData, nil
+}
+
+func (T *Thing) GetThings(ctx context.Context, companyId *int, departmentId *int) ([]core.Thing, error) {
+	logBase := logrus.Fields{
+		"module":       "service",
+		"function":     "GetThings",
+		"companyId":    companyId,
+		"departmentId": departmentId,
+		"context":      *core.LogContext(ctx),
+	}
+
+	if departmentId != nil {
+		departmentData, err := T.departmentDB.GetDepartment(ctx, *departmentId)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Error("error get department data from db")
+			return nil, err
+		}
+
+		err = authz.Authorize(ctx, authz.ActionRead, authz.Thing(*departmentData.CompanyId, *departmentId))
+		if err != nil {
+			return nil, err
+		}
+
+		things, err := T.thingDB.GetThingsByDepartment(ctx, *departmentId)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Error("error get department things from db")
+			return nil, err
+		}
+		return things, nil
+	}
+
+	if companyId == nil {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error("company id and department id not set")
+		return nil, moduleErrors.ErrorAllNoFields
+	}
+
+	if err := authz.Authorize(ctx, authz.ActionRead, authz.Thing(*companyId, 0)); err != nil {
+		return nil, err
+	}
+
+	things, err := T.thingDB.GetThingsByCompany(ctx, *companyId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get company things from db")
+		return nil, err
+	}
+
+	return things, nil
+}
+
+func (T *Thing) UpdateThing(ctx context.Context, thing *core.ThingUpdate, thingId int) (*core.Thing, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "UpdateThing",
+		"thingId":  thingId,
+		"context":  *core.LogContext(ctx),
+	}
+
+	if err := validateThingTypes(thing.Type, thing.RemainderType); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"thing": thing,
+			"error": err.Error(),
+		}).Error("invalid thing data")
+		return nil, err
+	}
+
+	ctx, err := T.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return nil, err
+	}
+
+	defer T.transactionDB.RollbackTxDefer(ctx)
+
+	thingData, err := T.getThing(ctx, thingId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = authz.Authorize(ctx, authz.ActionUpdate, authz.Thing(thingData.CompanyId, thingData.DepartmentId))
+	if err != nil {
+		return nil, err
+	}
+
+	becomesConsumable := thing.Type != nil && *thing.Type == core.ThingTypeConsumables &&
+		thingData.Type != core.ThingTypeConsumables
+	if thing.Remainder != nil && (thingData.Type == core.ThingTypeConsumables || becomesConsumable) {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"thing": thing,
+		}).Error(moduleErrors.ErrorServiceRemainderFromStock.Error())
+		return nil, moduleErrors.ErrorServiceRemainderFromStock
+	}
+
+	if thing.MinRemainder != nil || thing.Type != nil || thing.RemainderType != nil {
+		minRemainder, thingType, remainderType := thingData.MinRemainder, thingData.Type, thingData.RemainderType
+		if thing.MinRemainder != nil {
+			minRemainder = *thing.MinRemainder
+		}
+		if thing.Type != nil {
+			thingType = *thing.Type
+		}
+		if thing.RemainderType != nil {
+			remainderType = *thing.RemainderType
+		}
+		if err = validateMinRemainder(minRemainder, thingType, remainderType); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"thing": thing,
+				"error": err.Error(),
+			}).Error("invalid thing data")
+			return nil, err
+		}
+	}
+
+	err = T.thingDB.UpdateThing(ctx, thing, thingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"thing": thing,
+			"error": err.Error(),
+		}).Error("error update thing in database")
+		switch err {
+		case moduleErrors.ErrorDataBaseHasNotDataToChange:
+			return nil, moduleErrors.ErrorAllNoFields
+		case moduleErrors.ErrorDatabaseThingNotFound:
+			return nil, moduleErrors.ErrorServiceThingNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	thingData, err = T.getThing(ctx, thingId)
+	if err != nil {
+		return nil, err
+	}
+
+	// current remainder of thing which becomes consumable is opening balance of its stock ledger
+	if becomesConsumable {
+		_, err = addStockMovement(ctx, T.stockDB, thingData, core.StockMovementAdjustment, thingData.Remainder, nil,
+			"opening balance")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = T.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return nil, err
+	}
+
+	return thingData, nil
+}
+
+func (T *Thing) DeleteThing(ctx context.Context, thingId int) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "DeleteThing",
+		"thingId":  thingId,
+		"context":  *core.LogContext(ctx),
+	}
+
+	thingData, err := T.getThing(ctx, thingId)
+	if err != nil {
+		return err
+	}
+
+	err = authz.Authorize(ctx, authz.ActionDelete, authz.Thing(thingData.CompanyId, thingData.DepartmentId))
+	if err != nil {
+		return err
+	}
+
+	err = T.thingDB.DeleteThing(ctx, thingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error delete thing from database")
+		switch err {
+		case moduleErrors.ErrorDatabaseThingNotFound:
+			return moduleErrors.ErrorServiceThingNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (T *Thing) getThing(ctx context.Context, thingId int) (*core.Thing, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "getThing",
+		"thingId":  thingId,
+	}
+
+	thingData, err := T.thingDB.GetThing(ctx, thingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get thing from database")
+		switch err {
+		case moduleErrors.ErrorDatabaseThingNotFound:
+			return nil, moduleErrors.ErrorServiceThingNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return thingData, nil
+}
@@ -0,0 +1,565 @@
+package service
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/authz"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=thing_block.go -destination=mock/thingBlockMock.go
+type thingBlockDBThingBlock interface {
+	AddBlock(ctx context.Context, block *core.ThingBlockAdd) (*core.ThingBlock, error)
+	GetBlock(ctx context.Context, blockId int) (*core.ThingBlock, error)
+	GetBlocksByCompany(ctx context.Context, companyId int) ([]core.ThingBlock, error)
+	GetBlocksByDepartment(ctx context.Context, departmentId int) ([]core.ThingBlock, error)
+	UpdateBlock(ctx context.Context, block *core.ThingBlockAdd, blockId int) error
+	DeleteBlock(ctx context.Context, blockId int) error
+	AddUsageCancellation(ctx context.Context, usage *core.ThingUsage, blockId int, reason string) error
+}
+
+type thingDBThingBlock interface {
+	GetThing(ctx context.Context, thingId int) (*core.Thing, error)
+	GetThingForUpdate(ctx context.Context, thingId int) (*core.Thing, error)
+	RefreshThingBlocking(ctx context.Context, thingId int) error
+	RefreshExpiredBlocking(ctx context.Context) ([]int, error)
+}
+
+type thingUsageDBThingBlock interface {
+	GetOverlappingUsages(ctx context.Context, thingId int, startTime uint32, endTime uint32) ([]core.ThingUsage, error)
+	SetUsageStatus(ctx context.Context, usageId int, currentStatus string, status string) error
+}
+
+type departmentDBThingBlock interface {
+	GetDepartment(ctx context.Context, departmentId int) (*core.Department, error)
+}
+
+type notifierThingBlock interface {
+	Notify(ctx context.Context, notification *core.NotificationAdd) error
+}
+
+type publisherThingBlock interface {
+	Publish(ctx context.Context, companyId int, eventType string, data interface{}) error
+}
+
+type transactionDBThingBlock interface {
+	InjectTx(ctx context.Context) (context.Context, error)
+	CommitTx(ctx context.Context) error
+	RollbackTx(ctx context.Context) error
+	RollbackTxDefer(ctx context.Context)
+}
+
+type ThingBlock struct {
+	thingBlockDB  thingBlockDBThingBlock
+	thingDB       thingDBThingBlock
+	thingUsageDB  thingUsageDBThingBlock
+	departmentDB  departmentDBThingBlock
+	notifier      notifierThingBlock
+	publisher     publisherThingBlock
+	transactionDB transactionDBThingBlock
+}
+
+func NewThingBlock(thingBlockDB thingBlockDBThingBlock, thingDB thingDBThingBlock, thingUsageDB thingUsageDBThingBlock,
+	departmentDB departmentDBThingBlock, notifier notifierThingBlock, publisher publisherThingBlock,
+	transactionDB transactionDBThingBlock) *ThingBlock {
+	return &ThingBlock{
+		thingBlockDB:  thingBlockDB,
+		thingDB:       thingDB,
+		thingUsageDB:  thingUsageDB,
+		departmentDB:  departmentDB,
+		notifier:      notifier,
+		publisher:     publisher,
+		transactionDB: transactionDB,
+	}
+}
+
+func validateActionTime(startTime uint32, endTime uint32) bool {
+	return startTime != 0 && (endTime == 0 || endTime > startTime)
+}
+
+func (T *ThingBlock) AddBlock(ctx context.Context, block *core.ThingBlockAdd, force bool) (*core.ThingBlock, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "AddBlock",
+		"block":    block,
+		"force":    force,
+		"context":  *core.LogContext(ctx),
+	}
+
+	if !validateActionTime(block.StartTime, block.EndTime) {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error(moduleErrors.ErrorServiceInvalidUsageTime.Error())
+		return nil, moduleErrors.ErrorServiceInvalidUsageTime
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	block.UserId = userId
+
+	ctx, err = T.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return nil, err
+	}
+
+	defer T.transactionDB.RollbackTxDefer(ctx)
+
+	thingData, err := T.lockThing(ctx, block.ThingId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = authz.Authorize(ctx, authz.ActionCreate, authz.ThingBlock(thingData.CompanyId, thingData.DepartmentId))
+	if err != nil {
+		return nil, err
+	}
+
+	usages, err := T.getConflictUsages(ctx, block, force)
+	if err != nil {
+		return nil, err
+	}
+
+	blockData, err := T.thingBlockDB.AddBlock(ctx, block)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error add block to database")
+		return nil, err
+	}
+
+	if err = T.cancelUsages(ctx, usages, blockData.Id, block.Reason, thingData.Name); err != nil {
+		return nil, err
+	}
+
+	if err = T.thingDB.RefreshThingBlocking(ctx, block.ThingId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error refresh thing blocking")
+		return nil, err
+	}
+
+	err = T.publisher.Publish(ctx, thingData.CompanyId, core.WebhookEventThingBlocked, blockData)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = T.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return nil, err
+	}
+
+	return blockData, nil
+}
+
+func (T *ThingBlock) UpdateBlock(ctx context.Context, block *core.ThingBlockAdd, blockId int, force bool) (*core.ThingBlock, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "UpdateBlock",
+		"blockId":  blockId,
+		"block":    block,
+		"force":    force,
+		"context":  *core.LogContext(ctx),
+	}
+
+	if !validateActionTime(block.StartTime, block.EndTime) {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error(moduleErrors.ErrorServiceInvalidUsageTime.Error())
+		return nil, moduleErrors.ErrorServiceInvalidUsageTime
+	}
+
+	ctx, err := T.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return nil, err
+	}
+
+	defer T.transactionDB.RollbackTxDefer(ctx)
+
+	blockData, err := T.getBlock(ctx, blockId)
+	if err != nil {
+		return nil, err
+	}
+
+	// block can't be moved to other thing
+	block.ThingId = blockData.ThingId
+	block.UserId = blockData.UserId
+
+	thingData, err := T.lockThing(ctx, blockData.ThingId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = authz.Authorize(ctx, authz.ActionUpdate, authz.ThingBlock(thingData.CompanyId, thingData.DepartmentId))
+	if err != nil {
+		return nil, err
+	}
+
+	usages, err := T.getConflictUsages(ctx, block, force)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = T.thingBlockDB.UpdateBlock(ctx, block, blockId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error update block in database")
+		return nil, err
+	}
+
+	if err = T.cancelUsages(ctx, usages, blockId, block.Reason, thingData.Name); err != nil {
+		return nil, err
+	}
+
+	if err = T.thingDB.RefreshThingBlocking(ctx, block.ThingId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error refresh thing blocking")
+		return nil, err
+	}
+
+	blockData = &core.ThingBlock{
+		ThingBlockAdd: *block,
+		Id:            blockId,
+	}
+	err = T.publisher.Publish(ctx, thingData.CompanyId, core.WebhookEventThingBlocked, blockData)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = T.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return nil, err
+	}
+
+	return blockData, nil
+}
+
+func (T *ThingBlock) GetBlock(ctx context.Context, blockId int) (*core.ThingBlock, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "GetBlock",
+		"blockId":  blockId,
+		"context":  *core.LogContext(ctx),
+	}
+
+	blockData, err := T.getBlock(ctx, blockId)
+	if err != nil {
+		return nil, err
+	}
+
+	thingData, err := T.thingDB.GetThing(ctx, blockData.ThingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get thing from database")
+		return nil, err
+	}
+
+	err = authz.Authorize(ctx, authz.ActionRead, authz.ThingBlock(thingData.CompanyId, thingData.DepartmentId))
+	if err != nil {
+		return nil, err
+	}
+
+	return blockData, nil
+}
+
+func (T *ThingBlock) GetBlocks(ctx context.Context, companyId *int, departmentId *int) ([]core.ThingBlock, error) {
+	logBase := logrus.Fields{
+		"module":       "service",
+		"function":     "GetBlocks",
+		"companyId":    companyId,
+		"departmentId": departmentId,
+		"context":      *core.LogContext(ctx),
+	}
+
+	if departmentId != nil {
+		departmentData, err := T.departmentDB.GetDepartment(ctx, *departmentId)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Error("error get department data from db")
+			return nil, err
+		}
+
+		err = authz.Authorize(ctx, authz.ActionRead, authz.ThingBlock(*departmentData.CompanyId, *departmentId))
+		if err != nil {
+			return nil, err
+		}
+
+		blocks, err := T.thingBlockDB.GetBlocksByDepartment(ctx, *departmentId)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Error("error get department blocks from db")
+			return nil, err
+		}
+		return blocks, nil
+	}
+
+	if companyId == nil {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error("company id and department id not set")
+		return nil, moduleErrors.ErrorAllNoFields
+	}
+
+	if err := authz.Authorize(ctx, authz.ActionRead, authz.ThingBlock(*companyId, 0)); err != nil {
+		return nil, err
+	}
+
+	blocks, err := T.thingBlockDB.GetBlocksByCompany(ctx, *companyId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get company blocks from db")
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+func (T *ThingBlock) DeleteBlock(ctx context.Context, blockId int) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "DeleteBlock",
+		"blockId":  blockId,
+		"context":  *core.LogContext(ctx),
+	}
+
+	ctx, err := T.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return err
+	}
+
+	defer T.transactionDB.RollbackTxDefer(ctx)
+
+	blockData, err := T.getBlock(ctx, blockId)
+	if err != nil {
+		return err
+	}
+
+	thingData, err := T.lockThing(ctx, blockData.ThingId)
+	if err != nil {
+		return err
+	}
+
+	err = authz.Authorize(ctx, authz.ActionDelete, authz.ThingBlock(thingData.CompanyId, thingData.DepartmentId))
+	if err != nil {
+		return err
+	}
+
+	if err = T.thingBlockDB.DeleteBlock(ctx, blockId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error delete block from database")
+		return err
+	}
+
+	if err = T.thingDB.RefreshThingBlocking(ctx, blockData.ThingId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error refresh thing blocking")
+		return err
+	}
+
+	if err = T.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// getConflictUsages returns usages which must be cancelled for block, taken things can't be blocked
+// and other usages cancelled only with force flag
+func (T *ThingBlock) getConflictUsages(ctx context.Context, block *core.ThingBlockAdd, force bool) ([]core.ThingUsage, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "getConflictUsages",
+		"block":    block,
+		"force":    force,
+	}
+
+	usages, err := T.thingUsageDB.GetOverlappingUsages(ctx, block.ThingId, block.StartTime, block.EndTime)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get overlapping usages from database")
+		return nil, err
+	}
+
+	if len(usages) == 0 {
+		return usages, nil
+	}
+
+	if !force {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"usages": usages,
+		}).Error(moduleErrors.ErrorServiceBlockConflict.Error())
+		return nil, moduleErrors.ErrorServiceBlockConflict
+	}
+
+	for _, usage := range usages {
+		if usage.Status == core.UsageStatusTaken {
+			logrus.WithFields(logrus.Fields{
+				"base":    logBase,
+				"usageId": usage.Id,
+			}).Error("thing is taken in block time")
+			return nil, moduleErrors.ErrorServiceBlockConflict
+		}
+	}
+
+	return usages, nil
+}
+
+// cancelUsages cancels usages which conflict with block and notifies their owners
+func (T *ThingBlock) cancelUsages(ctx context.Context, usages []core.ThingUsage, blockId int, reason string,
+	thingName string) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "cancelUsages",
+		"blockId":  blockId,
+	}
+
+	for i := range usages {
+		err := T.thingUsageDB.SetUsageStatus(ctx, usages[i].Id, usages[i].Status, core.UsageStatusCancelled)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":    logBase,
+				"usageId": usages[i].Id,
+				"error":   err.Error(),
+			}).Error("error cancel usage")
+			return usageStatusDBError(err)
+		}
+		if err := T.thingBlockDB.AddUsageCancellation(ctx, &usages[i], blockId, reason); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":    logBase,
+				"usageId": usages[i].Id,
+				"error":   err.Error(),
+			}).Error("error add usage cancellation")
+			return err
+		}
+		err = notifyUsageOwner(ctx, T.notifier, core.NotificationThingBlocked, &usages[i], thingName, reason)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (T *ThingBlock) getBlock(ctx context.Context, blockId int) (*core.ThingBlock, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "getBlock",
+		"blockId":  blockId,
+	}
+
+	blockData, err := T.thingBlockDB.GetBlock(ctx, blockId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get block from database")
+		switch err {
+		case moduleErrors.ErrorDatabaseBlockNotFound:
+			return nil, moduleErrors.ErrorServiceBlockNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return blockData, nil
+}
+
+func (T *ThingBlock) lockThing(ctx context.Context, thingId int) (*core.Thing, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "lockThing",
+		"thingId":  thingId,
+	}
+
+	thingData, err := T.thingDB.GetThingForUpdate(ctx, thingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get thing from database")
+		switch err {
+		case moduleErrors.ErrorDatabaseThingNotFound:
+			return nil, moduleErrors.ErrorServiceThingNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return thingData, nil
+}
+
+// ExpireBlocks is background job, it clears blocking of things which block ended and
+// blocks things which scheduled block started
+func (T *ThingBlock) ExpireBlocks(ctx context.Context) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "ExpireBlocks",
+	}
+
+	thingIds, err := T.thingDB.RefreshExpiredBlocking(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error refresh expired blocking")
+		return err
+	}
+
+	if len(thingIds) != 0 {
+		logrus.WithFields(logrus.Fields{
+			"base":     logBase,
+			"thingIds": thingIds,
+		}).Info("blocking of things refreshed")
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	mockService "github.com/Thing-repository/backend-server/internal/service/mock"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+)
+
+const testBlockId = 5
+
+type thingBlockMocks struct {
+	thingBlockDB  *mockService.MockthingBlockDBThingBlock
+	thingDB       *mockService.MockthingDBThingBlock
+	thingUsageDB  *mockService.MockthingUsageDBThingBlock
+	notifier      *mockService.MocknotifierThingBlock
+	publisher     *mockService.MockpublisherThingBlock
+	transactionDB *mockService.MocktransactionDBThingBlock
+}
+
+func newTestThingBlock(c *gomock.Controller) (*ThingBlock, *thingBlockMocks) {
+	m := &thingBlockMocks{
+		thingBlockDB:  mockService.NewMockthingBlockDBThingBlock(c),
+		thingDB:       mockService.NewMockthingDBThingBlock(c),
+		thingUsageDB:  mockService.NewMockthingUsageDBThingBlock(c),
+		notifier:      mockService.NewMocknotifierThingBlock(c),
+		publisher:     mockService.NewMockpublisherThingBlock(c),
+		transactionDB: mockService.NewMocktransactionDBThingBlock(c),
+	}
+
+	m.transactionDB.EXPECT().InjectTx(gomock.Any()).DoAndReturn(
+		func(ctx context.Context) (context.Context, error) { return ctx, nil }).AnyTimes()
+	m.transactionDB.EXPECT().RollbackTxDefer(gomock.Any()).AnyTimes()
+	m.transactionDB.EXPECT().CommitTx(gomock.Any()).Return(nil).AnyTimes()
+
+	return NewThingBlock(m.thingBlockDB, m.thingDB, m.thingUsageDB, nil, m.notifier, m.publisher,
+		m.transactionDB), m
+}
+
+func testBlockUsage(id int, status string) core.ThingUsage {
+	usage := core.ThingUsage{Id: id, Status: status}
+	usage.UserId = testUsageOwnerId
+	usage.ThingId = testUsageThingId
+	return usage
+}
+
+func TestAddBlock(t *testing.T) {
+	type mockBehavior func(m *thingBlockMocks, block *core.ThingBlockAdd)
+
+	// blocked thing is set by database only after block starts, so blocks job applies scheduled block
+	expectBlockAdded := func(m *thingBlockMocks, block *core.ThingBlockAdd) {
+		blockData := &core.ThingBlock{ThingBlockAdd: *block, Id: testBlockId}
+		m.thingBlockDB.EXPECT().AddBlock(gomock.Any(), block).Return(blockData, nil)
+		m.thingDB.EXPECT().RefreshThingBlocking(gomock.Any(), testUsageThingId).Return(nil)
+		m.publisher.EXPECT().Publish(gomock.Any(), testUsageCompanyId, core.WebhookEventThingBlocked, blockData).
+			Return(nil)
+	}
+
+	testTable := []struct {
+		name         string
+		force        bool
+		startTime    time.Time
+		mockBehavior mockBehavior
+		wantError    error
+	}{
+		{
+			name:      "Conflict without force",
+			startTime: time.Now(),
+			mockBehavior: func(m *thingBlockMocks, block *core.ThingBlockAdd) {
+				m.thingUsageDB.EXPECT().GetOverlappingUsages(gomock.Any(), testUsageThingId, block.StartTime,
+					block.EndTime).Return([]core.ThingUsage{testBlockUsage(1, core.UsageStatusApproved)}, nil)
+			},
+			wantError: moduleErrors.ErrorServiceBlockConflict,
+		},
+		{
+			name:      "Force cancels usages",
+			force:     true,
+			startTime: time.Now(),
+			mockBehavior: func(m *thingBlockMocks, block *core.ThingBlockAdd) {
+				usages := []core.ThingUsage{testBlockUsage(1, core.UsageStatusRequested),
+					testBlockUsage(2, core.UsageStatusApproved)}
+				m.thingUsageDB.EXPECT().GetOverlappingUsages(gomock.Any(), testUsageThingId, block.StartTime,
+					block.EndTime).Return(usages, nil)
+				blockData := &core.ThingBlock{ThingBlockAdd: *block, Id: testBlockId}
+				m.thingBlockDB.EXPECT().AddBlock(gomock.Any(), block).Return(blockData, nil)
+				for i := range usages {
+					m.thingUsageDB.EXPECT().SetUsageStatus(gomock.Any(), usages[i].Id, usages[i].Status,
+						core.UsageStatusCancelled).Return(nil)
+					m.thingBlockDB.EXPECT().AddUsageCancellation(gomock.Any(), &usages[i], testBlockId, block.Reason).
+						Return(nil)
+				}
+				// owners of cancelled usages are notified
+				m.notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, notification *core.NotificationAdd) error {
+						if notification.UserId != testUsageOwnerId ||
+							notification.Type != core.NotificationThingBlocked {
+							t.Errorf("unexpected notification %+v", notification)
+						}
+						return nil
+					}).Times(len(usages))
+				m.thingDB.EXPECT().RefreshThingBlocking(gomock.Any(), testUsageThingId).Return(nil)
+				m.publisher.EXPECT().Publish(gomock.Any(), testUsageCompanyId, core.WebhookEventThingBlocked,
+					blockData).Return(nil)
+			},
+		},
+		{
+			name:      "Taken thing with force",
+			force:     true,
+			startTime: time.Now(),
+			mockBehavior: func(m *thingBlockMocks, block *core.ThingBlockAdd) {
+				m.thingUsageDB.EXPECT().GetOverlappingUsages(gomock.Any(), testUsageThingId, block.StartTime,
+					block.EndTime).Return([]core.ThingUsage{testBlockUsage(1, core.UsageStatusTaken)}, nil)
+			},
+			wantError: moduleErrors.ErrorServiceBlockConflict,
+		},
+		{
+			name:      "Future block",
+			startTime: time.Now().Add(24 * time.Hour),
+			mockBehavior: func(m *thingBlockMocks, block *core.ThingBlockAdd) {
+				m.thingUsageDB.EXPECT().GetOverlappingUsages(gomock.Any(), testUsageThingId, block.StartTime,
+					block.EndTime).Return(nil, nil)
+				expectBlockAdded(m, block)
+			},
+		},
+	}
+
+	ctx := core.ContextWithUser(context.Background(), testUsageMaintainerId, []core.Credentials{
+		{CredentialType: core.CredentialTypeDepartmentMaintainer, ObjectId: testUsageDepartmentId},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service, m := newTestThingBlock(c)
+
+			block := &core.ThingBlockAdd{Reason: "repair", ThingActionBase: core.ThingActionBase{
+				ThingId:   testUsageThingId,
+				StartTime: uint32(testCase.startTime.Unix()),
+				EndTime:   uint32(testCase.startTime.Add(time.Hour).Unix()),
+			}}
+			m.thingDB.EXPECT().GetThingForUpdate(gomock.Any(), testUsageThingId).Return(testUsageThing(), nil)
+			testCase.mockBehavior(m, block)
+
+			blockData, err := service.AddBlock(ctx, block, testCase.force)
+			if err != testCase.wantError {
+				t.Fatalf("error = %v, want %v", err, testCase.wantError)
+			}
+			if err == nil && blockData.Id != testBlockId {
+				t.Errorf("block id = %d, want %d", blockData.Id, testBlockId)
+			}
+		})
+	}
+}
+
+func TestUpdateBlockConflict(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	service, m := newTestThingBlock(c)
+
+	ctx := core.ContextWithUser(context.Background(), testUsageMaintainerId, []core.Credentials{
+		{CredentialType: core.CredentialTypeDepartmentMaintainer, ObjectId: testUsageDepartmentId},
+	})
+
+	block := &core.ThingBlockAdd{Reason: "repair", ThingActionBase: core.ThingActionBase{
+		StartTime: 1700000000, EndTime: 1700003600}}
+	current := &core.ThingBlock{Id: testBlockId}
+	current.ThingId = testUsageThingId
+	current.UserId = testUsageMaintainerId
+	m.thingBlockDB.EXPECT().GetBlock(gomock.Any(), testBlockId).Return(current, nil)
+	m.thingDB.EXPECT().GetThingForUpdate(gomock.Any(), testUsageThingId).Return(testUsageThing(), nil)
+	// extended block overlaps usage, it isn't saved without force
+	m.thingUsageDB.EXPECT().GetOverlappingUsages(gomock.Any(), testUsageThingId, block.StartTime, block.EndTime).
+		Return([]core.ThingUsage{testBlockUsage(1, core.UsageStatusRequested)}, nil)
+
+	if _, err := service.UpdateBlock(ctx, block, testBlockId, false); err != moduleErrors.ErrorServiceBlockConflict {
+		t.Errorf("error = %v, want %v", err, moduleErrors.ErrorServiceBlockConflict)
+	}
+}
+
+func TestExpireBlocks(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	service, m := newTestThingBlock(c)
+
+	// job clears ended blocks and applies blocks which started after they were added
+	m.thingDB.EXPECT().RefreshExpiredBlocking(gomock.Any()).Return([]int{testUsageThingId}, nil)
+
+	if err := service.ExpireBlocks(context.Background()); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
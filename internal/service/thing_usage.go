@@ -0,0 +1,811 @@
+package service
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/authz"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
+	"sort"
+	"time"
+)
+
+// usageTransitions contains allowed changes of thing usage status
+var usageTransitions = map[string][]string{
+	core.UsageStatusRequested: {core.UsageStatusApproved, core.UsageStatusCancelled},
+	core.UsageStatusApproved:  {core.UsageStatusTaken, core.UsageStatusCancelled},
+	core.UsageStatusTaken:     {core.UsageStatusReturned},
+}
+
+// usageStatusEvents contains webhook events of usage status changes
+var usageStatusEvents = map[string]string{
+	core.UsageStatusApproved:  core.WebhookEventUsageApproved,
+	core.UsageStatusCancelled: core.WebhookEventUsageCancelled,
+	core.UsageStatusTaken:     core.WebhookEventUsageTaken,
+	core.UsageStatusReturned:  core.WebhookEventUsageReturned,
+}
+
+//go:generate mockgen -source=thing_usage.go -destination=mock/thingUsageMock.go
+type thingUsageDBThingUsage interface {
+	AddUsage(ctx context.Context, usage *core.ThingUsageAdd, status string) (*core.ThingUsage, error)
+	GetUsage(ctx context.Context, usageId int) (*core.ThingUsage, error)
+	GetUsagesByUser(ctx context.Context, userId int) ([]core.ThingUsage, error)
+	GetUsagesByThing(ctx context.Context, thingId int) ([]core.ThingUsage, error)
+	GetUsagesByCompany(ctx context.Context, companyId int) ([]core.ThingUsage, error)
+	GetUsagesByDepartment(ctx context.Context, departmentId int) ([]core.ThingUsage, error)
+	GetRequestedUsagesByDepartments(ctx context.Context, departmentIds []int) ([]core.ThingUsage, error)
+	CountOverlappingUsages(ctx context.Context, thingId int, startTime uint32, endTime uint32) (int, error)
+	SetUsageStatus(ctx context.Context, usageId int, currentStatus string, status string) error
+	MarkOverdueUsages(ctx context.Context) ([]core.ThingUsage, error)
+	CancelNotTakenUsages(ctx context.Context, gracePeriod time.Duration) ([]core.ThingUsage, error)
+}
+
+type thingDBThingUsage interface {
+	GetThing(ctx context.Context, thingId int) (*core.Thing, error)
+	GetThingForUpdate(ctx context.Context, thingId int) (*core.Thing, error)
+}
+
+type thingBlockDBThingUsage interface {
+	GetOverlappingBlocks(ctx context.Context, thingId int, startTime uint32, endTime uint32,
+		excludeBlockId int) ([]core.ThingBlock, error)
+}
+
+type departmentDBThingUsage interface {
+	GetDepartment(ctx context.Context, departmentId int) (*core.Department, error)
+	GetDepartmentsByCompany(ctx context.Context, companyId int) ([]core.Department, error)
+}
+
+type vacationDBThingUsage interface {
+	CountOverlappingVacations(ctx context.Context, userId int, startTime uint32, endTime uint32,
+		excludeVacationId int) (int, error)
+	GetUsagesWithApproversAway(ctx context.Context, usageIds []int, at uint32) ([]int, error)
+}
+
+type stockDBThingUsage interface {
+	AddStockMovement(ctx context.Context, movement *core.StockMovement) (*core.StockMovement, error)
+}
+
+type notifierThingUsage interface {
+	Notify(ctx context.Context, notification *core.NotificationAdd) error
+}
+
+type publisherThingUsage interface {
+	Publish(ctx context.Context, companyId int, eventType string, data interface{}) error
+}
+
+type transactionDBThingUsage interface {
+	InjectTx(ctx context.Context) (context.Context, error)
+	CommitTx(ctx context.Context) error
+	RollbackTx(ctx context.Context) error
+	RollbackTxDefer(ctx context.Context)
+}
+
+type ThingUsage struct {
+	thingUsageDB  thingUsageDBThingUsage
+	thingDB       thingDBThingUsage
+	thingBlockDB  thingBlockDBThingUsage
+	departmentDB  departmentDBThingUsage
+	vacationDB    vacationDBThingUsage
+	stockDB       stockDBThingUsage
+	notifier      notifierThingUsage
+	publisher     publisherThingUsage
+	transactionDB transactionDBThingUsage
+}
+
+func NewThingUsage(thingUsageDB thingUsageDBThingUsage, thingDB thingDBThingUsage, thingBlockDB thingBlockDBThingUsage,
+	departmentDB departmentDBThingUsage, vacationDB vacationDBThingUsage, stockDB stockDBThingUsage,
+	notifier notifierThingUsage, publisher publisherThingUsage, transactionDB transactionDBThingUsage) *ThingUsage {
+	return &ThingUsage{
+		thingUsageDB:  thingUsageDB,
+		thingDB:       thingDB,
+		thingBlockDB:  thingBlockDB,
+		departmentDB:  departmentDB,
+		vacationDB:    vacationDB,
+		stockDB:       stockDB,
+		notifier:      notifier,
+		publisher:     publisher,
+		transactionDB: transactionDB,
+	}
+}
+
+// AddUsage books thing for current user. Booking for time when user is on vacation is refused, if force isn't set.
+func (T *ThingUsage) AddUsage(ctx context.Context, usage *core.ThingUsageAdd, force bool) (*core.ThingUsage, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "AddUsage",
+		"usage":    usage,
+		"force":    force,
+		"context":  *core.LogContext(ctx),
+	}
+
+	if !validateActionTime(usage.StartTime, usage.EndTime) {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error(moduleErrors.ErrorServiceInvalidUsageTime.Error())
+		return nil, moduleErrors.ErrorServiceInvalidUsageTime
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	// usage always created for current user
+	usage.UserId = userId
+
+	ctx, err = T.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return nil, err
+	}
+
+	defer T.transactionDB.RollbackTxDefer(ctx)
+
+	// lock thing for serialize bookings of one thing
+	thingData, err := T.thingDB.GetThingForUpdate(ctx, usage.ThingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get thing from database")
+		switch err {
+		case moduleErrors.ErrorDatabaseThingNotFound:
+			return nil, moduleErrors.ErrorServiceThingNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	err = authz.Authorize(ctx, authz.ActionCreate, authz.ThingUsage(thingData.CompanyId, thingData.DepartmentId))
+	if err != nil {
+		return nil, err
+	}
+
+	overlapping, err := T.thingUsageDB.CountOverlappingUsages(ctx, usage.ThingId, usage.StartTime, usage.EndTime)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error count overlapping usages")
+		return nil, err
+	}
+	if overlapping != 0 {
+		logrus.WithFields(logrus.Fields{
+			"base":        logBase,
+			"overlapping": overlapping,
+		}).Error(moduleErrors.ErrorServiceUsageOverlap.Error())
+		return nil, moduleErrors.ErrorServiceUsageOverlap
+	}
+
+	blocks, err := T.thingBlockDB.GetOverlappingBlocks(ctx, usage.ThingId, usage.StartTime, usage.EndTime, 0)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get overlapping blocks")
+		return nil, err
+	}
+	if len(blocks) != 0 {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"blocks": blocks,
+		}).Error(moduleErrors.ErrorServiceThingBlocked.Error())
+		return nil, moduleErrors.ErrorServiceThingBlocked
+	}
+
+	if !force {
+		vacations, err := T.vacationDB.CountOverlappingVacations(ctx, userId, usage.StartTime, usage.EndTime, 0)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Error("error count user vacations in usage time")
+			return nil, err
+		}
+		if vacations != 0 {
+			logrus.WithFields(logrus.Fields{
+				"base":      logBase,
+				"vacations": vacations,
+			}).Warning(moduleErrors.ErrorServiceUserOnVacation.Error())
+			return nil, moduleErrors.ErrorServiceUserOnVacation
+		}
+	}
+
+	status := core.UsageStatusApproved
+	if thingData.NeedAdminApproval {
+		status = core.UsageStatusRequested
+	}
+
+	usageData, err := T.thingUsageDB.AddUsage(ctx, usage, status)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error add usage to database")
+		return nil, err
+	}
+
+	if status == core.UsageStatusRequested {
+		usages := []core.ThingUsage{*usageData}
+		if err = T.markApproversAway(ctx, usages); err != nil {
+			return nil, err
+		}
+		usageData = &usages[0]
+	}
+
+	if err = T.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return nil, err
+	}
+
+	return usageData, nil
+}
+
+func (T *ThingUsage) GetUsage(ctx context.Context, usageId int) (*core.ThingUsage, error) {
+	usageData, thingData, err := T.getUsageWithThing(ctx, usageId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = authz.Authorize(ctx, authz.ActionRead, authz.ThingUsage(thingData.CompanyId, thingData.DepartmentId))
+	if err != nil {
+		return nil, err
+	}
+
+	return usageData, nil
+}
+
+func (T *ThingUsage) GetUsages(ctx context.Context, companyId *int, departmentId *int) ([]core.ThingUsage, error) {
+	logBase := logrus.Fields{
+		"module":       "service",
+		"function":     "GetUsages",
+		"companyId":    companyId,
+		"departmentId": departmentId,
+		"context":      *core.LogContext(ctx),
+	}
+
+	if departmentId != nil {
+		departmentData, err := T.departmentDB.GetDepartment(ctx, *departmentId)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Error("error get department data from db")
+			return nil, err
+		}
+
+		err = authz.Authorize(ctx, authz.ActionRead, authz.ThingUsage(*departmentData.CompanyId, *departmentId))
+		if err != nil {
+			return nil, err
+		}
+
+		usages, err := T.thingUsageDB.GetUsagesByDepartment(ctx, *departmentId)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Error("error get department usages from db")
+			return nil, err
+		}
+		return usages, nil
+	}
+
+	if companyId == nil {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error("company id and department id not set")
+		return nil, moduleErrors.ErrorAllNoFields
+	}
+
+	if err := authz.Authorize(ctx, authz.ActionRead, authz.ThingUsage(*companyId, 0)); err != nil {
+		return nil, err
+	}
+
+	usages, err := T.thingUsageDB.GetUsagesByCompany(ctx, *companyId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get company usages from db")
+		return nil, err
+	}
+
+	return usages, nil
+}
+
+func (T *ThingUsage) GetMyUsages(ctx context.Context) ([]core.ThingUsage, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "GetMyUsages",
+		"context":  *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	usages, err := T.thingUsageDB.GetUsagesByUser(ctx, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user usages from db")
+		return nil, err
+	}
+
+	return usages, nil
+}
+
+func (T *ThingUsage) GetThingUsages(ctx context.Context, thingId int) ([]core.ThingUsage, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "GetThingUsages",
+		"thingId":  thingId,
+		"context":  *core.LogContext(ctx),
+	}
+
+	thingData, err := T.thingDB.GetThing(ctx, thingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get thing from database")
+		switch err {
+		case moduleErrors.ErrorDatabaseThingNotFound:
+			return nil, moduleErrors.ErrorServiceThingNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	err = authz.Authorize(ctx, authz.ActionRead, authz.ThingUsage(thingData.CompanyId, thingData.DepartmentId))
+	if err != nil {
+		return nil, err
+	}
+
+	usages, err := T.thingUsageDB.GetUsagesByThing(ctx, thingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get thing usages from db")
+		return nil, err
+	}
+
+	return usages, nil
+}
+
+// GetUsagesForApprove returns usages which current user can approve. Usages of departments where all admins
+// and maintainers are on vacation are marked and go first, they are routed to company admins, which can approve
+// usages of all company departments.
+func (T *ThingUsage) GetUsagesForApprove(ctx context.Context) ([]core.ThingUsage, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "GetUsagesForApprove",
+		"context":  *core.LogContext(ctx),
+	}
+
+	credentials, err := core.ContextGetUserCredentials(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user credentials from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	// departments where user has approve rights by authz policy:
+	// admins and maintainers of department and admins of department company
+	departmentIds := make([]int, 0)
+	addDepartment := func(departmentId int) {
+		if slices.Index(departmentIds, departmentId) == -1 {
+			departmentIds = append(departmentIds, departmentId)
+		}
+	}
+	for _, credential := range credentials {
+		switch credential.CredentialType {
+		case core.CredentialTypeDepartmentAdmin, core.CredentialTypeDepartmentMaintainer:
+			addDepartment(credential.ObjectId)
+		case core.CredentialTypeCompanyAdmin:
+			departments, err := T.departmentDB.GetDepartmentsByCompany(ctx, credential.ObjectId)
+			if err != nil {
+				logrus.WithFields(logrus.Fields{
+					"base":      logBase,
+					"companyId": credential.ObjectId,
+					"error":     err.Error(),
+				}).Error("error get company departments from db")
+				return nil, err
+			}
+			for _, department := range departments {
+				addDepartment(*department.Id)
+			}
+		}
+	}
+
+	if len(departmentIds) == 0 {
+		return make([]core.ThingUsage, 0), nil
+	}
+
+	usages, err := T.thingUsageDB.GetRequestedUsagesByDepartments(ctx, departmentIds)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":          logBase,
+			"departmentIds": departmentIds,
+			"error":         err.Error(),
+		}).Error("error get requested usages from db")
+		return nil, err
+	}
+
+	if err = T.markApproversAway(ctx, usages); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(usages, func(i, j int) bool {
+		return usages[i].ApproverAway && !usages[j].ApproverAway
+	})
+
+	return usages, nil
+}
+
+// markApproversAway sets ApproverAway for usages whose department approvers are all on vacation now
+func (T *ThingUsage) markApproversAway(ctx context.Context, usages []core.ThingUsage) error {
+	if len(usages) == 0 {
+		return nil
+	}
+
+	usageIds := make([]int, 0, len(usages))
+	for _, usage := range usages {
+		usageIds = append(usageIds, usage.Id)
+	}
+
+	awayUsageIds, err := T.vacationDB.GetUsagesWithApproversAway(ctx, usageIds, uint32(time.Now().Unix()))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"module":   "service",
+			"function": "markApproversAway",
+			"usageIds": usageIds,
+			"error":    err.Error(),
+		}).Error("error get usages with approvers on vacation")
+		return err
+	}
+
+	for i := range usages {
+		usages[i].ApproverAway = slices.Index(awayUsageIds, usages[i].Id) != -1
+	}
+
+	return nil
+}
+
+func (T *ThingUsage) ApproveUsage(ctx context.Context, usageId int) (*core.ThingUsage, error) {
+	return T.changeUsageStatus(ctx, usageId, core.UsageStatusApproved, false, nil)
+}
+
+func (T *ThingUsage) TakeUsage(ctx context.Context, usageId int) (*core.ThingUsage, error) {
+	return T.changeUsageStatus(ctx, usageId, core.UsageStatusTaken, true, nil)
+}
+
+// ReturnUsage returns taken thing, consumed quantity of consumable is written off its stock
+func (T *ThingUsage) ReturnUsage(ctx context.Context, usageId int, usageReturn *core.UsageReturn) (*core.ThingUsage, error) {
+	return T.changeUsageStatus(ctx, usageId, core.UsageStatusReturned, true,
+		func(ctx context.Context, usage *core.ThingUsage) error {
+			return T.recordConsumption(ctx, usage, usageReturn.Consumed)
+		})
+}
+
+func (T *ThingUsage) CancelUsage(ctx context.Context, usageId int) (*core.ThingUsage, error) {
+	return T.changeUsageStatus(ctx, usageId, core.UsageStatusCancelled, true, nil)
+}
+
+// changeUsageStatus moves usage to new status, department admins and maintainers can do any
+// allowed transition, usage owner only if ownerAllowed. onChange is called in transaction before status is changed.
+// Owner is notified when usage is approved or cancelled by other user, change is published to company webhooks.
+func (T *ThingUsage) changeUsageStatus(ctx context.Context, usageId int, status string, ownerAllowed bool,
+	onChange func(ctx context.Context, usage *core.ThingUsage) error) (*core.ThingUsage, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "changeUsageStatus",
+		"usageId":  usageId,
+		"status":   status,
+		"context":  *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	ctx, err = T.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return nil, err
+	}
+
+	defer T.transactionDB.RollbackTxDefer(ctx)
+
+	usageData, thingData, err := T.getUsageWithThing(ctx, usageId)
+	if err != nil {
+		return nil, err
+	}
+
+	isOwner := ownerAllowed && usageData.UserId == userId
+	if !isOwner {
+		err = authz.Authorize(ctx, authz.ActionApprove, authz.ThingUsage(thingData.CompanyId, thingData.DepartmentId))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if slices.Index(usageTransitions[usageData.Status], status) == -1 {
+		logrus.WithFields(logrus.Fields{
+			"base":          logBase,
+			"currentStatus": usageData.Status,
+		}).Error(moduleErrors.ErrorServiceInvalidUsageStatus.Error())
+		return nil, moduleErrors.ErrorServiceInvalidUsageStatus
+	}
+
+	if onChange != nil {
+		if err = onChange(ctx, usageData); err != nil {
+			return nil, err
+		}
+	}
+
+	// status is changed only if it wasn't changed by concurrent request after it was read
+	err = T.thingUsageDB.SetUsageStatus(ctx, usageId, usageData.Status, status)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error set usage status in database")
+		return nil, usageStatusDBError(err)
+	}
+
+	usageData, err = T.thingUsageDB.GetUsage(ctx, usageId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get usage from database")
+		return nil, err
+	}
+
+	if err = T.publisher.Publish(ctx, thingData.CompanyId, usageStatusEvents[status], usageData); err != nil {
+		return nil, err
+	}
+
+	if usageData.UserId != userId &&
+		(status == core.UsageStatusApproved || status == core.UsageStatusCancelled) {
+		notificationType := core.NotificationUsageApproved
+		if status == core.UsageStatusCancelled {
+			notificationType = core.NotificationUsageCancelled
+		}
+		err = notifyUsageOwner(ctx, T.notifier, notificationType, usageData, thingData.Name, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = T.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return nil, err
+	}
+
+	return usageData, nil
+}
+
+// MarkOverdueUsages is background job, it marks taken usages which weren't returned in time and notifies owners
+func (T *ThingUsage) MarkOverdueUsages(ctx context.Context) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "MarkOverdueUsages",
+	}
+
+	usages, err := T.thingUsageDB.MarkOverdueUsages(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error mark overdue usages")
+		return err
+	}
+
+	if err = T.reportUsages(ctx, core.NotificationUsageOverdue, core.WebhookEventUsageOverdue, usages); err != nil {
+		return err
+	}
+
+	if len(usages) != 0 {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"usages": len(usages),
+		}).Info("usages are overdue")
+	}
+
+	return nil
+}
+
+// CancelNotTakenUsages is background job, it cancels approved usages which weren't taken during grace period
+// after start, so booked time is free for other users. Owners of cancelled usages are notified.
+func (T *ThingUsage) CancelNotTakenUsages(ctx context.Context, gracePeriod time.Duration) error {
+	logBase := logrus.Fields{
+		"module":      "service",
+		"function":    "CancelNotTakenUsages",
+		"gracePeriod": gracePeriod,
+	}
+
+	usages, err := T.thingUsageDB.CancelNotTakenUsages(ctx, gracePeriod)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error cancel not taken usages")
+		return err
+	}
+
+	if err = T.reportUsages(ctx, core.NotificationUsageCancelled, core.WebhookEventUsageCancelled, usages); err != nil {
+		return err
+	}
+
+	if len(usages) != 0 {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"usages": len(usages),
+		}).Info("not taken usages are cancelled")
+	}
+
+	return nil
+}
+
+// reportUsages notifies owners of usages changed by background job and publishes changes to company webhooks
+func (T *ThingUsage) reportUsages(ctx context.Context, notificationType string, eventType string,
+	usages []core.ThingUsage) error {
+	logBase := logrus.Fields{
+		"module":           "service",
+		"function":         "reportUsages",
+		"notificationType": notificationType,
+	}
+
+	things := make(map[int]*core.Thing)
+	for i := range usages {
+		thingData, ok := things[usages[i].ThingId]
+		if !ok {
+			var err error
+			thingData, err = T.thingDB.GetThing(ctx, usages[i].ThingId)
+			if err != nil {
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"thingId": usages[i].ThingId,
+					"error":   err.Error(),
+				}).Error("error get thing from database")
+				return err
+			}
+			things[usages[i].ThingId] = thingData
+		}
+
+		if err := notifyUsageOwner(ctx, T.notifier, notificationType, &usages[i], thingData.Name, ""); err != nil {
+			return err
+		}
+		if err := T.publisher.Publish(ctx, thingData.CompanyId, eventType, &usages[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recordConsumption writes off consumed quantity of consumable usage, it must be called in transaction
+func (T *ThingUsage) recordConsumption(ctx context.Context, usage *core.ThingUsage, consumed *float32) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "recordConsumption",
+		"usageId":  usage.Id,
+		"consumed": consumed,
+	}
+
+	// lock thing for serialize changes of remainder
+	thingData, err := T.thingDB.GetThingForUpdate(ctx, usage.ThingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get thing from database")
+		switch err {
+		case moduleErrors.ErrorDatabaseThingNotFound:
+			return moduleErrors.ErrorServiceThingNotFound
+		default:
+			return err
+		}
+	}
+
+	if thingData.Type != core.ThingTypeConsumables {
+		if consumed != nil {
+			return moduleErrors.ErrorServiceThingNotConsumable
+		}
+		return nil
+	}
+
+	if consumed == nil {
+		return moduleErrors.ErrorServiceConsumedRequired
+	}
+	if *consumed < 0 {
+		return moduleErrors.ErrorServiceInvalidQuantity
+	}
+	if *consumed == 0 {
+		return nil
+	}
+
+	_, err = addStockMovement(ctx, T.stockDB, thingData, core.StockMovementConsumption, *consumed, &usage.Id, "")
+	return err
+}
+
+func usageStatusDBError(err error) error {
+	switch err {
+	case moduleErrors.ErrorDatabaseUsageStatusChanged:
+		return moduleErrors.ErrorServiceUsageStatusChanged
+	default:
+		return err
+	}
+}
+
+func (T *ThingUsage) getUsageWithThing(ctx context.Context, usageId int) (*core.ThingUsage, *core.Thing, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "getUsageWithThing",
+		"usageId":  usageId,
+	}
+
+	usageData, err := T.thingUsageDB.GetUsage(ctx, usageId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get usage from database")
+		switch err {
+		case moduleErrors.ErrorDatabaseUsageNotFound:
+			return nil, nil, moduleErrors.ErrorServiceUsageNotFound
+		default:
+			return nil, nil, err
+		}
+	}
+
+	thingData, err := T.thingDB.GetThing(ctx, usageData.ThingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":    logBase,
+			"thingId": usageData.ThingId,
+			"error":   err.Error(),
+		}).Error("error get thing from database")
+		switch err {
+		case moduleErrors.ErrorDatabaseThingNotFound:
+			return nil, nil, moduleErrors.ErrorServiceThingNotFound
+		default:
+			return nil, nil, err
+		}
+	}
+
+	return usageData, thingData, nil
+}
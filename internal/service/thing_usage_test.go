@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	mockService "github.com/Thing-repository/backend-server/internal/service/mock"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/golang/mock/gomock"
+	"testing"
+)
+
+const (
+	testUsageCompanyId    = 1
+	testUsageDepartmentId = 10
+	testUsageThingId      = 100
+	testUsageOwnerId      = 7
+	testUsageMaintainerId = 8
+)
+
+type thingUsageMocks struct {
+	thingUsageDB  *mockService.MockthingUsageDBThingUsage
+	thingDB       *mockService.MockthingDBThingUsage
+	thingBlockDB  *mockService.MockthingBlockDBThingUsage
+	vacationDB    *mockService.MockvacationDBThingUsage
+	notifier      *mockService.MocknotifierThingUsage
+	publisher     *mockService.MockpublisherThingUsage
+	transactionDB *mockService.MocktransactionDBThingUsage
+}
+
+func newTestThingUsage(c *gomock.Controller) (*ThingUsage, *thingUsageMocks) {
+	m := &thingUsageMocks{
+		thingUsageDB:  mockService.NewMockthingUsageDBThingUsage(c),
+		thingDB:       mockService.NewMockthingDBThingUsage(c),
+		thingBlockDB:  mockService.NewMockthingBlockDBThingUsage(c),
+		vacationDB:    mockService.NewMockvacationDBThingUsage(c),
+		notifier:      mockService.NewMocknotifierThingUsage(c),
+		publisher:     mockService.NewMockpublisherThingUsage(c),
+		transactionDB: mockService.NewMocktransactionDBThingUsage(c),
+	}
+
+	m.transactionDB.EXPECT().InjectTx(gomock.Any()).DoAndReturn(
+		func(ctx context.Context) (context.Context, error) { return ctx, nil }).AnyTimes()
+	m.transactionDB.EXPECT().RollbackTxDefer(gomock.Any()).AnyTimes()
+	m.transactionDB.EXPECT().CommitTx(gomock.Any()).Return(nil).AnyTimes()
+
+	return NewThingUsage(m.thingUsageDB, m.thingDB, m.thingBlockDB, nil, m.vacationDB, nil, m.notifier,
+		m.publisher, m.transactionDB), m
+}
+
+func testUsageThing() *core.Thing {
+	return &core.Thing{Id: testUsageThingId, CompanyId: testUsageCompanyId, DepartmentId: testUsageDepartmentId}
+}
+
+func TestChangeUsageStatus(t *testing.T) {
+	statuses := []string{core.UsageStatusRequested, core.UsageStatusApproved, core.UsageStatusTaken,
+		core.UsageStatusReturned, core.UsageStatusCancelled}
+
+	type change func(s *ThingUsage, ctx context.Context) (*core.ThingUsage, error)
+
+	changes := []struct {
+		status string
+		change change
+	}{
+		{core.UsageStatusApproved, func(s *ThingUsage, ctx context.Context) (*core.ThingUsage, error) {
+			return s.ApproveUsage(ctx, 1)
+		}},
+		{core.UsageStatusTaken, func(s *ThingUsage, ctx context.Context) (*core.ThingUsage, error) {
+			return s.TakeUsage(ctx, 1)
+		}},
+		{core.UsageStatusReturned, func(s *ThingUsage, ctx context.Context) (*core.ThingUsage, error) {
+			return s.ReturnUsage(ctx, 1, &core.UsageReturn{})
+		}},
+		{core.UsageStatusCancelled, func(s *ThingUsage, ctx context.Context) (*core.ThingUsage, error) {
+			return s.CancelUsage(ctx, 1)
+		}},
+	}
+
+	allowed := map[string]map[string]bool{
+		core.UsageStatusRequested: {core.UsageStatusApproved: true, core.UsageStatusCancelled: true},
+		core.UsageStatusApproved:  {core.UsageStatusTaken: true, core.UsageStatusCancelled: true},
+		core.UsageStatusTaken:     {core.UsageStatusReturned: true},
+	}
+
+	ctx := core.ContextWithUser(context.Background(), testUsageMaintainerId, []core.Credentials{
+		{CredentialType: core.CredentialTypeDepartmentMaintainer, ObjectId: testUsageDepartmentId},
+	})
+
+	for _, current := range statuses {
+		for _, testCase := range changes {
+			t.Run(current+" to "+testCase.status, func(t *testing.T) {
+				c := gomock.NewController(t)
+				defer c.Finish()
+
+				service, m := newTestThingUsage(c)
+
+				usage := &core.ThingUsage{Id: 1, Status: current}
+				usage.UserId = testUsageOwnerId
+				usage.ThingId = testUsageThingId
+				m.thingUsageDB.EXPECT().GetUsage(gomock.Any(), 1).Return(usage, nil)
+				m.thingDB.EXPECT().GetThing(gomock.Any(), testUsageThingId).Return(testUsageThing(), nil)
+
+				wantAllowed := allowed[current][testCase.status]
+				if wantAllowed {
+					changed := *usage
+					changed.Status = testCase.status
+					if testCase.status == core.UsageStatusReturned {
+						m.thingDB.EXPECT().GetThingForUpdate(gomock.Any(), testUsageThingId).
+							Return(testUsageThing(), nil)
+					}
+					m.thingUsageDB.EXPECT().SetUsageStatus(gomock.Any(), 1, current, testCase.status).Return(nil)
+					m.thingUsageDB.EXPECT().GetUsage(gomock.Any(), 1).Return(&changed, nil)
+					m.publisher.EXPECT().Publish(gomock.Any(), testUsageCompanyId, usageStatusEvents[testCase.status],
+						&changed).Return(nil)
+					if testCase.status == core.UsageStatusApproved || testCase.status == core.UsageStatusCancelled {
+						m.notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(nil)
+					}
+				}
+
+				usageData, err := testCase.change(service, ctx)
+				if !wantAllowed {
+					if err != moduleErrors.ErrorServiceInvalidUsageStatus {
+						t.Fatalf("error = %v, want %v", err, moduleErrors.ErrorServiceInvalidUsageStatus)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if usageData.Status != testCase.status {
+					t.Errorf("status = %s, want %s", usageData.Status, testCase.status)
+				}
+			})
+		}
+	}
+}
+
+func TestChangeUsageStatusConcurrent(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	service, m := newTestThingUsage(c)
+
+	ctx := core.ContextWithUser(context.Background(), testUsageMaintainerId, []core.Credentials{
+		{CredentialType: core.CredentialTypeDepartmentMaintainer, ObjectId: testUsageDepartmentId},
+	})
+
+	usage := &core.ThingUsage{Id: 1, Status: core.UsageStatusRequested}
+	usage.ThingId = testUsageThingId
+	m.thingUsageDB.EXPECT().GetUsage(gomock.Any(), 1).Return(usage, nil)
+	m.thingDB.EXPECT().GetThing(gomock.Any(), testUsageThingId).Return(testUsageThing(), nil)
+	// usage was cancelled after it was read
+	m.thingUsageDB.EXPECT().SetUsageStatus(gomock.Any(), 1, core.UsageStatusRequested, core.UsageStatusApproved).
+		Return(moduleErrors.ErrorDatabaseUsageStatusChanged)
+
+	if _, err := service.ApproveUsage(ctx, 1); err != moduleErrors.ErrorServiceUsageStatusChanged {
+		t.Errorf("error = %v, want %v", err, moduleErrors.ErrorServiceUsageStatusChanged)
+	}
+}
+
+func TestAddUsageOverlap(t *testing.T) {
+	type mockBehavior func(m *thingUsageMocks, usage *core.ThingUsageAdd)
+
+	testTable := []struct {
+		name         string
+		force        bool
+		mockBehavior mockBehavior
+		wantError    error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(m *thingUsageMocks, usage *core.ThingUsageAdd) {
+				m.thingUsageDB.EXPECT().CountOverlappingUsages(gomock.Any(), testUsageThingId, usage.StartTime,
+					usage.EndTime).Return(0, nil)
+				m.thingBlockDB.EXPECT().GetOverlappingBlocks(gomock.Any(), testUsageThingId, usage.StartTime,
+					usage.EndTime, 0).Return(nil, nil)
+				m.vacationDB.EXPECT().CountOverlappingVacations(gomock.Any(), testUsageOwnerId, usage.StartTime,
+					usage.EndTime, 0).Return(0, nil)
+				m.thingUsageDB.EXPECT().AddUsage(gomock.Any(), usage, core.UsageStatusApproved).
+					Return(&core.ThingUsage{ThingUsageAdd: *usage, Id: 1, Status: core.UsageStatusApproved}, nil)
+			},
+		},
+		{
+			name: "Booked",
+			mockBehavior: func(m *thingUsageMocks, usage *core.ThingUsageAdd) {
+				m.thingUsageDB.EXPECT().CountOverlappingUsages(gomock.Any(), testUsageThingId, usage.StartTime,
+					usage.EndTime).Return(1, nil)
+			},
+			wantError: moduleErrors.ErrorServiceUsageOverlap,
+		},
+		{
+			name: "Blocked",
+			mockBehavior: func(m *thingUsageMocks, usage *core.ThingUsageAdd) {
+				m.thingUsageDB.EXPECT().CountOverlappingUsages(gomock.Any(), testUsageThingId, usage.StartTime,
+					usage.EndTime).Return(0, nil)
+				m.thingBlockDB.EXPECT().GetOverlappingBlocks(gomock.Any(), testUsageThingId, usage.StartTime,
+					usage.EndTime, 0).Return([]core.ThingBlock{{Id: 3}}, nil)
+			},
+			wantError: moduleErrors.ErrorServiceThingBlocked,
+		},
+		{
+			name: "On vacation",
+			mockBehavior: func(m *thingUsageMocks, usage *core.ThingUsageAdd) {
+				m.thingUsageDB.EXPECT().CountOverlappingUsages(gomock.Any(), testUsageThingId, usage.StartTime,
+					usage.EndTime).Return(0, nil)
+				m.thingBlockDB.EXPECT().GetOverlappingBlocks(gomock.Any(), testUsageThingId, usage.StartTime,
+					usage.EndTime, 0).Return(nil, nil)
+				m.vacationDB.EXPECT().CountOverlappingVacations(gomock.Any(), testUsageOwnerId, usage.StartTime,
+					usage.EndTime, 0).Return(1, nil)
+			},
+			wantError: moduleErrors.ErrorServiceUserOnVacation,
+		},
+		{
+			// user confirmed booking during own vacation
+			name:  "On vacation with force",
+			force: true,
+			mockBehavior: func(m *thingUsageMocks, usage *core.ThingUsageAdd) {
+				m.thingUsageDB.EXPECT().CountOverlappingUsages(gomock.Any(), testUsageThingId, usage.StartTime,
+					usage.EndTime).Return(0, nil)
+				m.thingBlockDB.EXPECT().GetOverlappingBlocks(gomock.Any(), testUsageThingId, usage.StartTime,
+					usage.EndTime, 0).Return(nil, nil)
+				m.thingUsageDB.EXPECT().AddUsage(gomock.Any(), usage, core.UsageStatusApproved).
+					Return(&core.ThingUsage{ThingUsageAdd: *usage, Id: 1, Status: core.UsageStatusApproved}, nil)
+			},
+		},
+	}
+
+	ctx := core.ContextWithUser(context.Background(), testUsageOwnerId, []core.Credentials{
+		{CredentialType: core.CredentialTypeCompanyUser, ObjectId: testUsageCompanyId},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service, m := newTestThingUsage(c)
+
+			usage := &core.ThingUsageAdd{ThingActionBase: core.ThingActionBase{ThingId: testUsageThingId,
+				StartTime: 1700000000, EndTime: 1700003600}}
+			m.thingDB.EXPECT().GetThingForUpdate(gomock.Any(), testUsageThingId).Return(testUsageThing(), nil)
+			testCase.mockBehavior(m, usage)
+
+			_, err := service.AddUsage(ctx, usage, testCase.force)
+			if err != testCase.wantError {
+				t.Errorf("error = %v, want %v", err, testCase.wantError)
+			}
+		})
+	}
+}
+
+func TestGetUsagesForApprove(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	service, m := newTestThingUsage(c)
+
+	usages := []core.ThingUsage{{Id: 1}, {Id: 2}, {Id: 3}}
+	m.thingUsageDB.EXPECT().GetRequestedUsagesByDepartments(gomock.Any(), []int{testUsageDepartmentId}).
+		Return(usages, nil)
+	// all approvers of department of usage 2 are on vacation
+	m.vacationDB.EXPECT().GetUsagesWithApproversAway(gomock.Any(), []int{1, 2, 3}, gomock.Any()).
+		Return([]int{2}, nil)
+
+	ctx := core.ContextWithUser(context.Background(), testUsageMaintainerId, []core.Credentials{
+		{CredentialType: core.CredentialTypeDepartmentMaintainer, ObjectId: testUsageDepartmentId},
+	})
+
+	usagesData, err := service.GetUsagesForApprove(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	wantIds := []int{2, 1, 3}
+	if len(usagesData) != len(wantIds) {
+		t.Fatalf("usages count = %d, want %d", len(usagesData), len(wantIds))
+	}
+	for i, usage := range usagesData {
+		if usage.Id != wantIds[i] || usage.ApproverAway != (usage.Id == 2) {
+			t.Errorf("usage %d = {Id: %d, ApproverAway: %t}, want id %d", i, usage.Id, usage.ApproverAway,
+				wantIds[i])
+		}
+	}
+}
@@ -2,15 +2,24 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/Thing-repository/backend-server/pkg/authz"
 	"github.com/Thing-repository/backend-server/pkg/core"
 	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
 )
 
+//go:generate mockgen -source=user.go -destination=mock/userMock.go
 type userDBUser interface {
 	PathUser(ctx context.Context, user *core.UserDB, userId int) error
 	GetUser(ctx context.Context, userId int) (*core.UserDB, error)
+	RemoveUserCompany(ctx context.Context, userId int) error
 	GetUsersFilter(ctx context.Context, filter string, limit int, offset int) ([]core.User, error)
+	GetCompanyUsers(ctx context.Context, companyId int, filter *core.UserDirectoryFilter,
+		cursor *core.UserDirectoryCursor, limit int) ([]core.UserDirectoryEntry, error)
+	CountCompanyUsers(ctx context.Context, companyId int, filter *core.UserDirectoryFilter) (int, error)
 }
 
 type departmentDBUser interface {
@@ -19,6 +28,41 @@ type departmentDBUser interface {
 
 type credentialsDBUser interface {
 	CreateCredential(ctx context.Context, credentials *core.AddCredentials) (int, error)
+	EnsureCredential(ctx context.Context, credentials *core.AddCredentials) error
+	GetUserCredential(ctx context.Context, userId int) ([]core.Credentials, error)
+	CountCredentialsForUpdate(ctx context.Context, credentialType string, objectId int) (int, error)
+	DeleteUserCompanyCredentials(ctx context.Context, userId int, companyId int) error
+	DeleteUserDepartmentCredentials(ctx context.Context, userId int, departmentId int) error
+}
+
+type userDBMember interface {
+	PathUser(ctx context.Context, user *core.UserDB, userId int) error
+}
+
+type credentialsDBMember interface {
+	CreateCredential(ctx context.Context, credentials *core.AddCredentials) (int, error)
+}
+
+type publisherMember interface {
+	Publish(ctx context.Context, companyId int, eventType string, data interface{}) error
+}
+
+type credentialsDBAdminCheck interface {
+	GetUserCredential(ctx context.Context, userId int) ([]core.Credentials, error)
+	CountCredentialsForUpdate(ctx context.Context, credentialType string, objectId int) (int, error)
+}
+
+type thingUsageDBTaken interface {
+	CountUserTakenUsages(ctx context.Context, userId int, companyId int, departmentId int) (int, error)
+}
+
+type thingUsageDBUser interface {
+	CountUserTakenUsages(ctx context.Context, userId int, companyId int, departmentId int) (int, error)
+	CancelUserUsages(ctx context.Context, userId int, companyId int) error
+}
+
+type publisherUser interface {
+	Publish(ctx context.Context, companyId int, eventType string, data interface{}) error
 }
 
 type transactionDBUser interface {
@@ -32,14 +76,19 @@ type User struct {
 	userDB        userDBUser
 	departmentDB  departmentDBUser
 	credentialsDB credentialsDBUser
+	thingUsageDB  thingUsageDBUser
+	publisher     publisherUser
 	transactionDB transactionDBUser
 }
 
-func NewUser(userDB userDBUser, departmentDB departmentDBUser, credentialsDB credentialsDBUser, transactionDB transactionDBUser) *User {
+func NewUser(userDB userDBUser, departmentDB departmentDBUser, credentialsDB credentialsDBUser,
+	thingUsageDB thingUsageDBUser, publisher publisherUser, transactionDB transactionDBUser) *User {
 	return &User{
 		userDB:        userDB,
 		departmentDB:  departmentDB,
 		credentialsDB: credentialsDB,
+		thingUsageDB:  thingUsageDB,
+		publisher:     publisher,
 		transactionDB: transactionDB,
 	}
 }
@@ -66,6 +115,145 @@ func (U *User) FindUsersForInvite(ctx context.Context, filter string, limit int,
 	return users, nil
 }
 
+const (
+	userDirectoryDefaultLimit = 20
+	userDirectoryMaxLimit     = 100
+)
+
+// GetCompanyUsers returns page of company users directory, it is available to all company users
+func (U *User) GetCompanyUsers(ctx context.Context, filter *core.UserDirectoryFilter) (*core.UserDirectoryPage, error) {
+	logBase := logrus.Fields{
+		"module":   "user",
+		"function": "GetCompanyUsers",
+		"filter":   filter,
+		"context":  *core.LogContext(ctx),
+	}
+
+	if filter.Sort == "" {
+		filter.Sort = core.UserSortName
+	}
+	if slices.Index([]string{core.UserSortName, core.UserSortEmail, core.UserSortId}, filter.Sort) == -1 {
+		return nil, moduleErrors.ErrorServiceInvalidSort
+	}
+	if filter.Role != nil && slices.Index(core.AllCredentialTypes, *filter.Role) == -1 {
+		return nil, moduleErrors.ErrorServiceInvalidCredentialType
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = userDirectoryDefaultLimit
+	}
+	if filter.Limit > userDirectoryMaxLimit {
+		filter.Limit = userDirectoryMaxLimit
+	}
+
+	var cursor *core.UserDirectoryCursor
+	if filter.Cursor != "" {
+		var err error
+		if cursor, err = decodeUserCursor(filter.Cursor); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Warning("error decode cursor")
+			return nil, moduleErrors.ErrorServiceInvalidCursor
+		}
+		if cursor.Sort != filter.Sort || cursor.Descending != filter.Descending {
+			return nil, moduleErrors.ErrorServiceInvalidCursor
+		}
+	}
+
+	companyId, err := U.directoryCompany(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = authz.Authorize(ctx, authz.ActionRead, authz.Company(companyId)); err != nil {
+		return nil, err
+	}
+
+	// one more user is loaded to find out that next page exists
+	users, err := U.userDB.GetCompanyUsers(ctx, companyId, filter, cursor, filter.Limit+1)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get company users from db")
+		return nil, err
+	}
+
+	total, err := U.userDB.CountCompanyUsers(ctx, companyId, filter)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error count company users in db")
+		return nil, err
+	}
+
+	page := &core.UserDirectoryPage{Users: users, Total: total}
+	if len(users) > filter.Limit {
+		page.Users = users[:filter.Limit]
+		last := page.Users[filter.Limit-1]
+		page.NextCursor = encodeUserCursor(&core.UserDirectoryCursor{
+			Sort:       filter.Sort,
+			Descending: filter.Descending,
+			SortKey:    last.SortKey,
+			Id:         last.Id,
+		})
+	}
+
+	return page, nil
+}
+
+// directoryCompany returns company of directory: from filter, from filter department or company of current user
+func (U *User) directoryCompany(ctx context.Context, filter *core.UserDirectoryFilter) (int, error) {
+	if filter.CompanyId != nil {
+		return *filter.CompanyId, nil
+	}
+
+	if filter.DepartmentId != nil {
+		departmentData, err := U.departmentDB.GetDepartment(ctx, *filter.DepartmentId)
+		if err != nil {
+			return 0, departmentDBError(err)
+		}
+		return *departmentData.CompanyId, nil
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		return 0, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	userData, err := U.getUser(ctx, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	if userData.CompanyId == nil {
+		return 0, moduleErrors.ErrorServiceUserNotInCompany
+	}
+
+	return *userData.CompanyId, nil
+}
+
+// encodeUserCursor returns opaque cursor for client
+func encodeUserCursor(cursor *core.UserDirectoryCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeUserCursor(cursor string) (*core.UserDirectoryCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret core.UserDirectoryCursor
+	if err = json.Unmarshal(data, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
 func (U *User) AddUserToCompany(ctx context.Context, userId int, departmentId int) error {
 	logBase := logrus.Fields{
 		"module":       "user",
@@ -85,15 +273,6 @@ func (U *User) AddUserToCompany(ctx context.Context, userId int, departmentId in
 
 	defer U.transactionDB.RollbackTxDefer(ctx)
 
-	credentials, err := core.ContextGetUserCredentials(ctx)
-	if err != nil {
-		logrus.WithFields(logrus.Fields{
-			"base":  logBase,
-			"error": err.Error(),
-		}).Error("error get user credentials from context")
-		return moduleErrors.ErrorServiceInvalidContext
-	}
-
 	departmentData, err := U.departmentDB.GetDepartment(ctx, departmentId)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
@@ -104,17 +283,10 @@ func (U *User) AddUserToCompany(ctx context.Context, userId int, departmentId in
 		return err
 	}
 
-	// TODO: add check rights for service admin, load and check permission form context
-	isCompanyAdmin := core.CheckCredential(credentials, core.CredentialTypeCompanyAdmin, *departmentData.CompanyId)
-	isDepartmentAdmin := core.CheckCredential(credentials, core.CredentialTypeDepartmentAdmin, departmentId)
-
-	if !isCompanyAdmin || !isDepartmentAdmin {
-		logrus.WithFields(logrus.Fields{
-			"base":         logBase,
-			"companyId":    departmentData.CompanyId,
-			"departmentId": departmentId,
-		}).Error("user has not credentials")
-		return moduleErrors.ErrorServiceBadPermissions
+	// direct add is admin override of invitations
+	err = authz.Authorize(ctx, authz.ActionAddMember, authz.Company(*departmentData.CompanyId))
+	if err != nil {
+		return err
 	}
 
 	userData, err := U.userDB.GetUser(ctx, userId)
@@ -134,52 +306,411 @@ func (U *User) AddUserToCompany(ctx context.Context, userId int, departmentId in
 		return moduleErrors.ErrorServiceUserAlreadyHasCompany
 	}
 
+	if err = addMember(ctx, U.userDB, U.credentialsDB, U.publisher, userId, departmentData); err != nil {
+		return err
+	}
+
+	if err = U.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// RemoveUserFromCompany removes user from company of user by company admin
+func (U *User) RemoveUserFromCompany(ctx context.Context, userId int) error {
+	logBase := logrus.Fields{
+		"module":   "user",
+		"function": "RemoveUserFromCompany",
+		"userId":   userId,
+		"context":  *core.LogContext(ctx),
+	}
+
+	ctx, err := U.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return err
+	}
+
+	defer U.transactionDB.RollbackTxDefer(ctx)
+
+	userData, err := U.getUser(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	if userData.CompanyId == nil {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error(moduleErrors.ErrorServiceUserNotInCompany.Error())
+		return moduleErrors.ErrorServiceUserNotInCompany
+	}
+
+	if err = authz.Authorize(ctx, authz.ActionRemoveMember, authz.Company(*userData.CompanyId)); err != nil {
+		return err
+	}
+
+	if err = U.removeFromCompany(ctx, userId, *userData.CompanyId); err != nil {
+		return err
+	}
+
+	if err = U.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// LeaveCompany removes user from context from own company
+func (U *User) LeaveCompany(ctx context.Context) error {
+	logBase := logrus.Fields{
+		"module":   "user",
+		"function": "LeaveCompany",
+		"context":  *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return moduleErrors.ErrorServiceInvalidContext
+	}
+
+	ctx, err = U.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return err
+	}
+
+	defer U.transactionDB.RollbackTxDefer(ctx)
+
+	userData, err := U.getUser(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	if userData.CompanyId == nil {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error(moduleErrors.ErrorServiceUserNotInCompany.Error())
+		return moduleErrors.ErrorServiceUserNotInCompany
+	}
+
+	if err = U.removeFromCompany(ctx, userId, *userData.CompanyId); err != nil {
+		return err
+	}
+
+	if err = U.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// MoveUserToDepartment moves user to other department of the same company. Credentials of user in old department
+// are deleted, things of old department taken by user must be returned first.
+func (U *User) MoveUserToDepartment(ctx context.Context, userId int, departmentId int) error {
+	logBase := logrus.Fields{
+		"module":       "user",
+		"function":     "MoveUserToDepartment",
+		"userId":       userId,
+		"departmentId": departmentId,
+		"context":      *core.LogContext(ctx),
+	}
+
+	ctx, err := U.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return err
+	}
+
+	defer U.transactionDB.RollbackTxDefer(ctx)
+
+	departmentData, err := U.departmentDB.GetDepartment(ctx, departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get department data from db")
+		return departmentDBError(err)
+	}
+
+	userData, err := U.getUser(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	if userData.CompanyId == nil || *userData.CompanyId != *departmentData.CompanyId {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error(moduleErrors.ErrorServiceUserNotInCompany.Error())
+		return moduleErrors.ErrorServiceUserNotInCompany
+	}
+
+	if userData.DepartmentId == nil {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error(moduleErrors.ErrorServiceUserHasNotDepartment.Error())
+		return moduleErrors.ErrorServiceUserHasNotDepartment
+	}
+
+	oldDepartmentId := *userData.DepartmentId
+	if oldDepartmentId == departmentId {
+		return moduleErrors.ErrorServiceInvalidTargetDepartment
+	}
+
+	err = authz.Authorize(ctx, authz.ActionRemoveMember, authz.Department(*userData.CompanyId, oldDepartmentId))
+	if err != nil {
+		return err
+	}
+
+	err = authz.Authorize(ctx, authz.ActionAddMember, authz.Department(*userData.CompanyId, departmentId))
+	if err != nil {
+		return err
+	}
+
+	if err = checkTakenThings(ctx, U.thingUsageDB, userId, *userData.CompanyId, oldDepartmentId); err != nil {
+		return err
+	}
+
+	err = U.credentialsDB.DeleteUserDepartmentCredentials(ctx, userId, oldDepartmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error delete credentials in old department")
+		return err
+	}
+
+	// user can already have credential in target department, for example maintainer
+	err = U.credentialsDB.EnsureCredential(ctx, newCredential(departmentId, userId, core.CredentialTypeDepartmentUser))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error add department user to database")
+		return err
+	}
+
+	err = U.userDB.PathUser(ctx, &core.UserDB{User: core.User{DepartmentId: &departmentId}}, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error update user data in db")
+		return err
+	}
+
+	if err = U.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// removeFromCompany deletes credentials of user in company and cancels not taken usages of user.
+// The last company admin can't be removed, taken things must be returned first.
+func (U *User) removeFromCompany(ctx context.Context, userId int, companyId int) error {
+	logBase := logrus.Fields{
+		"module":    "user",
+		"function":  "removeFromCompany",
+		"userId":    userId,
+		"companyId": companyId,
+	}
+
+	err := checkLastCompanyAdmin(ctx, U.credentialsDB, userId, companyId)
+	if err != nil {
+		return err
+	}
+
+	if err = checkTakenThings(ctx, U.thingUsageDB, userId, companyId, 0); err != nil {
+		return err
+	}
+
+	if err = U.thingUsageDB.CancelUserUsages(ctx, userId, companyId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error cancel user usages")
+		return err
+	}
+
+	if err = U.credentialsDB.DeleteUserCompanyCredentials(ctx, userId, companyId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error delete user credentials")
+		return err
+	}
+
+	if err = U.userDB.RemoveUserCompany(ctx, userId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error remove user company")
+		return err
+	}
+
+	return nil
+}
+
+// checkLastCompanyAdmin returns error if user is the only admin of company
+func checkLastCompanyAdmin(ctx context.Context, credentialsDB credentialsDBAdminCheck, userId int, companyId int) error {
+	logBase := logrus.Fields{
+		"module":    "user",
+		"function":  "checkLastCompanyAdmin",
+		"userId":    userId,
+		"companyId": companyId,
+	}
+
+	credentials, err := credentialsDB.GetUserCredential(ctx, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user credentials")
+		return err
+	}
+
+	if !core.CheckCredential(credentials, core.CredentialTypeCompanyAdmin, companyId) {
+		return nil
+	}
+
+	// credentials are locked, so concurrent removals can't remove all company admins
+	count, err := credentialsDB.CountCredentialsForUpdate(ctx, core.CredentialTypeCompanyAdmin, companyId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error count company admins")
+		return err
+	}
+	if count <= 1 {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error(moduleErrors.ErrorServiceLastCompanyAdmin.Error())
+		return moduleErrors.ErrorServiceLastCompanyAdmin
+	}
+
+	return nil
+}
+
+// checkTakenThings returns error if user has taken things of company or department
+func checkTakenThings(ctx context.Context, thingUsageDB thingUsageDBTaken, userId int, companyId int, departmentId int) error {
+	count, err := thingUsageDB.CountUserTakenUsages(ctx, userId, companyId, departmentId)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		logrus.WithFields(logrus.Fields{
+			"module":       "user",
+			"function":     "checkTakenThings",
+			"userId":       userId,
+			"companyId":    companyId,
+			"departmentId": departmentId,
+			"count":        count,
+		}).Error(moduleErrors.ErrorServiceUserHasTakenThings.Error())
+		return moduleErrors.ErrorServiceUserHasTakenThings
+	}
+
+	return nil
+}
+
+func (U *User) getUser(ctx context.Context, userId int) (*core.UserDB, error) {
+	userData, err := U.userDB.GetUser(ctx, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"module":   "user",
+			"function": "getUser",
+			"userId":   userId,
+			"error":    err.Error(),
+		}).Error("error get user data from db")
+		if err == moduleErrors.ErrorDatabaseUserNotFound {
+			return nil, moduleErrors.ErrorServiceUserNotFound
+		}
+		return nil, err
+	}
+
+	return userData, nil
+}
+
+// addMember attaches user without company to department and grants department and company user credentials to user,
+// user.joined event is published to webhooks of company
+func addMember(ctx context.Context, userDB userDBMember, credentialsDB credentialsDBMember, publisher publisherMember,
+	userId int, departmentData *core.Department) error {
+	logBase := logrus.Fields{
+		"module":       "user",
+		"function":     "addMember",
+		"userId":       userId,
+		"departmentId": *departmentData.Id,
+	}
+
 	newUserData := core.UserDB{
 		User: core.User{
 			CompanyId:    departmentData.CompanyId,
-			DepartmentId: &departmentId,
+			DepartmentId: departmentData.Id,
 		},
 	}
-	err = U.userDB.PathUser(ctx, &newUserData, userId)
+	err := userDB.PathUser(ctx, &newUserData, userId)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"base":        logBase,
-			"userId":      userId,
 			"newUserData": newUserData,
 			"error":       err.Error(),
 		}).Error("error update user data in db")
 		return err
 	}
 
-	_, err = U.credentialsDB.CreateCredential(ctx, newCredential(departmentId, userId, core.CredentialTypeDepartmentUser))
+	_, err = credentialsDB.CreateCredential(ctx, newCredential(*departmentData.Id, userId, core.CredentialTypeDepartmentUser))
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
-			"base":         logBase,
-			"departmentId": departmentId,
-			"userId":       userId,
-			"error":        err.Error(),
-		}).Error("error add department admin to database")
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error add department user to database")
 		return err
 	}
 
-	_, err = U.credentialsDB.CreateCredential(ctx, newCredential(*departmentData.CompanyId, userId, core.CredentialTypeCompanyUser))
+	_, err = credentialsDB.CreateCredential(ctx, newCredential(*departmentData.CompanyId, userId, core.CredentialTypeCompanyUser))
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"base":      logBase,
-			"companyId": departmentData.CompanyId,
-			"userId":    userId,
+			"companyId": *departmentData.CompanyId,
 			"error":     err.Error(),
-		}).Error("error add department admin to database")
+		}).Error("error add company user to database")
 		return err
 	}
 
-	if err = U.transactionDB.CommitTx(ctx); err != nil {
-		logrus.WithFields(logrus.Fields{
-			"base":  logBase,
-			"error": err.Error(),
-		}).Error("error commit transaction")
-		return err
-	}
-
-	return nil
+	return publisher.Publish(ctx, *departmentData.CompanyId, core.WebhookEventUserJoined, &core.WebhookUserJoined{
+		UserId:       userId,
+		CompanyId:    *departmentData.CompanyId,
+		DepartmentId: *departmentData.Id,
+	})
 }
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	mockService "github.com/Thing-repository/backend-server/internal/service/mock"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/golang/mock/gomock"
+	"github.com/openlyinc/pointy"
+	"testing"
+)
+
+const (
+	testUserCompanyId    = 1
+	testUserDepartmentId = 10
+	testUserId           = 3
+	testUserTargetId     = 11
+)
+
+type userMocks struct {
+	userDB        *mockService.MockuserDBUser
+	departmentDB  *mockService.MockdepartmentDBUser
+	credentialsDB *mockService.MockcredentialsDBUser
+	thingUsageDB  *mockService.MockthingUsageDBUser
+	publisher     *mockService.MockpublisherUser
+	transactionDB *mockService.MocktransactionDBUser
+}
+
+func newTestUser(c *gomock.Controller) (*User, *userMocks) {
+	m := &userMocks{
+		userDB:        mockService.NewMockuserDBUser(c),
+		departmentDB:  mockService.NewMockdepartmentDBUser(c),
+		credentialsDB: mockService.NewMockcredentialsDBUser(c),
+		thingUsageDB:  mockService.NewMockthingUsageDBUser(c),
+		publisher:     mockService.NewMockpublisherUser(c),
+		transactionDB: mockService.NewMocktransactionDBUser(c),
+	}
+
+	m.transactionDB.EXPECT().InjectTx(gomock.Any()).DoAndReturn(
+		func(ctx context.Context) (context.Context, error) { return ctx, nil }).AnyTimes()
+	m.transactionDB.EXPECT().RollbackTxDefer(gomock.Any()).AnyTimes()
+	m.transactionDB.EXPECT().CommitTx(gomock.Any()).Return(nil).AnyTimes()
+
+	return NewUser(m.userDB, m.departmentDB, m.credentialsDB, m.thingUsageDB, m.publisher, m.transactionDB), m
+}
+
+// testDirectoryUsers returns count users ordered by id, sort keys are "b", "c" and so on
+func testDirectoryUsers(count int) []core.UserDirectoryEntry {
+	users := make([]core.UserDirectoryEntry, 0, count)
+	for i := 1; i <= count; i++ {
+		users = append(users, core.UserDirectoryEntry{User: core.User{Id: i}, SortKey: string(rune('a' + i))})
+	}
+	return users
+}
+
+func TestUserCursor(t *testing.T) {
+	cursor := core.UserDirectoryCursor{Sort: core.UserSortName, Descending: true, SortKey: "doe john", Id: 42}
+
+	decoded, err := decodeUserCursor(encodeUserCursor(&cursor))
+	if err != nil {
+		t.Fatalf("error decode cursor: %s", err)
+	}
+	if *decoded != cursor {
+		t.Errorf("decoded cursor = %+v, want %+v", *decoded, cursor)
+	}
+
+	for _, invalid := range []string{"not base64!", "bm90IGpzb24"} {
+		if _, err := decodeUserCursor(invalid); err == nil {
+			t.Errorf("cursor %q decoded without error", invalid)
+		}
+	}
+}
+
+func TestGetCompanyUsers(t *testing.T) {
+	type mockBehavior func(m *userMocks, filter *core.UserDirectoryFilter)
+
+	testTable := []struct {
+		name           string
+		filter         core.UserDirectoryFilter
+		mockBehavior   mockBehavior
+		wantLimit      int
+		wantUsers      int
+		wantTotal      int
+		wantNextCursor *core.UserDirectoryCursor
+		wantError      error
+	}{
+		{
+			name:   "Default filter",
+			filter: core.UserDirectoryFilter{CompanyId: pointy.Int(testUserCompanyId)},
+			mockBehavior: func(m *userMocks, filter *core.UserDirectoryFilter) {
+				m.userDB.EXPECT().GetCompanyUsers(gomock.Any(), testUserCompanyId, filter, nil,
+					userDirectoryDefaultLimit+1).Return(testDirectoryUsers(5), nil)
+				m.userDB.EXPECT().CountCompanyUsers(gomock.Any(), testUserCompanyId, filter).Return(5, nil)
+			},
+			wantLimit: userDirectoryDefaultLimit,
+			wantUsers: 5,
+			wantTotal: 5,
+		},
+		{
+			name:   "Limit is clamped",
+			filter: core.UserDirectoryFilter{CompanyId: pointy.Int(testUserCompanyId), Limit: 1000},
+			mockBehavior: func(m *userMocks, filter *core.UserDirectoryFilter) {
+				m.userDB.EXPECT().GetCompanyUsers(gomock.Any(), testUserCompanyId, filter, nil,
+					userDirectoryMaxLimit+1).Return(testDirectoryUsers(5), nil)
+				m.userDB.EXPECT().CountCompanyUsers(gomock.Any(), testUserCompanyId, filter).Return(5, nil)
+			},
+			wantLimit: userDirectoryMaxLimit,
+			wantUsers: 5,
+			wantTotal: 5,
+		},
+		{
+			// page is full only if user after it is loaded
+			name:   "Full page",
+			filter: core.UserDirectoryFilter{CompanyId: pointy.Int(testUserCompanyId), Limit: 2},
+			mockBehavior: func(m *userMocks, filter *core.UserDirectoryFilter) {
+				m.userDB.EXPECT().GetCompanyUsers(gomock.Any(), testUserCompanyId, filter, nil, 3).
+					Return(testDirectoryUsers(3), nil)
+				m.userDB.EXPECT().CountCompanyUsers(gomock.Any(), testUserCompanyId, filter).Return(7, nil)
+			},
+			wantLimit:      2,
+			wantUsers:      2,
+			wantTotal:      7,
+			wantNextCursor: &core.UserDirectoryCursor{Sort: core.UserSortName, SortKey: "c", Id: 2},
+		},
+		{
+			name:   "Exactly page",
+			filter: core.UserDirectoryFilter{CompanyId: pointy.Int(testUserCompanyId), Limit: 2},
+			mockBehavior: func(m *userMocks, filter *core.UserDirectoryFilter) {
+				m.userDB.EXPECT().GetCompanyUsers(gomock.Any(), testUserCompanyId, filter, nil, 3).
+					Return(testDirectoryUsers(2), nil)
+				m.userDB.EXPECT().CountCompanyUsers(gomock.Any(), testUserCompanyId, filter).Return(2, nil)
+			},
+			wantLimit: 2,
+			wantUsers: 2,
+			wantTotal: 2,
+		},
+		{
+			// company is taken from department of filter
+			name: "Department and role filter",
+			filter: core.UserDirectoryFilter{
+				DepartmentId: pointy.Int(testUserDepartmentId),
+				Role:         pointy.String(core.CredentialTypeDepartmentMaintainer),
+				NamePrefix:   "jo",
+				Sort:         core.UserSortEmail,
+			},
+			mockBehavior: func(m *userMocks, filter *core.UserDirectoryFilter) {
+				m.departmentDB.EXPECT().GetDepartment(gomock.Any(), testUserDepartmentId).Return(&core.Department{
+					Id:             pointy.Int(testUserDepartmentId),
+					DepartmentBase: core.DepartmentBase{CompanyId: pointy.Int(testUserCompanyId)},
+				}, nil)
+				m.userDB.EXPECT().GetCompanyUsers(gomock.Any(), testUserCompanyId, filter, nil,
+					userDirectoryDefaultLimit+1).Return(testDirectoryUsers(1), nil)
+				m.userDB.EXPECT().CountCompanyUsers(gomock.Any(), testUserCompanyId, filter).Return(1, nil)
+			},
+			wantLimit: userDirectoryDefaultLimit,
+			wantUsers: 1,
+			wantTotal: 1,
+		},
+		{
+			name: "Service admin role",
+			filter: core.UserDirectoryFilter{CompanyId: pointy.Int(testUserCompanyId),
+				Role: pointy.String(core.CredentialTypeServiceAdmin)},
+			mockBehavior: func(m *userMocks, filter *core.UserDirectoryFilter) {},
+			wantError:    moduleErrors.ErrorServiceInvalidCredentialType,
+		},
+		{
+			name:         "Invalid sort",
+			filter:       core.UserDirectoryFilter{CompanyId: pointy.Int(testUserCompanyId), Sort: "password"},
+			mockBehavior: func(m *userMocks, filter *core.UserDirectoryFilter) {},
+			wantError:    moduleErrors.ErrorServiceInvalidSort,
+		},
+		{
+			name:         "Other company",
+			filter:       core.UserDirectoryFilter{CompanyId: pointy.Int(testUserCompanyId + 1)},
+			mockBehavior: func(m *userMocks, filter *core.UserDirectoryFilter) {},
+			wantError:    moduleErrors.ErrorServiceBadPermissions,
+		},
+	}
+
+	ctx := core.ContextWithUser(context.Background(), testUserId, []core.Credentials{
+		{CredentialType: core.CredentialTypeCompanyUser, ObjectId: testUserCompanyId},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service, m := newTestUser(c)
+			filter := testCase.filter
+			testCase.mockBehavior(m, &filter)
+
+			page, err := service.GetCompanyUsers(ctx, &filter)
+			if err != testCase.wantError {
+				t.Fatalf("error = %v, want %v", err, testCase.wantError)
+			}
+			if err != nil {
+				return
+			}
+
+			if filter.Limit != testCase.wantLimit {
+				t.Errorf("limit = %d, want %d", filter.Limit, testCase.wantLimit)
+			}
+			if len(page.Users) != testCase.wantUsers {
+				t.Errorf("users count = %d, want %d", len(page.Users), testCase.wantUsers)
+			}
+			if page.Total != testCase.wantTotal {
+				t.Errorf("total = %d, want %d", page.Total, testCase.wantTotal)
+			}
+
+			if testCase.wantNextCursor == nil {
+				if page.NextCursor != "" {
+					t.Errorf("next cursor %q of last page", page.NextCursor)
+				}
+				return
+			}
+			cursor, err := decodeUserCursor(page.NextCursor)
+			if err != nil {
+				t.Fatalf("error decode next cursor: %s", err)
+			}
+			if *cursor != *testCase.wantNextCursor {
+				t.Errorf("next cursor = %+v, want %+v", *cursor, *testCase.wantNextCursor)
+			}
+		})
+	}
+}
+
+func TestMoveUserToDepartment(t *testing.T) {
+	type mockBehavior func(m *userMocks)
+
+	testTable := []struct {
+		name         string
+		departmentId int
+		mockBehavior mockBehavior
+		wantError    error
+	}{
+		{
+			// user is maintainer of target department already, the credential is kept
+			name:         "User has credential in target department",
+			departmentId: testUserTargetId,
+			mockBehavior: func(m *userMocks) {
+				m.thingUsageDB.EXPECT().CountUserTakenUsages(gomock.Any(), testUserId, testUserCompanyId,
+					testUserDepartmentId).Return(0, nil)
+				m.credentialsDB.EXPECT().DeleteUserDepartmentCredentials(gomock.Any(), testUserId,
+					testUserDepartmentId).Return(nil)
+				m.credentialsDB.EXPECT().EnsureCredential(gomock.Any(), newCredential(testUserTargetId, testUserId,
+					core.CredentialTypeDepartmentUser)).Return(nil)
+				m.userDB.EXPECT().PathUser(gomock.Any(), &core.UserDB{User: core.User{
+					DepartmentId: pointy.Int(testUserTargetId)}}, testUserId).Return(nil)
+			},
+		},
+		{
+			name:         "Taken things",
+			departmentId: testUserTargetId,
+			mockBehavior: func(m *userMocks) {
+				m.thingUsageDB.EXPECT().CountUserTakenUsages(gomock.Any(), testUserId, testUserCompanyId,
+					testUserDepartmentId).Return(1, nil)
+			},
+			wantError: moduleErrors.ErrorServiceUserHasTakenThings,
+		},
+		{
+			name:         "Same department",
+			departmentId: testUserDepartmentId,
+			mockBehavior: func(m *userMocks) {},
+			wantError:    moduleErrors.ErrorServiceInvalidTargetDepartment,
+		},
+	}
+
+	ctx := core.ContextWithUser(context.Background(), 1, []core.Credentials{
+		{CredentialType: core.CredentialTypeCompanyAdmin, ObjectId: testUserCompanyId},
+	})
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service, m := newTestUser(c)
+			m.departmentDB.EXPECT().GetDepartment(gomock.Any(), testCase.departmentId).Return(&core.Department{
+				Id:             pointy.Int(testCase.departmentId),
+				DepartmentBase: core.DepartmentBase{CompanyId: pointy.Int(testUserCompanyId)},
+			}, nil)
+			m.userDB.EXPECT().GetUser(gomock.Any(), testUserId).Return(&core.UserDB{User: core.User{Id: testUserId,
+				CompanyId: pointy.Int(testUserCompanyId), DepartmentId: pointy.Int(testUserDepartmentId)}}, nil)
+			testCase.mockBehavior(m)
+
+			err := service.MoveUserToDepartment(ctx, testUserId, testCase.departmentId)
+			if err != testCase.wantError {
+				t.Errorf("error = %v, want %v", err, testCase.wantError)
+			}
+		})
+	}
+}
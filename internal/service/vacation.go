@@ -0,0 +1,347 @@
+package service
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/authz"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+type vacationDBVacation interface {
+	AddVacation(ctx context.Context, vacation *core.VacationAdd, userId int) (*core.Vacation, error)
+	GetVacation(ctx context.Context, vacationId int) (*core.Vacation, error)
+	GetUserVacations(ctx context.Context, userId int) ([]core.Vacation, error)
+	GetDepartmentVacations(ctx context.Context, departmentId int, startTime uint32,
+		endTime uint32) ([]core.DepartmentVacation, error)
+	UpdateVacation(ctx context.Context, vacation *core.VacationAdd, vacationId int) error
+	DeleteVacation(ctx context.Context, vacationId int) error
+	LockUserVacations(ctx context.Context, userId int) error
+	CountOverlappingVacations(ctx context.Context, userId int, startTime uint32, endTime uint32,
+		excludeVacationId int) (int, error)
+}
+
+type departmentDBVacation interface {
+	GetDepartment(ctx context.Context, departmentId int) (*core.Department, error)
+}
+
+type transactionDBVacation interface {
+	InjectTx(ctx context.Context) (context.Context, error)
+	CommitTx(ctx context.Context) error
+	RollbackTx(ctx context.Context) error
+	RollbackTxDefer(ctx context.Context)
+}
+
+// Vacation is service of user vacations, users manage only their own vacations
+type Vacation struct {
+	vacationDB    vacationDBVacation
+	departmentDB  departmentDBVacation
+	transactionDB transactionDBVacation
+}
+
+func NewVacation(vacationDB vacationDBVacation, departmentDB departmentDBVacation,
+	transactionDB transactionDBVacation) *Vacation {
+	return &Vacation{
+		vacationDB:    vacationDB,
+		departmentDB:  departmentDB,
+		transactionDB: transactionDB,
+	}
+}
+
+func (V *Vacation) AddVacation(ctx context.Context, vacation *core.VacationAdd) (*core.Vacation, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "AddVacation",
+		"vacation": vacation,
+		"context":  *core.LogContext(ctx),
+	}
+
+	if vacation.StartTime == 0 || vacation.EndTime <= vacation.StartTime {
+		return nil, moduleErrors.ErrorServiceInvalidVacationTime
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	ctx, err = V.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return nil, err
+	}
+
+	defer V.transactionDB.RollbackTxDefer(ctx)
+
+	if err = V.checkOverlap(ctx, userId, vacation, 0); err != nil {
+		return nil, err
+	}
+
+	vacationData, err := V.vacationDB.AddVacation(ctx, vacation, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error add vacation to database")
+		return nil, err
+	}
+
+	if err = V.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return nil, err
+	}
+
+	return vacationData, nil
+}
+
+func (V *Vacation) GetMyVacations(ctx context.Context) ([]core.Vacation, error) {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "GetMyVacations",
+		"context":  *core.LogContext(ctx),
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	vacations, err := V.vacationDB.GetUserVacations(ctx, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user vacations from database")
+		return nil, err
+	}
+
+	return vacations, nil
+}
+
+func (V *Vacation) UpdateVacation(ctx context.Context, vacation *core.VacationAdd, vacationId int) (*core.Vacation, error) {
+	logBase := logrus.Fields{
+		"module":     "service",
+		"function":   "UpdateVacation",
+		"vacation":   vacation,
+		"vacationId": vacationId,
+		"context":    *core.LogContext(ctx),
+	}
+
+	if vacation.StartTime == 0 || vacation.EndTime <= vacation.StartTime {
+		return nil, moduleErrors.ErrorServiceInvalidVacationTime
+	}
+
+	ctx, err := V.transactionDB.InjectTx(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create transaction")
+		return nil, err
+	}
+
+	defer V.transactionDB.RollbackTxDefer(ctx)
+
+	vacationData, err := V.getMyVacation(ctx, vacationId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = V.checkOverlap(ctx, vacationData.UserId, vacation, vacationId); err != nil {
+		return nil, err
+	}
+
+	if err = V.vacationDB.UpdateVacation(ctx, vacation, vacationId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error update vacation in database")
+		if err == moduleErrors.ErrorDatabaseVacationNotFound {
+			return nil, moduleErrors.ErrorServiceVacationNotFound
+		}
+		return nil, err
+	}
+
+	vacationData, err = V.getMyVacation(ctx, vacationId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = V.transactionDB.CommitTx(ctx); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error commit transaction")
+		return nil, err
+	}
+
+	return vacationData, nil
+}
+
+func (V *Vacation) DeleteVacation(ctx context.Context, vacationId int) error {
+	logBase := logrus.Fields{
+		"module":     "service",
+		"function":   "DeleteVacation",
+		"vacationId": vacationId,
+		"context":    *core.LogContext(ctx),
+	}
+
+	if _, err := V.getMyVacation(ctx, vacationId); err != nil {
+		return err
+	}
+
+	if err := V.vacationDB.DeleteVacation(ctx, vacationId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error delete vacation from database")
+		if err == moduleErrors.ErrorDatabaseVacationNotFound {
+			return moduleErrors.ErrorServiceVacationNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetDepartmentVacations returns calendar of department members vacations in time interval. Interval starts now
+// if start time isn't set, interval without end time lasts forever.
+func (V *Vacation) GetDepartmentVacations(ctx context.Context, departmentId int, startTime uint32,
+	endTime uint32) ([]core.DepartmentVacation, error) {
+	logBase := logrus.Fields{
+		"module":       "service",
+		"function":     "GetDepartmentVacations",
+		"departmentId": departmentId,
+		"startTime":    startTime,
+		"endTime":      endTime,
+		"context":      *core.LogContext(ctx),
+	}
+
+	if startTime == 0 {
+		startTime = uint32(time.Now().Unix())
+	}
+	if !validateActionTime(startTime, endTime) {
+		return nil, moduleErrors.ErrorServiceInvalidVacationTime
+	}
+
+	departmentData, err := V.departmentDB.GetDepartment(ctx, departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get department from database")
+		return nil, departmentDBError(err)
+	}
+
+	err = authz.Authorize(ctx, authz.ActionRead, authz.Department(*departmentData.CompanyId, departmentId))
+	if err != nil {
+		return nil, err
+	}
+
+	vacations, err := V.vacationDB.GetDepartmentVacations(ctx, departmentId, startTime, endTime)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get department vacations from database")
+		return nil, err
+	}
+
+	return vacations, nil
+}
+
+// checkOverlap locks vacations of user and checks that vacation doesn't intersect with other user vacations,
+// it must be called in transaction
+func (V *Vacation) checkOverlap(ctx context.Context, userId int, vacation *core.VacationAdd, excludeVacationId int) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "checkOverlap",
+		"userId":   userId,
+		"vacation": vacation,
+	}
+
+	if err := V.vacationDB.LockUserVacations(ctx, userId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error lock user vacations")
+		if err == moduleErrors.ErrorDatabaseUserNotFound {
+			return moduleErrors.ErrorServiceUserNotFound
+		}
+		return err
+	}
+
+	overlapping, err := V.vacationDB.CountOverlappingVacations(ctx, userId, vacation.StartTime, vacation.EndTime,
+		excludeVacationId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error count overlapping vacations")
+		return err
+	}
+	if overlapping != 0 {
+		logrus.WithFields(logrus.Fields{
+			"base":        logBase,
+			"overlapping": overlapping,
+		}).Warning(moduleErrors.ErrorServiceVacationOverlap.Error())
+		return moduleErrors.ErrorServiceVacationOverlap
+	}
+
+	return nil
+}
+
+// getMyVacation returns vacation of current user, vacations of other users are not found
+func (V *Vacation) getMyVacation(ctx context.Context, vacationId int) (*core.Vacation, error) {
+	logBase := logrus.Fields{
+		"module":     "service",
+		"function":   "getMyVacation",
+		"vacationId": vacationId,
+	}
+
+	userId, err := core.ContextGetUserId(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user id from context")
+		return nil, moduleErrors.ErrorServiceInvalidContext
+	}
+
+	vacationData, err := V.vacationDB.GetVacation(ctx, vacationId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get vacation from database")
+		if err == moduleErrors.ErrorDatabaseVacationNotFound {
+			return nil, moduleErrors.ErrorServiceVacationNotFound
+		}
+		return nil, err
+	}
+
+	if vacationData.UserId != userId {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"userId": userId,
+		}).Warning("vacation of other user")
+		return nil, moduleErrors.ErrorServiceVacationNotFound
+	}
+
+	return vacationData, nil
+}
@@ -0,0 +1,465 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/Thing-repository/backend-server/pkg/authz"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
+	"time"
+)
+
+const (
+	webhookSecretLength = 32
+	// webhookDeliveriesBatch is count of deliveries sent by one run of delivery job
+	webhookDeliveriesBatch = 20
+	// webhookDeliveriesLease is time in seconds for sending of claimed batch, it is longer than sending of
+	// whole batch with timeouts
+	webhookDeliveriesLease = 5 * 60
+	webhookDeliveriesLog   = 100
+	webhookMaxAttempts     = 8
+	// webhookRetryBase is delay before second attempt in seconds, it is doubled after every failed attempt
+	webhookRetryBase = 30
+	webhookRetryMax  = 6 * 60 * 60
+)
+
+//go:generate mockgen -source=webhook.go -destination=mock/webhookMock.go
+type webhookDBWebhook interface {
+	AddWebhook(ctx context.Context, companyId int, webhook *core.WebhookAdd) (*core.Webhook, error)
+	GetWebhook(ctx context.Context, webhookId int) (*core.Webhook, error)
+	GetCompanyWebhooks(ctx context.Context, companyId int) ([]core.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhookId int, webhook *core.WebhookUpdate) (*core.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookId int) error
+	EnqueueWebhookDeliveries(ctx context.Context, companyId int, eventType string, payload []byte) (int, error)
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease int) ([]core.WebhookDispatch, error)
+	SetWebhookDeliveryResult(ctx context.Context, deliveryId int, result *core.WebhookDeliveryResult) error
+	GetWebhookDeliveries(ctx context.Context, webhookId int, limit int) ([]core.WebhookDelivery, error)
+	RedeliverWebhookDelivery(ctx context.Context, deliveryId int, webhookId int) (*core.WebhookDelivery, error)
+}
+
+type senderWebhook interface {
+	Send(ctx context.Context, dispatch *core.WebhookDispatch) (int, error)
+}
+
+type transactionDBWebhook interface {
+	InjectTx(ctx context.Context) (context.Context, error)
+	CommitTx(ctx context.Context) error
+	RollbackTx(ctx context.Context) error
+	RollbackTxDefer(ctx context.Context)
+}
+
+// Webhook is service of company integrations. Events are queued in transaction of change which produced them
+// and are sent by background job with retries.
+type Webhook struct {
+	webhookDB     webhookDBWebhook
+	sender        senderWebhook
+	transactionDB transactionDBWebhook
+}
+
+func NewWebhook(webhookDB webhookDBWebhook, sender senderWebhook, transactionDB transactionDBWebhook) *Webhook {
+	return &Webhook{
+		webhookDB:     webhookDB,
+		sender:        sender,
+		transactionDB: transactionDB,
+	}
+}
+
+// AddWebhook subscribes company to events, generated secret is returned only in response of this request
+func (W *Webhook) AddWebhook(ctx context.Context, companyId int, webhook *core.WebhookAdd) (*core.Webhook, error) {
+	logBase := logrus.Fields{
+		"module":    "service",
+		"function":  "AddWebhook",
+		"companyId": companyId,
+		"url":       webhook.URL,
+		"context":   *core.LogContext(ctx),
+	}
+
+	if err := authz.Authorize(ctx, authz.ActionCreate, authz.Webhook(companyId)); err != nil {
+		return nil, err
+	}
+
+	if !validWebhookURL(webhook.URL) {
+		return nil, moduleErrors.ErrorServiceInvalidWebhookURL
+	}
+	if err := validateWebhookEventTypes(webhook.EventTypes); err != nil {
+		return nil, err
+	}
+
+	if webhook.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Error("error generate webhook secret")
+			return nil, err
+		}
+		webhook.Secret = secret
+	}
+
+	webhookData, err := W.webhookDB.AddWebhook(ctx, companyId, webhook)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error add webhook to database")
+		return nil, err
+	}
+
+	return webhookData, nil
+}
+
+func (W *Webhook) GetWebhooks(ctx context.Context, companyId int) ([]core.Webhook, error) {
+	logBase := logrus.Fields{
+		"module":    "service",
+		"function":  "GetWebhooks",
+		"companyId": companyId,
+		"context":   *core.LogContext(ctx),
+	}
+
+	if err := authz.Authorize(ctx, authz.ActionRead, authz.Webhook(companyId)); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := W.webhookDB.GetCompanyWebhooks(ctx, companyId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get webhooks from database")
+		return nil, err
+	}
+
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+
+	return webhooks, nil
+}
+
+func (W *Webhook) GetWebhook(ctx context.Context, webhookId int) (*core.Webhook, error) {
+	webhookData, err := W.getWebhook(ctx, webhookId, authz.ActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	webhookData.Secret = ""
+
+	return webhookData, nil
+}
+
+// UpdateWebhook changes set fields of webhook, secret is returned only if it is changed
+func (W *Webhook) UpdateWebhook(ctx context.Context, webhookId int, webhook *core.WebhookUpdate) (*core.Webhook, error) {
+	logBase := logrus.Fields{
+		"module":    "service",
+		"function":  "UpdateWebhook",
+		"webhookId": webhookId,
+		"context":   *core.LogContext(ctx),
+	}
+
+	if _, err := W.getWebhook(ctx, webhookId, authz.ActionUpdate); err != nil {
+		return nil, err
+	}
+
+	if webhook.URL != nil && !validWebhookURL(*webhook.URL) {
+		return nil, moduleErrors.ErrorServiceInvalidWebhookURL
+	}
+	if webhook.EventTypes != nil {
+		if err := validateWebhookEventTypes(webhook.EventTypes); err != nil {
+			return nil, err
+		}
+	}
+	if webhook.Secret != nil && *webhook.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Error("error generate webhook secret")
+			return nil, err
+		}
+		webhook.Secret = &secret
+	}
+
+	webhookData, err := W.webhookDB.UpdateWebhook(ctx, webhookId, webhook)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error update webhook in database")
+		return nil, webhookDBError(err)
+	}
+
+	if webhook.Secret == nil {
+		webhookData.Secret = ""
+	}
+
+	return webhookData, nil
+}
+
+func (W *Webhook) DeleteWebhook(ctx context.Context, webhookId int) error {
+	logBase := logrus.Fields{
+		"module":    "service",
+		"function":  "DeleteWebhook",
+		"webhookId": webhookId,
+		"context":   *core.LogContext(ctx),
+	}
+
+	if _, err := W.getWebhook(ctx, webhookId, authz.ActionDelete); err != nil {
+		return err
+	}
+
+	if err := W.webhookDB.DeleteWebhook(ctx, webhookId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error delete webhook from database")
+		return webhookDBError(err)
+	}
+
+	return nil
+}
+
+// GetDeliveries returns delivery log of webhook, newest deliveries first
+func (W *Webhook) GetDeliveries(ctx context.Context, webhookId int) ([]core.WebhookDelivery, error) {
+	logBase := logrus.Fields{
+		"module":    "service",
+		"function":  "GetDeliveries",
+		"webhookId": webhookId,
+		"context":   *core.LogContext(ctx),
+	}
+
+	if _, err := W.getWebhook(ctx, webhookId, authz.ActionRead); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := W.webhookDB.GetWebhookDeliveries(ctx, webhookId, webhookDeliveriesLog)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get webhook deliveries from database")
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// Redeliver queues delivery again with reset attempts, it is sent by next run of delivery job
+func (W *Webhook) Redeliver(ctx context.Context, webhookId int, deliveryId int) (*core.WebhookDelivery, error) {
+	logBase := logrus.Fields{
+		"module":     "service",
+		"function":   "Redeliver",
+		"webhookId":  webhookId,
+		"deliveryId": deliveryId,
+		"context":    *core.LogContext(ctx),
+	}
+
+	if _, err := W.getWebhook(ctx, webhookId, authz.ActionUpdate); err != nil {
+		return nil, err
+	}
+
+	delivery, err := W.webhookDB.RedeliverWebhookDelivery(ctx, deliveryId, webhookId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error redeliver webhook delivery")
+		return nil, webhookDBError(err)
+	}
+
+	return delivery, nil
+}
+
+// Publish queues event for webhooks of company subscribed to its type. It is called in transaction of change,
+// so event isn't sent if change is rolled back.
+func (W *Webhook) Publish(ctx context.Context, companyId int, eventType string, data interface{}) error {
+	logBase := logrus.Fields{
+		"module":    "service",
+		"function":  "Publish",
+		"companyId": companyId,
+		"eventType": eventType,
+	}
+
+	payload, err := json.Marshal(&core.WebhookEvent{
+		Type:        eventType,
+		CompanyId:   companyId,
+		CreatedTime: uint32(time.Now().Unix()),
+		Data:        data,
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error marshal webhook event")
+		return err
+	}
+
+	if _, err = W.webhookDB.EnqueueWebhookDeliveries(ctx, companyId, eventType, payload); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error enqueue webhook deliveries")
+		return err
+	}
+
+	return nil
+}
+
+// ProcessDeliveries is background job, it sends due deliveries. Failed delivery is retried with exponential
+// backoff and marked failed after last attempt. Requests aren't sent in transaction of job: batch is claimed
+// in own transaction, and result of every delivery is recorded in own transaction, so recorded results
+// aren't rolled back and deliveries aren't sent again.
+func (W *Webhook) ProcessDeliveries(ctx context.Context) error {
+	logBase := logrus.Fields{
+		"module":   "service",
+		"function": "ProcessDeliveries",
+	}
+
+	dispatches, err := W.claimDeliveries(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error claim webhook deliveries")
+		return err
+	}
+
+	var resultErr error
+	for i := range dispatches {
+		result := &core.WebhookDeliveryResult{Status: core.WebhookDeliveryDelivered}
+
+		statusCode, err := W.sender.Send(ctx, &dispatches[i])
+		result.StatusCode = statusCode
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":       logBase,
+				"deliveryId": dispatches[i].DeliveryId,
+				"attempts":   dispatches[i].Attempts + 1,
+				"error":      err.Error(),
+			}).Warning("error send webhook delivery")
+			result.Error = err.Error()
+			result.Status = core.WebhookDeliveryPending
+			result.RetryAfter = webhookRetryDelay(dispatches[i].Attempts + 1)
+			if dispatches[i].Attempts+1 >= webhookMaxAttempts {
+				result.Status = core.WebhookDeliveryFailed
+				result.RetryAfter = 0
+			}
+		}
+
+		// other deliveries are already claimed, so they are sent even if result isn't recorded
+		if err = W.setDeliveryResult(ctx, dispatches[i].DeliveryId, result); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":       logBase,
+				"deliveryId": dispatches[i].DeliveryId,
+				"error":      err.Error(),
+			}).Error("error set webhook delivery result")
+			resultErr = err
+		}
+	}
+
+	return resultErr
+}
+
+func (W *Webhook) claimDeliveries(ctx context.Context) ([]core.WebhookDispatch, error) {
+	ctx, err := W.transactionDB.InjectTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer W.transactionDB.RollbackTxDefer(ctx)
+
+	dispatches, err := W.webhookDB.ClaimWebhookDeliveries(ctx, webhookDeliveriesBatch, webhookDeliveriesLease)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = W.transactionDB.CommitTx(ctx); err != nil {
+		return nil, err
+	}
+
+	return dispatches, nil
+}
+
+func (W *Webhook) setDeliveryResult(ctx context.Context, deliveryId int, result *core.WebhookDeliveryResult) error {
+	ctx, err := W.transactionDB.InjectTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer W.transactionDB.RollbackTxDefer(ctx)
+
+	if err = W.webhookDB.SetWebhookDeliveryResult(ctx, deliveryId, result); err != nil {
+		return err
+	}
+
+	return W.transactionDB.CommitTx(ctx)
+}
+
+func (W *Webhook) getWebhook(ctx context.Context, webhookId int, action authz.Action) (*core.Webhook, error) {
+	logBase := logrus.Fields{
+		"module":    "service",
+		"function":  "getWebhook",
+		"webhookId": webhookId,
+	}
+
+	webhookData, err := W.webhookDB.GetWebhook(ctx, webhookId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get webhook from database")
+		return nil, webhookDBError(err)
+	}
+
+	if err = authz.Authorize(ctx, action, authz.Webhook(webhookData.CompanyId)); err != nil {
+		return nil, err
+	}
+
+	return webhookData, nil
+}
+
+func webhookDBError(err error) error {
+	switch err {
+	case moduleErrors.ErrorDatabaseWebhookNotFound:
+		return moduleErrors.ErrorServiceWebhookNotFound
+	case moduleErrors.ErrorDatabaseWebhookDeliveryNotFound:
+		return moduleErrors.ErrorServiceWebhookDeliveryNotFound
+	default:
+		return err
+	}
+}
+
+// webhookRetryDelay returns delay in seconds after failed attempt with number attempts
+func webhookRetryDelay(attempts int) int {
+	delay := webhookRetryBase
+	for i := 1; i < attempts && delay < webhookRetryMax; i++ {
+		delay *= 2
+	}
+	if delay > webhookRetryMax {
+		delay = webhookRetryMax
+	}
+	return delay
+}
+
+func validateWebhookEventTypes(eventTypes []string) error {
+	if len(eventTypes) == 0 {
+		return moduleErrors.ErrorServiceInvalidEventType
+	}
+	for _, eventType := range eventTypes {
+		if slices.Index(core.WebhookEventTypes, eventType) == -1 {
+			return moduleErrors.ErrorServiceInvalidEventType
+		}
+	}
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, webhookSecretLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	mockService "github.com/Thing-repository/backend-server/internal/service/mock"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/golang/mock/gomock"
+	"testing"
+)
+
+type testTxKey struct{}
+
+func TestProcessDeliveries(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	webhookDB := mockService.NewMockwebhookDBWebhook(c)
+	sender := mockService.NewMocksenderWebhook(c)
+	transactionDB := mockService.NewMocktransactionDBWebhook(c)
+
+	// every transaction is own context value, so calls are checked to be made in expected transaction
+	transactions := 0
+	transactionDB.EXPECT().InjectTx(gomock.Any()).DoAndReturn(func(ctx context.Context) (context.Context, error) {
+		transactions++
+		return context.WithValue(ctx, testTxKey{}, transactions), nil
+	}).Times(4)
+	transactionDB.EXPECT().RollbackTxDefer(gomock.Any()).Times(4)
+	inTx := func(tx int) gomock.Matcher {
+		return contextMatcher{tx: tx}
+	}
+
+	dispatches := []core.WebhookDispatch{
+		{DeliveryId: 1, URL: "https://example.com/1"},
+		{DeliveryId: 2, URL: "https://example.com/2", Attempts: 2},
+		{DeliveryId: 3, URL: "https://example.com/3", Attempts: webhookMaxAttempts - 1},
+	}
+	sendError := errors.New("connection refused")
+
+	gomock.InOrder(
+		webhookDB.EXPECT().ClaimWebhookDeliveries(inTx(1), webhookDeliveriesBatch, webhookDeliveriesLease).
+			Return(dispatches, nil),
+		transactionDB.EXPECT().CommitTx(inTx(1)).Return(nil),
+
+		sender.EXPECT().Send(inTx(0), &dispatches[0]).Return(200, nil),
+		webhookDB.EXPECT().SetWebhookDeliveryResult(inTx(2), 1,
+			&core.WebhookDeliveryResult{Status: core.WebhookDeliveryDelivered, StatusCode: 200}).
+			Return(errors.New("connection reset")),
+
+		// result of other delivery is recorded even if previous result isn't
+		sender.EXPECT().Send(inTx(0), &dispatches[1]).Return(0, sendError),
+		webhookDB.EXPECT().SetWebhookDeliveryResult(inTx(3), 2, &core.WebhookDeliveryResult{
+			Status: core.WebhookDeliveryPending, Error: sendError.Error(), RetryAfter: webhookRetryDelay(3)}).
+			Return(nil),
+		transactionDB.EXPECT().CommitTx(inTx(3)).Return(nil),
+
+		sender.EXPECT().Send(inTx(0), &dispatches[2]).Return(500, sendError),
+		webhookDB.EXPECT().SetWebhookDeliveryResult(inTx(4), 3, &core.WebhookDeliveryResult{
+			Status: core.WebhookDeliveryFailed, StatusCode: 500, Error: sendError.Error()}).
+			Return(nil),
+		transactionDB.EXPECT().CommitTx(inTx(4)).Return(nil),
+	)
+
+	service := NewWebhook(webhookDB, sender, transactionDB)
+	if err := service.ProcessDeliveries(context.Background()); err == nil {
+		t.Errorf("error of recording result isn't returned")
+	}
+}
+
+// contextMatcher matches context of transaction with number tx, 0 is context without transaction
+type contextMatcher struct {
+	tx int
+}
+
+func (C contextMatcher) Matches(x interface{}) bool {
+	ctx, ok := x.(context.Context)
+	if !ok {
+		return false
+	}
+	tx, _ := ctx.Value(testTxKey{}).(int)
+	return tx == C.tx
+}
+
+func (C contextMatcher) String() string {
+	return fmt.Sprintf("is context of transaction %d", C.tx)
+}
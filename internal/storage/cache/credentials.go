@@ -0,0 +1,157 @@
+package cache
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+type credentialsDB interface {
+	CreateCredential(ctx context.Context, credentials *core.AddCredentials) (int, error)
+	EnsureCredential(ctx context.Context, credentials *core.AddCredentials) error
+	GetUserCredential(ctx context.Context, userId int) ([]core.Credentials, error)
+	GetCredentialUsers(ctx context.Context, credentialType string, objectId int) ([]core.User, error)
+	CountCredentialsForUpdate(ctx context.Context, credentialType string, objectId int) (int, error)
+	DeleteCredential(ctx context.Context, credentials *core.AddCredentials) error
+	AddServiceAdmin(ctx context.Context, userId int) error
+	DeleteUserCompanyCredentials(ctx context.Context, userId int, companyId int) error
+	DeleteUserDepartmentCredentials(ctx context.Context, userId int, departmentId int) error
+}
+
+type transactionDB interface {
+	AfterCommit(ctx context.Context, fn func())
+}
+
+type credentialsEntry struct {
+	credentials []core.Credentials
+	expiresTime time.Time
+}
+
+// Credentials wraps credentials database and caches credentials of users for middleware.
+// Changes made through it drop cached credentials after commit of transaction,
+// changes made by other modules (cascade deletes, members moving) are dropped by InvalidateAllCredentials.
+type Credentials struct {
+	credentialsDB
+	transactionDB transactionDB
+	ttl           time.Duration
+
+	mutex   sync.Mutex
+	entries map[int]credentialsEntry
+	// generation is changed by every invalidation, credentials loaded before it aren't cached
+	generation uint64
+}
+
+func NewCredentials(credentialsDB credentialsDB, transactionDB transactionDB, ttl time.Duration) *Credentials {
+	return &Credentials{
+		credentialsDB: credentialsDB,
+		transactionDB: transactionDB,
+		ttl:           ttl,
+		entries:       make(map[int]credentialsEntry),
+	}
+}
+
+// LoadUserCredential returns cached credentials of user, credentials are loaded from database
+// when they aren't cached or expired. GetUserCredential always reads database.
+func (C *Credentials) LoadUserCredential(ctx context.Context, userId int) ([]core.Credentials, error) {
+	C.mutex.Lock()
+	entry, ok := C.entries[userId]
+	generation := C.generation
+	C.mutex.Unlock()
+
+	if ok && time.Now().Before(entry.expiresTime) {
+		return entry.credentials, nil
+	}
+
+	credentials, err := C.credentialsDB.GetUserCredential(ctx, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"module":   "cache",
+			"function": "LoadUserCredential",
+			"userId":   userId,
+			"error":    err.Error(),
+		}).Error("error load user credentials")
+		return nil, err
+	}
+
+	C.mutex.Lock()
+	if C.generation == generation {
+		C.entries[userId] = credentialsEntry{
+			credentials: credentials,
+			expiresTime: time.Now().Add(C.ttl),
+		}
+	}
+	C.mutex.Unlock()
+
+	return credentials, nil
+}
+
+func (C *Credentials) CreateCredential(ctx context.Context, credentials *core.AddCredentials) (int, error) {
+	id, err := C.credentialsDB.CreateCredential(ctx, credentials)
+	if err != nil {
+		return 0, err
+	}
+	C.invalidateAfterCommit(ctx, credentials.UserId)
+	return id, nil
+}
+
+func (C *Credentials) EnsureCredential(ctx context.Context, credentials *core.AddCredentials) error {
+	if err := C.credentialsDB.EnsureCredential(ctx, credentials); err != nil {
+		return err
+	}
+	C.invalidateAfterCommit(ctx, credentials.UserId)
+	return nil
+}
+
+func (C *Credentials) DeleteCredential(ctx context.Context, credentials *core.AddCredentials) error {
+	if err := C.credentialsDB.DeleteCredential(ctx, credentials); err != nil {
+		return err
+	}
+	C.invalidateAfterCommit(ctx, credentials.UserId)
+	return nil
+}
+
+func (C *Credentials) AddServiceAdmin(ctx context.Context, userId int) error {
+	if err := C.credentialsDB.AddServiceAdmin(ctx, userId); err != nil {
+		return err
+	}
+	C.invalidateAfterCommit(ctx, userId)
+	return nil
+}
+
+func (C *Credentials) DeleteUserCompanyCredentials(ctx context.Context, userId int, companyId int) error {
+	if err := C.credentialsDB.DeleteUserCompanyCredentials(ctx, userId, companyId); err != nil {
+		return err
+	}
+	C.invalidateAfterCommit(ctx, userId)
+	return nil
+}
+
+func (C *Credentials) DeleteUserDepartmentCredentials(ctx context.Context, userId int, departmentId int) error {
+	if err := C.credentialsDB.DeleteUserDepartmentCredentials(ctx, userId, departmentId); err != nil {
+		return err
+	}
+	C.invalidateAfterCommit(ctx, userId)
+	return nil
+}
+
+// InvalidateAllCredentials drops all cached credentials after commit of transaction from context,
+// it is used when credentials of unknown set of users are changed
+func (C *Credentials) InvalidateAllCredentials(ctx context.Context) {
+	C.transactionDB.AfterCommit(ctx, func() {
+		C.mutex.Lock()
+		C.entries = make(map[int]credentialsEntry)
+		C.generation++
+		C.mutex.Unlock()
+	})
+}
+
+func (C *Credentials) invalidateAfterCommit(ctx context.Context, userId int) {
+	C.transactionDB.AfterCommit(ctx, func() {
+		C.mutex.Lock()
+		delete(C.entries, userId)
+		C.generation++
+		C.mutex.Unlock()
+	})
+}
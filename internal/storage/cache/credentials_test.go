@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"testing"
+	"time"
+)
+
+// testCredentialsDB counts loads of user credentials
+type testCredentialsDB struct {
+	credentialsDB
+	credentials map[int][]core.Credentials
+	loads       int
+}
+
+func (t *testCredentialsDB) GetUserCredential(ctx context.Context, userId int) ([]core.Credentials, error) {
+	t.loads++
+	return t.credentials[userId], nil
+}
+
+func (t *testCredentialsDB) CreateCredential(ctx context.Context, credentials *core.AddCredentials) (int, error) {
+	t.credentials[credentials.UserId] = append(t.credentials[credentials.UserId], credentials.Credentials)
+	return 1, nil
+}
+
+// EnsureCredential adds credential only if user doesn't have it like database does
+func (t *testCredentialsDB) EnsureCredential(ctx context.Context, credentials *core.AddCredentials) error {
+	if !core.CheckCredential(t.credentials[credentials.UserId], credentials.CredentialType, credentials.ObjectId) {
+		t.credentials[credentials.UserId] = append(t.credentials[credentials.UserId], credentials.Credentials)
+	}
+	return nil
+}
+
+// testTransactionDB keeps hooks until commit, inTx emulates open transaction
+type testTransactionDB struct {
+	inTx  bool
+	hooks []func()
+}
+
+func (t *testTransactionDB) AfterCommit(ctx context.Context, fn func()) {
+	if !t.inTx {
+		fn()
+		return
+	}
+	t.hooks = append(t.hooks, fn)
+}
+
+func (t *testTransactionDB) commit() {
+	for _, hook := range t.hooks {
+		hook()
+	}
+	t.hooks = nil
+	t.inTx = false
+}
+
+func TestCredentials(t *testing.T) {
+	ctx := context.Background()
+	credential := core.Credentials{CredentialType: core.CredentialTypeCompanyUser, ObjectId: 1}
+
+	load := func(t *testing.T, cache *Credentials, db *testCredentialsDB, wantCount int, wantLoads int) {
+		t.Helper()
+		credentials, err := cache.LoadUserCredential(ctx, 1)
+		if err != nil {
+			t.Fatalf("error load credentials: %s", err)
+		}
+		if len(credentials) != wantCount {
+			t.Errorf("credentials count = %d, want %d", len(credentials), wantCount)
+		}
+		if db.loads != wantLoads {
+			t.Errorf("database loads = %d, want %d", db.loads, wantLoads)
+		}
+	}
+
+	t.Run("Cached until change", func(t *testing.T) {
+		db := &testCredentialsDB{credentials: map[int][]core.Credentials{}}
+		transaction := &testTransactionDB{}
+		cache := NewCredentials(db, transaction, time.Minute)
+
+		load(t, cache, db, 0, 1)
+		load(t, cache, db, 0, 1)
+
+		if _, err := cache.CreateCredential(ctx, &core.AddCredentials{Credentials: credential, UserId: 1}); err != nil {
+			t.Fatalf("error create credential: %s", err)
+		}
+		load(t, cache, db, 1, 2)
+	})
+
+	t.Run("Invalidated after commit", func(t *testing.T) {
+		db := &testCredentialsDB{credentials: map[int][]core.Credentials{}}
+		transaction := &testTransactionDB{}
+		cache := NewCredentials(db, transaction, time.Minute)
+
+		load(t, cache, db, 0, 1)
+
+		transaction.inTx = true
+		if _, err := cache.CreateCredential(ctx, &core.AddCredentials{Credentials: credential, UserId: 1}); err != nil {
+			t.Fatalf("error create credential: %s", err)
+		}
+		load(t, cache, db, 0, 1)
+
+		transaction.commit()
+		load(t, cache, db, 1, 2)
+	})
+
+	t.Run("Ensure existing credential", func(t *testing.T) {
+		db := &testCredentialsDB{credentials: map[int][]core.Credentials{1: {credential}}}
+		transaction := &testTransactionDB{}
+		cache := NewCredentials(db, transaction, time.Minute)
+
+		load(t, cache, db, 1, 1)
+
+		if err := cache.EnsureCredential(ctx, &core.AddCredentials{Credentials: credential, UserId: 1}); err != nil {
+			t.Fatalf("error ensure credential: %s", err)
+		}
+		load(t, cache, db, 1, 2)
+	})
+
+	t.Run("Invalidate all", func(t *testing.T) {
+		db := &testCredentialsDB{credentials: map[int][]core.Credentials{}}
+		transaction := &testTransactionDB{}
+		cache := NewCredentials(db, transaction, time.Minute)
+
+		load(t, cache, db, 0, 1)
+		cache.InvalidateAllCredentials(ctx)
+		load(t, cache, db, 0, 2)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		db := &testCredentialsDB{credentials: map[int][]core.Credentials{}}
+		transaction := &testTransactionDB{}
+		cache := NewCredentials(db, transaction, 0)
+
+		load(t, cache, db, 0, 1)
+		load(t, cache, db, 0, 2)
+	})
+}
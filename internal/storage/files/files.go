@@ -0,0 +1,13 @@
+package files
+
+import (
+	"context"
+	"io"
+)
+
+// Storage stores files by slash separated keys
+type Storage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, string, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,140 @@
+package files
+
+import (
+	"bytes"
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/sirupsen/logrus"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Local stores files in directory of local filesystem
+type Local struct {
+	root string
+}
+
+func NewLocal(root string) (*Local, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	return &Local{root: root}, nil
+}
+
+// checkKey rejects keys which can point outside of storage, keys are slash separated relative paths
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") || path.Clean(key) != key ||
+		key == ".." || strings.HasPrefix(key, "../") {
+		return moduleErrors.ErrorStorageInvalidKey
+	}
+	return nil
+}
+
+func (L *Local) filePath(key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(L.root, filepath.FromSlash(key)), nil
+}
+
+// Put writes file through temporary file, so readers never see partially written file
+func (L *Local) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	logBase := logrus.Fields{
+		"module":   "files",
+		"function": "Put",
+		"key":      key,
+	}
+
+	filePath, err := L.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create directory")
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error create temporary file")
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = io.Copy(tmp, bytes.NewReader(data)); err != nil {
+		tmp.Close()
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error write file")
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmp.Name(), filePath); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error rename file")
+		return err
+	}
+
+	return nil
+}
+
+// Get opens file, content type is detected by extension of key
+func (L *Local) Get(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	filePath, err := L.filePath(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", moduleErrors.ErrorStorageFileNotFound
+		}
+		logrus.WithFields(logrus.Fields{
+			"module":   "files",
+			"function": "Get",
+			"key":      key,
+			"error":    err.Error(),
+		}).Error("error open file")
+		return nil, "", err
+	}
+
+	return file, mime.TypeByExtension(path.Ext(key)), nil
+}
+
+// Delete removes file, missing file isn't error
+func (L *Local) Delete(ctx context.Context, key string) error {
+	filePath, err := L.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		logrus.WithFields(logrus.Fields{
+			"module":   "files",
+			"function": "Delete",
+			"key":      key,
+			"error":    err.Error(),
+		}).Error("error delete file")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package files
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	s3Service    = "s3"
+	s3TimeFormat = "20060102T150405Z"
+	s3DateFormat = "20060102"
+)
+
+// S3Config is connection to S3 compatible storage, bucket is addressed in path style
+// (endpoint/bucket/key), so it works with MinIO and other self-hosted stand-ins
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3 stores files in bucket of S3 compatible storage, requests are signed with AWS signature version 4
+type S3 struct {
+	endpoint *url.URL
+	config   S3Config
+	client   *http.Client
+	now      func() time.Time
+}
+
+func NewS3(config S3Config) (*S3, error) {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", config.Endpoint)
+	}
+	if config.Bucket == "" {
+		return nil, fmt.Errorf("s3 bucket is not set")
+	}
+
+	return &S3{
+		endpoint: endpoint,
+		config:   config,
+		client:   &http.Client{Timeout: 30 * time.Second},
+		now:      time.Now,
+	}, nil
+}
+
+func (S *S3) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	resp, err := S.do(ctx, http.MethodPut, key, data, contentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return S.responseError("Put", key, resp)
+	}
+
+	return nil
+}
+
+func (S *S3) Get(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	resp, err := S.do(ctx, http.MethodGet, key, nil, "")
+	if err != nil {
+		return nil, "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, "", S.responseError("Get", key, resp)
+	}
+
+	return resp.Body, resp.Header.Get("Content-Type"), nil
+}
+
+// Delete removes object, S3 doesn't report missing objects on delete
+func (S *S3) Delete(ctx context.Context, key string) error {
+	resp, err := S.do(ctx, http.MethodDelete, key, nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return S.responseError("Delete", key, resp)
+	}
+
+	return nil
+}
+
+func (S *S3) do(ctx context.Context, method string, key string, data []byte, contentType string) (*http.Response, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+
+	objectURL := *S.endpoint
+	objectURL.Path = strings.TrimSuffix(objectURL.Path, "/") + "/" + S.config.Bucket + "/" + key
+
+	req, err := http.NewRequestWithContext(ctx, method, objectURL.String(), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	signV4(req, data, S.config.AccessKey, S.config.SecretKey, S.config.Region, S.now())
+
+	resp, err := S.client.Do(req)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"module":   "files",
+			"function": "do",
+			"method":   method,
+			"key":      key,
+			"error":    err.Error(),
+		}).Error("error send request to s3")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (S *S3) responseError(function string, key string, resp *http.Response) error {
+	if resp.StatusCode == http.StatusNotFound {
+		return moduleErrors.ErrorStorageFileNotFound
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	logrus.WithFields(logrus.Fields{
+		"module":   "files",
+		"function": function,
+		"key":      key,
+		"status":   resp.StatusCode,
+		"body":     string(body),
+	}).Error("error response from s3")
+
+	return fmt.Errorf("s3 responded with status %d", resp.StatusCode)
+}
+
+// signV4 adds AWS signature version 4 to request. Host, Content-Type, Range and all x-amz-* headers are signed.
+func signV4(req *http.Request, payload []byte, accessKey string, secretKey string, region string, now time.Time) {
+	now = now.UTC()
+	payloadHash := sha256Hex(payload)
+	req.Header.Set("X-Amz-Date", now.Format(s3TimeFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, "x-amz-") || name == "content-type" || name == "range" {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := now.Format(s3DateFormat) + "/" + region + "/" + s3Service + "/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		now.Format(s3TimeFormat),
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), now.Format(s3DateFormat))
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -175,6 +175,11 @@ func (C *CompanyDB) UpdateCompany(ctx context.Context, company core.CompanyUpdat
 		args = append(args, *company.Address)
 		argId++
 	}
+	if company.ImageURL != nil {
+		setValues = append(setValues, fmt.Sprintf("image_url = $%d", argId))
+		args = append(args, *company.ImageURL)
+		argId++
+	}
 	if argId == 1 {
 		return moduleErrors.ErrorDataBaseHasNotDataToChange
 	}
@@ -276,3 +281,74 @@ func (C *CompanyDB) DeleteCompany(ctx context.Context, companyId int) error {
 
 	return nil
 }
+
+// FindCompanies returns companies which name starts with filter
+func (C *CompanyDB) FindCompanies(ctx context.Context, filter string, limit int, offset int) ([]core.Company, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "company.go",
+		"function": "FindCompanies",
+		"filter":   filter,
+		"limit":    limit,
+		"offset":   offset,
+	}
+
+	db := C.db
+	tx, ok := C.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	filter += "%"
+
+	query := `
+				SELECT
+				    id,
+					company_name, 
+					address,
+					image_url
+				FROM
+				    companies
+				WHERE 
+					company_name ILIKE $1
+				ORDER BY
+					company_name, id
+				LIMIT $2 OFFSET $3`
+
+	rows, err := db.Query(ctx, query, filter, limit, offset)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error find companies in postgres")
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]core.Company, 0)
+
+	for rows.Next() {
+		var companyData core.Company
+		if err := rows.Scan(&companyData.Id, &companyData.CompanyName, &companyData.Address, &companyData.ImageURL); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error scan rows")
+			return nil, err
+		}
+		ret = append(ret, companyData)
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -14,6 +14,7 @@ import (
 type dbDriverRightsDB interface {
 	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
 	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
 }
 
 type transactionDBRightsDB interface {
@@ -29,6 +30,17 @@ func NewCredentialsDB(dbDriver dbDriverRightsDB, transactionDB transactionDBRigh
 	return &RightsDB{dbDriver: dbDriver, transactionDB: transactionDB}
 }
 
+// credentialTable returns table which stores credentials of given type
+func credentialTable(credentialType string) (string, error) {
+	if slices.Index(core.CompanyCredential, credentialType) != -1 {
+		return "company_credentials", nil
+	}
+	if slices.Index(core.DepartmentCredential, credentialType) != -1 {
+		return "department_credentials", nil
+	}
+	return "", moduleErrors.ErrorDataBaseInvalidCredentialsType
+}
+
 func (R *RightsDB) CreateCredential(ctx context.Context,
 	credentials *core.AddCredentials) (int, error) {
 	logBase := logrus.Fields{
@@ -36,19 +48,14 @@ func (R *RightsDB) CreateCredential(ctx context.Context,
 		"function": "CreateCredential",
 	}
 
-	var table string
-
-	if slices.Index(core.CompanyCredential, credentials.CredentialType) != -1 {
-		table = "company_credentials"
-	} else if slices.Index(core.DepartmentCredential, credentials.CredentialType) != -1 {
-		table = "department_credentials"
-	} else {
+	table, err := credentialTable(credentials.CredentialType)
+	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"base":           logBase,
 			"credentials":    credentials,
 			"CredentialType": credentials.CredentialType,
 		}).Error("error add credential to postgres")
-		return 0, moduleErrors.ErrorDataBaseInvalidCredentialsType
+		return 0, err
 	}
 
 	db := R.dbDriver
@@ -74,6 +81,15 @@ func (R *RightsDB) CreateCredential(ctx context.Context,
 	if err := row.Scan(&credentialId); err != nil {
 		if pgErr, ok := err.(*pgconn.PgError); ok {
 			switch pgErr.Code {
+			case "23505":
+				logrus.WithFields(logrus.Fields{
+					"base":        logBase,
+					"credentials": credentials,
+					"massage":     pgErr.Message,
+					"detail":      pgErr.Detail,
+					"code":        pgErr.Code,
+				}).Error("credential already exists")
+				return 0, moduleErrors.ErrorDatabaseCredentialAlreadyExists
 			default:
 				logrus.WithFields(logrus.Fields{
 					"base":        logBase,
@@ -101,6 +117,67 @@ func (R *RightsDB) CreateCredential(ctx context.Context,
 
 }
 
+// EnsureCredential gives credential to user if user doesn't have it yet. Unlike CreateCredential
+// existing credential isn't error, so it doesn't abort transaction
+func (R *RightsDB) EnsureCredential(ctx context.Context, credentials *core.AddCredentials) error {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"function": "EnsureCredential",
+	}
+
+	table, err := credentialTable(credentials.CredentialType)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":           logBase,
+			"credentials":    credentials,
+			"CredentialType": credentials.CredentialType,
+		}).Error("error ensure credential in postgres")
+		return err
+	}
+
+	db := R.dbDriver
+	tx, ok := R.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+			INSERT INTO %s
+				(credential_type, user_id, object_id)
+			VALUES
+				($1, $2, $3)
+			ON CONFLICT DO NOTHING`
+
+	query = fmt.Sprintf(query, table)
+
+	cmdTag, err := db.Exec(ctx, query, credentials.CredentialType, credentials.UserId, credentials.ObjectId)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			logrus.WithFields(logrus.Fields{
+				"base":        logBase,
+				"credentials": credentials,
+				"massage":     pgErr.Message,
+				"where":       pgErr.Where,
+				"detail":      pgErr.Detail,
+				"code":        pgErr.Code,
+				"query":       logQuery(query),
+				"cmdTag":      cmdTag,
+			}).Error("error ensure credential in postgres")
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":        logBase,
+				"credentials": credentials,
+				"query":       logQuery(query),
+				"error":       err,
+				"cmdTag":      cmdTag,
+			}).Error("error ensure credential in postgres")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (R *RightsDB) getCredentials(ctx context.Context,
 	userId int, table string) ([]core.Credentials, error) {
 	logBase := logrus.Fields{
@@ -216,5 +293,464 @@ func (R *RightsDB) GetUserCredential(ctx context.Context,
 		return nil, err
 	}
 
-	return append(companyCredentials, departmentCredentials...), nil
+	credentials := append(companyCredentials, departmentCredentials...)
+
+	isServiceAdmin, err := R.isServiceAdmin(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if isServiceAdmin {
+		credentials = append(credentials, core.Credentials{CredentialType: core.CredentialTypeServiceAdmin})
+	}
+
+	return credentials, nil
+}
+
+func (R *RightsDB) isServiceAdmin(ctx context.Context, userId int) (bool, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"function": "isServiceAdmin",
+		"userId":   userId,
+	}
+
+	db := R.dbDriver
+	tx, ok := R.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+			SELECT
+				EXISTS(SELECT 1 FROM service_admins WHERE user_id = $1)`
+
+	var isServiceAdmin bool
+
+	if err := db.QueryRow(ctx, query, userId).Scan(&isServiceAdmin); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error check service admin in postgres")
+				return false, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error check service admin in postgres")
+			return false, err
+		}
+	}
+
+	return isServiceAdmin, nil
+}
+
+// AddServiceAdmin gives platform level service_admin credential to user
+func (R *RightsDB) AddServiceAdmin(ctx context.Context, userId int) error {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"function": "AddServiceAdmin",
+		"userId":   userId,
+	}
+
+	db := R.dbDriver
+	tx, ok := R.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+			INSERT INTO service_admins
+				(user_id)
+			VALUES
+				($1)`
+
+	cmdTag, err := db.Exec(ctx, query, userId)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case "23505":
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+				}).Error("user is already service admin")
+				return moduleErrors.ErrorDatabaseCredentialAlreadyExists
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+					"cmdTag":  cmdTag,
+				}).Error("error add service admin to postgres")
+				return err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":   logBase,
+				"query":  logQuery(query),
+				"error":  err,
+				"cmdTag": cmdTag,
+			}).Error("error add service admin to postgres")
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetCredentialUsers returns users which have credential of given type on object
+func (R *RightsDB) GetCredentialUsers(ctx context.Context, credentialType string, objectId int) ([]core.User, error) {
+	logBase := logrus.Fields{
+		"module":         "postgres",
+		"function":       "GetCredentialUsers",
+		"credentialType": credentialType,
+		"objectId":       objectId,
+	}
+
+	table, err := credentialTable(credentialType)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error("error get credential users from postgres")
+		return nil, err
+	}
+
+	db := R.dbDriver
+	tx, ok := R.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := fmt.Sprintf(`
+			SELECT
+				u.id,
+				u.first_name,
+				u.last_name,
+				u.email,
+				u.image_url,
+				u.company_id,
+				u.department_id
+			FROM
+				%s c
+				JOIN users u ON u.id = c.user_id
+			WHERE
+				c.credential_type = $1 AND c.object_id = $2
+			ORDER BY
+				u.id`, table)
+
+	rows, err := db.Query(ctx, query, credentialType, objectId)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error get credential users from postgres")
+				return nil, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error get credential users from postgres")
+			return nil, err
+		}
+	}
+	defer rows.Close()
+
+	ret := make([]core.User, 0)
+
+	for rows.Next() {
+		var userData core.User
+		err = rows.Scan(&userData.Id, &userData.FirstName, &userData.LastName, &userData.Email,
+			&userData.ImageURL, &userData.CompanyId, &userData.DepartmentId)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error scan rows")
+			return nil, err
+		}
+		ret = append(ret, userData)
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// CountCredentialsForUpdate counts credentials of given type on object and locks them until the end of transaction
+func (R *RightsDB) CountCredentialsForUpdate(ctx context.Context, credentialType string, objectId int) (int, error) {
+	logBase := logrus.Fields{
+		"module":         "postgres",
+		"function":       "CountCredentialsForUpdate",
+		"credentialType": credentialType,
+		"objectId":       objectId,
+	}
+
+	table, err := credentialTable(credentialType)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error("error count credentials in postgres")
+		return 0, err
+	}
+
+	db := R.dbDriver
+	tx, ok := R.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := fmt.Sprintf(`
+			SELECT
+				id
+			FROM
+				%s
+			WHERE
+				credential_type = $1 AND object_id = $2
+			FOR UPDATE`, table)
+
+	rows, err := db.Query(ctx, query, credentialType, objectId)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error count credentials in postgres")
+				return 0, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error count credentials in postgres")
+			return 0, err
+		}
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		count++
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (R *RightsDB) DeleteCredential(ctx context.Context, credentials *core.AddCredentials) error {
+	logBase := logrus.Fields{
+		"module":      "postgres",
+		"function":    "DeleteCredential",
+		"credentials": credentials,
+	}
+
+	table, err := credentialTable(credentials.CredentialType)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error("error delete credential from postgres")
+		return err
+	}
+
+	db := R.dbDriver
+	tx, ok := R.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := fmt.Sprintf(`
+			DELETE
+			FROM
+				%s
+			WHERE
+				credential_type = $1 AND user_id = $2 AND object_id = $3`, table)
+
+	cmdTag, err := db.Exec(ctx, query, credentials.CredentialType, credentials.UserId, credentials.ObjectId)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+					"cmdTag":  cmdTag,
+				}).Error("error delete credential from postgres")
+				return err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":   logBase,
+				"query":  logQuery(query),
+				"error":  err,
+				"cmdTag": cmdTag,
+			}).Error("error delete credential from postgres")
+			return err
+		}
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return moduleErrors.ErrorDatabaseCredentialNotFound
+	}
+
+	return nil
+}
+
+// DeleteUserCompanyCredentials deletes all credentials of user in company and its departments
+func (R *RightsDB) DeleteUserCompanyCredentials(ctx context.Context, userId int, companyId int) error {
+	logBase := logrus.Fields{
+		"module":    "postgres",
+		"function":  "DeleteUserCompanyCredentials",
+		"userId":    userId,
+		"companyId": companyId,
+	}
+
+	db := R.dbDriver
+	tx, ok := R.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	queries := []string{`
+			DELETE
+			FROM
+				department_credentials
+			WHERE
+				user_id = $1 AND
+				object_id IN (SELECT id FROM departments WHERE company_id = $2)`, `
+			DELETE
+			FROM
+				company_credentials
+			WHERE
+				user_id = $1 AND object_id = $2`,
+	}
+
+	for _, query := range queries {
+		cmdTag, err := db.Exec(ctx, query, userId, companyId)
+		if err != nil {
+			if pgErr, ok := err.(*pgconn.PgError); ok {
+				switch pgErr.Code {
+				default:
+					logrus.WithFields(logrus.Fields{
+						"base":    logBase,
+						"massage": pgErr.Message,
+						"where":   pgErr.Where,
+						"detail":  pgErr.Detail,
+						"code":    pgErr.Code,
+						"query":   logQuery(query),
+						"cmdTag":  cmdTag,
+					}).Error("error delete user credentials from postgres")
+					return err
+				}
+			} else {
+				logrus.WithFields(logrus.Fields{
+					"base":   logBase,
+					"query":  logQuery(query),
+					"error":  err,
+					"cmdTag": cmdTag,
+				}).Error("error delete user credentials from postgres")
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// DeleteUserDepartmentCredentials deletes all credentials of user in department
+func (R *RightsDB) DeleteUserDepartmentCredentials(ctx context.Context, userId int, departmentId int) error {
+	logBase := logrus.Fields{
+		"module":       "postgres",
+		"function":     "DeleteUserDepartmentCredentials",
+		"userId":       userId,
+		"departmentId": departmentId,
+	}
+
+	db := R.dbDriver
+	tx, ok := R.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+			DELETE
+			FROM
+				department_credentials
+			WHERE
+				user_id = $1 AND object_id = $2`
+
+	cmdTag, err := db.Exec(ctx, query, userId, departmentId)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+					"cmdTag":  cmdTag,
+				}).Error("error delete user credentials from postgres")
+				return err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":   logBase,
+				"query":  logQuery(query),
+				"error":  err,
+				"cmdTag": cmdTag,
+			}).Error("error delete user credentials from postgres")
+			return err
+		}
+	}
+
+	return nil
 }
@@ -2,15 +2,19 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/sirupsen/logrus"
+	"strings"
 )
 
 type dbDepartmentDB interface {
 	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
 	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
 }
 
 type transactionDBDepartmentDB interface {
@@ -53,6 +57,15 @@ func (D *DepartmentDB) AddDepartment(ctx context.Context, departmentBase *core.D
 	if err := row.Scan(&departmentId); err != nil {
 		if pgErr, ok := err.(*pgconn.PgError); ok {
 			switch pgErr.Code {
+			case "23505":
+				logrus.WithFields(logrus.Fields{
+					"base":           logBase,
+					"departmentBase": departmentBase,
+					"massage":        pgErr.Message,
+					"detail":         pgErr.Detail,
+					"code":           pgErr.Code,
+				}).Error("department already exists")
+				return nil, moduleErrors.ErrorDatabaseDepartmentAlreadyExists
 			default:
 				logrus.WithFields(logrus.Fields{
 					"base":           logBase,
@@ -110,6 +123,14 @@ func (D *DepartmentDB) GetDepartment(ctx context.Context, departmentId int) (*co
 	var departmentData core.Department
 
 	if err := row.Scan(&departmentData.Id, &departmentData.DepartmentName, &departmentData.CompanyId, &departmentData.ImageURL); err != nil {
+		if err == pgx.ErrNoRows {
+			logrus.WithFields(logrus.Fields{
+				"base":         logBase,
+				"query":        logQuery(query),
+				"departmentId": departmentId,
+			}).Error("department not found")
+			return nil, moduleErrors.ErrorDatabaseDepartmentNotFound
+		}
 		if pgErr, ok := err.(*pgconn.PgError); ok {
 			switch pgErr.Code {
 			default:
@@ -137,3 +158,350 @@ func (D *DepartmentDB) GetDepartment(ctx context.Context, departmentId int) (*co
 
 	return &departmentData, nil
 }
+
+func (D *DepartmentDB) GetDepartmentByName(ctx context.Context, companyId int, departmentName string) (*core.Department, error) {
+	logBase := logrus.Fields{
+		"module":         "postgres",
+		"file":           "department.go",
+		"function":       "GetDepartmentByName",
+		"companyId":      companyId,
+		"departmentName": departmentName,
+	}
+
+	db := D.db
+	tx, ok := D.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT
+				    id,
+					department_name, 
+					company_id,
+					image_url
+				FROM
+				    departments
+				WHERE 
+					company_id = $1 AND department_name = $2`
+
+	row := db.QueryRow(ctx, query, companyId, departmentName)
+
+	var departmentData core.Department
+
+	if err := row.Scan(&departmentData.Id, &departmentData.DepartmentName, &departmentData.CompanyId, &departmentData.ImageURL); err != nil {
+		if err == pgx.ErrNoRows {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+			}).Error("department not found")
+			return nil, moduleErrors.ErrorDatabaseDepartmentNotFound
+		}
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error get department from postgres")
+		return nil, err
+	}
+
+	return &departmentData, nil
+}
+
+func (D *DepartmentDB) GetDepartmentsByCompany(ctx context.Context, companyId int) ([]core.Department, error) {
+	logBase := logrus.Fields{
+		"module":    "postgres",
+		"file":      "department.go",
+		"function":  "GetDepartmentsByCompany",
+		"companyId": companyId,
+	}
+
+	db := D.db
+	tx, ok := D.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT
+				    id,
+					department_name, 
+					company_id,
+					image_url
+				FROM
+				    departments
+				WHERE 
+					company_id = $1
+				ORDER BY
+					id`
+
+	rows, err := db.Query(ctx, query, companyId)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error get departments from postgres")
+				return nil, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error get departments from postgres")
+			return nil, err
+		}
+	}
+	defer rows.Close()
+
+	ret := make([]core.Department, 0)
+
+	for rows.Next() {
+		var departmentData core.Department
+		if err := rows.Scan(&departmentData.Id, &departmentData.DepartmentName, &departmentData.CompanyId, &departmentData.ImageURL); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error scan rows")
+			return nil, err
+		}
+		ret = append(ret, departmentData)
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (D *DepartmentDB) UpdateDepartment(ctx context.Context, department *core.DepartmentUpdate, departmentId int) error {
+	logBase := logrus.Fields{
+		"module":       "postgres",
+		"file":         "department.go",
+		"function":     "UpdateDepartment",
+		"departmentId": departmentId,
+	}
+
+	db := D.db
+	tx, ok := D.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	setValues := make([]string, 0)
+	args := make([]interface{}, 0)
+	argId := 1
+
+	if department.DepartmentName != nil {
+		setValues = append(setValues, fmt.Sprintf("department_name = $%d", argId))
+		args = append(args, *department.DepartmentName)
+		argId++
+	}
+	if department.ImageURL != nil {
+		setValues = append(setValues, fmt.Sprintf("image_url = $%d", argId))
+		args = append(args, *department.ImageURL)
+		argId++
+	}
+
+	if argId == 1 {
+		return moduleErrors.ErrorDataBaseHasNotDataToChange
+	}
+
+	setQuery := strings.Join(setValues, ", ")
+	query := fmt.Sprintf(`
+				UPDATE
+					departments
+				SET
+					%s
+				WHERE
+					id = $%d
+`, setQuery, argId)
+
+	args = append(args, departmentId)
+
+	cmdTag, err := db.Exec(ctx, query, args...)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case "23505":
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"args":    args,
+				}).Error("department already exists")
+				return moduleErrors.ErrorDatabaseDepartmentAlreadyExists
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+					"args":    args,
+					"cmdTag":  cmdTag,
+				}).Error("error update department in postgres")
+				return err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":   logBase,
+				"query":  logQuery(query),
+				"error":  err,
+				"args":   args,
+				"cmdTag": cmdTag,
+			}).Error("error update department in postgres")
+			return err
+		}
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return moduleErrors.ErrorDatabaseDepartmentNotFound
+	}
+
+	return nil
+}
+
+// MoveDepartmentMembers moves users and things of department to target department,
+// moved users get department_user credential in target department
+func (D *DepartmentDB) MoveDepartmentMembers(ctx context.Context, departmentId int, targetDepartmentId int) error {
+	logBase := logrus.Fields{
+		"module":             "postgres",
+		"file":               "department.go",
+		"function":           "MoveDepartmentMembers",
+		"departmentId":       departmentId,
+		"targetDepartmentId": targetDepartmentId,
+	}
+
+	db := D.db
+	tx, ok := D.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	queries := []string{`
+				INSERT INTO department_credentials
+					(credential_type, user_id, object_id)
+				SELECT
+					'department_user', id, $2
+				FROM
+					users
+				WHERE
+					department_id = $1
+				ON CONFLICT DO NOTHING`, `
+				UPDATE
+					users
+				SET
+					department_id = $2
+				WHERE
+					department_id = $1`, `
+				UPDATE
+					things
+				SET
+					department_id = $2
+				WHERE
+					department_id = $1`,
+	}
+
+	for _, query := range queries {
+		cmdTag, err := db.Exec(ctx, query, departmentId, targetDepartmentId)
+		if err != nil {
+			if pgErr, ok := err.(*pgconn.PgError); ok {
+				switch pgErr.Code {
+				default:
+					logrus.WithFields(logrus.Fields{
+						"base":    logBase,
+						"massage": pgErr.Message,
+						"where":   pgErr.Where,
+						"detail":  pgErr.Detail,
+						"code":    pgErr.Code,
+						"query":   logQuery(query),
+						"cmdTag":  cmdTag,
+					}).Error("error move department members in postgres")
+					return err
+				}
+			} else {
+				logrus.WithFields(logrus.Fields{
+					"base":   logBase,
+					"query":  logQuery(query),
+					"error":  err,
+					"cmdTag": cmdTag,
+				}).Error("error move department members in postgres")
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (D *DepartmentDB) DeleteDepartment(ctx context.Context, departmentId int) error {
+	logBase := logrus.Fields{
+		"module":       "postgres",
+		"file":         "department.go",
+		"function":     "DeleteDepartment",
+		"departmentId": departmentId,
+	}
+
+	db := D.db
+	tx, ok := D.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				DELETE
+				FROM
+				    departments
+				WHERE
+					id = $1`
+
+	cmdTag, err := db.Exec(ctx, query, departmentId)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+					"cmdTag":  cmdTag,
+				}).Error("error delete department from postgres")
+				return err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":   logBase,
+				"query":  logQuery(query),
+				"error":  err,
+				"cmdTag": cmdTag,
+			}).Error("error delete department from postgres")
+			return err
+		}
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return moduleErrors.ErrorDatabaseDepartmentNotFound
+	}
+
+	return nil
+}
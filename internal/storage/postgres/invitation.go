@@ -0,0 +1,372 @@
+package postgres
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+type dbDriverInvitationDB interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type transactionDBInvitationDB interface {
+	ExtractTx(ctx context.Context) (pgx.Tx, bool)
+}
+
+type InvitationDB struct {
+	dbDriver      dbDriverInvitationDB
+	transactionDB transactionDBInvitationDB
+}
+
+func NewInvitationDB(dbDriver dbDriverInvitationDB, transactionDB transactionDBInvitationDB) *InvitationDB {
+	return &InvitationDB{
+		dbDriver:      dbDriver,
+		transactionDB: transactionDB,
+	}
+}
+
+// invitationColumns are selected from invitations joined with departments as d
+const invitationColumns = `
+					invitations.id,
+					invitations.department_id,
+					d.company_id,
+					invitations.user_id,
+					invitations.email,
+					invitations.invited_by,
+					invitations.status,
+					invitations.created_time,
+					invitations.expires_time`
+
+func scanInvitation(row pgx.Row) (*core.Invitation, error) {
+	var invitation core.Invitation
+	var createdTime time.Time
+	var expiresTime time.Time
+
+	err := row.Scan(&invitation.Id, &invitation.DepartmentId, &invitation.CompanyId, &invitation.UserId,
+		&invitation.Email, &invitation.InvitedBy, &invitation.Status, &createdTime, &expiresTime)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation.CreatedTime = timestampToUnix(&createdTime)
+	invitation.ExpiresTime = timestampToUnix(&expiresTime)
+
+	return &invitation, nil
+}
+
+// AddInvitation adds invitation, tokenHash is hash of secret token sent to email, it is empty for invitation of user
+func (I *InvitationDB) AddInvitation(ctx context.Context, invitation *core.InvitationAdd, invitedBy int,
+	tokenHash string, expiresTime uint32) (*core.Invitation, error) {
+	logBase := logrus.Fields{
+		"module":     "postgres",
+		"file":       "invitation.go",
+		"function":   "AddInvitation",
+		"invitation": invitation,
+	}
+
+	db := I.dbDriver
+	tx, ok := I.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				WITH inserted AS (
+					INSERT INTO invitations
+						(department_id, user_id, email, invited_by, token_hash, expires_time)
+					VALUES
+						($1, $2, $3, $4, nullif($5, ''), $6)
+					RETURNING
+						*
+				)
+				SELECT` + invitationColumns + `
+				FROM
+					inserted invitations
+				JOIN
+					departments d ON d.id = invitations.department_id`
+
+	row := db.QueryRow(ctx, query, invitation.DepartmentId, invitation.UserId, invitation.Email, invitedBy, tokenHash,
+		unixToTimestamp(expiresTime))
+
+	ret, err := scanInvitation(row)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case "23503":
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"detail":  pgErr.Detail,
+				}).Warning("department or user of invitation not found")
+				return nil, moduleErrors.ErrorDatabaseDepartmentNotFound
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error add invitation to postgres")
+				return nil, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error add invitation to postgres")
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
+// GetInvitationForUpdate returns invitation and locks it until end of transaction
+func (I *InvitationDB) GetInvitationForUpdate(ctx context.Context, invitationId int) (*core.Invitation, error) {
+	logBase := logrus.Fields{
+		"module":       "postgres",
+		"file":         "invitation.go",
+		"function":     "GetInvitationForUpdate",
+		"invitationId": invitationId,
+	}
+
+	db := I.dbDriver
+	tx, ok := I.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT` + invitationColumns + `
+				FROM
+					invitations
+				JOIN
+					departments d ON d.id = invitations.department_id
+				WHERE
+					invitations.id = $1
+				FOR UPDATE OF invitations`
+
+	ret, err := scanInvitation(db.QueryRow(ctx, query, invitationId))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+			}).Warning("invitation not found")
+			return nil, moduleErrors.ErrorDatabaseInvitationNotFound
+		}
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error get invitation from postgres")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// GetUserInvitations returns pending and not expired invitations of user, newest first
+func (I *InvitationDB) GetUserInvitations(ctx context.Context, userId int) ([]core.Invitation, error) {
+	query := `
+				SELECT` + invitationColumns + `
+				FROM
+					invitations
+				JOIN
+					departments d ON d.id = invitations.department_id
+				WHERE
+					invitations.user_id = $1 AND
+					invitations.status = $2 AND
+					invitations.expires_time > now() at time zone 'utc'
+				ORDER BY
+					invitations.created_time DESC`
+
+	return I.getInvitations(ctx, query, userId, core.InvitationStatusPending)
+}
+
+// AttachEmailInvitations binds pending and not expired invitations sent to email to user with verified email,
+// invitations are returned newest first
+func (I *InvitationDB) AttachEmailInvitations(ctx context.Context, email string, userId int) ([]core.Invitation, error) {
+	query := `
+				WITH attached AS (
+					UPDATE
+						invitations
+					SET
+						user_id = $2
+					WHERE
+						lower(email) = lower($1) AND
+						user_id IS NULL AND
+						status = $3 AND
+						expires_time > now() at time zone 'utc'
+					RETURNING
+						*
+				)
+				SELECT` + invitationColumns + `
+				FROM
+					attached invitations
+				JOIN
+					departments d ON d.id = invitations.department_id
+				ORDER BY
+					invitations.created_time DESC`
+
+	return I.getInvitations(ctx, query, email, userId, core.InvitationStatusPending)
+}
+
+// AttachTokenInvitation binds pending and not expired invitation with token sent to email to registered user
+func (I *InvitationDB) AttachTokenInvitation(ctx context.Context, tokenHash string, email string,
+	userId int) (*core.Invitation, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "invitation.go",
+		"function": "AttachTokenInvitation",
+		"userId":   userId,
+	}
+
+	db := I.dbDriver
+	tx, ok := I.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				WITH attached AS (
+					UPDATE
+						invitations
+					SET
+						user_id = $3
+					WHERE
+						token_hash = $1 AND
+						lower(email) = lower($2) AND
+						user_id IS NULL AND
+						status = $4 AND
+						expires_time > now() at time zone 'utc'
+					RETURNING
+						*
+				)
+				SELECT` + invitationColumns + `
+				FROM
+					attached invitations
+				JOIN
+					departments d ON d.id = invitations.department_id`
+
+	ret, err := scanInvitation(db.QueryRow(ctx, query, tokenHash, email, userId, core.InvitationStatusPending))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+			}).Warning("invitation not found")
+			return nil, moduleErrors.ErrorDatabaseInvitationNotFound
+		}
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error attach invitation in postgres")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (I *InvitationDB) getInvitations(ctx context.Context, query string, args ...interface{}) ([]core.Invitation, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "invitation.go",
+		"function": "getInvitations",
+		"args":     args,
+	}
+
+	db := I.dbDriver
+	tx, ok := I.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error get invitations from postgres")
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]core.Invitation, 0)
+
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error scan rows")
+			return nil, err
+		}
+		ret = append(ret, *invitation)
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (I *InvitationDB) SetInvitationStatus(ctx context.Context, invitationId int, status string) error {
+	logBase := logrus.Fields{
+		"module":       "postgres",
+		"file":         "invitation.go",
+		"function":     "SetInvitationStatus",
+		"invitationId": invitationId,
+		"status":       status,
+	}
+
+	db := I.dbDriver
+	tx, ok := I.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				UPDATE
+					invitations
+				SET
+					status = $2
+				WHERE
+					id = $1`
+
+	cmdTag, err := db.Exec(ctx, query, invitationId, status)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"query":  logQuery(query),
+			"error":  err,
+			"cmdTag": cmdTag,
+		}).Error("error set invitation status")
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return moduleErrors.ErrorDatabaseInvitationNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/jackc/pgx/v4"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+type transactionDBJobDB interface {
+	ExtractTx(ctx context.Context) (pgx.Tx, bool)
+}
+
+type JobDB struct {
+	transactionDB transactionDBJobDB
+}
+
+func NewJobDB(transactionDB transactionDBJobDB) *JobDB {
+	return &JobDB{
+		transactionDB: transactionDB,
+	}
+}
+
+// ClaimJobRun takes advisory lock of job until the end of transaction and records run of job if previous run
+// was at least minInterval ago. false is returned if job is run by other replica or was run recently.
+// Run is recorded in the same transaction, so it is reverted if job fails.
+func (J *JobDB) ClaimJobRun(ctx context.Context, jobName string, minInterval time.Duration) (bool, error) {
+	logBase := logrus.Fields{
+		"module":      "postgres",
+		"file":        "job.go",
+		"function":    "ClaimJobRun",
+		"jobName":     jobName,
+		"minInterval": minInterval,
+	}
+
+	// transaction level lock is released at once without transaction
+	tx, ok := J.transactionDB.ExtractTx(ctx)
+	if !ok {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error(moduleErrors.ErrorDataBaseGetTransaction.Error())
+		return false, moduleErrors.ErrorDataBaseGetTransaction
+	}
+
+	query := `
+				SELECT
+					pg_try_advisory_xact_lock(hashtext('jobs'), hashtext($1))`
+
+	var locked bool
+	if err := tx.QueryRow(ctx, query, jobName).Scan(&locked); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error lock job")
+		return false, err
+	}
+	if !locked {
+		return false, nil
+	}
+
+	query = `
+				INSERT INTO job_runs
+					(job_name, last_run_time)
+				VALUES
+					($1, now() at time zone 'utc')
+				ON CONFLICT (job_name) DO UPDATE
+				SET
+					last_run_time = excluded.last_run_time
+				WHERE
+					job_runs.last_run_time <= excluded.last_run_time - $2 * interval '1 second'`
+
+	cmdTag, err := tx.Exec(ctx, query, jobName, minInterval.Seconds())
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"query":  logQuery(query),
+			"error":  err,
+			"cmdTag": cmdTag,
+		}).Error("error record job run")
+		return false, err
+	}
+
+	return cmdTag.RowsAffected() != 0, nil
+}
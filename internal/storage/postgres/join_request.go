@@ -0,0 +1,366 @@
+package postgres
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+type dbDriverJoinRequestDB interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type transactionDBJoinRequestDB interface {
+	ExtractTx(ctx context.Context) (pgx.Tx, bool)
+}
+
+type JoinRequestDB struct {
+	dbDriver      dbDriverJoinRequestDB
+	transactionDB transactionDBJoinRequestDB
+}
+
+func NewJoinRequestDB(dbDriver dbDriverJoinRequestDB, transactionDB transactionDBJoinRequestDB) *JoinRequestDB {
+	return &JoinRequestDB{
+		dbDriver:      dbDriver,
+		transactionDB: transactionDB,
+	}
+}
+
+// joinRequestColumns are selected from join_requests joined with departments as d
+const joinRequestColumns = `
+					join_requests.id,
+					join_requests.department_id,
+					d.company_id,
+					join_requests.user_id,
+					coalesce(join_requests.message, ''),
+					join_requests.status,
+					join_requests.created_time,
+					join_requests.answered_by,
+					join_requests.answered_time`
+
+func scanJoinRequest(row pgx.Row) (*core.JoinRequest, error) {
+	var joinRequest core.JoinRequest
+	var createdTime time.Time
+	var answeredTime *time.Time
+
+	err := row.Scan(&joinRequest.Id, &joinRequest.DepartmentId, &joinRequest.CompanyId, &joinRequest.UserId,
+		&joinRequest.Message, &joinRequest.Status, &createdTime, &joinRequest.AnsweredBy, &answeredTime)
+	if err != nil {
+		return nil, err
+	}
+
+	joinRequest.CreatedTime = timestampToUnix(&createdTime)
+	if answeredTime != nil {
+		joinRequest.AnsweredTime = timestampToUnix(answeredTime)
+	}
+
+	return &joinRequest, nil
+}
+
+func (J *JoinRequestDB) AddJoinRequest(ctx context.Context, joinRequest *core.JoinRequestAdd, userId int) (*core.JoinRequest, error) {
+	logBase := logrus.Fields{
+		"module":      "postgres",
+		"file":        "join_request.go",
+		"function":    "AddJoinRequest",
+		"joinRequest": joinRequest,
+		"userId":      userId,
+	}
+
+	db := J.dbDriver
+	tx, ok := J.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				WITH inserted AS (
+					INSERT INTO join_requests
+						(user_id, department_id, message)
+					VALUES
+						($1, $2, nullif($3, ''))
+					RETURNING
+						*
+				)
+				SELECT` + joinRequestColumns + `
+				FROM
+					inserted join_requests
+				JOIN
+					departments d ON d.id = join_requests.department_id`
+
+	ret, err := scanJoinRequest(db.QueryRow(ctx, query, userId, joinRequest.DepartmentId, joinRequest.Message))
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case "23505":
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"detail":  pgErr.Detail,
+				}).Warning("pending join request already exists")
+				return nil, moduleErrors.ErrorDatabaseJoinRequestExists
+			case "23503":
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"detail":  pgErr.Detail,
+				}).Warning("department of join request not found")
+				return nil, moduleErrors.ErrorDatabaseDepartmentNotFound
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error add join request to postgres")
+				return nil, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error add join request to postgres")
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
+// GetJoinRequestForUpdate returns join request and locks it until end of transaction
+func (J *JoinRequestDB) GetJoinRequestForUpdate(ctx context.Context, joinRequestId int) (*core.JoinRequest, error) {
+	logBase := logrus.Fields{
+		"module":        "postgres",
+		"file":          "join_request.go",
+		"function":      "GetJoinRequestForUpdate",
+		"joinRequestId": joinRequestId,
+	}
+
+	db := J.dbDriver
+	tx, ok := J.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT` + joinRequestColumns + `
+				FROM
+					join_requests
+				JOIN
+					departments d ON d.id = join_requests.department_id
+				WHERE
+					join_requests.id = $1
+				FOR UPDATE OF join_requests`
+
+	ret, err := scanJoinRequest(db.QueryRow(ctx, query, joinRequestId))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+			}).Warning("join request not found")
+			return nil, moduleErrors.ErrorDatabaseJoinRequestNotFound
+		}
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error get join request from postgres")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// GetUserJoinRequests returns all join requests of user, newest first
+func (J *JoinRequestDB) GetUserJoinRequests(ctx context.Context, userId int) ([]core.JoinRequest, error) {
+	query := `
+				SELECT` + joinRequestColumns + `
+				FROM
+					join_requests
+				JOIN
+					departments d ON d.id = join_requests.department_id
+				WHERE
+					join_requests.user_id = $1
+				ORDER BY
+					join_requests.created_time DESC`
+
+	return J.getJoinRequests(ctx, query, userId)
+}
+
+// GetDepartmentJoinRequests returns pending join requests to department, oldest first
+func (J *JoinRequestDB) GetDepartmentJoinRequests(ctx context.Context, departmentId int) ([]core.JoinRequest, error) {
+	query := `
+				SELECT` + joinRequestColumns + `
+				FROM
+					join_requests
+				JOIN
+					departments d ON d.id = join_requests.department_id
+				WHERE
+					join_requests.department_id = $1 AND
+					join_requests.status = $2
+				ORDER BY
+					join_requests.created_time`
+
+	return J.getJoinRequests(ctx, query, departmentId, core.JoinRequestStatusPending)
+}
+
+// GetCompanyJoinRequests returns pending join requests to all departments of company, oldest first
+func (J *JoinRequestDB) GetCompanyJoinRequests(ctx context.Context, companyId int) ([]core.JoinRequest, error) {
+	query := `
+				SELECT` + joinRequestColumns + `
+				FROM
+					join_requests
+				JOIN
+					departments d ON d.id = join_requests.department_id
+				WHERE
+					d.company_id = $1 AND
+					join_requests.status = $2
+				ORDER BY
+					join_requests.created_time`
+
+	return J.getJoinRequests(ctx, query, companyId, core.JoinRequestStatusPending)
+}
+
+func (J *JoinRequestDB) getJoinRequests(ctx context.Context, query string, args ...interface{}) ([]core.JoinRequest, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "join_request.go",
+		"function": "getJoinRequests",
+		"args":     args,
+	}
+
+	db := J.dbDriver
+	tx, ok := J.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error get join requests from postgres")
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]core.JoinRequest, 0)
+
+	for rows.Next() {
+		joinRequest, err := scanJoinRequest(rows)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error scan rows")
+			return nil, err
+		}
+		ret = append(ret, *joinRequest)
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// SetJoinRequestStatus sets status of join request, answeredBy is nil when request is cancelled by user
+func (J *JoinRequestDB) SetJoinRequestStatus(ctx context.Context, joinRequestId int, status string, answeredBy *int) error {
+	logBase := logrus.Fields{
+		"module":        "postgres",
+		"file":          "join_request.go",
+		"function":      "SetJoinRequestStatus",
+		"joinRequestId": joinRequestId,
+		"status":        status,
+	}
+
+	db := J.dbDriver
+	tx, ok := J.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				UPDATE
+					join_requests
+				SET
+					status = $2,
+					answered_by = $3,
+					answered_time = now() at time zone 'utc'
+				WHERE
+					id = $1`
+
+	cmdTag, err := db.Exec(ctx, query, joinRequestId, status, answeredBy)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"query":  logQuery(query),
+			"error":  err,
+			"cmdTag": cmdTag,
+		}).Error("error set join request status")
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return moduleErrors.ErrorDatabaseJoinRequestNotFound
+	}
+
+	return nil
+}
+
+// CancelUserJoinRequests cancels all pending join requests of user, it is called when user joins company
+func (J *JoinRequestDB) CancelUserJoinRequests(ctx context.Context, userId int) error {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "join_request.go",
+		"function": "CancelUserJoinRequests",
+		"userId":   userId,
+	}
+
+	db := J.dbDriver
+	tx, ok := J.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				UPDATE
+					join_requests
+				SET
+					status = $2,
+					answered_time = now() at time zone 'utc'
+				WHERE
+					user_id = $1 AND
+					status = $3`
+
+	cmdTag, err := db.Exec(ctx, query, userId, core.JoinRequestStatusCancelled, core.JoinRequestStatusPending)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"query":  logQuery(query),
+			"error":  err,
+			"cmdTag": cmdTag,
+		}).Error("error cancel join requests of user")
+		return err
+	}
+
+	return nil
+}
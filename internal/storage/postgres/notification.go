@@ -0,0 +1,428 @@
+package postgres
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+type dbDriverNotificationDB interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type transactionDBNotificationDB interface {
+	ExtractTx(ctx context.Context) (pgx.Tx, bool)
+}
+
+type NotificationDB struct {
+	dbDriver      dbDriverNotificationDB
+	transactionDB transactionDBNotificationDB
+}
+
+func NewNotificationDB(dbDriver dbDriverNotificationDB, transactionDB transactionDBNotificationDB) *NotificationDB {
+	return &NotificationDB{
+		dbDriver:      dbDriver,
+		transactionDB: transactionDB,
+	}
+}
+
+const notificationColumns = `
+					id,
+					user_id,
+					notification_type,
+					title,
+					body,
+					object_id,
+					is_read,
+					created_time`
+
+func scanNotification(row pgx.Row) (*core.Notification, error) {
+	var notification core.Notification
+	var createdTime time.Time
+
+	err := row.Scan(&notification.Id, &notification.UserId, &notification.Type, &notification.Title,
+		&notification.Body, &notification.ObjectId, &notification.IsRead, &createdTime)
+	if err != nil {
+		return nil, err
+	}
+
+	notification.CreatedTime = timestampToUnix(&createdTime)
+
+	return &notification, nil
+}
+
+func (N *NotificationDB) AddNotification(ctx context.Context, notification *core.NotificationAdd) (*core.Notification, error) {
+	logBase := logrus.Fields{
+		"module":       "postgres",
+		"file":         "notification.go",
+		"function":     "AddNotification",
+		"notification": notification,
+	}
+
+	db := N.dbDriver
+	tx, ok := N.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				INSERT INTO notifications
+					(user_id, notification_type, title, body, object_id)
+				VALUES
+					($1, $2, $3, $4, $5)
+				RETURNING` + notificationColumns
+
+	ret, err := scanNotification(db.QueryRow(ctx, query, notification.UserId, notification.Type, notification.Title,
+		notification.Body, notification.ObjectId))
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case "23503":
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"detail":  pgErr.Detail,
+				}).Warning("user of notification not found")
+				return nil, moduleErrors.ErrorDatabaseUserNotFound
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error add notification to postgres")
+				return nil, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error add notification to postgres")
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
+// GetUserNotifications returns page of user notifications, newest first. Notifications with id less than
+// beforeId are returned if it is set.
+func (N *NotificationDB) GetUserNotifications(ctx context.Context, userId int, unreadOnly bool, beforeId int,
+	limit int) ([]core.Notification, error) {
+	logBase := logrus.Fields{
+		"module":     "postgres",
+		"file":       "notification.go",
+		"function":   "GetUserNotifications",
+		"userId":     userId,
+		"unreadOnly": unreadOnly,
+		"beforeId":   beforeId,
+	}
+
+	db := N.dbDriver
+	tx, ok := N.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT` + notificationColumns + `
+				FROM
+					notifications
+				WHERE
+					user_id = $1 AND
+					(NOT $2 OR NOT is_read) AND
+					($3 = 0 OR id < $3)
+				ORDER BY
+					id DESC
+				LIMIT $4`
+
+	rows, err := db.Query(ctx, query, userId, unreadOnly, beforeId, limit)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error get notifications from postgres")
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]core.Notification, 0)
+
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error scan rows")
+			return nil, err
+		}
+		ret = append(ret, *notification)
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (N *NotificationDB) CountUnreadNotifications(ctx context.Context, userId int) (int, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "notification.go",
+		"function": "CountUnreadNotifications",
+		"userId":   userId,
+	}
+
+	db := N.dbDriver
+	tx, ok := N.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT
+					count(*)
+				FROM
+					notifications
+				WHERE
+					user_id = $1 AND
+					NOT is_read`
+
+	var count int
+	if err := db.QueryRow(ctx, query, userId).Scan(&count); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error count unread notifications in postgres")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// MarkNotificationRead marks notification of user as read, notifications of other users are not found
+func (N *NotificationDB) MarkNotificationRead(ctx context.Context, notificationId int, userId int) error {
+	logBase := logrus.Fields{
+		"module":         "postgres",
+		"file":           "notification.go",
+		"function":       "MarkNotificationRead",
+		"notificationId": notificationId,
+		"userId":         userId,
+	}
+
+	db := N.dbDriver
+	tx, ok := N.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				UPDATE
+					notifications
+				SET
+					is_read = true
+				WHERE
+					id = $1 AND
+					user_id = $2`
+
+	cmdTag, err := db.Exec(ctx, query, notificationId, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"query":  logQuery(query),
+			"error":  err,
+			"cmdTag": cmdTag,
+		}).Error("error mark notification read in postgres")
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return moduleErrors.ErrorDatabaseNotificationNotFound
+	}
+
+	return nil
+}
+
+func (N *NotificationDB) MarkAllNotificationsRead(ctx context.Context, userId int) error {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "notification.go",
+		"function": "MarkAllNotificationsRead",
+		"userId":   userId,
+	}
+
+	db := N.dbDriver
+	tx, ok := N.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				UPDATE
+					notifications
+				SET
+					is_read = true
+				WHERE
+					user_id = $1 AND
+					NOT is_read`
+
+	cmdTag, err := db.Exec(ctx, query, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"query":  logQuery(query),
+			"error":  err,
+			"cmdTag": cmdTag,
+		}).Error("error mark notifications read in postgres")
+		return err
+	}
+
+	return nil
+}
+
+// GetNotificationPreferences returns saved preferences of user, channels without preference are disabled
+func (N *NotificationDB) GetNotificationPreferences(ctx context.Context, userId int) ([]core.NotificationPreference, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "notification.go",
+		"function": "GetNotificationPreferences",
+		"userId":   userId,
+	}
+
+	db := N.dbDriver
+	tx, ok := N.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT
+					channel,
+					enabled,
+					coalesce(webhook_url, '')
+				FROM
+					notification_preferences
+				WHERE
+					user_id = $1
+				ORDER BY
+					channel`
+
+	rows, err := db.Query(ctx, query, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error get notification preferences from postgres")
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]core.NotificationPreference, 0)
+
+	for rows.Next() {
+		var preference core.NotificationPreference
+		if err = rows.Scan(&preference.Channel, &preference.Enabled, &preference.WebhookURL); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error scan rows")
+			return nil, err
+		}
+		ret = append(ret, preference)
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (N *NotificationDB) SetNotificationPreference(ctx context.Context, userId int,
+	preference *core.NotificationPreference) error {
+	logBase := logrus.Fields{
+		"module":     "postgres",
+		"file":       "notification.go",
+		"function":   "SetNotificationPreference",
+		"userId":     userId,
+		"preference": preference,
+	}
+
+	db := N.dbDriver
+	tx, ok := N.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				INSERT INTO notification_preferences
+					(user_id, channel, enabled, webhook_url)
+				VALUES
+					($1, $2, $3, nullif($4, ''))
+				ON CONFLICT (user_id, channel) DO UPDATE
+				SET
+					enabled = excluded.enabled,
+					webhook_url = excluded.webhook_url`
+
+	cmdTag, err := db.Exec(ctx, query, userId, preference.Channel, preference.Enabled, preference.WebhookURL)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case "23503":
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"detail":  pgErr.Detail,
+				}).Warning("user of notification preference not found")
+				return moduleErrors.ErrorDatabaseUserNotFound
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+					"cmdTag":  cmdTag,
+				}).Error("error set notification preference in postgres")
+				return err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":   logBase,
+				"query":  logQuery(query),
+				"error":  err,
+				"cmdTag": cmdTag,
+			}).Error("error set notification preference in postgres")
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type dbDriverPasswordResetDB interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type transactionDBPasswordResetDB interface {
+	ExtractTx(ctx context.Context) (pgx.Tx, bool)
+}
+
+type PasswordResetDB struct {
+	dbDriver      dbDriverPasswordResetDB
+	transactionDB transactionDBPasswordResetDB
+}
+
+func NewPasswordResetDB(dbDriver dbDriverPasswordResetDB, transactionDB transactionDBPasswordResetDB) *PasswordResetDB {
+	return &PasswordResetDB{
+		dbDriver:      dbDriver,
+		transactionDB: transactionDB,
+	}
+}
+
+func (P *PasswordResetDB) AddPasswordReset(ctx context.Context, userId int, tokenHash string, expiresTime uint32) error {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "password_reset.go",
+		"function": "AddPasswordReset",
+		"userId":   userId,
+	}
+
+	db := P.dbDriver
+	tx, ok := P.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				INSERT INTO password_resets
+				    (user_id, token_hash, expires_time)
+				VALUES
+				    ($1, $2, $3)`
+
+	cmdTag, err := db.Exec(ctx, query, userId, tokenHash, unixToTimestamp(expiresTime))
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+					"cmdTag":  cmdTag,
+				}).Error("error add password reset to postgres")
+				return err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":   logBase,
+				"query":  logQuery(query),
+				"error":  err,
+				"cmdTag": cmdTag,
+			}).Error("error add password reset to postgres")
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CountPasswordResets counts reset tokens of user created after since
+func (P *PasswordResetDB) CountPasswordResets(ctx context.Context, userId int, since uint32) (int, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "password_reset.go",
+		"function": "CountPasswordResets",
+		"userId":   userId,
+	}
+
+	db := P.dbDriver
+	tx, ok := P.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT
+					count(*)
+				FROM
+					password_resets
+				WHERE
+					user_id = $1 AND created_time > $2`
+
+	var count int
+
+	if err := db.QueryRow(ctx, query, userId, unixToTimestamp(since)).Scan(&count); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error count password resets")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// UsePasswordReset marks not used and not expired token as used and returns its user
+func (P *PasswordResetDB) UsePasswordReset(ctx context.Context, tokenHash string) (int, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "password_reset.go",
+		"function": "UsePasswordReset",
+	}
+
+	db := P.dbDriver
+	tx, ok := P.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				UPDATE
+					password_resets
+				SET
+					used_time = now() at time zone 'utc'
+				WHERE
+					token_hash = $1 AND
+					used_time IS NULL AND
+					expires_time > now() at time zone 'utc'
+				RETURNING
+					user_id`
+
+	var userId int
+
+	if err := db.QueryRow(ctx, query, tokenHash).Scan(&userId); err != nil {
+		if err == pgx.ErrNoRows {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+			}).Warning("password reset token not found")
+			return 0, moduleErrors.ErrorDatabaseResetTokenNotFound
+		}
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error use password reset token")
+		return 0, err
+	}
+
+	return userId, nil
+}
+
+// RevokeUserPasswordResets marks all tokens of user as used
+func (P *PasswordResetDB) RevokeUserPasswordResets(ctx context.Context, userId int) error {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "password_reset.go",
+		"function": "RevokeUserPasswordResets",
+		"userId":   userId,
+	}
+
+	db := P.dbDriver
+	tx, ok := P.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				UPDATE
+					password_resets
+				SET
+					used_time = now() at time zone 'utc'
+				WHERE
+					user_id = $1 AND used_time IS NULL`
+
+	cmdTag, err := db.Exec(ctx, query, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"query":  logQuery(query),
+			"error":  err,
+			"cmdTag": cmdTag,
+		}).Error("error revoke password resets")
+		return err
+	}
+
+	return nil
+}
@@ -69,3 +69,11 @@ func logQuery(query string) string {
 	query = strings.ReplaceAll(query, "\n", "")
 	return strings.ReplaceAll(query, "\t", "")
 }
+
+// likeEscaper escapes wildcards of LIKE pattern with backslash, which is default escape character of postgres
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike returns s which is matched by LIKE literally
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
@@ -0,0 +1,243 @@
+package postgres
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+type dbDriverSessionDB interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type transactionDBSessionDB interface {
+	ExtractTx(ctx context.Context) (pgx.Tx, bool)
+}
+
+type SessionDB struct {
+	dbDriver      dbDriverSessionDB
+	transactionDB transactionDBSessionDB
+}
+
+func NewSessionDB(dbDriver dbDriverSessionDB, transactionDB transactionDBSessionDB) *SessionDB {
+	return &SessionDB{
+		dbDriver:      dbDriver,
+		transactionDB: transactionDB,
+	}
+}
+
+func (S *SessionDB) AddSession(ctx context.Context, session *core.SessionAdd) (*core.Session, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "session.go",
+		"function": "AddSession",
+		"userId":   session.UserId,
+		"familyId": session.FamilyId,
+	}
+
+	db := S.dbDriver
+	tx, ok := S.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				INSERT INTO sessions
+				    (user_id, family_id, refresh_token_hash, expires_time)
+				VALUES
+				    ($1, coalesce($2::uuid, gen_random_uuid()), $3, $4)
+				RETURNING
+					id, family_id::text`
+
+	ret := core.Session{
+		UserId:      session.UserId,
+		ExpiresTime: session.ExpiresTime,
+	}
+
+	row := db.QueryRow(ctx, query, session.UserId, session.FamilyId, session.RefreshTokenHash,
+		unixToTimestamp(session.ExpiresTime))
+
+	if err := row.Scan(&ret.Id, &ret.FamilyId); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error add session to postgres")
+				return nil, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error add session to postgres")
+			return nil, err
+		}
+	}
+
+	return &ret, nil
+}
+
+// GetSessionForUpdate finds session by refresh token hash and locks it until the end of transaction
+func (S *SessionDB) GetSessionForUpdate(ctx context.Context, refreshTokenHash string) (*core.Session, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "session.go",
+		"function": "GetSessionForUpdate",
+	}
+
+	db := S.dbDriver
+	tx, ok := S.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT
+					id,
+					user_id,
+					family_id::text,
+					expires_time,
+					used_time,
+					revoked_time
+				FROM
+					sessions
+				WHERE
+					refresh_token_hash = $1
+				FOR UPDATE`
+
+	var session core.Session
+	var expiresTime time.Time
+	var usedTime, revokedTime *time.Time
+
+	err := db.QueryRow(ctx, query, refreshTokenHash).Scan(&session.Id, &session.UserId, &session.FamilyId,
+		&expiresTime, &usedTime, &revokedTime)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+			}).Warning("session not found")
+			return nil, moduleErrors.ErrorDatabaseSessionNotFound
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error get session from postgres")
+				return nil, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error get session from postgres")
+			return nil, err
+		}
+	}
+
+	session.ExpiresTime = timestampToUnix(&expiresTime)
+	session.UsedTime = timestampToUnix(usedTime)
+	session.RevokedTime = timestampToUnix(revokedTime)
+
+	return &session, nil
+}
+
+// SetSessionUsed marks refresh token of session as rotated, next use of it is reuse
+func (S *SessionDB) SetSessionUsed(ctx context.Context, sessionId int) error {
+	query := `
+				UPDATE
+					sessions
+				SET
+					used_time = now() at time zone 'utc'
+				WHERE
+					id = $1`
+
+	return S.exec(ctx, "SetSessionUsed", query, sessionId)
+}
+
+func (S *SessionDB) RevokeSessionFamily(ctx context.Context, familyId string) error {
+	query := `
+				UPDATE
+					sessions
+				SET
+					revoked_time = now() at time zone 'utc'
+				WHERE
+					family_id = $1::uuid AND revoked_time IS NULL`
+
+	return S.exec(ctx, "RevokeSessionFamily", query, familyId)
+}
+
+func (S *SessionDB) RevokeUserSessions(ctx context.Context, userId int) error {
+	query := `
+				UPDATE
+					sessions
+				SET
+					revoked_time = now() at time zone 'utc'
+				WHERE
+					user_id = $1 AND revoked_time IS NULL`
+
+	return S.exec(ctx, "RevokeUserSessions", query, userId)
+}
+
+func (S *SessionDB) exec(ctx context.Context, function string, query string, args ...interface{}) error {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "session.go",
+		"function": function,
+		"args":     args,
+	}
+
+	db := S.dbDriver
+	tx, ok := S.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	cmdTag, err := db.Exec(ctx, query, args...)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+					"cmdTag":  cmdTag,
+				}).Error("error update sessions in postgres")
+				return err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":   logBase,
+				"query":  logQuery(query),
+				"error":  err,
+				"cmdTag": cmdTag,
+			}).Error("error update sessions in postgres")
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,349 @@
+package postgres
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+type dbDriverStockDB interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type transactionDBStockDB interface {
+	ExtractTx(ctx context.Context) (pgx.Tx, bool)
+}
+
+type StockDB struct {
+	dbDriver      dbDriverStockDB
+	transactionDB transactionDBStockDB
+}
+
+func NewStockDB(dbDriver dbDriverStockDB, transactionDB transactionDBStockDB) *StockDB {
+	return &StockDB{
+		dbDriver:      dbDriver,
+		transactionDB: transactionDB,
+	}
+}
+
+const stockMovementColumns = `
+					id,
+					thing_id,
+					movement_type,
+					quantity,
+					remainder,
+					usage_id,
+					user_id,
+					coalesce(comment, ''),
+					created_time`
+
+func scanStockMovement(row pgx.Row) (*core.StockMovement, error) {
+	var movement core.StockMovement
+	var createdTime time.Time
+
+	err := row.Scan(&movement.Id, &movement.ThingId, &movement.Type, &movement.Quantity, &movement.Remainder,
+		&movement.UsageId, &movement.UserId, &movement.Comment, &createdTime)
+	if err != nil {
+		return nil, err
+	}
+
+	movement.CreatedTime = timestampToUnix(&createdTime)
+
+	return &movement, nil
+}
+
+// AddStockMovement changes remainder of thing by movement quantity and records movement with new remainder
+// in one statement, so remainder always matches the ledger
+func (S *StockDB) AddStockMovement(ctx context.Context, movement *core.StockMovement) (*core.StockMovement, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "stock.go",
+		"function": "AddStockMovement",
+		"movement": movement,
+	}
+
+	db := S.dbDriver
+	tx, ok := S.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				WITH updated AS (
+					UPDATE things
+					SET
+						thing_remainder = coalesce(thing_remainder, 0) + $3
+					WHERE
+						id = $1
+					RETURNING
+						id, thing_remainder
+				)
+				INSERT INTO stock_movements
+					(thing_id, movement_type, quantity, remainder, usage_id, user_id, comment)
+				SELECT
+					id, $2, $3, thing_remainder, $4, $5, nullif($6, '')
+				FROM
+					updated
+				RETURNING` + stockMovementColumns
+
+	ret, err := scanStockMovement(db.QueryRow(ctx, query, movement.ThingId, movement.Type, movement.Quantity,
+		movement.UsageId, movement.UserId, movement.Comment))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			logrus.WithFields(logrus.Fields{
+				"base": logBase,
+			}).Warning("thing of stock movement not found")
+			return nil, moduleErrors.ErrorDatabaseThingNotFound
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case "23514":
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"detail":  pgErr.Detail,
+				}).Warning("stock remainder would be negative")
+				return nil, moduleErrors.ErrorDatabaseInsufficientStock
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error add stock movement to postgres")
+				return nil, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error add stock movement to postgres")
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
+// GetStockMovements returns ledger of thing, newest movements first
+func (S *StockDB) GetStockMovements(ctx context.Context, thingId int) ([]core.StockMovement, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "stock.go",
+		"function": "GetStockMovements",
+		"thingId":  thingId,
+	}
+
+	db := S.dbDriver
+	tx, ok := S.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT` + stockMovementColumns + `
+				FROM
+					stock_movements
+				WHERE
+					thing_id = $1
+				ORDER BY
+					id DESC`
+
+	rows, err := db.Query(ctx, query, thingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error get stock movements from postgres")
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]core.StockMovement, 0)
+
+	for rows.Next() {
+		movement, err := scanStockMovement(rows)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error scan rows")
+			return nil, err
+		}
+		ret = append(ret, *movement)
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// CreateStockAlerts opens alerts for consumables below threshold which have no open alert yet
+func (S *StockDB) CreateStockAlerts(ctx context.Context) (int, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "stock.go",
+		"function": "CreateStockAlerts",
+	}
+
+	db := S.dbDriver
+	tx, ok := S.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				INSERT INTO stock_alerts
+					(thing_id)
+				SELECT
+					things.id
+				FROM
+					things
+				WHERE` + lowStockCondition + `
+				ON CONFLICT (thing_id) WHERE resolved_time IS NULL DO NOTHING`
+
+	tag, err := db.Exec(ctx, query)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error create stock alerts in postgres")
+		return 0, err
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// ResolveStockAlerts closes open alerts of things which are not below threshold anymore
+func (S *StockDB) ResolveStockAlerts(ctx context.Context) (int, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "stock.go",
+		"function": "ResolveStockAlerts",
+	}
+
+	db := S.dbDriver
+	tx, ok := S.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				UPDATE stock_alerts
+				SET
+					resolved_time = now() at time zone 'utc'
+				FROM
+					things
+				WHERE
+					stock_alerts.thing_id = things.id AND
+					stock_alerts.resolved_time IS NULL AND
+					NOT (` + lowStockCondition + `)`
+
+	tag, err := db.Exec(ctx, query)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error resolve stock alerts in postgres")
+		return 0, err
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// GetDepartmentStockAlerts returns open alerts of department things, oldest first
+func (S *StockDB) GetDepartmentStockAlerts(ctx context.Context, departmentId int) ([]core.StockAlert, error) {
+	logBase := logrus.Fields{
+		"module":       "postgres",
+		"file":         "stock.go",
+		"function":     "GetDepartmentStockAlerts",
+		"departmentId": departmentId,
+	}
+
+	db := S.dbDriver
+	tx, ok := S.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT
+					stock_alerts.id,
+					things.id,
+					things.thing_name,
+					things.department_id,
+					coalesce(things.thing_remainder, 0),
+					things.min_remainder,
+					coalesce(things.remainder_type::text, ''),
+					stock_alerts.created_time
+				FROM
+					stock_alerts
+					JOIN things ON things.id = stock_alerts.thing_id
+				WHERE
+					things.department_id = $1 AND
+					stock_alerts.resolved_time IS NULL
+				ORDER BY
+					stock_alerts.id`
+
+	rows, err := db.Query(ctx, query, departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error get stock alerts from postgres")
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]core.StockAlert, 0)
+
+	for rows.Next() {
+		var alert core.StockAlert
+		var createdTime time.Time
+
+		err = rows.Scan(&alert.Id, &alert.ThingId, &alert.ThingName, &alert.DepartmentId, &alert.Remainder,
+			&alert.MinRemainder, &alert.RemainderType, &createdTime)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error scan rows")
+			return nil, err
+		}
+		alert.CreatedTime = timestampToUnix(&createdTime)
+		ret = append(ret, alert)
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,637 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/sirupsen/logrus"
+	"strings"
+)
+
+type dbDriverThingDB interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type transactionDBThingDB interface {
+	ExtractTx(ctx context.Context) (pgx.Tx, bool)
+}
+
+type ThingDB struct {
+	dbDriver      dbDriverThingDB
+	transactionDB transactionDBThingDB
+}
+
+func NewThingDB(dbDriver dbDriverThingDB, transactionDB transactionDBThingDB) *ThingDB {
+	return &ThingDB{
+		dbDriver:      dbDriver,
+		transactionDB: transactionDB,
+	}
+}
+
+const thingSelectQuery = `
+				SELECT
+					id,
+					thing_name,
+					company_id,
+					department_id,
+					image_url,
+					thing_type,
+					thing_remainder,
+					remainder_type,
+					need_admin_approval,
+					min_remainder
+				FROM
+					things`
+
+// lowStockCondition selects consumables with threshold and remainder below it
+const lowStockCondition = `
+					things.thing_type = 'consumables' AND
+					things.min_remainder > 0 AND
+					coalesce(things.thing_remainder, 0) < things.min_remainder`
+
+func scanThing(row pgx.Row) (*core.Thing, error) {
+	var thingData core.Thing
+	var imageURL *string
+	var remainder *float32
+	var remainderType *string
+
+	err := row.Scan(&thingData.Id, &thingData.Name, &thingData.CompanyId, &thingData.DepartmentId,
+		&imageURL, &thingData.Type, &remainder, &remainderType, &thingData.NeedAdminApproval, &thingData.MinRemainder)
+	if err != nil {
+		return nil, err
+	}
+
+	if imageURL != nil {
+		thingData.ImageURL = *imageURL
+	}
+	if remainder != nil {
+		thingData.Remainder = *remainder
+	}
+	if remainderType != nil {
+		thingData.RemainderType = *remainderType
+	}
+
+	return &thingData, nil
+}
+
+func (T *ThingDB) AddThing(ctx context.Context, thingBase *core.ThingBase, companyId int, departmentId int) (*core.Thing, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "thing.go",
+		"function": "AddThing",
+	}
+
+	db := T.dbDriver
+	tx, ok := T.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				INSERT INTO things
+				    (thing_name, company_id, department_id, thing_type, thing_remainder,
+				     remainder_type, is_blocked, need_admin_approval, min_remainder)
+				VALUES
+				    ($1, $2, $3, $4, $5, $6, false, $7, $8)
+				RETURNING
+					id`
+
+	var remainderType *string
+	if thingBase.RemainderType != "" {
+		remainderType = &thingBase.RemainderType
+	}
+
+	row := db.QueryRow(ctx, query, thingBase.Name, companyId, departmentId, thingBase.Type,
+		thingBase.Remainder, remainderType, thingBase.NeedAdminApproval, thingBase.MinRemainder)
+
+	var thingId int
+
+	if err := row.Scan(&thingId); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":      logBase,
+					"thingBase": thingBase,
+					"massage":   pgErr.Message,
+					"where":     pgErr.Where,
+					"detail":    pgErr.Detail,
+					"code":      pgErr.Code,
+					"query":     logQuery(query),
+				}).Error("error add thing to postgres")
+				return nil, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":      logBase,
+				"thingBase": thingBase,
+				"query":     logQuery(query),
+				"error":     err,
+			}).Error("error add thing to postgres")
+			return nil, err
+		}
+	}
+
+	return &core.Thing{
+		ThingBase:    *thingBase,
+		Id:           thingId,
+		CompanyId:    companyId,
+		DepartmentId: departmentId,
+	}, nil
+}
+
+func (T *ThingDB) GetThing(ctx context.Context, thingId int) (*core.Thing, error) {
+	return T.getThing(ctx, thingId, false)
+}
+
+// GetThingForUpdate locks thing row until the end of transaction
+func (T *ThingDB) GetThingForUpdate(ctx context.Context, thingId int) (*core.Thing, error) {
+	return T.getThing(ctx, thingId, true)
+}
+
+func (T *ThingDB) getThing(ctx context.Context, thingId int, forUpdate bool) (*core.Thing, error) {
+	logBase := logrus.Fields{
+		"module":    "postgres",
+		"file":      "thing.go",
+		"function":  "getThing",
+		"thingId":   thingId,
+		"forUpdate": forUpdate,
+	}
+
+	db := T.dbDriver
+	tx, ok := T.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := thingSelectQuery + `
+				WHERE
+					id = $1`
+	if forUpdate {
+		query += `
+				FOR UPDATE`
+	}
+
+	thingData, err := scanThing(db.QueryRow(ctx, query, thingId))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("thing not found")
+			return nil, moduleErrors.ErrorDatabaseThingNotFound
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error get thing from postgres")
+				return nil, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error get thing from postgres")
+			return nil, err
+		}
+	}
+
+	return thingData, nil
+}
+
+func (T *ThingDB) getThings(ctx context.Context, field string, objectId int) ([]core.Thing, error) {
+	query := fmt.Sprintf(thingSelectQuery+`
+				WHERE
+					%s = $1
+				ORDER BY
+					id`, field)
+
+	return T.queryThings(ctx, logrus.Fields{
+		"module":   "postgres",
+		"file":     "thing.go",
+		"function": "getThings",
+		"field":    field,
+		"objectId": objectId,
+	}, query, objectId)
+}
+
+func (T *ThingDB) queryThings(ctx context.Context, logBase logrus.Fields, query string,
+	args ...interface{}) ([]core.Thing, error) {
+	db := T.dbDriver
+	tx, ok := T.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error get things from postgres")
+				return nil, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error get things from postgres")
+			return nil, err
+		}
+	}
+	defer rows.Close()
+
+	ret := make([]core.Thing, 0)
+
+	for rows.Next() {
+		thingData, err := scanThing(rows)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error scan rows")
+			return nil, err
+		}
+		ret = append(ret, *thingData)
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (T *ThingDB) GetThingsByCompany(ctx context.Context, companyId int) ([]core.Thing, error) {
+	return T.getThings(ctx, "company_id", companyId)
+}
+
+func (T *ThingDB) GetThingsByDepartment(ctx context.Context, departmentId int) ([]core.Thing, error) {
+	return T.getThings(ctx, "department_id", departmentId)
+}
+
+// GetLowStockThings returns consumables of company with remainder below threshold
+func (T *ThingDB) GetLowStockThings(ctx context.Context, companyId int) ([]core.Thing, error) {
+	query := thingSelectQuery + `
+				WHERE
+					company_id = $1 AND ` + lowStockCondition + `
+				ORDER BY
+					department_id, id`
+
+	return T.queryThings(ctx, logrus.Fields{
+		"module":    "postgres",
+		"file":      "thing.go",
+		"function":  "GetLowStockThings",
+		"companyId": companyId,
+	}, query, companyId)
+}
+
+func (T *ThingDB) UpdateThing(ctx context.Context, thing *core.ThingUpdate, thingId int) error {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "thing.go",
+		"function": "UpdateThing",
+		"thingId":  thingId,
+	}
+
+	db := T.dbDriver
+	tx, ok := T.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	setValues := make([]string, 0)
+	args := make([]interface{}, 0)
+	argId := 1
+
+	if thing.Name != nil {
+		setValues = append(setValues, fmt.Sprintf("thing_name = $%d", argId))
+		args = append(args, *thing.Name)
+		argId++
+	}
+	if thing.Type != nil {
+		setValues = append(setValues, fmt.Sprintf("thing_type = $%d", argId))
+		args = append(args, *thing.Type)
+		argId++
+	}
+	if thing.Remainder != nil {
+		setValues = append(setValues, fmt.Sprintf("thing_remainder = $%d", argId))
+		args = append(args, *thing.Remainder)
+		argId++
+	}
+	if thing.RemainderType != nil {
+		setValues = append(setValues, fmt.Sprintf("remainder_type = $%d", argId))
+		args = append(args, *thing.RemainderType)
+		argId++
+	}
+	if thing.NeedAdminApproval != nil {
+		setValues = append(setValues, fmt.Sprintf("need_admin_approval = $%d", argId))
+		args = append(args, *thing.NeedAdminApproval)
+		argId++
+	}
+	if thing.MinRemainder != nil {
+		setValues = append(setValues, fmt.Sprintf("min_remainder = $%d", argId))
+		args = append(args, *thing.MinRemainder)
+		argId++
+	}
+	if thing.ImageURL != nil {
+		setValues = append(setValues, fmt.Sprintf("image_url = $%d", argId))
+		args = append(args, *thing.ImageURL)
+		argId++
+	}
+
+	if argId == 1 {
+		return moduleErrors.ErrorDataBaseHasNotDataToChange
+	}
+
+	setQuery := strings.Join(setValues, ", ")
+	query := fmt.Sprintf(`
+				UPDATE
+					things
+				SET
+					%s
+				WHERE
+					id = $%d
+`, setQuery, argId)
+
+	args = append(args, thingId)
+
+	cmdTag, err := db.Exec(ctx, query, args...)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+					"args":    args,
+					"cmdTag":  cmdTag,
+				}).Error("error update thing in postgres")
+				return err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":   logBase,
+				"query":  logQuery(query),
+				"error":  err,
+				"args":   args,
+				"cmdTag": cmdTag,
+			}).Error("error update thing in postgres")
+			return err
+		}
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return moduleErrors.ErrorDatabaseThingNotFound
+	}
+
+	return nil
+}
+
+func (T *ThingDB) DeleteThing(ctx context.Context, thingId int) error {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "thing.go",
+		"function": "DeleteThing",
+		"thingId":  thingId,
+	}
+
+	db := T.dbDriver
+	tx, ok := T.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				DELETE
+				FROM
+				    things
+				WHERE
+					id = $1`
+
+	cmdTag, err := db.Exec(ctx, query, thingId)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+					"cmdTag":  cmdTag,
+				}).Error("error delete thing from postgres")
+				return err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":   logBase,
+				"query":  logQuery(query),
+				"error":  err,
+				"cmdTag": cmdTag,
+			}).Error("error delete thing from postgres")
+			return err
+		}
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return moduleErrors.ErrorDatabaseThingNotFound
+	}
+
+	return nil
+}
+
+// RefreshThingBlocking syncs things blocking columns with nearest not expired block from blocking_things,
+// thing is blocked only when this block has started
+func (T *ThingDB) RefreshThingBlocking(ctx context.Context, thingId int) error {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "thing.go",
+		"function": "RefreshThingBlocking",
+		"thingId":  thingId,
+	}
+
+	db := T.dbDriver
+	tx, ok := T.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				UPDATE
+					things
+				SET
+					is_blocked = block.id IS NOT NULL AND block.start_time <= (now() at time zone 'utc'),
+					blocked_time_start = block.start_time,
+					blocked_time_end = block.end_time
+				FROM
+					(SELECT 1) AS dummy
+					LEFT JOIN LATERAL (
+						SELECT
+							id,
+							start_time,
+							end_time
+						FROM
+							blocking_things
+						WHERE
+							thing_id = $1 AND
+							(end_time IS NULL OR end_time > (now() at time zone 'utc'))
+						ORDER BY
+							start_time
+						LIMIT 1
+					) AS block ON true
+				WHERE
+					things.id = $1`
+
+	cmdTag, err := db.Exec(ctx, query, thingId)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+					"cmdTag":  cmdTag,
+				}).Error("error refresh thing blocking")
+				return err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":   logBase,
+				"query":  logQuery(query),
+				"error":  err,
+				"cmdTag": cmdTag,
+			}).Error("error refresh thing blocking")
+			return err
+		}
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return moduleErrors.ErrorDatabaseThingNotFound
+	}
+
+	return nil
+}
+
+// RefreshExpiredBlocking refreshes blocking of things which current block ended or scheduled block started,
+// thing stays blocked if its next block has started. Ids of refreshed things are returned.
+func (T *ThingDB) RefreshExpiredBlocking(ctx context.Context) ([]int, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "thing.go",
+		"function": "RefreshExpiredBlocking",
+	}
+
+	db := T.dbDriver
+	tx, ok := T.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				UPDATE
+					things
+				SET
+					is_blocked = block.id IS NOT NULL AND block.start_time <= (now() at time zone 'utc'),
+					blocked_time_start = block.start_time,
+					blocked_time_end = block.end_time
+				FROM
+					things AS expired
+					LEFT JOIN LATERAL (
+						SELECT
+							id,
+							start_time,
+							end_time
+						FROM
+							blocking_things
+						WHERE
+							thing_id = expired.id AND
+							(end_time IS NULL OR end_time > (now() at time zone 'utc'))
+						ORDER BY
+							start_time
+						LIMIT 1
+					) AS block ON true
+				WHERE
+					things.id = expired.id AND
+					(
+						(expired.is_blocked AND expired.blocked_time_end <= (now() at time zone 'utc')) OR
+						(NOT expired.is_blocked AND expired.blocked_time_start <= (now() at time zone 'utc'))
+					)
+				RETURNING
+					things.id`
+
+	rows, err := db.Query(ctx, query)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error refresh expired blocking")
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]int, 0)
+
+	for rows.Next() {
+		var thingId int
+		if err = rows.Scan(&thingId); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error scan rows")
+			return nil, err
+		}
+		ret = append(ret, thingId)
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return nil, err
+	}
+
+	return ret, nil
+}
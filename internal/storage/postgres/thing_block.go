@@ -0,0 +1,447 @@
+package postgres
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+type dbDriverThingBlockDB interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type transactionDBThingBlockDB interface {
+	ExtractTx(ctx context.Context) (pgx.Tx, bool)
+}
+
+type ThingBlockDB struct {
+	dbDriver      dbDriverThingBlockDB
+	transactionDB transactionDBThingBlockDB
+}
+
+func NewThingBlockDB(dbDriver dbDriverThingBlockDB, transactionDB transactionDBThingBlockDB) *ThingBlockDB {
+	return &ThingBlockDB{
+		dbDriver:      dbDriver,
+		transactionDB: transactionDB,
+	}
+}
+
+const thingBlockSelectQuery = `
+				SELECT
+					blocking_things.id,
+					blocking_things.user_id,
+					blocking_things.thing_id,
+					blocking_things.start_time,
+					blocking_things.end_time,
+					blocking_things.reason
+				FROM
+					blocking_things`
+
+func scanThingBlock(row pgx.Row) (*core.ThingBlock, error) {
+	var block core.ThingBlock
+	var startTime time.Time
+	var endTime *time.Time
+	var reason *string
+
+	err := row.Scan(&block.Id, &block.UserId, &block.ThingId, &startTime, &endTime, &reason)
+	if err != nil {
+		return nil, err
+	}
+
+	block.StartTime = timestampToUnix(&startTime)
+	block.EndTime = timestampToUnix(endTime)
+	if reason != nil {
+		block.Reason = *reason
+	}
+
+	return &block, nil
+}
+
+func (T *ThingBlockDB) AddBlock(ctx context.Context, block *core.ThingBlockAdd) (*core.ThingBlock, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "thing_block.go",
+		"function": "AddBlock",
+	}
+
+	db := T.dbDriver
+	tx, ok := T.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				INSERT INTO blocking_things
+				    (user_id, thing_id, start_time, end_time, reason)
+				VALUES
+				    ($1, $2, $3, $4, $5)
+				RETURNING
+					id`
+
+	row := db.QueryRow(ctx, query, block.UserId, block.ThingId, unixToTimestamp(block.StartTime),
+		unixToTimestamp(block.EndTime), block.Reason)
+
+	var blockId int
+
+	if err := row.Scan(&blockId); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"block":   block,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error add thing block to postgres")
+				return nil, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"block": block,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error add thing block to postgres")
+			return nil, err
+		}
+	}
+
+	return &core.ThingBlock{
+		ThingBlockAdd: *block,
+		Id:            blockId,
+	}, nil
+}
+
+func (T *ThingBlockDB) GetBlock(ctx context.Context, blockId int) (*core.ThingBlock, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "thing_block.go",
+		"function": "GetBlock",
+		"blockId":  blockId,
+	}
+
+	db := T.dbDriver
+	tx, ok := T.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := thingBlockSelectQuery + `
+				WHERE
+					blocking_things.id = $1`
+
+	block, err := scanThingBlock(db.QueryRow(ctx, query, blockId))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("thing block not found")
+			return nil, moduleErrors.ErrorDatabaseBlockNotFound
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error get thing block from postgres")
+				return nil, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error get thing block from postgres")
+			return nil, err
+		}
+	}
+
+	return block, nil
+}
+
+func (T *ThingBlockDB) getBlocks(ctx context.Context, query string, args ...interface{}) ([]core.ThingBlock, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "thing_block.go",
+		"function": "getBlocks",
+		"args":     args,
+	}
+
+	db := T.dbDriver
+	tx, ok := T.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error get thing blocks from postgres")
+				return nil, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error get thing blocks from postgres")
+			return nil, err
+		}
+	}
+	defer rows.Close()
+
+	ret := make([]core.ThingBlock, 0)
+
+	for rows.Next() {
+		block, err := scanThingBlock(rows)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error scan rows")
+			return nil, err
+		}
+		ret = append(ret, *block)
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (T *ThingBlockDB) GetBlocksByCompany(ctx context.Context, companyId int) ([]core.ThingBlock, error) {
+	query := thingBlockSelectQuery + `
+				JOIN things ON things.id = blocking_things.thing_id
+				WHERE
+					things.company_id = $1
+				ORDER BY
+					blocking_things.start_time DESC`
+
+	return T.getBlocks(ctx, query, companyId)
+}
+
+func (T *ThingBlockDB) GetBlocksByDepartment(ctx context.Context, departmentId int) ([]core.ThingBlock, error) {
+	query := thingBlockSelectQuery + `
+				JOIN things ON things.id = blocking_things.thing_id
+				WHERE
+					things.department_id = $1
+				ORDER BY
+					blocking_things.start_time DESC`
+
+	return T.getBlocks(ctx, query, departmentId)
+}
+
+// GetOverlappingBlocks returns blocks of thing which intersect with time interval,
+// excludeBlockId used for skip changed block
+func (T *ThingBlockDB) GetOverlappingBlocks(ctx context.Context, thingId int, startTime uint32, endTime uint32,
+	excludeBlockId int) ([]core.ThingBlock, error) {
+	query := thingBlockSelectQuery + `
+				WHERE
+					blocking_things.thing_id = $1 AND
+					blocking_things.id != $2 AND
+					tsrange(blocking_things.start_time, coalesce(blocking_things.end_time, 'infinity')) &&
+					tsrange($3, coalesce($4, 'infinity'::timestamp))
+				ORDER BY
+					blocking_things.start_time`
+
+	return T.getBlocks(ctx, query, thingId, excludeBlockId, unixToTimestamp(startTime), unixToTimestamp(endTime))
+}
+
+func (T *ThingBlockDB) UpdateBlock(ctx context.Context, block *core.ThingBlockAdd, blockId int) error {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "thing_block.go",
+		"function": "UpdateBlock",
+		"blockId":  blockId,
+	}
+
+	db := T.dbDriver
+	tx, ok := T.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				UPDATE
+					blocking_things
+				SET
+					start_time = $1,
+					end_time = $2,
+					reason = $3
+				WHERE
+					id = $4`
+
+	cmdTag, err := db.Exec(ctx, query, unixToTimestamp(block.StartTime), unixToTimestamp(block.EndTime),
+		block.Reason, blockId)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+					"cmdTag":  cmdTag,
+				}).Error("error update thing block in postgres")
+				return err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":   logBase,
+				"query":  logQuery(query),
+				"error":  err,
+				"cmdTag": cmdTag,
+			}).Error("error update thing block in postgres")
+			return err
+		}
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return moduleErrors.ErrorDatabaseBlockNotFound
+	}
+
+	return nil
+}
+
+func (T *ThingBlockDB) DeleteBlock(ctx context.Context, blockId int) error {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "thing_block.go",
+		"function": "DeleteBlock",
+		"blockId":  blockId,
+	}
+
+	db := T.dbDriver
+	tx, ok := T.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				DELETE
+				FROM
+					blocking_things
+				WHERE
+					id = $1`
+
+	cmdTag, err := db.Exec(ctx, query, blockId)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+					"cmdTag":  cmdTag,
+				}).Error("error delete thing block from postgres")
+				return err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":   logBase,
+				"query":  logQuery(query),
+				"error":  err,
+				"cmdTag": cmdTag,
+			}).Error("error delete thing block from postgres")
+			return err
+		}
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return moduleErrors.ErrorDatabaseBlockNotFound
+	}
+
+	return nil
+}
+
+// AddUsageCancellation saves record for user about usage cancelled by block
+func (T *ThingBlockDB) AddUsageCancellation(ctx context.Context, usage *core.ThingUsage, blockId int, reason string) error {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "thing_block.go",
+		"function": "AddUsageCancellation",
+		"usageId":  usage.Id,
+		"blockId":  blockId,
+	}
+
+	db := T.dbDriver
+	tx, ok := T.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				INSERT INTO usage_cancellations
+				    (usage_id, user_id, block_id, reason)
+				VALUES
+				    ($1, $2, $3, $4)`
+
+	cmdTag, err := db.Exec(ctx, query, usage.Id, usage.UserId, blockId, reason)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+					"cmdTag":  cmdTag,
+				}).Error("error add usage cancellation to postgres")
+				return err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":   logBase,
+				"query":  logQuery(query),
+				"error":  err,
+				"cmdTag": cmdTag,
+			}).Error("error add usage cancellation to postgres")
+			return err
+		}
+	}
+
+	return nil
+}
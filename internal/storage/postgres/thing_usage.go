@@ -0,0 +1,638 @@
+package postgres
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+type dbDriverThingUsageDB interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type transactionDBThingUsageDB interface {
+	ExtractTx(ctx context.Context) (pgx.Tx, bool)
+}
+
+type ThingUsageDB struct {
+	dbDriver      dbDriverThingUsageDB
+	transactionDB transactionDBThingUsageDB
+}
+
+func NewThingUsageDB(dbDriver dbDriverThingUsageDB, transactionDB transactionDBThingUsageDB) *ThingUsageDB {
+	return &ThingUsageDB{
+		dbDriver:      dbDriver,
+		transactionDB: transactionDB,
+	}
+}
+
+const thingUsageColumns = `
+					using_things.id,
+					using_things.user_id,
+					using_things.thing_id,
+					using_things.start_time,
+					using_things.end_time,
+					using_things.is_approve,
+					using_things.is_taken,
+					using_things.status,
+					using_things.is_overdue`
+
+const thingUsageSelectQuery = `
+				SELECT` + thingUsageColumns + `
+				FROM
+					using_things`
+
+// unixToTimestamp converts unix time from api to time for postgres timestamp
+func unixToTimestamp(unixTime uint32) *time.Time {
+	if unixTime == 0 {
+		return nil
+	}
+	ret := time.Unix(int64(unixTime), 0).UTC()
+	return &ret
+}
+
+func timestampToUnix(timestamp *time.Time) uint32 {
+	if timestamp == nil {
+		return 0
+	}
+	return uint32(timestamp.Unix())
+}
+
+func scanThingUsage(row pgx.Row) (*core.ThingUsage, error) {
+	var usage core.ThingUsage
+	var startTime time.Time
+	var endTime *time.Time
+
+	err := row.Scan(&usage.Id, &usage.UserId, &usage.ThingId, &startTime, &endTime,
+		&usage.IsApproved, &usage.IsTaken, &usage.Status, &usage.IsOverdue)
+	if err != nil {
+		return nil, err
+	}
+
+	usage.StartTime = timestampToUnix(&startTime)
+	usage.EndTime = timestampToUnix(endTime)
+
+	return &usage, nil
+}
+
+func (T *ThingUsageDB) AddUsage(ctx context.Context, usage *core.ThingUsageAdd, status string) (*core.ThingUsage, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "thing_usage.go",
+		"function": "AddUsage",
+	}
+
+	db := T.dbDriver
+	tx, ok := T.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				INSERT INTO using_things
+				    (user_id, thing_id, start_time, end_time, is_approve, is_taken, status)
+				VALUES
+				    ($1, $2, $3, $4, $5, $6, $7)
+				RETURNING
+					id`
+
+	isApproved, isTaken := usageStatusFlags(status)
+
+	row := db.QueryRow(ctx, query, usage.UserId, usage.ThingId, unixToTimestamp(usage.StartTime),
+		unixToTimestamp(usage.EndTime), isApproved, isTaken, status)
+
+	var usageId int
+
+	if err := row.Scan(&usageId); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"usage":   usage,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error add thing usage to postgres")
+				return nil, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"usage": usage,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error add thing usage to postgres")
+			return nil, err
+		}
+	}
+
+	return &core.ThingUsage{
+		ThingUsageAdd: *usage,
+		Id:            usageId,
+		IsApproved:    isApproved,
+		IsTaken:       isTaken,
+		Status:        status,
+	}, nil
+}
+
+func (T *ThingUsageDB) GetUsage(ctx context.Context, usageId int) (*core.ThingUsage, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "thing_usage.go",
+		"function": "GetUsage",
+		"usageId":  usageId,
+	}
+
+	db := T.dbDriver
+	tx, ok := T.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := thingUsageSelectQuery + `
+				WHERE
+					using_things.id = $1`
+
+	usage, err := scanThingUsage(db.QueryRow(ctx, query, usageId))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("thing usage not found")
+			return nil, moduleErrors.ErrorDatabaseUsageNotFound
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error get thing usage from postgres")
+				return nil, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error get thing usage from postgres")
+			return nil, err
+		}
+	}
+
+	return usage, nil
+}
+
+func (T *ThingUsageDB) getUsages(ctx context.Context, query string, args ...interface{}) ([]core.ThingUsage, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "thing_usage.go",
+		"function": "getUsages",
+		"args":     args,
+	}
+
+	db := T.dbDriver
+	tx, ok := T.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error get thing usages from postgres")
+				return nil, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error get thing usages from postgres")
+			return nil, err
+		}
+	}
+	defer rows.Close()
+
+	ret := make([]core.ThingUsage, 0)
+
+	for rows.Next() {
+		usage, err := scanThingUsage(rows)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error scan rows")
+			return nil, err
+		}
+		ret = append(ret, *usage)
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (T *ThingUsageDB) GetUsagesByUser(ctx context.Context, userId int) ([]core.ThingUsage, error) {
+	query := thingUsageSelectQuery + `
+				WHERE
+					using_things.user_id = $1
+				ORDER BY
+					using_things.start_time DESC`
+
+	return T.getUsages(ctx, query, userId)
+}
+
+func (T *ThingUsageDB) GetUsagesByThing(ctx context.Context, thingId int) ([]core.ThingUsage, error) {
+	query := thingUsageSelectQuery + `
+				WHERE
+					using_things.thing_id = $1
+				ORDER BY
+					using_things.start_time DESC`
+
+	return T.getUsages(ctx, query, thingId)
+}
+
+func (T *ThingUsageDB) GetUsagesByCompany(ctx context.Context, companyId int) ([]core.ThingUsage, error) {
+	query := thingUsageSelectQuery + `
+				JOIN things ON things.id = using_things.thing_id
+				WHERE
+					things.company_id = $1
+				ORDER BY
+					using_things.start_time DESC`
+
+	return T.getUsages(ctx, query, companyId)
+}
+
+func (T *ThingUsageDB) GetUsagesByDepartment(ctx context.Context, departmentId int) ([]core.ThingUsage, error) {
+	query := thingUsageSelectQuery + `
+				JOIN things ON things.id = using_things.thing_id
+				WHERE
+					things.department_id = $1
+				ORDER BY
+					using_things.start_time DESC`
+
+	return T.getUsages(ctx, query, departmentId)
+}
+
+// MarkOverdueUsages marks taken usages which end time passed, only newly overdue usages are returned
+func (T *ThingUsageDB) MarkOverdueUsages(ctx context.Context) ([]core.ThingUsage, error) {
+	query := `
+				UPDATE
+					using_things
+				SET
+					is_overdue = true
+				WHERE
+					status = $1 AND
+					NOT is_overdue AND
+					end_time < (now() at time zone 'utc')
+				RETURNING` + thingUsageColumns
+
+	return T.getUsages(ctx, query, core.UsageStatusTaken)
+}
+
+// CancelNotTakenUsages cancels approved usages which weren't taken during grace period after start
+// or before end of usage
+func (T *ThingUsageDB) CancelNotTakenUsages(ctx context.Context, gracePeriod time.Duration) ([]core.ThingUsage, error) {
+	query := `
+				UPDATE
+					using_things
+				SET
+					status = $1,
+					is_approve = $2,
+					is_taken = $3
+				WHERE
+					status = $4 AND
+					least(start_time + $5 * interval '1 second', coalesce(end_time, 'infinity')) <
+						(now() at time zone 'utc')
+				RETURNING` + thingUsageColumns
+
+	isApproved, isTaken := usageStatusFlags(core.UsageStatusCancelled)
+
+	return T.getUsages(ctx, query, core.UsageStatusCancelled, isApproved, isTaken, core.UsageStatusApproved,
+		int(gracePeriod.Seconds()))
+}
+
+// GetRequestedUsagesByDepartments returns usages waiting for approval in departments
+func (T *ThingUsageDB) GetRequestedUsagesByDepartments(ctx context.Context, departmentIds []int) ([]core.ThingUsage, error) {
+	query := thingUsageSelectQuery + `
+				JOIN things ON things.id = using_things.thing_id
+				WHERE
+					things.department_id = ANY($1) AND
+					using_things.status = $2
+				ORDER BY
+					using_things.start_time`
+
+	return T.getUsages(ctx, query, departmentIds, core.UsageStatusRequested)
+}
+
+// CountOverlappingUsages counts active usages of thing which intersect with time interval,
+// usage without end time lasts forever
+func (T *ThingUsageDB) CountOverlappingUsages(ctx context.Context, thingId int, startTime uint32, endTime uint32) (int, error) {
+	logBase := logrus.Fields{
+		"module":    "postgres",
+		"file":      "thing_usage.go",
+		"function":  "CountOverlappingUsages",
+		"thingId":   thingId,
+		"startTime": startTime,
+		"endTime":   endTime,
+	}
+
+	db := T.dbDriver
+	tx, ok := T.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT
+					count(*)
+				FROM
+					using_things
+				WHERE
+					thing_id = $1 AND
+					status IN ($2, $3, $4) AND
+					tsrange(start_time, coalesce(end_time, 'infinity')) &&
+					tsrange($5, coalesce($6, 'infinity'::timestamp))`
+
+	row := db.QueryRow(ctx, query, thingId, core.UsageStatusRequested, core.UsageStatusApproved,
+		core.UsageStatusTaken, unixToTimestamp(startTime), unixToTimestamp(endTime))
+
+	var count int
+
+	if err := row.Scan(&count); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error count overlapping usages")
+				return 0, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error count overlapping usages")
+			return 0, err
+		}
+	}
+
+	return count, nil
+}
+
+// GetOverlappingUsages returns active usages of thing which intersect with time interval
+func (T *ThingUsageDB) GetOverlappingUsages(ctx context.Context, thingId int, startTime uint32, endTime uint32) ([]core.ThingUsage, error) {
+	query := thingUsageSelectQuery + `
+				WHERE
+					using_things.thing_id = $1 AND
+					using_things.status IN ($2, $3, $4) AND
+					tsrange(using_things.start_time, coalesce(using_things.end_time, 'infinity')) &&
+					tsrange($5, coalesce($6, 'infinity'::timestamp))
+				ORDER BY
+					using_things.start_time`
+
+	return T.getUsages(ctx, query, thingId, core.UsageStatusRequested, core.UsageStatusApproved,
+		core.UsageStatusTaken, unixToTimestamp(startTime), unixToTimestamp(endTime))
+}
+
+// SetUsageStatus changes status of usage if it still has currentStatus, so concurrent changes of usage
+// can't overwrite each other
+func (T *ThingUsageDB) SetUsageStatus(ctx context.Context, usageId int, currentStatus string, status string) error {
+	logBase := logrus.Fields{
+		"module":        "postgres",
+		"file":          "thing_usage.go",
+		"function":      "SetUsageStatus",
+		"usageId":       usageId,
+		"currentStatus": currentStatus,
+		"status":        status,
+	}
+
+	db := T.dbDriver
+	tx, ok := T.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				UPDATE
+					using_things
+				SET
+					status = $1,
+					is_approve = $2,
+					is_taken = $3
+				WHERE
+					id = $4 AND
+					status = $5`
+
+	isApproved, isTaken := usageStatusFlags(status)
+
+	cmdTag, err := db.Exec(ctx, query, status, isApproved, isTaken, usageId, currentStatus)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+					"cmdTag":  cmdTag,
+				}).Error("error update thing usage status")
+				return err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":   logBase,
+				"query":  logQuery(query),
+				"error":  err,
+				"cmdTag": cmdTag,
+			}).Error("error update thing usage status")
+			return err
+		}
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return moduleErrors.ErrorDatabaseUsageStatusChanged
+	}
+
+	return nil
+}
+
+// usageStatusFlags returns values of legacy is_approve and is_taken columns for status
+func usageStatusFlags(status string) (bool, bool) {
+	switch status {
+	case core.UsageStatusApproved, core.UsageStatusReturned:
+		return true, false
+	case core.UsageStatusTaken:
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+// CountUserTakenUsages counts things of company which are taken by user now,
+// departmentId 0 means all departments of company
+func (T *ThingUsageDB) CountUserTakenUsages(ctx context.Context, userId int, companyId int, departmentId int) (int, error) {
+	logBase := logrus.Fields{
+		"module":       "postgres",
+		"file":         "thing_usage.go",
+		"function":     "CountUserTakenUsages",
+		"userId":       userId,
+		"companyId":    companyId,
+		"departmentId": departmentId,
+	}
+
+	db := T.dbDriver
+	tx, ok := T.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT
+					count(*)
+				FROM
+					using_things
+				JOIN
+					things ON things.id = using_things.thing_id
+				WHERE
+					using_things.user_id = $1 AND
+					using_things.status = $2 AND
+					things.company_id = $3 AND
+					($4 = 0 OR things.department_id = $4)`
+
+	row := db.QueryRow(ctx, query, userId, core.UsageStatusTaken, companyId, departmentId)
+
+	var count int
+
+	if err := row.Scan(&count); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error count taken usages")
+				return 0, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error count taken usages")
+			return 0, err
+		}
+	}
+
+	return count, nil
+}
+
+// CancelUserUsages cancels requested and approved usages of user for things of company
+func (T *ThingUsageDB) CancelUserUsages(ctx context.Context, userId int, companyId int) error {
+	logBase := logrus.Fields{
+		"module":    "postgres",
+		"file":      "thing_usage.go",
+		"function":  "CancelUserUsages",
+		"userId":    userId,
+		"companyId": companyId,
+	}
+
+	db := T.dbDriver
+	tx, ok := T.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				UPDATE
+					using_things
+				SET
+					status = $1,
+					is_approve = $2,
+					is_taken = $3
+				FROM
+					things
+				WHERE
+					things.id = using_things.thing_id AND
+					using_things.user_id = $4 AND
+					using_things.status IN ($5, $6) AND
+					things.company_id = $7`
+
+	isApproved, isTaken := usageStatusFlags(core.UsageStatusCancelled)
+
+	cmdTag, err := db.Exec(ctx, query, core.UsageStatusCancelled, isApproved, isTaken, userId,
+		core.UsageStatusRequested, core.UsageStatusApproved, companyId)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+					"cmdTag":  cmdTag,
+				}).Error("error cancel user usages")
+				return err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":   logBase,
+				"query":  logQuery(query),
+				"error":  err,
+				"cmdTag": cmdTag,
+			}).Error("error cancel user usages")
+			return err
+		}
+	}
+
+	return nil
+}
@@ -7,7 +7,15 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-const transaction = "transaction"
+const (
+	transaction      = "transaction"
+	transactionHooks = "transactionHooks"
+)
+
+// commitHooks are called after successful commit of transaction
+type commitHooks struct {
+	hooks []func()
+}
 
 type db interface {
 	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
@@ -35,6 +43,7 @@ func (T *Transaction) InjectTx(ctx context.Context) (context.Context, error) {
 		}).Error("error starting transaction")
 		return nil, err
 	}
+	ctx = context.WithValue(ctx, transactionHooks, &commitHooks{})
 	return context.WithValue(ctx, transaction, tx), nil
 }
 
@@ -65,9 +74,27 @@ func (T *Transaction) CommitTx(ctx context.Context) error {
 		return err
 	}
 
+	if hooks, ok := ctx.Value(transactionHooks).(*commitHooks); ok {
+		for _, hook := range hooks.hooks {
+			hook()
+		}
+		hooks.hooks = nil
+	}
+
 	return nil
 }
 
+// AfterCommit registers fn which is called after commit of transaction from context,
+// fn isn't called if transaction is rolled back. Without transaction fn is called at once.
+func (T *Transaction) AfterCommit(ctx context.Context, fn func()) {
+	hooks, ok := ctx.Value(transactionHooks).(*commitHooks)
+	if !ok {
+		fn()
+		return
+	}
+	hooks.hooks = append(hooks.hooks, fn)
+}
+
 func (T *Transaction) RollbackTx(ctx context.Context) error {
 	logBase := logrus.Fields{
 		"module":   "postgres",
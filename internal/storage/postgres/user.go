@@ -9,6 +9,7 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/sirupsen/logrus"
 	"strings"
+	"time"
 )
 
 type dbDriverUserDB interface {
@@ -54,7 +55,9 @@ func (U *UserDB) GetUserByEmail(ctx context.Context, email string) (*core.UserDB
 				image_url, 
 				password_hash, 
 				company_id, 
-				department_id
+				department_id,
+				email_is_validated,
+				locked_time
 			FROM 
 				users 
 			WHERE 
@@ -63,9 +66,11 @@ func (U *UserDB) GetUserByEmail(ctx context.Context, email string) (*core.UserDB
 	row := db.QueryRow(ctx, query, email)
 
 	var userData core.UserDB
+	var lockedTime *time.Time
 
 	err := row.Scan(&userData.Id, &userData.FirstName, &userData.LastName, &userData.Email,
-		&userData.ImageURL, &userData.PasswordHash, &userData.CompanyId, &userData.DepartmentId)
+		&userData.ImageURL, &userData.PasswordHash, &userData.CompanyId, &userData.DepartmentId,
+		&userData.EmailIsValidated, &lockedTime)
 	if err != nil {
 		switch err.Error() {
 		case "no rows in result set":
@@ -85,6 +90,7 @@ func (U *UserDB) GetUserByEmail(ctx context.Context, email string) (*core.UserDB
 			return nil, err
 		}
 	}
+	userData.LockedTime = timestampToUnix(lockedTime)
 	return &userData, nil
 }
 
@@ -110,7 +116,10 @@ func (U *UserDB) GetUser(ctx context.Context, userId int) (*core.UserDB, error)
 				image_url, 
 				password_hash, 
 				company_id, 
-				department_id
+				department_id,
+				email_is_validated,
+				email_validation_sent_time,
+				locked_time
 			FROM 
 				users 
 			WHERE 
@@ -119,9 +128,11 @@ func (U *UserDB) GetUser(ctx context.Context, userId int) (*core.UserDB, error)
 	row := db.QueryRow(ctx, query, userId)
 
 	var userData core.UserDB
+	var emailValidationSentTime, lockedTime *time.Time
 
 	err := row.Scan(&userData.Id, &userData.FirstName, &userData.LastName, &userData.Email,
-		&userData.ImageURL, &userData.PasswordHash, &userData.CompanyId, &userData.DepartmentId)
+		&userData.ImageURL, &userData.PasswordHash, &userData.CompanyId, &userData.DepartmentId,
+		&userData.EmailIsValidated, &emailValidationSentTime, &lockedTime)
 	if err != nil {
 		switch err.Error() {
 		case "no rows in result set":
@@ -142,6 +153,8 @@ func (U *UserDB) GetUser(ctx context.Context, userId int) (*core.UserDB, error)
 			return nil, err
 		}
 	}
+	userData.EmailValidationSentTime = timestampToUnix(emailValidationSentTime)
+	userData.LockedTime = timestampToUnix(lockedTime)
 	return &userData, nil
 }
 
@@ -387,6 +400,13 @@ func (U *UserDB) PathUser(ctx context.Context, user *core.UserDB, userId int) er
 	if err != nil {
 		if pgErr, ok := err.(*pgconn.PgError); ok {
 			switch pgErr.Code {
+			case "23505":
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"detail":  pgErr.Detail,
+				}).Warning("user with email already exists")
+				return moduleErrors.ErrorDataBaseUserAlreadyHas
 			default:
 				logrus.WithFields(logrus.Fields{
 					"base":    logBase,
@@ -498,3 +518,405 @@ func (U *UserDB) UserInCompany(ctx context.Context, companyId int) ([]int, error
 
 	return ret, nil
 }
+
+// SetEmailValidationToken saves hash of email validation token and time of sending it
+func (U *UserDB) SetEmailValidationToken(ctx context.Context, userId int, tokenHash string) error {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "user.go",
+		"function": "SetEmailValidationToken",
+		"userId":   userId,
+	}
+
+	db := U.dbDriver
+	tx, ok := U.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+		UPDATE
+			users
+		SET
+			email_validation_token = $2,
+			email_validation_sent_time = now() at time zone 'utc'
+		WHERE
+			id = $1`
+
+	cmdTag, err := db.Exec(ctx, query, userId, tokenHash)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"query":  logQuery(query),
+			"error":  err,
+			"cmdTag": cmdTag,
+		}).Error("error set email validation token")
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return moduleErrors.ErrorDatabaseUserNotFound
+	}
+
+	return nil
+}
+
+// ConfirmEmail marks email of user with token as validated, token must be sent after sentAfter
+func (U *UserDB) ConfirmEmail(ctx context.Context, tokenHash string, sentAfter uint32) (int, error) {
+	logBase := logrus.Fields{
+		"module":    "postgres",
+		"file":      "user.go",
+		"function":  "ConfirmEmail",
+		"sentAfter": sentAfter,
+	}
+
+	db := U.dbDriver
+	tx, ok := U.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+		UPDATE
+			users
+		SET
+			email_is_validated = true,
+			email_validation_token = NULL,
+			email_validation_sent_time = NULL
+		WHERE
+			email_validation_token = $1 AND email_validation_sent_time > $2
+		RETURNING
+			id`
+
+	var userId int
+
+	err := db.QueryRow(ctx, query, tokenHash, unixToTimestamp(sentAfter)).Scan(&userId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+			}).Warning("user with email validation token not found")
+			return 0, moduleErrors.ErrorDatabaseUserNotFound
+		}
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error confirm email")
+		return 0, err
+	}
+
+	return userId, nil
+}
+
+// SetUserLocked locks user or removes lock, locked users can't sign in
+func (U *UserDB) SetUserLocked(ctx context.Context, userId int, locked bool) error {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "user.go",
+		"function": "SetUserLocked",
+		"userId":   userId,
+		"locked":   locked,
+	}
+
+	db := U.dbDriver
+	tx, ok := U.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+		UPDATE
+			users
+		SET
+			locked_time = CASE WHEN $2 THEN coalesce(locked_time, now() at time zone 'utc') END
+		WHERE
+			id = $1`
+
+	cmdTag, err := db.Exec(ctx, query, userId, locked)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"query":  logQuery(query),
+			"error":  err,
+			"cmdTag": cmdTag,
+		}).Error("error set user lock")
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return moduleErrors.ErrorDatabaseUserNotFound
+	}
+
+	return nil
+}
+
+// RemoveUserCompany clears company and department of user, PathUser can't set them to null
+func (U *UserDB) RemoveUserCompany(ctx context.Context, userId int) error {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "user.go",
+		"function": "RemoveUserCompany",
+		"userId":   userId,
+	}
+
+	db := U.dbDriver
+	tx, ok := U.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+		UPDATE
+			users
+		SET
+			company_id = NULL,
+			department_id = NULL
+		WHERE
+			id = $1`
+
+	cmdTag, err := db.Exec(ctx, query, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"query":  logQuery(query),
+			"error":  err,
+			"cmdTag": cmdTag,
+		}).Error("error remove user company")
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return moduleErrors.ErrorDatabaseUserNotFound
+	}
+
+	return nil
+}
+
+// DeleteUser deletes user, credentials, sessions and usages of user are deleted by cascade
+func (U *UserDB) DeleteUser(ctx context.Context, userId int) error {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "user.go",
+		"function": "DeleteUser",
+		"userId":   userId,
+	}
+
+	db := U.dbDriver
+	tx, ok := U.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+		DELETE FROM
+			users
+		WHERE
+			id = $1`
+
+	cmdTag, err := db.Exec(ctx, query, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"query":  logQuery(query),
+			"error":  err,
+			"cmdTag": cmdTag,
+		}).Error("error delete user from postgres")
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return moduleErrors.ErrorDatabaseUserNotFound
+	}
+
+	return nil
+}
+
+// userSortKeys are expressions of directory sort orders, users with equal key are ordered by id.
+// Users sorted by id have empty key.
+var userSortKeys = map[string]string{
+	core.UserSortName:  "lower(u.last_name || ' ' || u.first_name)",
+	core.UserSortEmail: "lower(u.email)",
+	core.UserSortId:    "",
+}
+
+// companyUsersConditions returns where conditions of directory filter, arguments are numbered from 1
+func companyUsersConditions(companyId int, filter *core.UserDirectoryFilter) ([]string, []interface{}) {
+	conditions := []string{"u.company_id = $1"}
+	args := []interface{}{companyId}
+
+	if filter.DepartmentId != nil {
+		args = append(args, *filter.DepartmentId)
+		conditions = append(conditions, fmt.Sprintf("u.department_id = $%d", len(args)))
+	}
+	if filter.Role != nil {
+		args = append(args, *filter.Role)
+		conditions = append(conditions, fmt.Sprintf(`(
+			EXISTS(SELECT 1 FROM company_credentials cc
+				WHERE cc.user_id = u.id AND cc.object_id = $1 AND cc.credential_type = $%[1]d::credential_types) OR
+			EXISTS(SELECT 1 FROM department_credentials dc JOIN departments d ON d.id = dc.object_id
+				WHERE dc.user_id = u.id AND d.company_id = $1 AND dc.credential_type = $%[1]d::credential_types))`,
+			len(args)))
+	}
+	if filter.NamePrefix != "" {
+		args = append(args, escapeLike(filter.NamePrefix)+"%")
+		conditions = append(conditions, fmt.Sprintf(
+			"(u.first_name || ' ' || u.last_name ILIKE $%[1]d OR u.last_name || ' ' || u.first_name ILIKE $%[1]d)",
+			len(args)))
+	}
+
+	return conditions, args
+}
+
+// GetCompanyUsers returns page of company users with their roles in company, page starts after cursor
+func (U *UserDB) GetCompanyUsers(ctx context.Context, companyId int, filter *core.UserDirectoryFilter,
+	cursor *core.UserDirectoryCursor, limit int) ([]core.UserDirectoryEntry, error) {
+	logBase := logrus.Fields{
+		"module":    "postgres",
+		"file":      "user.go",
+		"function":  "GetCompanyUsers",
+		"companyId": companyId,
+		"filter":    filter,
+		"cursor":    cursor,
+	}
+
+	db := U.dbDriver
+	tx, ok := U.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	sortKey, ok := userSortKeys[filter.Sort]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort %q", filter.Sort)
+	}
+
+	direction, compare := "ASC", ">"
+	if filter.Descending {
+		direction, compare = "DESC", "<"
+	}
+
+	selectKey := "''"
+	orderBy := fmt.Sprintf("u.id %s", direction)
+	if sortKey != "" {
+		selectKey = sortKey
+		orderBy = fmt.Sprintf("%[1]s %[2]s, u.id %[2]s", sortKey, direction)
+	}
+
+	conditions, args := companyUsersConditions(companyId, filter)
+	if cursor != nil && sortKey != "" {
+		args = append(args, cursor.SortKey, cursor.Id)
+		conditions = append(conditions, fmt.Sprintf("(%s, u.id) %s ($%d, $%d)", sortKey, compare, len(args)-1, len(args)))
+	} else if cursor != nil {
+		args = append(args, cursor.Id)
+		conditions = append(conditions, fmt.Sprintf("u.id %s $%d", compare, len(args)))
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT
+			u.id,
+			u.first_name,
+			u.last_name,
+			u.email,
+			u.email_is_validated,
+			u.image_url,
+			u.company_id,
+			u.department_id,
+			coalesce((
+				SELECT
+					json_agg(json_build_object('CredentialType', r.credential_type, 'ObjectId', r.object_id))
+				FROM (
+					SELECT cc.credential_type, cc.object_id FROM company_credentials cc
+					WHERE cc.user_id = u.id AND cc.object_id = $1
+					UNION ALL
+					SELECT dc.credential_type, dc.object_id FROM department_credentials dc
+					JOIN departments d ON d.id = dc.object_id
+					WHERE dc.user_id = u.id AND d.company_id = $1
+				) r
+			), '[]'),
+			%s
+		FROM
+			users u
+		WHERE
+			%s
+		ORDER BY
+			%s
+		LIMIT $%d`, selectKey, strings.Join(conditions, " AND\n\t\t\t"), orderBy, len(args))
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"args":  args,
+			"error": err,
+		}).Error("error get company users from postgres")
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]core.UserDirectoryEntry, 0)
+
+	for rows.Next() {
+		var entry core.UserDirectoryEntry
+		err = rows.Scan(&entry.Id, &entry.FirstName, &entry.LastName, &entry.Email, &entry.EmailIsValidated,
+			&entry.ImageURL, &entry.CompanyId, &entry.DepartmentId, &entry.Roles, &entry.SortKey)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error scan rows")
+			return nil, err
+		}
+		ret = append(ret, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// CountCompanyUsers returns count of company users matching directory filter, cursor isn't applied
+func (U *UserDB) CountCompanyUsers(ctx context.Context, companyId int, filter *core.UserDirectoryFilter) (int, error) {
+	db := U.dbDriver
+	tx, ok := U.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	conditions, args := companyUsersConditions(companyId, filter)
+
+	query := fmt.Sprintf(`
+		SELECT
+			count(*)
+		FROM
+			users u
+		WHERE
+			%s`, strings.Join(conditions, " AND\n\t\t\t"))
+
+	var count int
+	if err := db.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"module":    "postgres",
+			"file":      "user.go",
+			"function":  "CountCompanyUsers",
+			"companyId": companyId,
+			"filter":    filter,
+			"query":     logQuery(query),
+			"error":     err,
+		}).Error("error count company users in postgres")
+		return 0, err
+	}
+
+	return count, nil
+}
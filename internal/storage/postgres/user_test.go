@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/openlyinc/pointy"
+	"reflect"
+	"testing"
+)
+
+func TestEscapeLike(t *testing.T) {
+	testTable := []struct {
+		value string
+		want  string
+	}{
+		{value: "john", want: "john"},
+		{value: "100%", want: `100\%`},
+		{value: "j_hn", want: `j\_hn`},
+		{value: `back\slash`, want: `back\\slash`},
+		{value: `%_\`, want: `\%\_\\`},
+	}
+
+	for _, testCase := range testTable {
+		if got := escapeLike(testCase.value); got != testCase.want {
+			t.Errorf("escapeLike(%q) = %q, want %q", testCase.value, got, testCase.want)
+		}
+	}
+}
+
+func TestCompanyUsersConditions(t *testing.T) {
+	filter := &core.UserDirectoryFilter{
+		DepartmentId: pointy.Int(2),
+		Role:         pointy.String(core.CredentialTypeDepartmentAdmin),
+		NamePrefix:   "j_%",
+	}
+
+	conditions, args := companyUsersConditions(1, filter)
+	if len(conditions) != 4 {
+		t.Errorf("conditions count = %d, want 4", len(conditions))
+	}
+
+	// wildcards of name prefix are matched literally
+	wantArgs := []interface{}{1, 2, core.CredentialTypeDepartmentAdmin, `j\_\%%`}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v, want %v", args, wantArgs)
+	}
+}
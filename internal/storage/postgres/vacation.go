@@ -0,0 +1,556 @@
+package postgres
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+type dbDriverVacationDB interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type transactionDBVacationDB interface {
+	ExtractTx(ctx context.Context) (pgx.Tx, bool)
+}
+
+type VacationDB struct {
+	dbDriver      dbDriverVacationDB
+	transactionDB transactionDBVacationDB
+}
+
+func NewVacationDB(dbDriver dbDriverVacationDB, transactionDB transactionDBVacationDB) *VacationDB {
+	return &VacationDB{
+		dbDriver:      dbDriver,
+		transactionDB: transactionDB,
+	}
+}
+
+const vacationColumns = `
+					vacations.id,
+					vacations.user_id,
+					vacations.vacation_time_start,
+					vacations.vacation_time_end,
+					coalesce(vacations.comment, '')`
+
+func scanVacation(row pgx.Row, dest ...interface{}) (*core.Vacation, error) {
+	var vacation core.Vacation
+	var startTime, endTime time.Time
+
+	err := row.Scan(append([]interface{}{&vacation.Id, &vacation.UserId, &startTime, &endTime, &vacation.Comment},
+		dest...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	vacation.StartTime = timestampToUnix(&startTime)
+	vacation.EndTime = timestampToUnix(&endTime)
+
+	return &vacation, nil
+}
+
+func (V *VacationDB) AddVacation(ctx context.Context, vacation *core.VacationAdd, userId int) (*core.Vacation, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "vacation.go",
+		"function": "AddVacation",
+		"vacation": vacation,
+		"userId":   userId,
+	}
+
+	db := V.dbDriver
+	tx, ok := V.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				INSERT INTO vacations
+					(user_id, vacation_time_start, vacation_time_end, comment)
+				VALUES
+					($1, $2, $3, nullif($4, ''))
+				RETURNING` + vacationColumns
+
+	ret, err := scanVacation(db.QueryRow(ctx, query, userId, unixToTimestamp(vacation.StartTime),
+		unixToTimestamp(vacation.EndTime), vacation.Comment))
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case "23503":
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"detail":  pgErr.Detail,
+				}).Warning("user of vacation not found")
+				return nil, moduleErrors.ErrorDatabaseUserNotFound
+			default:
+				logrus.WithFields(logrus.Fields{
+					"base":    logBase,
+					"massage": pgErr.Message,
+					"where":   pgErr.Where,
+					"detail":  pgErr.Detail,
+					"code":    pgErr.Code,
+					"query":   logQuery(query),
+				}).Error("error add vacation to postgres")
+				return nil, err
+			}
+		} else {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error add vacation to postgres")
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
+func (V *VacationDB) GetVacation(ctx context.Context, vacationId int) (*core.Vacation, error) {
+	logBase := logrus.Fields{
+		"module":     "postgres",
+		"file":       "vacation.go",
+		"function":   "GetVacation",
+		"vacationId": vacationId,
+	}
+
+	db := V.dbDriver
+	tx, ok := V.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT` + vacationColumns + `
+				FROM
+					vacations
+				WHERE
+					vacations.id = $1`
+
+	ret, err := scanVacation(db.QueryRow(ctx, query, vacationId))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+			}).Warning("vacation not found")
+			return nil, moduleErrors.ErrorDatabaseVacationNotFound
+		}
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error get vacation from postgres")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// GetUserVacations returns all vacations of user ordered by start time
+func (V *VacationDB) GetUserVacations(ctx context.Context, userId int) ([]core.Vacation, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "vacation.go",
+		"function": "GetUserVacations",
+		"userId":   userId,
+	}
+
+	db := V.dbDriver
+	tx, ok := V.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT` + vacationColumns + `
+				FROM
+					vacations
+				WHERE
+					vacations.user_id = $1
+				ORDER BY
+					vacations.vacation_time_start`
+
+	rows, err := db.Query(ctx, query, userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error get user vacations from postgres")
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]core.Vacation, 0)
+
+	for rows.Next() {
+		vacation, err := scanVacation(rows)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error scan rows")
+			return nil, err
+		}
+		ret = append(ret, *vacation)
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// GetDepartmentVacations returns vacations of department members which intersect with time interval,
+// interval without end time lasts forever
+func (V *VacationDB) GetDepartmentVacations(ctx context.Context, departmentId int, startTime uint32,
+	endTime uint32) ([]core.DepartmentVacation, error) {
+	logBase := logrus.Fields{
+		"module":       "postgres",
+		"file":         "vacation.go",
+		"function":     "GetDepartmentVacations",
+		"departmentId": departmentId,
+		"startTime":    startTime,
+		"endTime":      endTime,
+	}
+
+	db := V.dbDriver
+	tx, ok := V.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT` + vacationColumns + `,
+					users.first_name,
+					users.last_name
+				FROM
+					vacations
+				JOIN
+					users ON users.id = vacations.user_id
+				WHERE
+					users.department_id = $1 AND
+					tsrange(vacations.vacation_time_start, vacations.vacation_time_end) &&
+					tsrange($2, coalesce($3, 'infinity'::timestamp))
+				ORDER BY
+					vacations.vacation_time_start,
+					users.last_name,
+					users.first_name`
+
+	rows, err := db.Query(ctx, query, departmentId, unixToTimestamp(startTime), unixToTimestamp(endTime))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error get department vacations from postgres")
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]core.DepartmentVacation, 0)
+
+	for rows.Next() {
+		var entry core.DepartmentVacation
+		vacation, err := scanVacation(rows, &entry.FirstName, &entry.LastName)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error scan rows")
+			return nil, err
+		}
+		entry.Vacation = *vacation
+		ret = append(ret, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (V *VacationDB) UpdateVacation(ctx context.Context, vacation *core.VacationAdd, vacationId int) error {
+	logBase := logrus.Fields{
+		"module":     "postgres",
+		"file":       "vacation.go",
+		"function":   "UpdateVacation",
+		"vacation":   vacation,
+		"vacationId": vacationId,
+	}
+
+	db := V.dbDriver
+	tx, ok := V.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				UPDATE
+					vacations
+				SET
+					vacation_time_start = $2,
+					vacation_time_end = $3,
+					comment = nullif($4, '')
+				WHERE
+					id = $1`
+
+	cmdTag, err := db.Exec(ctx, query, vacationId, unixToTimestamp(vacation.StartTime),
+		unixToTimestamp(vacation.EndTime), vacation.Comment)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"query":  logQuery(query),
+			"error":  err,
+			"cmdTag": cmdTag,
+		}).Error("error update vacation")
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return moduleErrors.ErrorDatabaseVacationNotFound
+	}
+
+	return nil
+}
+
+func (V *VacationDB) DeleteVacation(ctx context.Context, vacationId int) error {
+	logBase := logrus.Fields{
+		"module":     "postgres",
+		"file":       "vacation.go",
+		"function":   "DeleteVacation",
+		"vacationId": vacationId,
+	}
+
+	db := V.dbDriver
+	tx, ok := V.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				DELETE FROM
+					vacations
+				WHERE
+					id = $1`
+
+	cmdTag, err := db.Exec(ctx, query, vacationId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"query":  logQuery(query),
+			"error":  err,
+			"cmdTag": cmdTag,
+		}).Error("error delete vacation")
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return moduleErrors.ErrorDatabaseVacationNotFound
+	}
+
+	return nil
+}
+
+// LockUserVacations locks user row until end of transaction to serialize changes of user vacations
+func (V *VacationDB) LockUserVacations(ctx context.Context, userId int) error {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "vacation.go",
+		"function": "LockUserVacations",
+		"userId":   userId,
+	}
+
+	db := V.dbDriver
+	tx, ok := V.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT
+					id
+				FROM
+					users
+				WHERE
+					id = $1
+				FOR UPDATE`
+
+	var id int
+	if err := db.QueryRow(ctx, query, userId).Scan(&id); err != nil {
+		if err == pgx.ErrNoRows {
+			return moduleErrors.ErrorDatabaseUserNotFound
+		}
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error lock user")
+		return err
+	}
+
+	return nil
+}
+
+// CountOverlappingVacations counts vacations of user which intersect with time interval. Interval without
+// end time is checked only at start time, excluded vacation isn't counted.
+func (V *VacationDB) CountOverlappingVacations(ctx context.Context, userId int, startTime uint32, endTime uint32,
+	excludeVacationId int) (int, error) {
+	logBase := logrus.Fields{
+		"module":            "postgres",
+		"file":              "vacation.go",
+		"function":          "CountOverlappingVacations",
+		"userId":            userId,
+		"startTime":         startTime,
+		"endTime":           endTime,
+		"excludeVacationId": excludeVacationId,
+	}
+
+	db := V.dbDriver
+	tx, ok := V.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT
+					count(*)
+				FROM
+					vacations
+				WHERE
+					user_id = $1 AND
+					id != $4 AND
+					tsrange(vacation_time_start, vacation_time_end) &&
+					tsrange($2, coalesce($3, $2), '[]')`
+
+	var count int
+
+	err := db.QueryRow(ctx, query, userId, unixToTimestamp(startTime), unixToTimestamp(endTime),
+		excludeVacationId).Scan(&count)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error count overlapping vacations")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetUsagesWithApproversAway returns ids of usages from list whose thing department has admins or maintainers
+// and all of them are on vacation at time
+func (V *VacationDB) GetUsagesWithApproversAway(ctx context.Context, usageIds []int, at uint32) ([]int, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "vacation.go",
+		"function": "GetUsagesWithApproversAway",
+		"usageIds": usageIds,
+		"at":       at,
+	}
+
+	db := V.dbDriver
+	tx, ok := V.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT
+					using_things.id
+				FROM
+					using_things
+				JOIN
+					things ON things.id = using_things.thing_id
+				WHERE
+					using_things.id = ANY($1) AND
+					EXISTS (
+						SELECT
+							1
+						FROM
+							department_credentials
+						WHERE
+							object_id = things.department_id AND
+							credential_type IN ($3, $4)
+					) AND
+					NOT EXISTS (
+						SELECT
+							1
+						FROM
+							department_credentials
+						WHERE
+							object_id = things.department_id AND
+							credential_type IN ($3, $4) AND
+							NOT EXISTS (
+								SELECT
+									1
+								FROM
+									vacations
+								WHERE
+									vacations.user_id = department_credentials.user_id AND
+									vacations.vacation_time_start <= $2 AND
+									vacations.vacation_time_end > $2
+							)
+					)`
+
+	rows, err := db.Query(ctx, query, usageIds, unixToTimestamp(at), core.CredentialTypeDepartmentAdmin,
+		core.CredentialTypeDepartmentMaintainer)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error get usages with approvers away from postgres")
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]int, 0)
+
+	for rows.Next() {
+		var usageId int
+		if err = rows.Scan(&usageId); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error scan rows")
+			return nil, err
+		}
+		ret = append(ret, usageId)
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,610 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+type dbDriverWebhookDB interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+type transactionDBWebhookDB interface {
+	ExtractTx(ctx context.Context) (pgx.Tx, bool)
+}
+
+type WebhookDB struct {
+	dbDriver      dbDriverWebhookDB
+	transactionDB transactionDBWebhookDB
+}
+
+func NewWebhookDB(dbDriver dbDriverWebhookDB, transactionDB transactionDBWebhookDB) *WebhookDB {
+	return &WebhookDB{
+		dbDriver:      dbDriver,
+		transactionDB: transactionDB,
+	}
+}
+
+const webhookColumns = `
+					id,
+					company_id,
+					url,
+					secret,
+					event_types,
+					is_active,
+					created_time`
+
+func scanWebhook(row pgx.Row) (*core.Webhook, error) {
+	var webhook core.Webhook
+	var createdTime time.Time
+
+	err := row.Scan(&webhook.Id, &webhook.CompanyId, &webhook.URL, &webhook.Secret, &webhook.EventTypes,
+		&webhook.IsActive, &createdTime)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.CreatedTime = timestampToUnix(&createdTime)
+
+	return &webhook, nil
+}
+
+const webhookDeliveryColumns = `
+					id,
+					webhook_id,
+					event_type,
+					payload,
+					status,
+					attempts,
+					last_status_code,
+					coalesce(last_error, ''),
+					next_attempt_time,
+					delivered_time,
+					created_time`
+
+func scanWebhookDelivery(row pgx.Row) (*core.WebhookDelivery, error) {
+	var delivery core.WebhookDelivery
+	var payload []byte
+	var nextAttemptTime, createdTime time.Time
+	var deliveredTime *time.Time
+
+	err := row.Scan(&delivery.Id, &delivery.WebhookId, &delivery.EventType, &payload, &delivery.Status,
+		&delivery.Attempts, &delivery.LastStatusCode, &delivery.LastError, &nextAttemptTime, &deliveredTime,
+		&createdTime)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Payload = json.RawMessage(payload)
+	delivery.NextAttemptTime = timestampToUnix(&nextAttemptTime)
+	delivery.DeliveredTime = timestampToUnix(deliveredTime)
+	delivery.CreatedTime = timestampToUnix(&createdTime)
+
+	return &delivery, nil
+}
+
+func (W *WebhookDB) AddWebhook(ctx context.Context, companyId int, webhook *core.WebhookAdd) (*core.Webhook, error) {
+	logBase := logrus.Fields{
+		"module":    "postgres",
+		"file":      "webhook.go",
+		"function":  "AddWebhook",
+		"companyId": companyId,
+		"url":       webhook.URL,
+	}
+
+	db := W.dbDriver
+	tx, ok := W.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				INSERT INTO webhooks
+					(company_id, url, secret, event_types)
+				VALUES
+					($1, $2, $3, $4)
+				RETURNING` + webhookColumns
+
+	ret, err := scanWebhook(db.QueryRow(ctx, query, companyId, webhook.URL, webhook.Secret, webhook.EventTypes))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error add webhook to postgres")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (W *WebhookDB) GetWebhook(ctx context.Context, webhookId int) (*core.Webhook, error) {
+	logBase := logrus.Fields{
+		"module":    "postgres",
+		"file":      "webhook.go",
+		"function":  "GetWebhook",
+		"webhookId": webhookId,
+	}
+
+	db := W.dbDriver
+	tx, ok := W.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT` + webhookColumns + `
+				FROM
+					webhooks
+				WHERE
+					id = $1`
+
+	ret, err := scanWebhook(db.QueryRow(ctx, query, webhookId))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			logrus.WithFields(logrus.Fields{
+				"base": logBase,
+			}).Warning("webhook not found")
+			return nil, moduleErrors.ErrorDatabaseWebhookNotFound
+		}
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error get webhook from postgres")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (W *WebhookDB) GetCompanyWebhooks(ctx context.Context, companyId int) ([]core.Webhook, error) {
+	logBase := logrus.Fields{
+		"module":    "postgres",
+		"file":      "webhook.go",
+		"function":  "GetCompanyWebhooks",
+		"companyId": companyId,
+	}
+
+	db := W.dbDriver
+	tx, ok := W.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT` + webhookColumns + `
+				FROM
+					webhooks
+				WHERE
+					company_id = $1
+				ORDER BY
+					id`
+
+	rows, err := db.Query(ctx, query, companyId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error get webhooks from postgres")
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]core.Webhook, 0)
+
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error scan rows")
+			return nil, err
+		}
+		ret = append(ret, *webhook)
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// UpdateWebhook changes fields of webhook which are set in update
+func (W *WebhookDB) UpdateWebhook(ctx context.Context, webhookId int, webhook *core.WebhookUpdate) (*core.Webhook, error) {
+	logBase := logrus.Fields{
+		"module":    "postgres",
+		"file":      "webhook.go",
+		"function":  "UpdateWebhook",
+		"webhookId": webhookId,
+	}
+
+	db := W.dbDriver
+	tx, ok := W.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				UPDATE
+					webhooks
+				SET
+					url = coalesce($2, url),
+					secret = coalesce($3, secret),
+					event_types = coalesce($4, event_types),
+					is_active = coalesce($5, is_active)
+				WHERE
+					id = $1
+				RETURNING` + webhookColumns
+
+	ret, err := scanWebhook(db.QueryRow(ctx, query, webhookId, webhook.URL, webhook.Secret, webhook.EventTypes,
+		webhook.IsActive))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			logrus.WithFields(logrus.Fields{
+				"base": logBase,
+			}).Warning("webhook not found")
+			return nil, moduleErrors.ErrorDatabaseWebhookNotFound
+		}
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error update webhook in postgres")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// DeleteWebhook deletes webhook with its deliveries
+func (W *WebhookDB) DeleteWebhook(ctx context.Context, webhookId int) error {
+	logBase := logrus.Fields{
+		"module":    "postgres",
+		"file":      "webhook.go",
+		"function":  "DeleteWebhook",
+		"webhookId": webhookId,
+	}
+
+	db := W.dbDriver
+	tx, ok := W.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				DELETE FROM
+					webhooks
+				WHERE
+					id = $1`
+
+	cmdTag, err := db.Exec(ctx, query, webhookId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"query":  logQuery(query),
+			"error":  err,
+			"cmdTag": cmdTag,
+		}).Error("error delete webhook from postgres")
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return moduleErrors.ErrorDatabaseWebhookNotFound
+	}
+
+	return nil
+}
+
+// EnqueueWebhookDeliveries queues event payload for active webhooks of company which are subscribed to event type
+func (W *WebhookDB) EnqueueWebhookDeliveries(ctx context.Context, companyId int, eventType string,
+	payload []byte) (int, error) {
+	logBase := logrus.Fields{
+		"module":    "postgres",
+		"file":      "webhook.go",
+		"function":  "EnqueueWebhookDeliveries",
+		"companyId": companyId,
+		"eventType": eventType,
+	}
+
+	db := W.dbDriver
+	tx, ok := W.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				INSERT INTO webhook_deliveries
+					(webhook_id, event_type, payload)
+				SELECT
+					id, $2::varchar, $3::jsonb
+				FROM
+					webhooks
+				WHERE
+					company_id = $1 AND
+					is_active AND
+					$2 = ANY (event_types)`
+
+	cmdTag, err := db.Exec(ctx, query, companyId, eventType, payload)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"query":  logQuery(query),
+			"error":  err,
+			"cmdTag": cmdTag,
+		}).Error("error enqueue webhook deliveries in postgres")
+		return 0, err
+	}
+
+	return int(cmdTag.RowsAffected()), nil
+}
+
+// ClaimWebhookDeliveries returns pending deliveries of active webhooks which next attempt time has come,
+// oldest first. Next attempt of claimed deliveries is moved by lease seconds, so after commit they aren't
+// claimed by other run while they are sent, and they are sent again if result isn't recorded before lease ends.
+func (W *WebhookDB) ClaimWebhookDeliveries(ctx context.Context, limit int, lease int) ([]core.WebhookDispatch, error) {
+	logBase := logrus.Fields{
+		"module":   "postgres",
+		"file":     "webhook.go",
+		"function": "ClaimWebhookDeliveries",
+		"limit":    limit,
+		"lease":    lease,
+	}
+
+	db := W.dbDriver
+	tx, ok := W.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				WITH due AS (
+					SELECT
+						webhook_deliveries.id
+					FROM
+						webhook_deliveries
+						JOIN webhooks ON webhooks.id = webhook_deliveries.webhook_id
+					WHERE
+						webhook_deliveries.status = 'pending' AND
+						webhook_deliveries.next_attempt_time <= now() at time zone 'utc' AND
+						webhooks.is_active
+					ORDER BY
+						webhook_deliveries.next_attempt_time
+					LIMIT $1
+					FOR UPDATE OF webhook_deliveries SKIP LOCKED
+				)
+				UPDATE
+					webhook_deliveries
+				SET
+					next_attempt_time = now() at time zone 'utc' + $2 * interval '1 second'
+				FROM
+					due,
+					webhooks
+				WHERE
+					webhook_deliveries.id = due.id AND
+					webhooks.id = webhook_deliveries.webhook_id
+				RETURNING
+					webhook_deliveries.id,
+					webhook_deliveries.event_type,
+					webhook_deliveries.payload,
+					webhook_deliveries.attempts,
+					webhooks.url,
+					webhooks.secret`
+
+	rows, err := db.Query(ctx, query, limit, lease)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error claim webhook deliveries in postgres")
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]core.WebhookDispatch, 0)
+
+	for rows.Next() {
+		var dispatch core.WebhookDispatch
+		err = rows.Scan(&dispatch.DeliveryId, &dispatch.EventType, &dispatch.Payload, &dispatch.Attempts,
+			&dispatch.URL, &dispatch.Secret)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error scan rows")
+			return nil, err
+		}
+		ret = append(ret, dispatch)
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// SetWebhookDeliveryResult records attempt of delivery, pending delivery is retried after result.RetryAfter seconds
+func (W *WebhookDB) SetWebhookDeliveryResult(ctx context.Context, deliveryId int,
+	result *core.WebhookDeliveryResult) error {
+	logBase := logrus.Fields{
+		"module":     "postgres",
+		"file":       "webhook.go",
+		"function":   "SetWebhookDeliveryResult",
+		"deliveryId": deliveryId,
+		"result":     result,
+	}
+
+	db := W.dbDriver
+	tx, ok := W.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				UPDATE
+					webhook_deliveries
+				SET
+					status = $2,
+					attempts = attempts + 1,
+					last_status_code = nullif($3, 0),
+					last_error = nullif($4, ''),
+					next_attempt_time = now() at time zone 'utc' + $5 * interval '1 second',
+					delivered_time = CASE WHEN $2 = 'delivered' THEN now() at time zone 'utc' END
+				WHERE
+					id = $1`
+
+	cmdTag, err := db.Exec(ctx, query, deliveryId, result.Status, result.StatusCode, result.Error,
+		result.RetryAfter)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"query":  logQuery(query),
+			"error":  err,
+			"cmdTag": cmdTag,
+		}).Error("error set webhook delivery result in postgres")
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return moduleErrors.ErrorDatabaseWebhookDeliveryNotFound
+	}
+
+	return nil
+}
+
+// GetWebhookDeliveries returns delivery log of webhook, newest deliveries first
+func (W *WebhookDB) GetWebhookDeliveries(ctx context.Context, webhookId int, limit int) ([]core.WebhookDelivery, error) {
+	logBase := logrus.Fields{
+		"module":    "postgres",
+		"file":      "webhook.go",
+		"function":  "GetWebhookDeliveries",
+		"webhookId": webhookId,
+	}
+
+	db := W.dbDriver
+	tx, ok := W.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				SELECT` + webhookDeliveryColumns + `
+				FROM
+					webhook_deliveries
+				WHERE
+					webhook_id = $1
+				ORDER BY
+					id DESC
+				LIMIT $2`
+
+	rows, err := db.Query(ctx, query, webhookId, limit)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error get webhook deliveries from postgres")
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]core.WebhookDelivery, 0)
+
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"query": logQuery(query),
+				"error": err,
+			}).Error("error scan rows")
+			return nil, err
+		}
+		ret = append(ret, *delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error read rows")
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// RedeliverWebhookDelivery queues delivery of webhook again with reset attempts, so it is sent by next run of
+// delivery job
+func (W *WebhookDB) RedeliverWebhookDelivery(ctx context.Context, deliveryId int,
+	webhookId int) (*core.WebhookDelivery, error) {
+	logBase := logrus.Fields{
+		"module":     "postgres",
+		"file":       "webhook.go",
+		"function":   "RedeliverWebhookDelivery",
+		"deliveryId": deliveryId,
+		"webhookId":  webhookId,
+	}
+
+	db := W.dbDriver
+	tx, ok := W.transactionDB.ExtractTx(ctx)
+	if ok {
+		db = tx
+	}
+
+	query := `
+				UPDATE
+					webhook_deliveries
+				SET
+					status = 'pending',
+					attempts = 0,
+					next_attempt_time = now() at time zone 'utc'
+				WHERE
+					id = $1 AND
+					webhook_id = $2
+				RETURNING` + webhookDeliveryColumns
+
+	ret, err := scanWebhookDelivery(db.QueryRow(ctx, query, deliveryId, webhookId))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			logrus.WithFields(logrus.Fields{
+				"base": logBase,
+			}).Warning("webhook delivery not found")
+			return nil, moduleErrors.ErrorDatabaseWebhookDeliveryNotFound
+		}
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"query": logQuery(query),
+			"error": err,
+		}).Error("error redeliver webhook delivery in postgres")
+		return nil, err
+	}
+
+	return ret, nil
+}
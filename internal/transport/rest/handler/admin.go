@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+func adminErrorResponse(c *gin.Context, err error) {
+	switch err {
+	case moduleErrors.ErrorServiceSelfLock:
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		credentialsErrorResponse(c, err)
+	}
+}
+
+// changeUserLock locks or unlocks user from path param
+func (H *Handler) changeUserLock(c *gin.Context, locked bool) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "changeUserLock",
+		"locked":   locked,
+		"context":  *core.LogContext(c),
+	}
+
+	userId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	if locked {
+		err = H.admin.LockUser(c.Request.Context(), userId)
+	} else {
+		err = H.admin.UnlockUser(c.Request.Context(), userId)
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"userId": userId,
+			"error":  err.Error(),
+		}).Error("change user lock error")
+		adminErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, "ok")
+}
+
+// @Summary LockUser
+// @Security ApiKeyAuth
+// @Tags admin
+// @Description This request for lock user, locked user can't sign in and all sessions of user are revoked. Only for service admins
+// @ID lockUser
+// @Accept json
+// @Produces json
+// @Param userId path int true "user id"
+// @Success 200 {string} string "ok"
+// @Failure 400,401,403,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /admin/users/{userId}/lock [post]
+func (H *Handler) lockUser(c *gin.Context) {
+	H.changeUserLock(c, true)
+}
+
+// @Summary UnlockUser
+// @Security ApiKeyAuth
+// @Tags admin
+// @Description This request for unlock user. Only for service admins
+// @ID unlockUser
+// @Accept json
+// @Produces json
+// @Param userId path int true "user id"
+// @Success 200 {string} string "ok"
+// @Failure 400,401,403,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /admin/users/{userId}/unlock [post]
+func (H *Handler) unlockUser(c *gin.Context) {
+	H.changeUserLock(c, false)
+}
+
+// @Summary TransferCompany
+// @Security ApiKeyAuth
+// @Tags admin
+// @Description This request for make user the only admin of company. Only for service admins
+// @ID transferCompany
+// @Accept json
+// @Produces json
+// @Param companyId path int true "company id"
+// @Param userId query int true "new owner id, must be a member of company"
+// @Success 200 {string} string "ok"
+// @Failure 400,401,403,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /admin/company/{companyId}/transfer [post]
+func (H *Handler) forceTransferCompany(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "forceTransferCompany",
+		"context":  *core.LogContext(c),
+	}
+
+	companyId, err := strconv.Atoi(c.Param("company_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	userId, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	if err = H.admin.TransferCompany(c.Request.Context(), companyId, userId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":      logBase,
+			"companyId": companyId,
+			"userId":    userId,
+			"error":     err.Error(),
+		}).Error("transfer company error")
+		adminErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, "ok")
+}
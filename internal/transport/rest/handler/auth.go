@@ -41,7 +41,7 @@ func validatePassword(password string) error {
 
 // @Summary SignUp
 // @Tags auth
-// @Description This request for sign up user
+// @Description This request for sign up user, access token is set to cookie too for browser clients
 // @ID signUp
 // @Accept json
 // @Produces json
@@ -105,13 +105,21 @@ func (H *Handler) signUp(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, userData)
+	if err = setAuthCookies(c, userData.Token); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error set auth cookies")
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
 
+	c.JSON(http.StatusOK, userData)
 }
 
 // @Summary SignIn
 // @Tags auth
-// @Description This request for sign in user
+// @Description This request for sign in user, access token is set to cookie too for browser clients
 // @ID signIn
 // @Accept json
 // @Produces json
@@ -157,10 +165,408 @@ func (H *Handler) signIn(c *gin.Context) {
 		case moduleErrors.ErrorServiceUserNotFound:
 			newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerInvalidUsernameOrPassword.Error())
 			return
+		case moduleErrors.ErrorServiceUserLocked:
+			newErrorResponse(c, http.StatusForbidden, err.Error())
+			return
 		default:
 			newErrorResponse(c, http.StatusInternalServerError, err.Error())
 			return
 		}
 	}
+
+	if err = setAuthCookies(c, userData.Token); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error set auth cookies")
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	c.JSON(http.StatusOK, userData)
 }
+
+func refreshTokenErrorResponse(c *gin.Context, err error) {
+	switch err {
+	case moduleErrors.ErrorServiceInvalidRefreshToken, moduleErrors.ErrorServiceRefreshTokenReused:
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+	case moduleErrors.ErrorServiceUserLocked:
+		newErrorResponse(c, http.StatusForbidden, err.Error())
+	default:
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// @Summary Refresh
+// @Tags auth
+// @Description This request for getting new access token, refresh token can be used only once. Access token is set to cookie too
+// @ID refresh
+// @Accept json
+// @Produces json
+// @Param input body core.RefreshTokenData true "refresh token"
+// @Success 200 {object} core.TokenResponse
+// @Failure 400,401,403 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /auth/refresh [post]
+func (H *Handler) refresh(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "refresh",
+	}
+
+	var input core.RefreshTokenData
+
+	if err := c.BindJSON(&input); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error("json parsing error")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tokens, err := H.auth.Refresh(input.RefreshToken)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error("refresh token error")
+		refreshTokenErrorResponse(c, err)
+		return
+	}
+
+	if err = setAuthCookies(c, tokens.Token); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error set auth cookies")
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// @Summary SignOut
+// @Tags auth
+// @Description This request for sign out, session of refresh token is revoked and auth cookies are removed
+// @ID signOut
+// @Accept json
+// @Produces json
+// @Param input body core.RefreshTokenData true "refresh token"
+// @Success 200 {string} string "ok"
+// @Failure 400,401 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /auth/sign-out [post]
+func (H *Handler) signOut(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "signOut",
+	}
+
+	var input core.RefreshTokenData
+
+	if err := c.BindJSON(&input); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error("json parsing error")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := H.auth.SignOut(input.RefreshToken); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error("sign out error")
+		refreshTokenErrorResponse(c, err)
+		return
+	}
+
+	clearAuthCookies(c)
+	c.JSON(http.StatusOK, "ok")
+}
+
+// @Summary SignOutAll
+// @Security ApiKeyAuth
+// @Tags auth
+// @Description This request for sign out from all devices, all user sessions are revoked and auth cookies are removed
+// @ID signOutAll
+// @Accept json
+// @Produces json
+// @Success 200 {string} string "ok"
+// @Failure 401 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /auth/sign-out-all [post]
+func (H *Handler) signOutAll(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "signOutAll",
+		"context":  *core.LogContext(c),
+	}
+
+	if err := H.auth.SignOutAll(c.Request.Context()); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error("sign out all error")
+		thingErrorResponse(c, err)
+		return
+	}
+
+	clearAuthCookies(c)
+	c.JSON(http.StatusOK, "ok")
+}
+
+func emailVerificationErrorResponse(c *gin.Context, err error) {
+	switch err {
+	case moduleErrors.ErrorServiceInvalidEmailToken:
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+	case moduleErrors.ErrorServiceEmailAlreadyVerified:
+		newErrorResponse(c, http.StatusConflict, err.Error())
+	case moduleErrors.ErrorServiceEmailResendTooSoon:
+		newErrorResponse(c, http.StatusTooManyRequests, err.Error())
+	default:
+		thingErrorResponse(c, err)
+	}
+}
+
+// @Summary VerifyEmail
+// @Tags auth
+// @Description This request for verify user email by token from verification email
+// @ID verifyEmail
+// @Accept json
+// @Produces json
+// @Param input body core.VerifyEmailData true "email verification token"
+// @Success 200 {string} string "ok"
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /auth/verify-email [post]
+func (H *Handler) verifyEmail(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "verifyEmail",
+	}
+
+	var input core.VerifyEmailData
+
+	if err := c.BindJSON(&input); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error("json parsing error")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := H.emailVerification.VerifyEmail(c.Request.Context(), input.Token); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error("verify email error")
+		emailVerificationErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, "ok")
+}
+
+// @Summary ResendVerificationEmail
+// @Security ApiKeyAuth
+// @Tags auth
+// @Description This request for send verification email again
+// @ID resendVerificationEmail
+// @Accept json
+// @Produces json
+// @Success 200 {string} string "ok"
+// @Failure 401,409,429 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /auth/verify-email/resend [post]
+func (H *Handler) resendVerificationEmail(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "resendVerificationEmail",
+		"context":  *core.LogContext(c),
+	}
+
+	if err := H.emailVerification.ResendVerification(c.Request.Context()); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error("resend verification email error")
+		emailVerificationErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, "ok")
+}
+
+func passwordErrorResponse(c *gin.Context, err error) {
+	switch err {
+	case moduleErrors.ErrorServiceInvalidResetToken:
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+	case moduleErrors.ErrorServiceInvalidPassword:
+		newErrorResponse(c, http.StatusForbidden, err.Error())
+	default:
+		thingErrorResponse(c, err)
+	}
+}
+
+// @Summary ForgotPassword
+// @Tags auth
+// @Description This request for send password reset email, response doesn't depend on email existence
+// @ID forgotPassword
+// @Accept json
+// @Produces json
+// @Param input body core.ForgotPasswordData true "user email"
+// @Success 200 {string} string "ok"
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /auth/password/forgot [post]
+func (H *Handler) forgotPassword(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "forgotPassword",
+	}
+
+	var input core.ForgotPasswordData
+
+	if err := c.BindJSON(&input); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error("json parsing error")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := validateEmail(input.Email); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+			"email": input.Email,
+		}).Error("invalid email address")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := H.password.ForgotPassword(c.Request.Context(), input.Email); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error("forgot password error")
+		passwordErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, "ok")
+}
+
+// @Summary ResetPassword
+// @Tags auth
+// @Description This request for set new password by token from password reset email, all sessions are revoked
+// @ID resetPassword
+// @Accept json
+// @Produces json
+// @Param input body core.ResetPasswordData true "reset token and new password"
+// @Success 200 {string} string "ok"
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /auth/password/reset [post]
+func (H *Handler) resetPassword(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "resetPassword",
+	}
+
+	var input core.ResetPasswordData
+
+	if err := c.BindJSON(&input); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error("json parsing error")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := validatePassword(input.Password); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("invalid password")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := H.password.ResetPassword(c.Request.Context(), input.Token, input.Password); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error("reset password error")
+		passwordErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, "ok")
+}
+
+// @Summary ChangePassword
+// @Security ApiKeyAuth
+// @Tags user
+// @Description This request for change password of current user, all sessions are revoked
+// @ID changePassword
+// @Accept json
+// @Produces json
+// @Param input body core.ChangePasswordData true "old and new password"
+// @Success 200 {string} string "ok"
+// @Failure 400,401,403 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /user/password [post]
+func (H *Handler) changePassword(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "changePassword",
+		"context":  *core.LogContext(c),
+	}
+
+	var input core.ChangePasswordData
+
+	if err := c.BindJSON(&input); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error("json parsing error")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := validatePassword(input.NewPassword); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("invalid password")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := H.password.ChangePassword(c.Request.Context(), input.OldPassword, input.NewPassword); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error("change password error")
+		passwordErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, "ok")
+}
@@ -1,5 +1,130 @@
 package handler
 
+import (
+	"bytes"
+	mockhandler "github.com/Thing-repository/backend-server/internal/transport/rest/handler/mocks"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthCookies(t *testing.T) {
+	type mockBehavior func(s *mockhandler.Mockauth)
+
+	testTable := []struct {
+		name         string
+		path         string
+		handler      func(H *Handler) gin.HandlerFunc
+		inputBody    string
+		mockBehavior mockBehavior
+		// wantToken is access token of cookie, empty if cookies are cleared
+		wantToken string
+	}{
+		{
+			name:      "Sign in",
+			path:      "/auth/sign-in",
+			handler:   func(H *Handler) gin.HandlerFunc { return H.signIn },
+			inputBody: `{"email":"user@example.com","password":"Password1"}`,
+			mockBehavior: func(s *mockhandler.Mockauth) {
+				s.EXPECT().SignIn(&core.UserSignInData{UserMail: "user@example.com", UserPassword: "Password1"}).
+					Return(&core.SignInResponse{Token: "access_token", RefreshToken: "refresh_token"}, nil)
+			},
+			wantToken: "access_token",
+		},
+		{
+			name:      "Refresh",
+			path:      "/auth/refresh",
+			handler:   func(H *Handler) gin.HandlerFunc { return H.refresh },
+			inputBody: `{"refresh_token":"refresh_token"}`,
+			mockBehavior: func(s *mockhandler.Mockauth) {
+				s.EXPECT().Refresh("refresh_token").
+					Return(&core.TokenResponse{Token: "new_access_token", RefreshToken: "new_refresh_token"}, nil)
+			},
+			wantToken: "new_access_token",
+		},
+		{
+			name:      "Sign out",
+			path:      "/auth/sign-out",
+			handler:   func(H *Handler) gin.HandlerFunc { return H.signOut },
+			inputBody: `{"refresh_token":"refresh_token"}`,
+			mockBehavior: func(s *mockhandler.Mockauth) {
+				s.EXPECT().SignOut("refresh_token").Return(nil)
+			},
+		},
+		{
+			name:    "Sign out all",
+			path:    "/auth/sign-out-all",
+			handler: func(H *Handler) gin.HandlerFunc { return H.signOutAll },
+			mockBehavior: func(s *mockhandler.Mockauth) {
+				s.EXPECT().SignOutAll(gomock.Any()).Return(nil)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Init deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := mockhandler.NewMockauth(c)
+			testCase.mockBehavior(auth)
+
+			handler := &Handler{auth: auth}
+
+			r := gin.New()
+			r.POST(testCase.path, testCase.handler(handler))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", testCase.path, bytes.NewBufferString(testCase.inputBody))
+
+			// Perform request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			if w.Code != http.StatusOK {
+				t.Fatalf("status code = %d, want %d", w.Code, http.StatusOK)
+			}
+
+			cookies := map[string]*http.Cookie{}
+			for _, cookie := range w.Result().Cookies() {
+				cookies[cookie.Name] = cookie
+			}
+			accessToken, csrfToken := cookies[accessTokenCookie], cookies[csrfTokenCookie]
+			if accessToken == nil || csrfToken == nil {
+				t.Fatalf("cookies aren't set: %v", w.Header().Values("Set-Cookie"))
+			}
+
+			// scripts can't read access token, but read csrf token to send it in header
+			if !accessToken.HttpOnly || csrfToken.HttpOnly {
+				t.Errorf("http only of access token = %t, of csrf token = %t", accessToken.HttpOnly,
+					csrfToken.HttpOnly)
+			}
+			for _, cookie := range []*http.Cookie{accessToken, csrfToken} {
+				if !cookie.Secure || cookie.SameSite != http.SameSiteStrictMode || cookie.Path != "/" {
+					t.Errorf("cookie %s isn't secure: %s", cookie.Name, cookie.String())
+				}
+			}
+
+			if testCase.wantToken == "" {
+				if accessToken.MaxAge >= 0 || csrfToken.MaxAge >= 0 || accessToken.Value != "" {
+					t.Errorf("cookies aren't cleared: %v", w.Header().Values("Set-Cookie"))
+				}
+				return
+			}
+			if accessToken.Value != testCase.wantToken {
+				t.Errorf("access token cookie = %s, want %s", accessToken.Value, testCase.wantToken)
+			}
+			if len(csrfToken.Value) != 2*csrfTokenLength {
+				t.Errorf("invalid csrf token %s", csrfToken.Value)
+			}
+		})
+	}
+}
+
 //
 //import (
 //	"bytes"
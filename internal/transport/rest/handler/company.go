@@ -18,7 +18,7 @@ import (
 // @Produces json
 // @Param input body core.CompanyBase true "company info"
 // @Success 200 {object} core.Company
-// @Failure 400,401,409 {object} errorResponse
+// @Failure 400,401,403,409 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /company [post]
@@ -48,7 +48,7 @@ func (H *Handler) addCompany(c *gin.Context) {
 		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
 	}
 
-	res, err := H.company.AddCompany(c, &company)
+	res, err := H.company.AddCompany(c.Request.Context(), &company)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"base":  logBase,
@@ -58,6 +58,9 @@ func (H *Handler) addCompany(c *gin.Context) {
 		case moduleErrors.ErrorServiceUserAlreadyHasCompany:
 			newErrorResponse(c, http.StatusConflict, moduleErrors.ErrorServiceUserAlreadyHasCompany.Error())
 			return
+		case moduleErrors.ErrorServiceEmailNotVerified:
+			newErrorResponse(c, http.StatusForbidden, moduleErrors.ErrorServiceEmailNotVerified.Error())
+			return
 		case moduleErrors.ErrorServiceInvalidContext:
 			newErrorResponse(c, http.StatusUnauthorized, moduleErrors.ErrorHandlerForbidden.Error())
 			return
@@ -97,7 +100,7 @@ func (H *Handler) getCompany(c *gin.Context) {
 		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
 	}
 
-	companyData, err := H.company.GetCompany(c, companyId)
+	companyData, err := H.company.GetCompany(c.Request.Context(), companyId)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"base":  logBase,
@@ -169,7 +172,7 @@ func (H *Handler) patchCompany(c *gin.Context) {
 		return
 	}
 
-	companyData, err := H.company.UpdateCompany(c, company, companyId)
+	companyData, err := H.company.UpdateCompany(c.Request.Context(), company, companyId)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"base":      logBase,
@@ -220,7 +223,7 @@ func (H *Handler) deleteCompany(c *gin.Context) {
 		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
 	}
 
-	err = H.company.DeleteCompany(c, companyId)
+	err = H.company.DeleteCompany(c.Request.Context(), companyId)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"base":      logBase,
@@ -242,20 +245,121 @@ func (H *Handler) deleteCompany(c *gin.Context) {
 	c.AbortWithStatusJSON(http.StatusOK, "ok")
 }
 
-// @Summary NOT IMPLEMENTED! LoadCompanyImage
+// @Summary LoadCompanyImage
 // @Security ApiKeyAuth
 // @Tags company
-// @Description This request for load company image
+// @Description This request for load company image, image is sent in multipart field image. Jpeg, png and gif
+// @Description images are allowed, thumbnail is generated for every image
 // @ID loadCompanyImage
-// @Accept json
+// @Accept mpfd
 // @Produces json
 // @Param id path int true "company id"
-// @Param input body []byte true "image file"
-// @Success 200 {string} string "image_url"
-// @Failure 400,401 {object} errorResponse
+// @Param image formData file true "image file"
+// @Success 200 {object} core.Image
+// @Failure 400,401,403,404,413,415 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /company/{id}/image [post]
 func (H *Handler) loadCompanyImage(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "loadCompanyImage",
+		"context":  *core.LogContext(c),
+	}
+
+	companyId, err := strconv.Atoi(c.Param("company_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	upload, err := readImageUpload(c)
+	if err != nil {
+		imageErrorResponse(c, err)
+		return
+	}
+
+	image, err := H.image.LoadCompanyImage(c.Request.Context(), companyId, upload)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":      logBase,
+			"companyId": companyId,
+			"error":     err.Error(),
+		}).Error("load company image error")
+		imageErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, image)
+}
+
+// @Summary FindCompanies
+// @Security ApiKeyAuth
+// @Tags company
+// @Description This request for find companies by name with their departments for join request
+// @ID findCompanies
+// @Accept json
+// @Produces json
+// @Param filter query string true "beginning of company name"
+// @Param limit query int true "limit for found companies list"
+// @Param offset query int true "offset for found companies list"
+// @Success 200 {array} core.CompanySearchResult
+// @Failure 400,401 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /companies/find [get]
+func (H *Handler) findCompanies(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "findCompanies",
+		"context":  *core.LogContext(c),
+	}
+
+	filter, ok := c.GetQuery("filter")
+	if !ok {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error("error get filter from query")
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get limit from query")
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	offset, err := strconv.Atoi(c.Query("offset"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get offset from query")
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	companies, err := H.company.FindCompanies(c.Request.Context(), filter, limit, offset)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"filter": filter,
+			"limit":  limit,
+			"offset": offset,
+			"error":  err.Error(),
+		}).Error("error find companies")
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, companies)
 }
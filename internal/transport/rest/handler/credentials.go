@@ -1,11 +1,113 @@
 package handler
 
 import (
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 	"net/http"
+	"strconv"
 )
 
-// @Summary NOT IMPLEMENTED! CompanyAdmins
+func credentialsErrorResponse(c *gin.Context, err error) {
+	switch err {
+	case moduleErrors.ErrorServiceUserNotFound,
+		moduleErrors.ErrorServiceCredentialNotFound:
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+	case moduleErrors.ErrorServiceCredentialAlreadyExists,
+		moduleErrors.ErrorServiceLastCompanyAdmin:
+		newErrorResponse(c, http.StatusConflict, err.Error())
+	case moduleErrors.ErrorServiceUserNotInCompany,
+		moduleErrors.ErrorServiceInvalidCredentialType:
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		departmentErrorResponse(c, err)
+	}
+}
+
+// getCredentialUsers responds with users which have credential on object from path param
+func (H *Handler) getCredentialUsers(c *gin.Context, credentialType string, objectParam string) {
+	logBase := logrus.Fields{
+		"module":         "handler",
+		"function":       "getCredentialUsers",
+		"credentialType": credentialType,
+		"context":        *core.LogContext(c),
+	}
+
+	objectId, err := strconv.Atoi(c.Param(objectParam))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	users, err := H.credentials.GetCredentialUsers(c.Request.Context(), credentialType, objectId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":     logBase,
+			"objectId": objectId,
+			"error":    err.Error(),
+		}).Error("get credential users error")
+		credentialsErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, users)
+}
+
+// changeCredential grants or revokes credential on object from path param to user from userId query
+func (H *Handler) changeCredential(c *gin.Context, credentialType string, objectParam string, grant bool) {
+	logBase := logrus.Fields{
+		"module":         "handler",
+		"function":       "changeCredential",
+		"credentialType": credentialType,
+		"grant":          grant,
+		"context":        *core.LogContext(c),
+	}
+
+	objectId, err := strconv.Atoi(c.Param(objectParam))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	userId, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	if grant {
+		err = H.credentials.GrantCredential(c.Request.Context(), credentialType, objectId, userId)
+	} else {
+		err = H.credentials.RevokeCredential(c.Request.Context(), credentialType, objectId, userId)
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":     logBase,
+			"objectId": objectId,
+			"userId":   userId,
+			"error":    err.Error(),
+		}).Error("change credential error")
+		credentialsErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, "ok")
+}
+
+// @Summary CompanyAdmins
 // @Security ApiKeyAuth
 // @Tags rights
 // @Description This request for get users from company admins list
@@ -14,15 +116,15 @@ import (
 // @Produces json
 // @Param companyId path int true "company id"
 // @Success 200 {array} core.User "list of company admins"
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /company/{companyId}/company_admins [get]
 func (H *Handler) getCompanyAdmins(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	H.getCredentialUsers(c, core.CredentialTypeCompanyAdmin, "company_id")
 }
 
-// @Summary NOT IMPLEMENTED! CompanyAdmins
+// @Summary CompanyAdmins
 // @Security ApiKeyAuth
 // @Tags rights
 // @Description This request for add user to company admins
@@ -32,33 +134,33 @@ func (H *Handler) getCompanyAdmins(c *gin.Context) {
 // @Param companyId path int true "company id"
 // @Param userId query int true "added user id"
 // @Success 200 {string} string "ok"
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404,409 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /company/{companyId}/company_admins [post]
 func (H *Handler) addCompanyAdmin(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	H.changeCredential(c, core.CredentialTypeCompanyAdmin, "company_id", true)
 }
 
-// @Summary NOT IMPLEMENTED! CompanyAdmins
+// @Summary CompanyAdmins
 // @Security ApiKeyAuth
 // @Tags rights
 // @Description This request for delete user from company admins
 // @ID deleteCompanyAdmin
 // @Accept json
 // @Produces json
-// @Param companyId path int false "company id"
+// @Param companyId path int true "company id"
 // @Param userId query int true "deleted user id"
 // @Success 200 {string} string "ok"
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404,409 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /company/{companyId}/company_admins [delete]
 func (H *Handler) deleteCompanyAdmin(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	H.changeCredential(c, core.CredentialTypeCompanyAdmin, "company_id", false)
 }
 
-// @Summary NOT IMPLEMENTED! DepartmentAdmins
+// @Summary DepartmentAdmins
 // @Security ApiKeyAuth
 // @Tags rights
 // @Description This request for get users from department admins list
@@ -67,15 +169,15 @@ func (H *Handler) deleteCompanyAdmin(c *gin.Context) {
 // @Produces json
 // @Param departmentId path int true "department id"
 // @Success 200 {array} core.User "list of department admins"
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /department/{departmentId}/department_admins [get]
 func (H *Handler) getDepartmentAdmins(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	H.getCredentialUsers(c, core.CredentialTypeDepartmentAdmin, "department_id")
 }
 
-// @Summary NOT IMPLEMENTED! DepartmentAdmin
+// @Summary DepartmentAdmin
 // @Security ApiKeyAuth
 // @Tags rights
 // @Description This request for add user to department admins
@@ -85,15 +187,15 @@ func (H *Handler) getDepartmentAdmins(c *gin.Context) {
 // @Param departmentId path int true "department id"
 // @Param userId query int true "added user id"
 // @Success 200 {string} string "ok"
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404,409 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /department/{departmentId}/department_admins [post]
 func (H *Handler) addDepartmentAdmin(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	H.changeCredential(c, core.CredentialTypeDepartmentAdmin, "department_id", true)
 }
 
-// @Summary NOT IMPLEMENTED! DepartmentAdmin
+// @Summary DepartmentAdmin
 // @Security ApiKeyAuth
 // @Tags rights
 // @Description This request for delete user from department admins
@@ -103,15 +205,15 @@ func (H *Handler) addDepartmentAdmin(c *gin.Context) {
 // @Param departmentId path int true "department id"
 // @Param userId query int true "deleted user id"
 // @Success 200 {string} string "ok"
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404,409 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /department/{departmentId}/department_admins [delete]
 func (H *Handler) deleteDepartmentAdmin(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	H.changeCredential(c, core.CredentialTypeDepartmentAdmin, "department_id", false)
 }
 
-// @Summary NOT IMPLEMENTED! DepartmentMaintainer
+// @Summary DepartmentMaintainer
 // @Security ApiKeyAuth
 // @Tags rights
 // @Description This request for get users from department maintainers list
@@ -120,15 +222,15 @@ func (H *Handler) deleteDepartmentAdmin(c *gin.Context) {
 // @Produces json
 // @Param departmentId path int true "department id"
 // @Success 200 {array} core.User "list of department maintainer"
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /department/{departmentId}/department_maintainers [get]
 func (H *Handler) getDepartmentMaintainers(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	H.getCredentialUsers(c, core.CredentialTypeDepartmentMaintainer, "department_id")
 }
 
-// @Summary NOT IMPLEMENTED! DepartmentMaintainer
+// @Summary DepartmentMaintainer
 // @Security ApiKeyAuth
 // @Tags rights
 // @Description This request for add user to department maintainers
@@ -138,15 +240,15 @@ func (H *Handler) getDepartmentMaintainers(c *gin.Context) {
 // @Param departmentId path int true "department id"
 // @Param userId query int true "added user id"
 // @Success 200 {string} string "ok"
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404,409 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /department/{departmentId}/department_maintainers [post]
 func (H *Handler) addDepartmentMaintainer(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	H.changeCredential(c, core.CredentialTypeDepartmentMaintainer, "department_id", true)
 }
 
-// @Summary NOT IMPLEMENTED! DepartmentMaintainer
+// @Summary DepartmentMaintainer
 // @Security ApiKeyAuth
 // @Tags rights
 // @Description This request for delete user from department maintainers
@@ -156,10 +258,10 @@ func (H *Handler) addDepartmentMaintainer(c *gin.Context) {
 // @Param departmentId path int true "department id"
 // @Param userId query int true "deleted user id"
 // @Success 200 {string} string "ok"
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404,409 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /department/{departmentId}/department_maintainers [delete]
 func (H *Handler) deleteDepartmentMaintainer(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	H.changeCredential(c, core.CredentialTypeDepartmentMaintainer, "department_id", false)
 }
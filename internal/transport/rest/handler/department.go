@@ -1,11 +1,29 @@
 package handler
 
 import (
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 	"net/http"
+	"strconv"
 )
 
-// @Summary NOT IMPLEMENTED! Department
+func departmentErrorResponse(c *gin.Context, err error) {
+	switch err {
+	case moduleErrors.ErrorServiceDepartmentNotFound:
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+	case moduleErrors.ErrorServiceDepartmentAlreadyExists,
+		moduleErrors.ErrorServiceHeadDepartmentProtected:
+		newErrorResponse(c, http.StatusConflict, err.Error())
+	case moduleErrors.ErrorServiceInvalidTargetDepartment:
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		thingErrorResponse(c, err)
+	}
+}
+
+// @Summary Department
 // @Security ApiKeyAuth
 // @Tags department
 // @Description This request for creating Department
@@ -14,15 +32,43 @@ import (
 // @Produces json
 // @Param input body core.DepartmentBase true "department info"
 // @Success 200 {object} core.Department
-// @Failure 400,401 {object} errorResponse
+// @Failure 400,401,403,409 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /department [post]
 func (H *Handler) addDepartment(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "addDepartment",
+		"context":  *core.LogContext(c),
+	}
+
+	var department core.DepartmentBase
+
+	if err := c.BindJSON(&department); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("json parse error")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	departmentData, err := H.department.AddDepartment(c.Request.Context(), &department)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":       logBase,
+			"department": department,
+			"error":      err.Error(),
+		}).Error("add department error")
+		departmentErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, departmentData)
 }
 
-// @Summary NOT IMPLEMENTED! Department
+// @Summary Department
 // @Security ApiKeyAuth
 // @Tags department
 // @Description This request for get Department info
@@ -31,15 +77,42 @@ func (H *Handler) addDepartment(c *gin.Context) {
 // @Produces json
 // @Param id path int true "department id"
 // @Success 200 {object} core.Department
-// @Failure 400,401 {object} errorResponse
+// @Failure 400,401,403,404 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /department/{id} [get]
 func (H *Handler) getDepartment(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getDepartment",
+		"context":  *core.LogContext(c),
+	}
+
+	departmentId, err := strconv.Atoi(c.Param("department_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	departmentData, err := H.department.GetDepartment(c.Request.Context(), departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":         logBase,
+			"departmentId": departmentId,
+			"error":        err.Error(),
+		}).Error("get department error")
+		departmentErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, departmentData)
 }
 
-// @Summary NOT IMPLEMENTED! Department
+// @Summary Department
 // @Security ApiKeyAuth
 // @Tags department
 // @Description This request for change department info
@@ -47,46 +120,203 @@ func (H *Handler) getDepartment(c *gin.Context) {
 // @Accept json
 // @Produces json
 // @Param id path int true "department id"
-// @Param input body core.DepartmentBase true "new department info"
+// @Param input body core.DepartmentUpdate true "new department info"
 // @Success 200 {object} core.Department
-// @Failure 400,401 {object} errorResponse
+// @Failure 400,401,403,404,409 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /department/{id} [patch]
 func (H *Handler) patchDepartment(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "patchDepartment",
+		"context":  *core.LogContext(c),
+	}
+
+	departmentId, err := strconv.Atoi(c.Param("department_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	var department core.DepartmentUpdate
+
+	if err := c.BindJSON(&department); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("json parse error")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	departmentData, err := H.department.UpdateDepartment(c.Request.Context(), &department, departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":         logBase,
+			"departmentId": departmentId,
+			"department":   department,
+			"error":        err.Error(),
+		}).Error("update department error")
+		departmentErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, departmentData)
 }
 
-// @Summary NOT IMPLEMENTED! Department
+// @Summary Department
 // @Security ApiKeyAuth
 // @Tags department
-// @Description This request for delete department
+// @Description This request for delete department, its users and things are moved to target department (head department by default)
 // @ID deleteDepartment
 // @Accept json
 // @Produces json
 // @Param id path int true "department id"
+// @Param targetDepartmentId query int false "target department id"
 // @Success 200 {string} string "ok"
-// @Failure 400,401 {object} errorResponse
+// @Failure 400,401,403,404,409 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /department/{id} [delete]
 func (H *Handler) deleteDepartment(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "deleteDepartment",
+		"context":  *core.LogContext(c),
+	}
+
+	departmentId, err := strconv.Atoi(c.Param("department_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	targetDepartmentId, err := getOptionalIntQuery(c, "targetDepartmentId")
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error convert targetDepartmentId to int")
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	err = H.department.DeleteDepartment(c.Request.Context(), departmentId, targetDepartmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":               logBase,
+			"departmentId":       departmentId,
+			"targetDepartmentId": targetDepartmentId,
+			"error":              err.Error(),
+		}).Error("delete department error")
+		departmentErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, "ok")
 }
 
-// @Summary NOT IMPLEMENTED! LoadDepartmentImage
+// @Summary All departments
 // @Security ApiKeyAuth
 // @Tags department
-// @Description This request for load department image
-// @ID loadDepartmentImage
+// @Description This request for getting all company departments
+// @ID getAllDepartments
 // @Accept json
 // @Produces json
-// @Param input body []byte true "image file"
-// @Success 200 {string} string "image_url"
-// @Failure 400,401 {object} errorResponse
+// @Param companyId query int true "company id"
+// @Success 200 {array} core.Department
+// @Failure 400,401,403 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /departments [get]
+func (H *Handler) getAllDepartments(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getAllDepartments",
+		"context":  *core.LogContext(c),
+	}
+
+	companyId, err := strconv.Atoi(c.Query("companyId"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error convert companyId to int")
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	departments, err := H.department.GetDepartments(c.Request.Context(), companyId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":      logBase,
+			"companyId": companyId,
+			"error":     err.Error(),
+		}).Error("get departments error")
+		departmentErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, departments)
+}
+
+// @Summary LoadDepartmentImage
+// @Security ApiKeyAuth
+// @Tags department
+// @Description This request for load department image, image is sent in multipart field image. Jpeg, png and gif
+// @Description images are allowed, thumbnail is generated for every image
+// @ID loadDepartmentImage
+// @Accept mpfd
+// @Produces json
+// @Param id path int true "department id"
+// @Param image formData file true "image file"
+// @Success 200 {object} core.Image
+// @Failure 400,401,403,404,413,415 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /department/{id}/image [post]
 func (H *Handler) loadDepartmentImage(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "loadDepartmentImage",
+		"context":  *core.LogContext(c),
+	}
+
+	departmentId, err := strconv.Atoi(c.Param("department_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	upload, err := readImageUpload(c)
+	if err != nil {
+		imageErrorResponse(c, err)
+		return
+	}
+
+	image, err := H.image.LoadDepartmentImage(c.Request.Context(), departmentId, upload)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":         logBase,
+			"departmentId": departmentId,
+			"error":        err.Error(),
+		}).Error("load department image error")
+		imageErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, image)
 }
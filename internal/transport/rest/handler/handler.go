@@ -7,34 +7,195 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/swaggo/files"
 	"github.com/swaggo/gin-swagger"
+	"io"
 )
 
-//go:generate mockgen -source=handler.go -destinations=mocks/authMock.go
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
 type auth interface {
 	SignIn(authData *core.UserSignInData) (*core.SignInResponse, error)
 	SignUp(authData *core.UserSignUpData) (*core.SignInResponse, error)
+	Refresh(refreshToken string) (*core.TokenResponse, error)
+	SignOut(refreshToken string) error
+	SignOutAll(ctx context.Context) error
 }
 
-//go:generate mockgen -source=handler.go -destination=mock/authMock.go
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
+type emailVerification interface {
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context) error
+}
+
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
+type password interface {
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token string, password string) error
+	ChangePassword(ctx context.Context, oldPassword string, newPassword string) error
+}
+
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
 type company interface {
 	AddCompany(ctx context.Context, companyAdd *core.CompanyBase) (*core.Company, error)
 	GetCompany(ctx context.Context, companyId int) (*core.Company, error)
 	UpdateCompany(ctx context.Context, companyBase core.CompanyBase, companyId int) (*core.Company, error)
 	DeleteCompany(ctx context.Context, companyId int) error
+	FindCompanies(ctx context.Context, filter string, limit int, offset int) ([]core.CompanySearchResult, error)
 }
 
-//go:generate mockgen -source=handler.go -destination=mock/authMock.go
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
 type user interface {
 	FindUsersForInvite(ctx context.Context, filter string, limit int, offset int) ([]core.User, error)
+	GetCompanyUsers(ctx context.Context, filter *core.UserDirectoryFilter) (*core.UserDirectoryPage, error)
 	AddUserToCompany(ctx context.Context, userId int, departmentId int) error
+	RemoveUserFromCompany(ctx context.Context, userId int) error
+	MoveUserToDepartment(ctx context.Context, userId int, departmentId int) error
+	LeaveCompany(ctx context.Context) error
+}
+
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
+type profile interface {
+	GetCurrentUser(ctx context.Context) (*core.User, error)
+	GetUser(ctx context.Context, userId int) (*core.User, error)
+	UpdateCurrentUser(ctx context.Context, userUpdate *core.UserUpdate) (*core.User, error)
+	DeleteCurrentUser(ctx context.Context) error
+}
+
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
+type invitation interface {
+	Invite(ctx context.Context, invitationAdd *core.InvitationAdd) (*core.Invitation, error)
+	GetMyInvitations(ctx context.Context) ([]core.Invitation, error)
+	AcceptInvitation(ctx context.Context, invitationId int) error
+	DeclineInvitation(ctx context.Context, invitationId int) error
+}
+
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
+type joinRequest interface {
+	AddJoinRequest(ctx context.Context, joinRequestAdd *core.JoinRequestAdd) (*core.JoinRequest, error)
+	GetMyJoinRequests(ctx context.Context) ([]core.JoinRequest, error)
+	CancelJoinRequest(ctx context.Context, joinRequestId int) error
+	GetDepartmentJoinRequests(ctx context.Context, departmentId int) ([]core.JoinRequest, error)
+	GetCompanyJoinRequests(ctx context.Context, companyId int) ([]core.JoinRequest, error)
+	ApproveJoinRequest(ctx context.Context, joinRequestId int) error
+	RejectJoinRequest(ctx context.Context, joinRequestId int) error
+}
+
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
+type thing interface {
+	AddThing(ctx context.Context, thingBase *core.ThingBase, departmentId *int) (*core.Thing, error)
+	GetThing(ctx context.Context, thingId int) (*core.Thing, error)
+	GetThings(ctx context.Context, companyId *int, departmentId *int) ([]core.Thing, error)
+	UpdateThing(ctx context.Context, thing *core.ThingUpdate, thingId int) (*core.Thing, error)
+	DeleteThing(ctx context.Context, thingId int) error
+}
+
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
+type thingUsage interface {
+	AddUsage(ctx context.Context, usage *core.ThingUsageAdd, force bool) (*core.ThingUsage, error)
+	GetUsage(ctx context.Context, usageId int) (*core.ThingUsage, error)
+	GetUsages(ctx context.Context, companyId *int, departmentId *int) ([]core.ThingUsage, error)
+	GetMyUsages(ctx context.Context) ([]core.ThingUsage, error)
+	GetThingUsages(ctx context.Context, thingId int) ([]core.ThingUsage, error)
+	GetUsagesForApprove(ctx context.Context) ([]core.ThingUsage, error)
+	ApproveUsage(ctx context.Context, usageId int) (*core.ThingUsage, error)
+	TakeUsage(ctx context.Context, usageId int) (*core.ThingUsage, error)
+	ReturnUsage(ctx context.Context, usageId int, usageReturn *core.UsageReturn) (*core.ThingUsage, error)
+	CancelUsage(ctx context.Context, usageId int) (*core.ThingUsage, error)
+}
+
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
+type department interface {
+	AddDepartment(ctx context.Context, departmentBase *core.DepartmentBase) (*core.Department, error)
+	GetDepartment(ctx context.Context, departmentId int) (*core.Department, error)
+	GetDepartments(ctx context.Context, companyId int) ([]core.Department, error)
+	UpdateDepartment(ctx context.Context, department *core.DepartmentUpdate, departmentId int) (*core.Department, error)
+	DeleteDepartment(ctx context.Context, departmentId int, targetDepartmentId *int) error
+}
+
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
+type credentials interface {
+	GetCredentialUsers(ctx context.Context, credentialType string, objectId int) ([]core.User, error)
+	GrantCredential(ctx context.Context, credentialType string, objectId int, userId int) error
+	RevokeCredential(ctx context.Context, credentialType string, objectId int, userId int) error
+}
+
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
+type thingBlock interface {
+	AddBlock(ctx context.Context, block *core.ThingBlockAdd, force bool) (*core.ThingBlock, error)
+	UpdateBlock(ctx context.Context, block *core.ThingBlockAdd, blockId int, force bool) (*core.ThingBlock, error)
+	GetBlock(ctx context.Context, blockId int) (*core.ThingBlock, error)
+	GetBlocks(ctx context.Context, companyId *int, departmentId *int) ([]core.ThingBlock, error)
+	DeleteBlock(ctx context.Context, blockId int) error
+}
+
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
+type stock interface {
+	AddMovement(ctx context.Context, thingId int, movement *core.StockMovementAdd) (*core.StockMovement, error)
+	GetMovements(ctx context.Context, thingId int) ([]core.StockMovement, error)
+}
+
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
+type stockAlert interface {
+	GetLowStock(ctx context.Context, companyId int) ([]core.Thing, error)
+	GetDepartmentAlerts(ctx context.Context, departmentId int) ([]core.StockAlert, error)
+}
+
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
+type vacation interface {
+	AddVacation(ctx context.Context, vacation *core.VacationAdd) (*core.Vacation, error)
+	GetMyVacations(ctx context.Context) ([]core.Vacation, error)
+	UpdateVacation(ctx context.Context, vacation *core.VacationAdd, vacationId int) (*core.Vacation, error)
+	DeleteVacation(ctx context.Context, vacationId int) error
+	GetDepartmentVacations(ctx context.Context, departmentId int, startTime uint32,
+		endTime uint32) ([]core.DepartmentVacation, error)
+}
+
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
+type notification interface {
+	GetNotifications(ctx context.Context, unreadOnly bool, beforeId int) ([]core.Notification, error)
+	GetUnreadCount(ctx context.Context) (*core.NotificationUnreadCount, error)
+	MarkRead(ctx context.Context, notificationId int) error
+	MarkAllRead(ctx context.Context) error
+	GetPreferences(ctx context.Context) ([]core.NotificationPreference, error)
+	SetPreference(ctx context.Context, preference *core.NotificationPreference) error
+}
+
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
+type webhook interface {
+	AddWebhook(ctx context.Context, companyId int, webhook *core.WebhookAdd) (*core.Webhook, error)
+	GetWebhooks(ctx context.Context, companyId int) ([]core.Webhook, error)
+	GetWebhook(ctx context.Context, webhookId int) (*core.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhookId int, webhook *core.WebhookUpdate) (*core.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookId int) error
+	GetDeliveries(ctx context.Context, webhookId int) ([]core.WebhookDelivery, error)
+	Redeliver(ctx context.Context, webhookId int, deliveryId int) (*core.WebhookDelivery, error)
+}
+
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
+type admin interface {
+	LockUser(ctx context.Context, userId int) error
+	UnlockUser(ctx context.Context, userId int) error
+	TransferCompany(ctx context.Context, companyId int, userId int) error
 }
 
-//go:generate mockgen -source=handler.go -destination=mock/authMock.go
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
+type image interface {
+	LoadCompanyImage(ctx context.Context, companyId int, upload *core.ImageUpload) (*core.Image, error)
+	LoadDepartmentImage(ctx context.Context, departmentId int, upload *core.ImageUpload) (*core.Image, error)
+	LoadThingImage(ctx context.Context, thingId int, upload *core.ImageUpload) (*core.Image, error)
+	LoadCurrentUserImage(ctx context.Context, upload *core.ImageUpload) (*core.Image, error)
+	GetFile(ctx context.Context, key string) (io.ReadCloser, string, error)
+}
+
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
 type token interface {
 	ValidateToken(token string) (int, []core.Credentials, error)
 }
 
-//go:generate mockgen -source=auth.go -destination=mock/authMock.go
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
+type credentialsLoader interface {
+	LoadUserCredential(ctx context.Context, userId int) ([]core.Credentials, error)
+}
+
+//go:generate mockgen -source=handler.go -destination=mocks/authMock.go
 type userDB interface {
 	GetUser(ctx context.Context, userId int) (*core.UserDB, error)
 	//UserIsCompanyAdmin(userId int, companyId int) (bool, error)
@@ -43,25 +204,69 @@ type userDB interface {
 }
 
 type Handler struct {
-	auth    auth
-	token   token
-	company company
-	user    user
-	userDB  userDB
+	auth              auth
+	emailVerification emailVerification
+	password          password
+	token             token
+	company           company
+	department        department
+	credentials       credentials
+	user              user
+	profile           profile
+	invitation        invitation
+	joinRequest       joinRequest
+	thing             thing
+	thingUsage        thingUsage
+	thingBlock        thingBlock
+	stock             stock
+	stockAlert        stockAlert
+	vacation          vacation
+	notification      notification
+	webhook           webhook
+	admin             admin
+	image             image
+	userDB            userDB
+	credentialsLoader credentialsLoader
+	// credentialsSource is core.CredentialsSourceToken or core.CredentialsSourceDatabase
+	credentialsSource string
 }
 
-func NewHandler(auth auth, company company, token token, user user, userDB userDB) *Handler {
+func NewHandler(auth auth, emailVerification emailVerification, password password, company company, department department,
+	credentials credentials, token token, user user, profile profile, invitation invitation,
+	joinRequest joinRequest, thing thing, thingUsage thingUsage, thingBlock thingBlock, stock stock,
+	stockAlert stockAlert, vacation vacation, notification notification, webhook webhook, admin admin, image image, userDB userDB, credentialsLoader credentialsLoader, credentialsSource string) *Handler {
 	return &Handler{
-		auth:    auth,
-		token:   token,
-		company: company,
-		user:    user,
-		userDB:  userDB,
+		auth:              auth,
+		emailVerification: emailVerification,
+		password:          password,
+		token:             token,
+		company:           company,
+		department:        department,
+		credentials:       credentials,
+		user:              user,
+		profile:           profile,
+		invitation:        invitation,
+		joinRequest:       joinRequest,
+		thing:             thing,
+		thingUsage:        thingUsage,
+		thingBlock:        thingBlock,
+		stock:             stock,
+		stockAlert:        stockAlert,
+		vacation:          vacation,
+		notification:      notification,
+		webhook:           webhook,
+		admin:             admin,
+		image:             image,
+		userDB:            userDB,
+		credentialsLoader: credentialsLoader,
+		credentialsSource: credentialsSource,
 	}
 }
 
 func (H *Handler) InitRoutes() *gin.Engine {
 	router := gin.New()
+	// handlers pass gin context to services, values from request context must be visible through it
+	router.ContextWithFallback = true
 
 	api := router.Group("/api/v1")
 	{
@@ -70,21 +275,144 @@ func (H *Handler) InitRoutes() *gin.Engine {
 		{
 			auth.POST("/sign-up", H.signUp)
 			auth.POST("/sign-in", H.signIn)
+			auth.POST("/refresh", H.refresh)
+			auth.POST("/sign-out", H.signOut)
+			auth.POST("/verify-email", H.verifyEmail)
+			auth.POST("/password/forgot", H.forgotPassword)
+			auth.POST("/password/reset", H.resetPassword)
 		}
+		api.GET("/files/*key", H.getFile)
+	}
+	// in database mode credentials and lock of user are checked on every request
+	var loader credentialsLoader
+	var lockDB userDB
+	if H.credentialsSource == core.CredentialsSourceDatabase {
+		loader = H.credentialsLoader
+		lockDB = H.userDB
 	}
-	apiPrivate := router.Group("/api/v1", H.userIdentity)
+
+	apiPrivate := router.Group("/api/v1", userIdentity(H.token, loader, lockDB))
 	{
+		apiPrivate.POST("/auth/sign-out-all", H.signOutAll)
+		apiPrivate.POST("/auth/verify-email/resend", H.resendVerificationEmail)
+		apiPrivate.GET("/user", H.getCurrentUser)
+		apiPrivate.PATCH("/user", H.patchCurrentUser)
+		apiPrivate.DELETE("/user", H.deleteCurrentUser)
+		apiPrivate.POST("/user/image", H.loadCurrentUserImage)
+		apiPrivate.GET("/user/:user_id", H.getUser)
+		apiPrivate.POST("/user/password", H.changePassword)
+		apiPrivate.POST("/user/leave_company", H.leaveCompany)
+		apiPrivate.GET("/user/invitations", H.getMyInvitations)
+		apiPrivate.POST("/user/invitations/:invitation_id/accept", H.acceptInvitation)
+		apiPrivate.POST("/user/invitations/:invitation_id/decline", H.declineInvitation)
+		apiPrivate.POST("/invitations", H.invite)
+		apiPrivate.GET("/user/vacations", H.getMyVacations)
+		apiPrivate.POST("/user/vacations", H.addVacation)
+		apiPrivate.PATCH("/user/vacations/:vacation_id", H.patchVacation)
+		apiPrivate.DELETE("/user/vacations/:vacation_id", H.deleteVacation)
+		apiPrivate.GET("/user/notifications", H.getNotifications)
+		apiPrivate.GET("/user/notifications/unread_count", H.getUnreadNotificationsCount)
+		apiPrivate.POST("/user/notifications/read_all", H.readAllNotifications)
+		apiPrivate.POST("/user/notifications/:notification_id/read", H.readNotification)
+		apiPrivate.GET("/user/notification_preferences", H.getNotificationPreferences)
+		apiPrivate.PUT("/user/notification_preferences", H.putNotificationPreference)
+		apiPrivate.GET("/user/join_requests", H.getMyJoinRequests)
+		apiPrivate.DELETE("/user/join_requests/:join_request_id", H.cancelJoinRequest)
+		joinRequest := apiPrivate.Group("/join_requests")
+		{
+			joinRequest.POST("", H.addJoinRequest)
+			joinRequest.POST("/:join_request_id/approve", H.approveJoinRequest)
+			joinRequest.POST("/:join_request_id/reject", H.rejectJoinRequest)
+		}
+		apiPrivate.GET("/companies/find", H.findCompanies)
 		company := apiPrivate.Group("/company")
 		{
 			company.POST("", H.addCompany)
 			company.GET("/:company_id", H.getCompany)
 			company.PATCH("/:company_id", H.patchCompany)
 			company.DELETE("/:company_id", H.deleteCompany)
+			company.POST("/:company_id/image", H.loadCompanyImage)
+			company.GET("/:company_id/company_admins", H.getCompanyAdmins)
+			company.POST("/:company_id/company_admins", H.addCompanyAdmin)
+			company.DELETE("/:company_id/company_admins", H.deleteCompanyAdmin)
+			company.GET("/:company_id/join_requests", H.getCompanyJoinRequests)
+			company.GET("/:company_id/low_stock", H.getLowStock)
+			company.GET("/:company_id/webhooks", H.getCompanyWebhooks)
+			company.POST("/:company_id/webhooks", H.addWebhook)
+		}
+		webhook := apiPrivate.Group("/webhook")
+		{
+			webhook.GET("/:webhook_id", H.getWebhook)
+			webhook.PATCH("/:webhook_id", H.patchWebhook)
+			webhook.DELETE("/:webhook_id", H.deleteWebhook)
+			webhook.GET("/:webhook_id/deliveries", H.getWebhookDeliveries)
+			webhook.POST("/:webhook_id/deliveries/:delivery_id/redeliver", H.redeliverWebhookDelivery)
+		}
+		department := apiPrivate.Group("/department")
+		{
+			department.POST("", H.addDepartment)
+			department.GET("/:department_id", H.getDepartment)
+			department.PATCH("/:department_id", H.patchDepartment)
+			department.DELETE("/:department_id", H.deleteDepartment)
+			department.POST("/:department_id/image", H.loadDepartmentImage)
+			department.GET("/:department_id/department_admins", H.getDepartmentAdmins)
+			department.POST("/:department_id/department_admins", H.addDepartmentAdmin)
+			department.DELETE("/:department_id/department_admins", H.deleteDepartmentAdmin)
+			department.GET("/:department_id/department_maintainers", H.getDepartmentMaintainers)
+			department.POST("/:department_id/department_maintainers", H.addDepartmentMaintainer)
+			department.DELETE("/:department_id/department_maintainers", H.deleteDepartmentMaintainer)
+			department.GET("/:department_id/join_requests", H.getDepartmentJoinRequests)
+			department.GET("/:department_id/vacations", H.getDepartmentVacations)
+			department.GET("/:department_id/stock_alerts", H.getDepartmentStockAlerts)
 		}
+		apiPrivate.GET("/departments", H.getAllDepartments)
 		user := apiPrivate.Group("/users")
 		{
+			user.GET("", H.getUsers)
 			user.GET("/find", H.findUsersForInvite)
 			user.POST("/:user_id/add_to_company", H.addUserToCompany)
+			user.POST("/:user_id/remove_from_company", H.removeUserFromCompany)
+			user.POST("/:user_id/move_to_department", H.moveUserToDepartment)
+		}
+		thing := apiPrivate.Group("/thing")
+		{
+			thing.POST("", H.addThing)
+			thing.GET("/:thing_id", H.getThing)
+			thing.PATCH("/:thing_id", H.patchThing)
+			thing.DELETE("/:thing_id", H.deleteThing)
+			thing.POST("/:thing_id/image", H.loadThingImage)
+			thing.GET("/:thing_id/usage", H.getThingUsageById)
+			thing.GET("/:thing_id/stock", H.getStockMovements)
+			thing.POST("/:thing_id/stock", H.addStockMovement)
+
+			usage := thing.Group("/usage")
+			{
+				usage.GET("", H.getAllThingUsage)
+				usage.POST("", H.addThingUsage)
+				usage.GET("/my", H.getMyThingUsage)
+				usage.GET("/approve", H.getNeedApproveThingUsage)
+				usage.GET("/:usage_id", H.getThingUsage)
+				usage.DELETE("/:usage_id", H.deleteThingUsage)
+				usage.POST("/:usage_id/approve", H.approveThingUsage)
+				usage.POST("/:usage_id/take", H.takeThingUsage)
+				usage.POST("/:usage_id/return", H.returnThingUsage)
+			}
+
+			block := thing.Group("/block")
+			{
+				block.GET("", H.getAllThingBlocking)
+				block.POST("", H.addThingBlock)
+				block.GET("/:block_id", H.getThingBlocking)
+				block.PATCH("/:block_id", H.editThingBlock)
+				block.DELETE("/:block_id", H.deleteThingBlocking)
+			}
+		}
+		apiPrivate.GET("/things", H.getAllThings)
+		admin := apiPrivate.Group("/admin")
+		{
+			admin.POST("/users/:user_id/lock", H.lockUser)
+			admin.POST("/users/:user_id/unlock", H.unlockUser)
+			admin.POST("/company/:company_id/transfer", H.forceTransferCompany)
 		}
 	}
 	return router
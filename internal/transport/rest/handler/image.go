@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// maxImageRequestSize limits multipart request with image, size of image itself is checked by service
+	maxImageRequestSize = 32 << 20
+	imageFormField      = "image"
+)
+
+func imageErrorResponse(c *gin.Context, err error) {
+	switch err {
+	case moduleErrors.ErrorServiceImageTooLarge:
+		newErrorResponse(c, http.StatusRequestEntityTooLarge, err.Error())
+	case moduleErrors.ErrorServiceUnsupportedImageType:
+		newErrorResponse(c, http.StatusUnsupportedMediaType, err.Error())
+	case moduleErrors.ErrorServiceInvalidImage,
+		moduleErrors.ErrorHandlerNoImageFile:
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+	case moduleErrors.ErrorServiceFileNotFound:
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+	default:
+		userErrorResponse(c, err)
+	}
+}
+
+// readImageUpload reads image from multipart field image
+func readImageUpload(c *gin.Context) (*core.ImageUpload, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImageRequestSize)
+
+	fileHeader, err := c.FormFile(imageFormField)
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			return nil, moduleErrors.ErrorServiceImageTooLarge
+		}
+		logrus.WithFields(logrus.Fields{
+			"module":   "handler",
+			"function": "readImageUpload",
+			"error":    err.Error(),
+		}).Error("error get image from multipart form")
+		return nil, moduleErrors.ErrorHandlerNoImageFile
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.ImageUpload{
+		Data:        data,
+		ContentType: fileHeader.Header.Get("Content-Type"),
+	}, nil
+}
+
+// @Summary File
+// @Tags file
+// @Description This request for get stored file, links to files are returned as image urls
+// @ID getFile
+// @Produces octet-stream
+// @Param key path string true "file key"
+// @Success 200 {file} file
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /files/{key} [get]
+func (H *Handler) getFile(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	reader, contentType, err := H.image.GetFile(c.Request.Context(), key)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"module":   "handler",
+			"function": "getFile",
+			"key":      key,
+			"error":    err.Error(),
+		}).Error("get file error")
+		imageErrorResponse(c, err)
+		return
+	}
+	defer reader.Close()
+
+	// keys of stored files are random and never reused, so files can be cached forever
+	c.DataFromReader(http.StatusOK, -1, contentType, reader, map[string]string{
+		"Cache-Control": "public, max-age=31536000, immutable",
+	})
+}
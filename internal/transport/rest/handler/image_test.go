@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"bytes"
+	mockhandler "github.com/Thing-repository/backend-server/internal/transport/rest/handler/mocks"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+)
+
+func TestLoadThingImage(t *testing.T) {
+	type mockBehavior func(s *mockhandler.Mockimage)
+
+	testImage := &core.Image{
+		ImageURL:     "/api/v1/files/things/1/image.png",
+		ThumbnailURL: "/api/v1/files/things/1/image_thumb.png",
+	}
+
+	testTable := []struct {
+		name                 string
+		thingId              string
+		field                string
+		contentType          string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Ok",
+			thingId:     "1",
+			field:       "image",
+			contentType: "image/png",
+			mockBehavior: func(s *mockhandler.Mockimage) {
+				s.EXPECT().LoadThingImage(gomock.Any(), 1,
+					&core.ImageUpload{Data: []byte("image data"), ContentType: "image/png"}).Return(testImage, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"image_url":"/api/v1/files/things/1/image.png",` +
+				`"thumbnail_url":"/api/v1/files/things/1/image_thumb.png"}`,
+		},
+		{
+			name:                 "No image field",
+			thingId:              "1",
+			field:                "file",
+			contentType:          "image/png",
+			mockBehavior:         func(s *mockhandler.Mockimage) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"image file is required in multipart field image"}`,
+		},
+		{
+			name:                 "Invalid thing id",
+			thingId:              "thing",
+			field:                "image",
+			contentType:          "image/png",
+			mockBehavior:         func(s *mockhandler.Mockimage) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"no required Fields in query"}`,
+		},
+		{
+			name:        "Unsupported type",
+			thingId:     "1",
+			field:       "image",
+			contentType: "text/plain",
+			mockBehavior: func(s *mockhandler.Mockimage) {
+				s.EXPECT().LoadThingImage(gomock.Any(), 1, gomock.Any()).
+					Return(nil, moduleErrors.ErrorServiceUnsupportedImageType)
+			},
+			expectedStatusCode:   http.StatusUnsupportedMediaType,
+			expectedResponseBody: `{"message":"unsupported image type, jpeg, png and gif are allowed"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Init deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			image := mockhandler.NewMockimage(c)
+			testCase.mockBehavior(image)
+
+			handler := &Handler{image: image}
+
+			r := gin.New()
+			r.POST("/thing/:thing_id/image", handler.loadThingImage)
+
+			var body bytes.Buffer
+			writer := multipart.NewWriter(&body)
+			header := textproto.MIMEHeader{}
+			header.Set("Content-Disposition", `form-data; name="`+testCase.field+`"; filename="image.png"`)
+			header.Set("Content-Type", testCase.contentType)
+			part, err := writer.CreatePart(header)
+			if err != nil {
+				t.Fatalf("error create multipart part: %s", err)
+			}
+			part.Write([]byte("image data"))
+			writer.Close()
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/thing/"+testCase.thingId+"/image", &body)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+
+			// Perform request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			if w.Code != testCase.expectedStatusCode {
+				t.Errorf("status code = %d, want %d", w.Code, testCase.expectedStatusCode)
+			}
+			if w.Body.String() != testCase.expectedResponseBody {
+				t.Errorf("response body = %s, want %s", w.Body.String(), testCase.expectedResponseBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+func invitationErrorResponse(c *gin.Context, err error) {
+	switch err {
+	case moduleErrors.ErrorServiceInvitationNotFound:
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+	case moduleErrors.ErrorServiceInvitationNotActive,
+		moduleErrors.ErrorServiceUserAlreadyHasCompany:
+		newErrorResponse(c, http.StatusConflict, err.Error())
+	case moduleErrors.ErrorServiceInvalidInvitation:
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		credentialsErrorResponse(c, err)
+	}
+}
+
+// @Summary Invite
+// @Security ApiKeyAuth
+// @Tags invitation
+// @Description This request for invite user without company to department by user id or by email. User without verified email gets email and joins department on sign up by link from email or after verification of email. Only for department admins
+// @ID invite
+// @Accept json
+// @Produces json
+// @Param input body core.InvitationAdd true "invitation info"
+// @Success 200 {object} core.Invitation
+// @Failure 400,401,403,404,409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /invitations [post]
+func (H *Handler) invite(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "invite",
+		"context":  *core.LogContext(c),
+	}
+
+	var input core.InvitationAdd
+	if err := c.BindJSON(&input); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error parse request body")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	invitation, err := H.invitation.Invite(c.Request.Context(), &input)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"input": input,
+			"error": err.Error(),
+		}).Error("invite error")
+		invitationErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, invitation)
+}
+
+// @Summary MyInvitations
+// @Security ApiKeyAuth
+// @Tags invitation
+// @Description This request for get active invitations of current user
+// @ID getMyInvitations
+// @Accept json
+// @Produces json
+// @Success 200 {array} core.Invitation
+// @Failure 401 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /user/invitations [get]
+func (H *Handler) getMyInvitations(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getMyInvitations",
+		"context":  *core.LogContext(c),
+	}
+
+	invitations, err := H.invitation.GetMyInvitations(c.Request.Context())
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("get invitations error")
+		invitationErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, invitations)
+}
+
+// answerInvitation accepts or declines invitation from path param
+func (H *Handler) answerInvitation(c *gin.Context, accept bool) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "answerInvitation",
+		"accept":   accept,
+		"context":  *core.LogContext(c),
+	}
+
+	invitationId, err := strconv.Atoi(c.Param("invitation_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	if accept {
+		err = H.invitation.AcceptInvitation(c.Request.Context(), invitationId)
+	} else {
+		err = H.invitation.DeclineInvitation(c.Request.Context(), invitationId)
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":         logBase,
+			"invitationId": invitationId,
+			"error":        err.Error(),
+		}).Error("answer invitation error")
+		invitationErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, "ok")
+}
+
+// @Summary AcceptInvitation
+// @Security ApiKeyAuth
+// @Tags invitation
+// @Description This request for accept invitation, current user joins department of invitation
+// @ID acceptInvitation
+// @Accept json
+// @Produces json
+// @Param invitationId path int true "invitation id"
+// @Success 200 {string} string "ok"
+// @Failure 400,401,404,409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /user/invitations/{invitationId}/accept [post]
+func (H *Handler) acceptInvitation(c *gin.Context) {
+	H.answerInvitation(c, true)
+}
+
+// @Summary DeclineInvitation
+// @Security ApiKeyAuth
+// @Tags invitation
+// @Description This request for decline invitation
+// @ID declineInvitation
+// @Accept json
+// @Produces json
+// @Param invitationId path int true "invitation id"
+// @Success 200 {string} string "ok"
+// @Failure 400,401,404,409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /user/invitations/{invitationId}/decline [post]
+func (H *Handler) declineInvitation(c *gin.Context) {
+	H.answerInvitation(c, false)
+}
@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+func joinRequestErrorResponse(c *gin.Context, err error) {
+	switch err {
+	case moduleErrors.ErrorServiceJoinRequestNotFound:
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+	case moduleErrors.ErrorServiceJoinRequestNotActive,
+		moduleErrors.ErrorServiceJoinRequestExists,
+		moduleErrors.ErrorServiceUserAlreadyHasCompany:
+		newErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		credentialsErrorResponse(c, err)
+	}
+}
+
+// @Summary JoinRequest
+// @Security ApiKeyAuth
+// @Tags join request
+// @Description This request for ask to join department of company, only for users without company
+// @ID addJoinRequest
+// @Accept json
+// @Produces json
+// @Param input body core.JoinRequestAdd true "join request info"
+// @Success 200 {object} core.JoinRequest
+// @Failure 400,401,404,409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /join_requests [post]
+func (H *Handler) addJoinRequest(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "addJoinRequest",
+		"context":  *core.LogContext(c),
+	}
+
+	var input core.JoinRequestAdd
+	if err := c.BindJSON(&input); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error parse request body")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	joinRequest, err := H.joinRequest.AddJoinRequest(c.Request.Context(), &input)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"input": input,
+			"error": err.Error(),
+		}).Error("add join request error")
+		joinRequestErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, joinRequest)
+}
+
+// @Summary MyJoinRequests
+// @Security ApiKeyAuth
+// @Tags join request
+// @Description This request for get all join requests of current user
+// @ID getMyJoinRequests
+// @Accept json
+// @Produces json
+// @Success 200 {array} core.JoinRequest
+// @Failure 401 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /user/join_requests [get]
+func (H *Handler) getMyJoinRequests(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getMyJoinRequests",
+		"context":  *core.LogContext(c),
+	}
+
+	joinRequests, err := H.joinRequest.GetMyJoinRequests(c.Request.Context())
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("get join requests error")
+		joinRequestErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, joinRequests)
+}
+
+// @Summary CancelJoinRequest
+// @Security ApiKeyAuth
+// @Tags join request
+// @Description This request for cancel pending join request of current user
+// @ID cancelJoinRequest
+// @Accept json
+// @Produces json
+// @Param joinRequestId path int true "join request id"
+// @Success 200 {string} string "ok"
+// @Failure 400,401,404,409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /user/join_requests/{joinRequestId} [delete]
+func (H *Handler) cancelJoinRequest(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "cancelJoinRequest",
+		"context":  *core.LogContext(c),
+	}
+
+	joinRequestId, err := strconv.Atoi(c.Param("join_request_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	if err = H.joinRequest.CancelJoinRequest(c.Request.Context(), joinRequestId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":          logBase,
+			"joinRequestId": joinRequestId,
+			"error":         err.Error(),
+		}).Error("cancel join request error")
+		joinRequestErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, "ok")
+}
+
+// @Summary DepartmentJoinRequests
+// @Security ApiKeyAuth
+// @Tags join request
+// @Description This request for get pending join requests to department. Only for department admins
+// @ID getDepartmentJoinRequests
+// @Accept json
+// @Produces json
+// @Param departmentId path int true "department id"
+// @Success 200 {array} core.JoinRequest
+// @Failure 400,401,403,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /department/{departmentId}/join_requests [get]
+func (H *Handler) getDepartmentJoinRequests(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getDepartmentJoinRequests",
+		"context":  *core.LogContext(c),
+	}
+
+	departmentId, err := strconv.Atoi(c.Param("department_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	joinRequests, err := H.joinRequest.GetDepartmentJoinRequests(c.Request.Context(), departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":         logBase,
+			"departmentId": departmentId,
+			"error":        err.Error(),
+		}).Error("get department join requests error")
+		joinRequestErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, joinRequests)
+}
+
+// @Summary CompanyJoinRequests
+// @Security ApiKeyAuth
+// @Tags join request
+// @Description This request for get pending join requests to all departments of company. Only for company admins
+// @ID getCompanyJoinRequests
+// @Accept json
+// @Produces json
+// @Param companyId path int true "company id"
+// @Success 200 {array} core.JoinRequest
+// @Failure 400,401,403 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /company/{companyId}/join_requests [get]
+func (H *Handler) getCompanyJoinRequests(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getCompanyJoinRequests",
+		"context":  *core.LogContext(c),
+	}
+
+	companyId, err := strconv.Atoi(c.Param("company_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	joinRequests, err := H.joinRequest.GetCompanyJoinRequests(c.Request.Context(), companyId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":      logBase,
+			"companyId": companyId,
+			"error":     err.Error(),
+		}).Error("get company join requests error")
+		joinRequestErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, joinRequests)
+}
+
+// answerJoinRequest approves or rejects join request from path param
+func (H *Handler) answerJoinRequest(c *gin.Context, approve bool) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "answerJoinRequest",
+		"approve":  approve,
+		"context":  *core.LogContext(c),
+	}
+
+	joinRequestId, err := strconv.Atoi(c.Param("join_request_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	if approve {
+		err = H.joinRequest.ApproveJoinRequest(c.Request.Context(), joinRequestId)
+	} else {
+		err = H.joinRequest.RejectJoinRequest(c.Request.Context(), joinRequestId)
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":          logBase,
+			"joinRequestId": joinRequestId,
+			"error":         err.Error(),
+		}).Error("answer join request error")
+		joinRequestErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, "ok")
+}
+
+// @Summary ApproveJoinRequest
+// @Security ApiKeyAuth
+// @Tags join request
+// @Description This request for approve join request, user joins department of request. Only for department admins
+// @ID approveJoinRequest
+// @Accept json
+// @Produces json
+// @Param joinRequestId path int true "join request id"
+// @Success 200 {string} string "ok"
+// @Failure 400,401,403,404,409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /join_requests/{joinRequestId}/approve [post]
+func (H *Handler) approveJoinRequest(c *gin.Context) {
+	H.answerJoinRequest(c, true)
+}
+
+// @Summary RejectJoinRequest
+// @Security ApiKeyAuth
+// @Tags join request
+// @Description This request for reject join request. Only for department admins
+// @ID rejectJoinRequest
+// @Accept json
+// @Produces json
+// @Param joinRequestId path int true "join request id"
+// @Success 200 {string} string "ok"
+// @Failure 400,401,403,404,409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /join_requests/{joinRequestId}/reject [post]
+func (H *Handler) rejectJoinRequest(c *gin.Context) {
+	H.answerJoinRequest(c, false)
+}
@@ -1,7 +1,11 @@
 package handler
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
 	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	"net/http"
@@ -10,31 +14,169 @@ import (
 
 const (
 	authorizationHeader = "Authorization"
+	authorizationScheme = "Bearer"
+	accessTokenCookie   = "access_token"
+	// csrfTokenCookie and csrfTokenHeader are double submit token of browser clients which use cookie,
+	// other sites can't read cookie of client and send it in header
+	csrfTokenCookie = "csrf_token"
+	csrfTokenHeader = "X-CSRF-Token"
+	csrfTokenLength = 32
 )
 
-func (H *Handler) userIdentity(c *gin.Context) {
-	// TODO: realise check permission
+// accessToken returns token from "Authorization: Bearer <token>" header, browser clients send
+// token in cookie which is set by sign in and refresh. Cookie is sent by browser
+// with cross site requests too, so state changing requests with cookie must have csrf token.
+func accessToken(c *gin.Context) (string, error) {
 	header := c.GetHeader(authorizationHeader)
 	if header == "" {
-		newErrorResponse(c, http.StatusUnauthorized, "empty authorization header")
-		logrus.WithFields(logrus.Fields{
-			"module":         "handler",
-			"function":       "userIdentity",
-			"header":         header,
-			"requestHeaders": c.Request.Header,
-		}).Error("empty header")
-		return
+		cookie, err := c.Cookie(accessTokenCookie)
+		if err != nil || cookie == "" {
+			return "", moduleErrors.ErrorHandlerEmptyAuthorization
+		}
+		if !safeMethod(c.Request.Method) && !validCSRFToken(c) {
+			return "", moduleErrors.ErrorHandlerInvalidCSRFToken
+		}
+		return cookie, nil
 	}
-	headerParts := strings.Split(header, " ")
-	if len(headerParts) != 2 {
-		newErrorResponse(c, http.StatusUnauthorized, "invalid authorization header")
-		return
+
+	headerParts := strings.Fields(header)
+	if len(headerParts) != 2 || !strings.EqualFold(headerParts[0], authorizationScheme) {
+		return "", moduleErrors.ErrorHandlerInvalidAuthorization
 	}
 
-	userId, credentials, err := H.token.ValidateToken(headerParts[1])
-	if err != nil {
-		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+	return headerParts[1], nil
+}
+
+func safeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+func validCSRFToken(c *gin.Context) bool {
+	header := c.GetHeader(csrfTokenHeader)
+	cookie, err := c.Cookie(csrfTokenCookie)
+	if err != nil || header == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(header), []byte(cookie)) == 1
+}
+
+// setAuthCookies gives access token to browser client in cookie which scripts can't read, so token
+// can't be stolen by injected script. New csrf token cookie is readable, client copies it to csrf token header.
+// Cookies live until browser is closed, expired access token is replaced by refresh.
+func setAuthCookies(c *gin.Context, token string) error {
+	buf := make([]byte, csrfTokenLength)
+	if _, err := rand.Read(buf); err != nil {
+		return err
+	}
+
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     accessTokenCookie,
+		Value:    token,
+		Path:     "/",
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     csrfTokenCookie,
+		Value:    hex.EncodeToString(buf),
+		Path:     "/",
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	return nil
+}
+
+// clearAuthCookies removes access token and csrf token cookies from browser
+func clearAuthCookies(c *gin.Context) {
+	for _, name := range []string{accessTokenCookie, csrfTokenCookie} {
+		http.SetCookie(c.Writer, &http.Cookie{
+			Name:     name,
+			Path:     "/",
+			MaxAge:   -1,
+			Secure:   true,
+			HttpOnly: name == accessTokenCookie,
+			SameSite: http.SameSiteStrictMode,
+		})
+	}
+}
+
+// userIdentity returns middleware which aborts request without valid access token.
+// User id and credentials are put to request context, handlers pass it to services.
+// Credentials are taken from token claims, or loaded by credentialsLoader if it isn't nil.
+// If userDB isn't nil requests of locked users are refused before access token expires.
+func userIdentity(token token, credentialsLoader credentialsLoader, userDB userDB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		logBase := logrus.Fields{
+			"module":   "handler",
+			"function": "userIdentity",
+			"path":     c.FullPath(),
+		}
+
+		tokenString, err := accessToken(c)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Warning("error get access token")
+			if err == moduleErrors.ErrorHandlerInvalidCSRFToken {
+				newErrorResponse(c, http.StatusForbidden, err.Error())
+				return
+			}
+			newErrorResponse(c, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		userId, credentials, err := token.ValidateToken(tokenString)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Warning("invalid access token")
+			newErrorResponse(c, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		if credentialsLoader != nil {
+			credentials, err = credentialsLoader.LoadUserCredential(c.Request.Context(), userId)
+			if err != nil {
+				logrus.WithFields(logrus.Fields{
+					"base":   logBase,
+					"userId": userId,
+					"error":  err.Error(),
+				}).Error("error load user credentials")
+				newErrorResponse(c, http.StatusInternalServerError, err.Error())
+				return
+			}
+		}
+
+		if userDB != nil {
+			userData, err := userDB.GetUser(c.Request.Context(), userId)
+			if err != nil {
+				logrus.WithFields(logrus.Fields{
+					"base":   logBase,
+					"userId": userId,
+					"error":  err.Error(),
+				}).Error("error get user data")
+				if err == moduleErrors.ErrorDatabaseUserNotFound {
+					newErrorResponse(c, http.StatusUnauthorized, moduleErrors.ErrorServiceUserNotFound.Error())
+					return
+				}
+				newErrorResponse(c, http.StatusInternalServerError, err.Error())
+				return
+			}
+			if userData.LockedTime != 0 {
+				logrus.WithFields(logrus.Fields{
+					"base":   logBase,
+					"userId": userId,
+				}).Warning(moduleErrors.ErrorServiceUserLocked.Error())
+				newErrorResponse(c, http.StatusForbidden, moduleErrors.ErrorServiceUserLocked.Error())
+				return
+			}
+		}
+
+		c.Request = c.Request.WithContext(core.ContextWithUser(c.Request.Context(), userId, credentials))
+		c.Next()
 	}
-	c.Set(core.UserIdCtx, userId)
-	c.Set(core.CredentialsCtx, credentials)
 }
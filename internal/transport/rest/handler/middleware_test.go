@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"errors"
+	mockhandler "github.com/Thing-repository/backend-server/internal/transport/rest/handler/mocks"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestUserIdentity(t *testing.T) {
+	type mockBehavior func(s *mockhandler.Mocktoken)
+	type loaderBehavior func(s *mockhandler.MockcredentialsLoader)
+	type userDBBehavior func(s *mockhandler.MockuserDB)
+
+	testCredentials := []core.Credentials{
+		{CredentialType: core.CredentialTypeCompanyUser, ObjectId: 1},
+	}
+
+	testTable := []struct {
+		name                 string
+		method               string
+		headerValue          string
+		cookieValue          string
+		csrfHeader           string
+		csrfCookie           string
+		mockBehavior         mockBehavior
+		loaderBehavior       loaderBehavior
+		userDBBehavior       userDBBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Ok",
+			headerValue: "Bearer test_token",
+			mockBehavior: func(s *mockhandler.Mocktoken) {
+				s.EXPECT().ValidateToken("test_token").Return(1, testCredentials, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "1",
+		},
+		{
+			name:        "Lowercase scheme",
+			headerValue: "bearer test_token",
+			mockBehavior: func(s *mockhandler.Mocktoken) {
+				s.EXPECT().ValidateToken("test_token").Return(1, testCredentials, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "1",
+		},
+		{
+			name:        "Cookie",
+			cookieValue: "test_token",
+			mockBehavior: func(s *mockhandler.Mocktoken) {
+				s.EXPECT().ValidateToken("test_token").Return(1, testCredentials, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "1",
+		},
+		{
+			name:        "Cookie with csrf token",
+			method:      "POST",
+			cookieValue: "test_token",
+			csrfHeader:  "csrf",
+			csrfCookie:  "csrf",
+			mockBehavior: func(s *mockhandler.Mocktoken) {
+				s.EXPECT().ValidateToken("test_token").Return(1, testCredentials, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "1",
+		},
+		{
+			name:                 "Cookie without csrf token",
+			method:               "POST",
+			cookieValue:          "test_token",
+			csrfCookie:           "csrf",
+			mockBehavior:         func(s *mockhandler.Mocktoken) {},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"csrf token header doesn't match csrf token cookie"}`,
+		},
+		{
+			name:                 "Cookie with other csrf token",
+			method:               "DELETE",
+			cookieValue:          "test_token",
+			csrfHeader:           "other",
+			csrfCookie:           "csrf",
+			mockBehavior:         func(s *mockhandler.Mocktoken) {},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"csrf token header doesn't match csrf token cookie"}`,
+		},
+		{
+			name:        "Header without csrf token",
+			method:      "POST",
+			headerValue: "Bearer test_token",
+			mockBehavior: func(s *mockhandler.Mocktoken) {
+				s.EXPECT().ValidateToken("test_token").Return(1, testCredentials, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "1",
+		},
+		{
+			name:        "Header has priority over cookie",
+			headerValue: "Bearer header_token",
+			cookieValue: "cookie_token",
+			mockBehavior: func(s *mockhandler.Mocktoken) {
+				s.EXPECT().ValidateToken("header_token").Return(1, testCredentials, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "1",
+		},
+		{
+			name:                 "No header",
+			mockBehavior:         func(s *mockhandler.Mocktoken) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"empty authorization header"}`,
+		},
+		{
+			name:                 "Invalid scheme",
+			headerValue:          "Basic test_token",
+			mockBehavior:         func(s *mockhandler.Mocktoken) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"invalid authorization header"}`,
+		},
+		{
+			name:                 "No token",
+			headerValue:          "Bearer",
+			mockBehavior:         func(s *mockhandler.Mocktoken) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"invalid authorization header"}`,
+		},
+		{
+			name:                 "Too many parts",
+			headerValue:          "Bearer test_token extra",
+			mockBehavior:         func(s *mockhandler.Mocktoken) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"invalid authorization header"}`,
+		},
+		{
+			name:        "Invalid token",
+			headerValue: "Bearer test_token",
+			mockBehavior: func(s *mockhandler.Mocktoken) {
+				s.EXPECT().ValidateToken("test_token").Return(0, nil, errors.New("token is expired"))
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"token is expired"}`,
+		},
+		{
+			name:        "Credentials from database",
+			headerValue: "Bearer test_token",
+			mockBehavior: func(s *mockhandler.Mocktoken) {
+				s.EXPECT().ValidateToken("test_token").Return(1, nil, nil)
+			},
+			loaderBehavior: func(s *mockhandler.MockcredentialsLoader) {
+				s.EXPECT().LoadUserCredential(gomock.Any(), 1).Return(testCredentials, nil)
+			},
+			userDBBehavior: func(s *mockhandler.MockuserDB) {
+				s.EXPECT().GetUser(gomock.Any(), 1).Return(&core.UserDB{User: core.User{Id: 1}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "1",
+		},
+		{
+			name:        "Locked user",
+			headerValue: "Bearer test_token",
+			mockBehavior: func(s *mockhandler.Mocktoken) {
+				s.EXPECT().ValidateToken("test_token").Return(1, nil, nil)
+			},
+			loaderBehavior: func(s *mockhandler.MockcredentialsLoader) {
+				s.EXPECT().LoadUserCredential(gomock.Any(), 1).Return(testCredentials, nil)
+			},
+			userDBBehavior: func(s *mockhandler.MockuserDB) {
+				s.EXPECT().GetUser(gomock.Any(), 1).Return(&core.UserDB{User: core.User{Id: 1},
+					LockedTime: 1700000000}, nil)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"user is locked"}`,
+		},
+		{
+			name:        "Error load credentials",
+			headerValue: "Bearer test_token",
+			mockBehavior: func(s *mockhandler.Mocktoken) {
+				s.EXPECT().ValidateToken("test_token").Return(1, nil, nil)
+			},
+			loaderBehavior: func(s *mockhandler.MockcredentialsLoader) {
+				s.EXPECT().LoadUserCredential(gomock.Any(), 1).Return(nil, errors.New("database error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"database error"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Init deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			token := mockhandler.NewMocktoken(c)
+			testCase.mockBehavior(token)
+
+			// Without loader credentials are taken from token
+			var loader credentialsLoader
+			if testCase.loaderBehavior != nil {
+				credentialsLoader := mockhandler.NewMockcredentialsLoader(c)
+				testCase.loaderBehavior(credentialsLoader)
+				loader = credentialsLoader
+			}
+			var lockDB userDB
+			if testCase.userDBBehavior != nil {
+				userDB := mockhandler.NewMockuserDB(c)
+				testCase.userDBBehavior(userDB)
+				lockDB = userDB
+			}
+
+			// Test middleware, handler must be called only for authenticated user
+			r := gin.New()
+			r.ContextWithFallback = true
+			r.Any("/identity", userIdentity(token, loader, lockDB), func(c *gin.Context) {
+				userId, err := core.ContextGetUserId(c)
+				if err != nil {
+					t.Fatalf("error get user id from context: %s", err)
+				}
+				credentials, err := core.ContextGetUserCredentials(c.Request.Context())
+				if err != nil {
+					t.Fatalf("error get user credentials from context: %s", err)
+				}
+				if !reflect.DeepEqual(credentials, testCredentials) {
+					t.Errorf("credentials = %v, want %v", credentials, testCredentials)
+				}
+				c.JSON(http.StatusOK, userId)
+			})
+
+			w := httptest.NewRecorder()
+			method := testCase.method
+			if method == "" {
+				method = "GET"
+			}
+			req := httptest.NewRequest(method, "/identity", nil)
+			if testCase.headerValue != "" {
+				req.Header.Set(authorizationHeader, testCase.headerValue)
+			}
+			if testCase.cookieValue != "" {
+				req.AddCookie(&http.Cookie{Name: accessTokenCookie, Value: testCase.cookieValue})
+			}
+			if testCase.csrfHeader != "" {
+				req.Header.Set(csrfTokenHeader, testCase.csrfHeader)
+			}
+			if testCase.csrfCookie != "" {
+				req.AddCookie(&http.Cookie{Name: csrfTokenCookie, Value: testCase.csrfCookie})
+			}
+
+			// Perform request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			if w.Code != testCase.expectedStatusCode {
+				t.Errorf("status code = %d, want %d", w.Code, testCase.expectedStatusCode)
+			}
+			if w.Body.String() != testCase.expectedResponseBody {
+				t.Errorf("response body = %s, want %s", w.Body.String(), testCase.expectedResponseBody)
+			}
+		})
+	}
+}
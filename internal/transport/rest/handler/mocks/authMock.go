@@ -5,37 +5,54 @@
 package mock_handler
 
 import (
+	context "context"
+	io "io"
 	reflect "reflect"
 
 	core "github.com/Thing-repository/backend-server/pkg/core"
 	gomock "github.com/golang/mock/gomock"
 )
 
-// MockAuth is a mock of Auth interface.
-type MockAuth struct {
+// Mockauth is a mock of auth interface.
+type Mockauth struct {
 	ctrl     *gomock.Controller
-	recorder *MockAuthMockRecorder
+	recorder *MockauthMockRecorder
 }
 
-// MockAuthMockRecorder is the mock recorder for MockAuth.
-type MockAuthMockRecorder struct {
-	mock *MockAuth
+// MockauthMockRecorder is the mock recorder for Mockauth.
+type MockauthMockRecorder struct {
+	mock *Mockauth
 }
 
-// NewMockAuth creates a new mock instance.
-func NewMockAuth(ctrl *gomock.Controller) *MockAuth {
-	mock := &MockAuth{ctrl: ctrl}
-	mock.recorder = &MockAuthMockRecorder{mock}
+// NewMockauth creates a new mock instance.
+func NewMockauth(ctrl *gomock.Controller) *Mockauth {
+	mock := &Mockauth{ctrl: ctrl}
+	mock.recorder = &MockauthMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockAuth) EXPECT() *MockAuthMockRecorder {
+func (m *Mockauth) EXPECT() *MockauthMockRecorder {
 	return m.recorder
 }
 
+// Refresh mocks base method.
+func (m *Mockauth) Refresh(refreshToken string) (*core.TokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", refreshToken)
+	ret0, _ := ret[0].(*core.TokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockauthMockRecorder) Refresh(refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*Mockauth)(nil).Refresh), refreshToken)
+}
+
 // SignIn mocks base method.
-func (m *MockAuth) SignIn(authData *core.UserSignInData) (*core.SignInResponse, error) {
+func (m *Mockauth) SignIn(authData *core.UserSignInData) (*core.SignInResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SignIn", authData)
 	ret0, _ := ret[0].(*core.SignInResponse)
@@ -44,13 +61,41 @@ func (m *MockAuth) SignIn(authData *core.UserSignInData) (*core.SignInResponse,
 }
 
 // SignIn indicates an expected call of SignIn.
-func (mr *MockAuthMockRecorder) SignIn(authData interface{}) *gomock.Call {
+func (mr *MockauthMockRecorder) SignIn(authData interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*Mockauth)(nil).SignIn), authData)
+}
+
+// SignOut mocks base method.
+func (m *Mockauth) SignOut(refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignOut", refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SignOut indicates an expected call of SignOut.
+func (mr *MockauthMockRecorder) SignOut(refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignOut", reflect.TypeOf((*Mockauth)(nil).SignOut), refreshToken)
+}
+
+// SignOutAll mocks base method.
+func (m *Mockauth) SignOutAll(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignOutAll", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SignOutAll indicates an expected call of SignOutAll.
+func (mr *MockauthMockRecorder) SignOutAll(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockAuth)(nil).SignIn), authData)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignOutAll", reflect.TypeOf((*Mockauth)(nil).SignOutAll), ctx)
 }
 
 // SignUp mocks base method.
-func (m *MockAuth) SignUp(authData *core.UserSignUpData) (*core.SignInResponse, error) {
+func (m *Mockauth) SignUp(authData *core.UserSignUpData) (*core.SignInResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SignUp", authData)
 	ret0, _ := ret[0].(*core.SignInResponse)
@@ -59,7 +104,1866 @@ func (m *MockAuth) SignUp(authData *core.UserSignUpData) (*core.SignInResponse,
 }
 
 // SignUp indicates an expected call of SignUp.
-func (mr *MockAuthMockRecorder) SignUp(authData interface{}) *gomock.Call {
+func (mr *MockauthMockRecorder) SignUp(authData interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignUp", reflect.TypeOf((*Mockauth)(nil).SignUp), authData)
+}
+
+// MockemailVerification is a mock of emailVerification interface.
+type MockemailVerification struct {
+	ctrl     *gomock.Controller
+	recorder *MockemailVerificationMockRecorder
+}
+
+// MockemailVerificationMockRecorder is the mock recorder for MockemailVerification.
+type MockemailVerificationMockRecorder struct {
+	mock *MockemailVerification
+}
+
+// NewMockemailVerification creates a new mock instance.
+func NewMockemailVerification(ctrl *gomock.Controller) *MockemailVerification {
+	mock := &MockemailVerification{ctrl: ctrl}
+	mock.recorder = &MockemailVerificationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockemailVerification) EXPECT() *MockemailVerificationMockRecorder {
+	return m.recorder
+}
+
+// ResendVerification mocks base method.
+func (m *MockemailVerification) ResendVerification(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerification", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerification indicates an expected call of ResendVerification.
+func (mr *MockemailVerificationMockRecorder) ResendVerification(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerification", reflect.TypeOf((*MockemailVerification)(nil).ResendVerification), ctx)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockemailVerification) VerifyEmail(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockemailVerificationMockRecorder) VerifyEmail(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockemailVerification)(nil).VerifyEmail), ctx, token)
+}
+
+// Mockpassword is a mock of password interface.
+type Mockpassword struct {
+	ctrl     *gomock.Controller
+	recorder *MockpasswordMockRecorder
+}
+
+// MockpasswordMockRecorder is the mock recorder for Mockpassword.
+type MockpasswordMockRecorder struct {
+	mock *Mockpassword
+}
+
+// NewMockpassword creates a new mock instance.
+func NewMockpassword(ctrl *gomock.Controller) *Mockpassword {
+	mock := &Mockpassword{ctrl: ctrl}
+	mock.recorder = &MockpasswordMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockpassword) EXPECT() *MockpasswordMockRecorder {
+	return m.recorder
+}
+
+// ChangePassword mocks base method.
+func (m *Mockpassword) ChangePassword(ctx context.Context, oldPassword, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, oldPassword, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockpasswordMockRecorder) ChangePassword(ctx, oldPassword, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*Mockpassword)(nil).ChangePassword), ctx, oldPassword, newPassword)
+}
+
+// ForgotPassword mocks base method.
+func (m *Mockpassword) ForgotPassword(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockpasswordMockRecorder) ForgotPassword(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*Mockpassword)(nil).ForgotPassword), ctx, email)
+}
+
+// ResetPassword mocks base method.
+func (m *Mockpassword) ResetPassword(ctx context.Context, token, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, token, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockpasswordMockRecorder) ResetPassword(ctx, token, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*Mockpassword)(nil).ResetPassword), ctx, token, password)
+}
+
+// Mockcompany is a mock of company interface.
+type Mockcompany struct {
+	ctrl     *gomock.Controller
+	recorder *MockcompanyMockRecorder
+}
+
+// MockcompanyMockRecorder is the mock recorder for Mockcompany.
+type MockcompanyMockRecorder struct {
+	mock *Mockcompany
+}
+
+// NewMockcompany creates a new mock instance.
+func NewMockcompany(ctrl *gomock.Controller) *Mockcompany {
+	mock := &Mockcompany{ctrl: ctrl}
+	mock.recorder = &MockcompanyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcompany) EXPECT() *MockcompanyMockRecorder {
+	return m.recorder
+}
+
+// AddCompany mocks base method.
+func (m *Mockcompany) AddCompany(ctx context.Context, companyAdd *core.CompanyBase) (*core.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCompany", ctx, companyAdd)
+	ret0, _ := ret[0].(*core.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCompany indicates an expected call of AddCompany.
+func (mr *MockcompanyMockRecorder) AddCompany(ctx, companyAdd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCompany", reflect.TypeOf((*Mockcompany)(nil).AddCompany), ctx, companyAdd)
+}
+
+// DeleteCompany mocks base method.
+func (m *Mockcompany) DeleteCompany(ctx context.Context, companyId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCompany", ctx, companyId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCompany indicates an expected call of DeleteCompany.
+func (mr *MockcompanyMockRecorder) DeleteCompany(ctx, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCompany", reflect.TypeOf((*Mockcompany)(nil).DeleteCompany), ctx, companyId)
+}
+
+// FindCompanies mocks base method.
+func (m *Mockcompany) FindCompanies(ctx context.Context, filter string, limit, offset int) ([]core.CompanySearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCompanies", ctx, filter, limit, offset)
+	ret0, _ := ret[0].([]core.CompanySearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCompanies indicates an expected call of FindCompanies.
+func (mr *MockcompanyMockRecorder) FindCompanies(ctx, filter, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCompanies", reflect.TypeOf((*Mockcompany)(nil).FindCompanies), ctx, filter, limit, offset)
+}
+
+// GetCompany mocks base method.
+func (m *Mockcompany) GetCompany(ctx context.Context, companyId int) (*core.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompany", ctx, companyId)
+	ret0, _ := ret[0].(*core.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompany indicates an expected call of GetCompany.
+func (mr *MockcompanyMockRecorder) GetCompany(ctx, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompany", reflect.TypeOf((*Mockcompany)(nil).GetCompany), ctx, companyId)
+}
+
+// UpdateCompany mocks base method.
+func (m *Mockcompany) UpdateCompany(ctx context.Context, companyBase core.CompanyBase, companyId int) (*core.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCompany", ctx, companyBase, companyId)
+	ret0, _ := ret[0].(*core.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCompany indicates an expected call of UpdateCompany.
+func (mr *MockcompanyMockRecorder) UpdateCompany(ctx, companyBase, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCompany", reflect.TypeOf((*Mockcompany)(nil).UpdateCompany), ctx, companyBase, companyId)
+}
+
+// Mockuser is a mock of user interface.
+type Mockuser struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserMockRecorder
+}
+
+// MockuserMockRecorder is the mock recorder for Mockuser.
+type MockuserMockRecorder struct {
+	mock *Mockuser
+}
+
+// NewMockuser creates a new mock instance.
+func NewMockuser(ctrl *gomock.Controller) *Mockuser {
+	mock := &Mockuser{ctrl: ctrl}
+	mock.recorder = &MockuserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockuser) EXPECT() *MockuserMockRecorder {
+	return m.recorder
+}
+
+// AddUserToCompany mocks base method.
+func (m *Mockuser) AddUserToCompany(ctx context.Context, userId, departmentId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserToCompany", ctx, userId, departmentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUserToCompany indicates an expected call of AddUserToCompany.
+func (mr *MockuserMockRecorder) AddUserToCompany(ctx, userId, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserToCompany", reflect.TypeOf((*Mockuser)(nil).AddUserToCompany), ctx, userId, departmentId)
+}
+
+// FindUsersForInvite mocks base method.
+func (m *Mockuser) FindUsersForInvite(ctx context.Context, filter string, limit, offset int) ([]core.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUsersForInvite", ctx, filter, limit, offset)
+	ret0, _ := ret[0].([]core.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUsersForInvite indicates an expected call of FindUsersForInvite.
+func (mr *MockuserMockRecorder) FindUsersForInvite(ctx, filter, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUsersForInvite", reflect.TypeOf((*Mockuser)(nil).FindUsersForInvite), ctx, filter, limit, offset)
+}
+
+// GetCompanyUsers mocks base method.
+func (m *Mockuser) GetCompanyUsers(ctx context.Context, filter *core.UserDirectoryFilter) (*core.UserDirectoryPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanyUsers", ctx, filter)
+	ret0, _ := ret[0].(*core.UserDirectoryPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanyUsers indicates an expected call of GetCompanyUsers.
+func (mr *MockuserMockRecorder) GetCompanyUsers(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyUsers", reflect.TypeOf((*Mockuser)(nil).GetCompanyUsers), ctx, filter)
+}
+
+// LeaveCompany mocks base method.
+func (m *Mockuser) LeaveCompany(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaveCompany", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LeaveCompany indicates an expected call of LeaveCompany.
+func (mr *MockuserMockRecorder) LeaveCompany(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaveCompany", reflect.TypeOf((*Mockuser)(nil).LeaveCompany), ctx)
+}
+
+// MoveUserToDepartment mocks base method.
+func (m *Mockuser) MoveUserToDepartment(ctx context.Context, userId, departmentId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveUserToDepartment", ctx, userId, departmentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveUserToDepartment indicates an expected call of MoveUserToDepartment.
+func (mr *MockuserMockRecorder) MoveUserToDepartment(ctx, userId, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveUserToDepartment", reflect.TypeOf((*Mockuser)(nil).MoveUserToDepartment), ctx, userId, departmentId)
+}
+
+// RemoveUserFromCompany mocks base method.
+func (m *Mockuser) RemoveUserFromCompany(ctx context.Context, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUserFromCompany", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveUserFromCompany indicates an expected call of RemoveUserFromCompany.
+func (mr *MockuserMockRecorder) RemoveUserFromCompany(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserFromCompany", reflect.TypeOf((*Mockuser)(nil).RemoveUserFromCompany), ctx, userId)
+}
+
+// Mockprofile is a mock of profile interface.
+type Mockprofile struct {
+	ctrl     *gomock.Controller
+	recorder *MockprofileMockRecorder
+}
+
+// MockprofileMockRecorder is the mock recorder for Mockprofile.
+type MockprofileMockRecorder struct {
+	mock *Mockprofile
+}
+
+// NewMockprofile creates a new mock instance.
+func NewMockprofile(ctrl *gomock.Controller) *Mockprofile {
+	mock := &Mockprofile{ctrl: ctrl}
+	mock.recorder = &MockprofileMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockprofile) EXPECT() *MockprofileMockRecorder {
+	return m.recorder
+}
+
+// DeleteCurrentUser mocks base method.
+func (m *Mockprofile) DeleteCurrentUser(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCurrentUser", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCurrentUser indicates an expected call of DeleteCurrentUser.
+func (mr *MockprofileMockRecorder) DeleteCurrentUser(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCurrentUser", reflect.TypeOf((*Mockprofile)(nil).DeleteCurrentUser), ctx)
+}
+
+// GetCurrentUser mocks base method.
+func (m *Mockprofile) GetCurrentUser(ctx context.Context) (*core.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentUser", ctx)
+	ret0, _ := ret[0].(*core.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentUser indicates an expected call of GetCurrentUser.
+func (mr *MockprofileMockRecorder) GetCurrentUser(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentUser", reflect.TypeOf((*Mockprofile)(nil).GetCurrentUser), ctx)
+}
+
+// GetUser mocks base method.
+func (m *Mockprofile) GetUser(ctx context.Context, userId int) (*core.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userId)
+	ret0, _ := ret[0].(*core.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockprofileMockRecorder) GetUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*Mockprofile)(nil).GetUser), ctx, userId)
+}
+
+// UpdateCurrentUser mocks base method.
+func (m *Mockprofile) UpdateCurrentUser(ctx context.Context, userUpdate *core.UserUpdate) (*core.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCurrentUser", ctx, userUpdate)
+	ret0, _ := ret[0].(*core.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCurrentUser indicates an expected call of UpdateCurrentUser.
+func (mr *MockprofileMockRecorder) UpdateCurrentUser(ctx, userUpdate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrentUser", reflect.TypeOf((*Mockprofile)(nil).UpdateCurrentUser), ctx, userUpdate)
+}
+
+// Mockinvitation is a mock of invitation interface.
+type Mockinvitation struct {
+	ctrl     *gomock.Controller
+	recorder *MockinvitationMockRecorder
+}
+
+// MockinvitationMockRecorder is the mock recorder for Mockinvitation.
+type MockinvitationMockRecorder struct {
+	mock *Mockinvitation
+}
+
+// NewMockinvitation creates a new mock instance.
+func NewMockinvitation(ctrl *gomock.Controller) *Mockinvitation {
+	mock := &Mockinvitation{ctrl: ctrl}
+	mock.recorder = &MockinvitationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockinvitation) EXPECT() *MockinvitationMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *Mockinvitation) AcceptInvitation(ctx context.Context, invitationId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, invitationId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockinvitationMockRecorder) AcceptInvitation(ctx, invitationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*Mockinvitation)(nil).AcceptInvitation), ctx, invitationId)
+}
+
+// DeclineInvitation mocks base method.
+func (m *Mockinvitation) DeclineInvitation(ctx context.Context, invitationId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineInvitation", ctx, invitationId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineInvitation indicates an expected call of DeclineInvitation.
+func (mr *MockinvitationMockRecorder) DeclineInvitation(ctx, invitationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineInvitation", reflect.TypeOf((*Mockinvitation)(nil).DeclineInvitation), ctx, invitationId)
+}
+
+// GetMyInvitations mocks base method.
+func (m *Mockinvitation) GetMyInvitations(ctx context.Context) ([]core.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMyInvitations", ctx)
+	ret0, _ := ret[0].([]core.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMyInvitations indicates an expected call of GetMyInvitations.
+func (mr *MockinvitationMockRecorder) GetMyInvitations(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyInvitations", reflect.TypeOf((*Mockinvitation)(nil).GetMyInvitations), ctx)
+}
+
+// Invite mocks base method.
+func (m *Mockinvitation) Invite(ctx context.Context, invitationAdd *core.InvitationAdd) (*core.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", ctx, invitationAdd)
+	ret0, _ := ret[0].(*core.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockinvitationMockRecorder) Invite(ctx, invitationAdd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*Mockinvitation)(nil).Invite), ctx, invitationAdd)
+}
+
+// MockjoinRequest is a mock of joinRequest interface.
+type MockjoinRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockjoinRequestMockRecorder
+}
+
+// MockjoinRequestMockRecorder is the mock recorder for MockjoinRequest.
+type MockjoinRequestMockRecorder struct {
+	mock *MockjoinRequest
+}
+
+// NewMockjoinRequest creates a new mock instance.
+func NewMockjoinRequest(ctrl *gomock.Controller) *MockjoinRequest {
+	mock := &MockjoinRequest{ctrl: ctrl}
+	mock.recorder = &MockjoinRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockjoinRequest) EXPECT() *MockjoinRequestMockRecorder {
+	return m.recorder
+}
+
+// AddJoinRequest mocks base method.
+func (m *MockjoinRequest) AddJoinRequest(ctx context.Context, joinRequestAdd *core.JoinRequestAdd) (*core.JoinRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddJoinRequest", ctx, joinRequestAdd)
+	ret0, _ := ret[0].(*core.JoinRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddJoinRequest indicates an expected call of AddJoinRequest.
+func (mr *MockjoinRequestMockRecorder) AddJoinRequest(ctx, joinRequestAdd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddJoinRequest", reflect.TypeOf((*MockjoinRequest)(nil).AddJoinRequest), ctx, joinRequestAdd)
+}
+
+// ApproveJoinRequest mocks base method.
+func (m *MockjoinRequest) ApproveJoinRequest(ctx context.Context, joinRequestId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveJoinRequest", ctx, joinRequestId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApproveJoinRequest indicates an expected call of ApproveJoinRequest.
+func (mr *MockjoinRequestMockRecorder) ApproveJoinRequest(ctx, joinRequestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveJoinRequest", reflect.TypeOf((*MockjoinRequest)(nil).ApproveJoinRequest), ctx, joinRequestId)
+}
+
+// CancelJoinRequest mocks base method.
+func (m *MockjoinRequest) CancelJoinRequest(ctx context.Context, joinRequestId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelJoinRequest", ctx, joinRequestId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelJoinRequest indicates an expected call of CancelJoinRequest.
+func (mr *MockjoinRequestMockRecorder) CancelJoinRequest(ctx, joinRequestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelJoinRequest", reflect.TypeOf((*MockjoinRequest)(nil).CancelJoinRequest), ctx, joinRequestId)
+}
+
+// GetCompanyJoinRequests mocks base method.
+func (m *MockjoinRequest) GetCompanyJoinRequests(ctx context.Context, companyId int) ([]core.JoinRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanyJoinRequests", ctx, companyId)
+	ret0, _ := ret[0].([]core.JoinRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanyJoinRequests indicates an expected call of GetCompanyJoinRequests.
+func (mr *MockjoinRequestMockRecorder) GetCompanyJoinRequests(ctx, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyJoinRequests", reflect.TypeOf((*MockjoinRequest)(nil).GetCompanyJoinRequests), ctx, companyId)
+}
+
+// GetDepartmentJoinRequests mocks base method.
+func (m *MockjoinRequest) GetDepartmentJoinRequests(ctx context.Context, departmentId int) ([]core.JoinRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartmentJoinRequests", ctx, departmentId)
+	ret0, _ := ret[0].([]core.JoinRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartmentJoinRequests indicates an expected call of GetDepartmentJoinRequests.
+func (mr *MockjoinRequestMockRecorder) GetDepartmentJoinRequests(ctx, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartmentJoinRequests", reflect.TypeOf((*MockjoinRequest)(nil).GetDepartmentJoinRequests), ctx, departmentId)
+}
+
+// GetMyJoinRequests mocks base method.
+func (m *MockjoinRequest) GetMyJoinRequests(ctx context.Context) ([]core.JoinRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMyJoinRequests", ctx)
+	ret0, _ := ret[0].([]core.JoinRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMyJoinRequests indicates an expected call of GetMyJoinRequests.
+func (mr *MockjoinRequestMockRecorder) GetMyJoinRequests(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyJoinRequests", reflect.TypeOf((*MockjoinRequest)(nil).GetMyJoinRequests), ctx)
+}
+
+// RejectJoinRequest mocks base method.
+func (m *MockjoinRequest) RejectJoinRequest(ctx context.Context, joinRequestId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectJoinRequest", ctx, joinRequestId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RejectJoinRequest indicates an expected call of RejectJoinRequest.
+func (mr *MockjoinRequestMockRecorder) RejectJoinRequest(ctx, joinRequestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectJoinRequest", reflect.TypeOf((*MockjoinRequest)(nil).RejectJoinRequest), ctx, joinRequestId)
+}
+
+// Mockthing is a mock of thing interface.
+type Mockthing struct {
+	ctrl     *gomock.Controller
+	recorder *MockthingMockRecorder
+}
+
+// MockthingMockRecorder is the mock recorder for Mockthing.
+type MockthingMockRecorder struct {
+	mock *Mockthing
+}
+
+// NewMockthing creates a new mock instance.
+func NewMockthing(ctrl *gomock.Controller) *Mockthing {
+	mock := &Mockthing{ctrl: ctrl}
+	mock.recorder = &MockthingMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockthing) EXPECT() *MockthingMockRecorder {
+	return m.recorder
+}
+
+// AddThing mocks base method.
+func (m *Mockthing) AddThing(ctx context.Context, thingBase *core.ThingBase, departmentId *int) (*core.Thing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddThing", ctx, thingBase, departmentId)
+	ret0, _ := ret[0].(*core.Thing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddThing indicates an expected call of AddThing.
+func (mr *MockthingMockRecorder) AddThing(ctx, thingBase, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddThing", reflect.TypeOf((*Mockthing)(nil).AddThing), ctx, thingBase, departmentId)
+}
+
+// DeleteThing mocks base method.
+func (m *Mockthing) DeleteThing(ctx context.Context, thingId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteThing", ctx, thingId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteThing indicates an expected call of DeleteThing.
+func (mr *MockthingMockRecorder) DeleteThing(ctx, thingId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteThing", reflect.TypeOf((*Mockthing)(nil).DeleteThing), ctx, thingId)
+}
+
+// GetThing mocks base method.
+func (m *Mockthing) GetThing(ctx context.Context, thingId int) (*core.Thing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetThing", ctx, thingId)
+	ret0, _ := ret[0].(*core.Thing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetThing indicates an expected call of GetThing.
+func (mr *MockthingMockRecorder) GetThing(ctx, thingId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThing", reflect.TypeOf((*Mockthing)(nil).GetThing), ctx, thingId)
+}
+
+// GetThings mocks base method.
+func (m *Mockthing) GetThings(ctx context.Context, companyId, departmentId *int) ([]core.Thing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetThings", ctx, companyId, departmentId)
+	ret0, _ := ret[0].([]core.Thing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetThings indicates an expected call of GetThings.
+func (mr *MockthingMockRecorder) GetThings(ctx, companyId, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThings", reflect.TypeOf((*Mockthing)(nil).GetThings), ctx, companyId, departmentId)
+}
+
+// UpdateThing mocks base method.
+func (m *Mockthing) UpdateThing(ctx context.Context, thing *core.ThingUpdate, thingId int) (*core.Thing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateThing", ctx, thing, thingId)
+	ret0, _ := ret[0].(*core.Thing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateThing indicates an expected call of UpdateThing.
+func (mr *MockthingMockRecorder) UpdateThing(ctx, thing, thingId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateThing", reflect.TypeOf((*Mockthing)(nil).UpdateThing), ctx, thing, thingId)
+}
+
+// MockthingUsage is a mock of thingUsage interface.
+type MockthingUsage struct {
+	ctrl     *gomock.Controller
+	recorder *MockthingUsageMockRecorder
+}
+
+// MockthingUsageMockRecorder is the mock recorder for MockthingUsage.
+type MockthingUsageMockRecorder struct {
+	mock *MockthingUsage
+}
+
+// NewMockthingUsage creates a new mock instance.
+func NewMockthingUsage(ctrl *gomock.Controller) *MockthingUsage {
+	mock := &MockthingUsage{ctrl: ctrl}
+	mock.recorder = &MockthingUsageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockthingUsage) EXPECT() *MockthingUsageMockRecorder {
+	return m.recorder
+}
+
+// AddUsage mocks base method.
+func (m *MockthingUsage) AddUsage(ctx context.Context, usage *core.ThingUsageAdd, force bool) (*core.ThingUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUsage", ctx, usage, force)
+	ret0, _ := ret[0].(*core.ThingUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddUsage indicates an expected call of AddUsage.
+func (mr *MockthingUsageMockRecorder) AddUsage(ctx, usage, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUsage", reflect.TypeOf((*MockthingUsage)(nil).AddUsage), ctx, usage, force)
+}
+
+// ApproveUsage mocks base method.
+func (m *MockthingUsage) ApproveUsage(ctx context.Context, usageId int) (*core.ThingUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveUsage", ctx, usageId)
+	ret0, _ := ret[0].(*core.ThingUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveUsage indicates an expected call of ApproveUsage.
+func (mr *MockthingUsageMockRecorder) ApproveUsage(ctx, usageId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveUsage", reflect.TypeOf((*MockthingUsage)(nil).ApproveUsage), ctx, usageId)
+}
+
+// CancelUsage mocks base method.
+func (m *MockthingUsage) CancelUsage(ctx context.Context, usageId int) (*core.ThingUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelUsage", ctx, usageId)
+	ret0, _ := ret[0].(*core.ThingUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelUsage indicates an expected call of CancelUsage.
+func (mr *MockthingUsageMockRecorder) CancelUsage(ctx, usageId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUsage", reflect.TypeOf((*MockthingUsage)(nil).CancelUsage), ctx, usageId)
+}
+
+// GetMyUsages mocks base method.
+func (m *MockthingUsage) GetMyUsages(ctx context.Context) ([]core.ThingUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMyUsages", ctx)
+	ret0, _ := ret[0].([]core.ThingUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMyUsages indicates an expected call of GetMyUsages.
+func (mr *MockthingUsageMockRecorder) GetMyUsages(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyUsages", reflect.TypeOf((*MockthingUsage)(nil).GetMyUsages), ctx)
+}
+
+// GetThingUsages mocks base method.
+func (m *MockthingUsage) GetThingUsages(ctx context.Context, thingId int) ([]core.ThingUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetThingUsages", ctx, thingId)
+	ret0, _ := ret[0].([]core.ThingUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetThingUsages indicates an expected call of GetThingUsages.
+func (mr *MockthingUsageMockRecorder) GetThingUsages(ctx, thingId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThingUsages", reflect.TypeOf((*MockthingUsage)(nil).GetThingUsages), ctx, thingId)
+}
+
+// GetUsage mocks base method.
+func (m *MockthingUsage) GetUsage(ctx context.Context, usageId int) (*core.ThingUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsage", ctx, usageId)
+	ret0, _ := ret[0].(*core.ThingUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockthingUsageMockRecorder) GetUsage(ctx, usageId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockthingUsage)(nil).GetUsage), ctx, usageId)
+}
+
+// GetUsages mocks base method.
+func (m *MockthingUsage) GetUsages(ctx context.Context, companyId, departmentId *int) ([]core.ThingUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsages", ctx, companyId, departmentId)
+	ret0, _ := ret[0].([]core.ThingUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsages indicates an expected call of GetUsages.
+func (mr *MockthingUsageMockRecorder) GetUsages(ctx, companyId, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsages", reflect.TypeOf((*MockthingUsage)(nil).GetUsages), ctx, companyId, departmentId)
+}
+
+// GetUsagesForApprove mocks base method.
+func (m *MockthingUsage) GetUsagesForApprove(ctx context.Context) ([]core.ThingUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsagesForApprove", ctx)
+	ret0, _ := ret[0].([]core.ThingUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsagesForApprove indicates an expected call of GetUsagesForApprove.
+func (mr *MockthingUsageMockRecorder) GetUsagesForApprove(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsagesForApprove", reflect.TypeOf((*MockthingUsage)(nil).GetUsagesForApprove), ctx)
+}
+
+// ReturnUsage mocks base method.
+func (m *MockthingUsage) ReturnUsage(ctx context.Context, usageId int, usageReturn *core.UsageReturn) (*core.ThingUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnUsage", ctx, usageId, usageReturn)
+	ret0, _ := ret[0].(*core.ThingUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnUsage indicates an expected call of ReturnUsage.
+func (mr *MockthingUsageMockRecorder) ReturnUsage(ctx, usageId, usageReturn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnUsage", reflect.TypeOf((*MockthingUsage)(nil).ReturnUsage), ctx, usageId, usageReturn)
+}
+
+// TakeUsage mocks base method.
+func (m *MockthingUsage) TakeUsage(ctx context.Context, usageId int) (*core.ThingUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeUsage", ctx, usageId)
+	ret0, _ := ret[0].(*core.ThingUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeUsage indicates an expected call of TakeUsage.
+func (mr *MockthingUsageMockRecorder) TakeUsage(ctx, usageId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeUsage", reflect.TypeOf((*MockthingUsage)(nil).TakeUsage), ctx, usageId)
+}
+
+// Mockdepartment is a mock of department interface.
+type Mockdepartment struct {
+	ctrl     *gomock.Controller
+	recorder *MockdepartmentMockRecorder
+}
+
+// MockdepartmentMockRecorder is the mock recorder for Mockdepartment.
+type MockdepartmentMockRecorder struct {
+	mock *Mockdepartment
+}
+
+// NewMockdepartment creates a new mock instance.
+func NewMockdepartment(ctrl *gomock.Controller) *Mockdepartment {
+	mock := &Mockdepartment{ctrl: ctrl}
+	mock.recorder = &MockdepartmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdepartment) EXPECT() *MockdepartmentMockRecorder {
+	return m.recorder
+}
+
+// AddDepartment mocks base method.
+func (m *Mockdepartment) AddDepartment(ctx context.Context, departmentBase *core.DepartmentBase) (*core.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDepartment", ctx, departmentBase)
+	ret0, _ := ret[0].(*core.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDepartment indicates an expected call of AddDepartment.
+func (mr *MockdepartmentMockRecorder) AddDepartment(ctx, departmentBase interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDepartment", reflect.TypeOf((*Mockdepartment)(nil).AddDepartment), ctx, departmentBase)
+}
+
+// DeleteDepartment mocks base method.
+func (m *Mockdepartment) DeleteDepartment(ctx context.Context, departmentId int, targetDepartmentId *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDepartment", ctx, departmentId, targetDepartmentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDepartment indicates an expected call of DeleteDepartment.
+func (mr *MockdepartmentMockRecorder) DeleteDepartment(ctx, departmentId, targetDepartmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDepartment", reflect.TypeOf((*Mockdepartment)(nil).DeleteDepartment), ctx, departmentId, targetDepartmentId)
+}
+
+// GetDepartment mocks base method.
+func (m *Mockdepartment) GetDepartment(ctx context.Context, departmentId int) (*core.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartment", ctx, departmentId)
+	ret0, _ := ret[0].(*core.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartment indicates an expected call of GetDepartment.
+func (mr *MockdepartmentMockRecorder) GetDepartment(ctx, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartment", reflect.TypeOf((*Mockdepartment)(nil).GetDepartment), ctx, departmentId)
+}
+
+// GetDepartments mocks base method.
+func (m *Mockdepartment) GetDepartments(ctx context.Context, companyId int) ([]core.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartments", ctx, companyId)
+	ret0, _ := ret[0].([]core.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartments indicates an expected call of GetDepartments.
+func (mr *MockdepartmentMockRecorder) GetDepartments(ctx, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartments", reflect.TypeOf((*Mockdepartment)(nil).GetDepartments), ctx, companyId)
+}
+
+// UpdateDepartment mocks base method.
+func (m *Mockdepartment) UpdateDepartment(ctx context.Context, department *core.DepartmentUpdate, departmentId int) (*core.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDepartment", ctx, department, departmentId)
+	ret0, _ := ret[0].(*core.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDepartment indicates an expected call of UpdateDepartment.
+func (mr *MockdepartmentMockRecorder) UpdateDepartment(ctx, department, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDepartment", reflect.TypeOf((*Mockdepartment)(nil).UpdateDepartment), ctx, department, departmentId)
+}
+
+// Mockcredentials is a mock of credentials interface.
+type Mockcredentials struct {
+	ctrl     *gomock.Controller
+	recorder *MockcredentialsMockRecorder
+}
+
+// MockcredentialsMockRecorder is the mock recorder for Mockcredentials.
+type MockcredentialsMockRecorder struct {
+	mock *Mockcredentials
+}
+
+// NewMockcredentials creates a new mock instance.
+func NewMockcredentials(ctrl *gomock.Controller) *Mockcredentials {
+	mock := &Mockcredentials{ctrl: ctrl}
+	mock.recorder = &MockcredentialsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcredentials) EXPECT() *MockcredentialsMockRecorder {
+	return m.recorder
+}
+
+// GetCredentialUsers mocks base method.
+func (m *Mockcredentials) GetCredentialUsers(ctx context.Context, credentialType string, objectId int) ([]core.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCredentialUsers", ctx, credentialType, objectId)
+	ret0, _ := ret[0].([]core.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCredentialUsers indicates an expected call of GetCredentialUsers.
+func (mr *MockcredentialsMockRecorder) GetCredentialUsers(ctx, credentialType, objectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentialUsers", reflect.TypeOf((*Mockcredentials)(nil).GetCredentialUsers), ctx, credentialType, objectId)
+}
+
+// GrantCredential mocks base method.
+func (m *Mockcredentials) GrantCredential(ctx context.Context, credentialType string, objectId, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantCredential", ctx, credentialType, objectId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantCredential indicates an expected call of GrantCredential.
+func (mr *MockcredentialsMockRecorder) GrantCredential(ctx, credentialType, objectId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantCredential", reflect.TypeOf((*Mockcredentials)(nil).GrantCredential), ctx, credentialType, objectId, userId)
+}
+
+// RevokeCredential mocks base method.
+func (m *Mockcredentials) RevokeCredential(ctx context.Context, credentialType string, objectId, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeCredential", ctx, credentialType, objectId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeCredential indicates an expected call of RevokeCredential.
+func (mr *MockcredentialsMockRecorder) RevokeCredential(ctx, credentialType, objectId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeCredential", reflect.TypeOf((*Mockcredentials)(nil).RevokeCredential), ctx, credentialType, objectId, userId)
+}
+
+// MockthingBlock is a mock of thingBlock interface.
+type MockthingBlock struct {
+	ctrl     *gomock.Controller
+	recorder *MockthingBlockMockRecorder
+}
+
+// MockthingBlockMockRecorder is the mock recorder for MockthingBlock.
+type MockthingBlockMockRecorder struct {
+	mock *MockthingBlock
+}
+
+// NewMockthingBlock creates a new mock instance.
+func NewMockthingBlock(ctrl *gomock.Controller) *MockthingBlock {
+	mock := &MockthingBlock{ctrl: ctrl}
+	mock.recorder = &MockthingBlockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockthingBlock) EXPECT() *MockthingBlockMockRecorder {
+	return m.recorder
+}
+
+// AddBlock mocks base method.
+func (m *MockthingBlock) AddBlock(ctx context.Context, block *core.ThingBlockAdd, force bool) (*core.ThingBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBlock", ctx, block, force)
+	ret0, _ := ret[0].(*core.ThingBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddBlock indicates an expected call of AddBlock.
+func (mr *MockthingBlockMockRecorder) AddBlock(ctx, block, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBlock", reflect.TypeOf((*MockthingBlock)(nil).AddBlock), ctx, block, force)
+}
+
+// DeleteBlock mocks base method.
+func (m *MockthingBlock) DeleteBlock(ctx context.Context, blockId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlock", ctx, blockId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBlock indicates an expected call of DeleteBlock.
+func (mr *MockthingBlockMockRecorder) DeleteBlock(ctx, blockId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlock", reflect.TypeOf((*MockthingBlock)(nil).DeleteBlock), ctx, blockId)
+}
+
+// GetBlock mocks base method.
+func (m *MockthingBlock) GetBlock(ctx context.Context, blockId int) (*core.ThingBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlock", ctx, blockId)
+	ret0, _ := ret[0].(*core.ThingBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlock indicates an expected call of GetBlock.
+func (mr *MockthingBlockMockRecorder) GetBlock(ctx, blockId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlock", reflect.TypeOf((*MockthingBlock)(nil).GetBlock), ctx, blockId)
+}
+
+// GetBlocks mocks base method.
+func (m *MockthingBlock) GetBlocks(ctx context.Context, companyId, departmentId *int) ([]core.ThingBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocks", ctx, companyId, departmentId)
+	ret0, _ := ret[0].([]core.ThingBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocks indicates an expected call of GetBlocks.
+func (mr *MockthingBlockMockRecorder) GetBlocks(ctx, companyId, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocks", reflect.TypeOf((*MockthingBlock)(nil).GetBlocks), ctx, companyId, departmentId)
+}
+
+// UpdateBlock mocks base method.
+func (m *MockthingBlock) UpdateBlock(ctx context.Context, block *core.ThingBlockAdd, blockId int, force bool) (*core.ThingBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBlock", ctx, block, blockId, force)
+	ret0, _ := ret[0].(*core.ThingBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBlock indicates an expected call of UpdateBlock.
+func (mr *MockthingBlockMockRecorder) UpdateBlock(ctx, block, blockId, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBlock", reflect.TypeOf((*MockthingBlock)(nil).UpdateBlock), ctx, block, blockId, force)
+}
+
+// Mockstock is a mock of stock interface.
+type Mockstock struct {
+	ctrl     *gomock.Controller
+	recorder *MockstockMockRecorder
+}
+
+// MockstockMockRecorder is the mock recorder for Mockstock.
+type MockstockMockRecorder struct {
+	mock *Mockstock
+}
+
+// NewMockstock creates a new mock instance.
+func NewMockstock(ctrl *gomock.Controller) *Mockstock {
+	mock := &Mockstock{ctrl: ctrl}
+	mock.recorder = &MockstockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockstock) EXPECT() *MockstockMockRecorder {
+	return m.recorder
+}
+
+// AddMovement mocks base method.
+func (m *Mockstock) AddMovement(ctx context.Context, thingId int, movement *core.StockMovementAdd) (*core.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMovement", ctx, thingId, movement)
+	ret0, _ := ret[0].(*core.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMovement indicates an expected call of AddMovement.
+func (mr *MockstockMockRecorder) AddMovement(ctx, thingId, movement interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMovement", reflect.TypeOf((*Mockstock)(nil).AddMovement), ctx, thingId, movement)
+}
+
+// GetMovements mocks base method.
+func (m *Mockstock) GetMovements(ctx context.Context, thingId int) ([]core.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovements", ctx, thingId)
+	ret0, _ := ret[0].([]core.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovements indicates an expected call of GetMovements.
+func (mr *MockstockMockRecorder) GetMovements(ctx, thingId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovements", reflect.TypeOf((*Mockstock)(nil).GetMovements), ctx, thingId)
+}
+
+// MockstockAlert is a mock of stockAlert interface.
+type MockstockAlert struct {
+	ctrl     *gomock.Controller
+	recorder *MockstockAlertMockRecorder
+}
+
+// MockstockAlertMockRecorder is the mock recorder for MockstockAlert.
+type MockstockAlertMockRecorder struct {
+	mock *MockstockAlert
+}
+
+// NewMockstockAlert creates a new mock instance.
+func NewMockstockAlert(ctrl *gomock.Controller) *MockstockAlert {
+	mock := &MockstockAlert{ctrl: ctrl}
+	mock.recorder = &MockstockAlertMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstockAlert) EXPECT() *MockstockAlertMockRecorder {
+	return m.recorder
+}
+
+// GetDepartmentAlerts mocks base method.
+func (m *MockstockAlert) GetDepartmentAlerts(ctx context.Context, departmentId int) ([]core.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartmentAlerts", ctx, departmentId)
+	ret0, _ := ret[0].([]core.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartmentAlerts indicates an expected call of GetDepartmentAlerts.
+func (mr *MockstockAlertMockRecorder) GetDepartmentAlerts(ctx, departmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartmentAlerts", reflect.TypeOf((*MockstockAlert)(nil).GetDepartmentAlerts), ctx, departmentId)
+}
+
+// GetLowStock mocks base method.
+func (m *MockstockAlert) GetLowStock(ctx context.Context, companyId int) ([]core.Thing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLowStock", ctx, companyId)
+	ret0, _ := ret[0].([]core.Thing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLowStock indicates an expected call of GetLowStock.
+func (mr *MockstockAlertMockRecorder) GetLowStock(ctx, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLowStock", reflect.TypeOf((*MockstockAlert)(nil).GetLowStock), ctx, companyId)
+}
+
+// Mockvacation is a mock of vacation interface.
+type Mockvacation struct {
+	ctrl     *gomock.Controller
+	recorder *MockvacationMockRecorder
+}
+
+// MockvacationMockRecorder is the mock recorder for Mockvacation.
+type MockvacationMockRecorder struct {
+	mock *Mockvacation
+}
+
+// NewMockvacation creates a new mock instance.
+func NewMockvacation(ctrl *gomock.Controller) *Mockvacation {
+	mock := &Mockvacation{ctrl: ctrl}
+	mock.recorder = &MockvacationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockvacation) EXPECT() *MockvacationMockRecorder {
+	return m.recorder
+}
+
+// AddVacation mocks base method.
+func (m *Mockvacation) AddVacation(ctx context.Context, vacation *core.VacationAdd) (*core.Vacation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddVacation", ctx, vacation)
+	ret0, _ := ret[0].(*core.Vacation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddVacation indicates an expected call of AddVacation.
+func (mr *MockvacationMockRecorder) AddVacation(ctx, vacation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVacation", reflect.TypeOf((*Mockvacation)(nil).AddVacation), ctx, vacation)
+}
+
+// DeleteVacation mocks base method.
+func (m *Mockvacation) DeleteVacation(ctx context.Context, vacationId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVacation", ctx, vacationId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVacation indicates an expected call of DeleteVacation.
+func (mr *MockvacationMockRecorder) DeleteVacation(ctx, vacationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVacation", reflect.TypeOf((*Mockvacation)(nil).DeleteVacation), ctx, vacationId)
+}
+
+// GetDepartmentVacations mocks base method.
+func (m *Mockvacation) GetDepartmentVacations(ctx context.Context, departmentId int, startTime, endTime uint32) ([]core.DepartmentVacation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartmentVacations", ctx, departmentId, startTime, endTime)
+	ret0, _ := ret[0].([]core.DepartmentVacation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartmentVacations indicates an expected call of GetDepartmentVacations.
+func (mr *MockvacationMockRecorder) GetDepartmentVacations(ctx, departmentId, startTime, endTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartmentVacations", reflect.TypeOf((*Mockvacation)(nil).GetDepartmentVacations), ctx, departmentId, startTime, endTime)
+}
+
+// GetMyVacations mocks base method.
+func (m *Mockvacation) GetMyVacations(ctx context.Context) ([]core.Vacation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMyVacations", ctx)
+	ret0, _ := ret[0].([]core.Vacation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMyVacations indicates an expected call of GetMyVacations.
+func (mr *MockvacationMockRecorder) GetMyVacations(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyVacations", reflect.TypeOf((*Mockvacation)(nil).GetMyVacations), ctx)
+}
+
+// UpdateVacation mocks base method.
+func (m *Mockvacation) UpdateVacation(ctx context.Context, vacation *core.VacationAdd, vacationId int) (*core.Vacation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVacation", ctx, vacation, vacationId)
+	ret0, _ := ret[0].(*core.Vacation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVacation indicates an expected call of UpdateVacation.
+func (mr *MockvacationMockRecorder) UpdateVacation(ctx, vacation, vacationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVacation", reflect.TypeOf((*Mockvacation)(nil).UpdateVacation), ctx, vacation, vacationId)
+}
+
+// Mocknotification is a mock of notification interface.
+type Mocknotification struct {
+	ctrl     *gomock.Controller
+	recorder *MocknotificationMockRecorder
+}
+
+// MocknotificationMockRecorder is the mock recorder for Mocknotification.
+type MocknotificationMockRecorder struct {
+	mock *Mocknotification
+}
+
+// NewMocknotification creates a new mock instance.
+func NewMocknotification(ctrl *gomock.Controller) *Mocknotification {
+	mock := &Mocknotification{ctrl: ctrl}
+	mock.recorder = &MocknotificationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocknotification) EXPECT() *MocknotificationMockRecorder {
+	return m.recorder
+}
+
+// GetNotifications mocks base method.
+func (m *Mocknotification) GetNotifications(ctx context.Context, unreadOnly bool, beforeId int) ([]core.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", ctx, unreadOnly, beforeId)
+	ret0, _ := ret[0].([]core.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MocknotificationMockRecorder) GetNotifications(ctx, unreadOnly, beforeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*Mocknotification)(nil).GetNotifications), ctx, unreadOnly, beforeId)
+}
+
+// GetPreferences mocks base method.
+func (m *Mocknotification) GetPreferences(ctx context.Context) ([]core.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx)
+	ret0, _ := ret[0].([]core.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MocknotificationMockRecorder) GetPreferences(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*Mocknotification)(nil).GetPreferences), ctx)
+}
+
+// GetUnreadCount mocks base method.
+func (m *Mocknotification) GetUnreadCount(ctx context.Context) (*core.NotificationUnreadCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnreadCount", ctx)
+	ret0, _ := ret[0].(*core.NotificationUnreadCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnreadCount indicates an expected call of GetUnreadCount.
+func (mr *MocknotificationMockRecorder) GetUnreadCount(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnreadCount", reflect.TypeOf((*Mocknotification)(nil).GetUnreadCount), ctx)
+}
+
+// MarkAllRead mocks base method.
+func (m *Mocknotification) MarkAllRead(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MocknotificationMockRecorder) MarkAllRead(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*Mocknotification)(nil).MarkAllRead), ctx)
+}
+
+// MarkRead mocks base method.
+func (m *Mocknotification) MarkRead(ctx context.Context, notificationId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, notificationId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MocknotificationMockRecorder) MarkRead(ctx, notificationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*Mocknotification)(nil).MarkRead), ctx, notificationId)
+}
+
+// SetPreference mocks base method.
+func (m *Mocknotification) SetPreference(ctx context.Context, preference *core.NotificationPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPreference", ctx, preference)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPreference indicates an expected call of SetPreference.
+func (mr *MocknotificationMockRecorder) SetPreference(ctx, preference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPreference", reflect.TypeOf((*Mocknotification)(nil).SetPreference), ctx, preference)
+}
+
+// Mockwebhook is a mock of webhook interface.
+type Mockwebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockwebhookMockRecorder
+}
+
+// MockwebhookMockRecorder is the mock recorder for Mockwebhook.
+type MockwebhookMockRecorder struct {
+	mock *Mockwebhook
+}
+
+// NewMockwebhook creates a new mock instance.
+func NewMockwebhook(ctrl *gomock.Controller) *Mockwebhook {
+	mock := &Mockwebhook{ctrl: ctrl}
+	mock.recorder = &MockwebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockwebhook) EXPECT() *MockwebhookMockRecorder {
+	return m.recorder
+}
+
+// AddWebhook mocks base method.
+func (m *Mockwebhook) AddWebhook(ctx context.Context, companyId int, webhook *core.WebhookAdd) (*core.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWebhook", ctx, companyId, webhook)
+	ret0, _ := ret[0].(*core.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWebhook indicates an expected call of AddWebhook.
+func (mr *MockwebhookMockRecorder) AddWebhook(ctx, companyId, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWebhook", reflect.TypeOf((*Mockwebhook)(nil).AddWebhook), ctx, companyId, webhook)
+}
+
+// DeleteWebhook mocks base method.
+func (m *Mockwebhook) DeleteWebhook(ctx context.Context, webhookId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, webhookId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockwebhookMockRecorder) DeleteWebhook(ctx, webhookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*Mockwebhook)(nil).DeleteWebhook), ctx, webhookId)
+}
+
+// GetDeliveries mocks base method.
+func (m *Mockwebhook) GetDeliveries(ctx context.Context, webhookId int) ([]core.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, webhookId)
+	ret0, _ := ret[0].([]core.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockwebhookMockRecorder) GetDeliveries(ctx, webhookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*Mockwebhook)(nil).GetDeliveries), ctx, webhookId)
+}
+
+// GetWebhook mocks base method.
+func (m *Mockwebhook) GetWebhook(ctx context.Context, webhookId int) (*core.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", ctx, webhookId)
+	ret0, _ := ret[0].(*core.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockwebhookMockRecorder) GetWebhook(ctx, webhookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*Mockwebhook)(nil).GetWebhook), ctx, webhookId)
+}
+
+// GetWebhooks mocks base method.
+func (m *Mockwebhook) GetWebhooks(ctx context.Context, companyId int) ([]core.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", ctx, companyId)
+	ret0, _ := ret[0].([]core.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockwebhookMockRecorder) GetWebhooks(ctx, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*Mockwebhook)(nil).GetWebhooks), ctx, companyId)
+}
+
+// Redeliver mocks base method.
+func (m *Mockwebhook) Redeliver(ctx context.Context, webhookId, deliveryId int) (*core.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", ctx, webhookId, deliveryId)
+	ret0, _ := ret[0].(*core.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockwebhookMockRecorder) Redeliver(ctx, webhookId, deliveryId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*Mockwebhook)(nil).Redeliver), ctx, webhookId, deliveryId)
+}
+
+// UpdateWebhook mocks base method.
+func (m *Mockwebhook) UpdateWebhook(ctx context.Context, webhookId int, webhook *core.WebhookUpdate) (*core.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", ctx, webhookId, webhook)
+	ret0, _ := ret[0].(*core.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockwebhookMockRecorder) UpdateWebhook(ctx, webhookId, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*Mockwebhook)(nil).UpdateWebhook), ctx, webhookId, webhook)
+}
+
+// Mockadmin is a mock of admin interface.
+type Mockadmin struct {
+	ctrl     *gomock.Controller
+	recorder *MockadminMockRecorder
+}
+
+// MockadminMockRecorder is the mock recorder for Mockadmin.
+type MockadminMockRecorder struct {
+	mock *Mockadmin
+}
+
+// NewMockadmin creates a new mock instance.
+func NewMockadmin(ctrl *gomock.Controller) *Mockadmin {
+	mock := &Mockadmin{ctrl: ctrl}
+	mock.recorder = &MockadminMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockadmin) EXPECT() *MockadminMockRecorder {
+	return m.recorder
+}
+
+// LockUser mocks base method.
+func (m *Mockadmin) LockUser(ctx context.Context, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUser", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockUser indicates an expected call of LockUser.
+func (mr *MockadminMockRecorder) LockUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUser", reflect.TypeOf((*Mockadmin)(nil).LockUser), ctx, userId)
+}
+
+// TransferCompany mocks base method.
+func (m *Mockadmin) TransferCompany(ctx context.Context, companyId, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferCompany", ctx, companyId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransferCompany indicates an expected call of TransferCompany.
+func (mr *MockadminMockRecorder) TransferCompany(ctx, companyId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferCompany", reflect.TypeOf((*Mockadmin)(nil).TransferCompany), ctx, companyId, userId)
+}
+
+// UnlockUser mocks base method.
+func (m *Mockadmin) UnlockUser(ctx context.Context, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockadminMockRecorder) UnlockUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*Mockadmin)(nil).UnlockUser), ctx, userId)
+}
+
+// Mockimage is a mock of image interface.
+type Mockimage struct {
+	ctrl     *gomock.Controller
+	recorder *MockimageMockRecorder
+}
+
+// MockimageMockRecorder is the mock recorder for Mockimage.
+type MockimageMockRecorder struct {
+	mock *Mockimage
+}
+
+// NewMockimage creates a new mock instance.
+func NewMockimage(ctrl *gomock.Controller) *Mockimage {
+	mock := &Mockimage{ctrl: ctrl}
+	mock.recorder = &MockimageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockimage) EXPECT() *MockimageMockRecorder {
+	return m.recorder
+}
+
+// GetFile mocks base method.
+func (m *Mockimage) GetFile(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFile", ctx, key)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFile indicates an expected call of GetFile.
+func (mr *MockimageMockRecorder) GetFile(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFile", reflect.TypeOf((*Mockimage)(nil).GetFile), ctx, key)
+}
+
+// LoadCompanyImage mocks base method.
+func (m *Mockimage) LoadCompanyImage(ctx context.Context, companyId int, upload *core.ImageUpload) (*core.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadCompanyImage", ctx, companyId, upload)
+	ret0, _ := ret[0].(*core.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadCompanyImage indicates an expected call of LoadCompanyImage.
+func (mr *MockimageMockRecorder) LoadCompanyImage(ctx, companyId, upload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadCompanyImage", reflect.TypeOf((*Mockimage)(nil).LoadCompanyImage), ctx, companyId, upload)
+}
+
+// LoadCurrentUserImage mocks base method.
+func (m *Mockimage) LoadCurrentUserImage(ctx context.Context, upload *core.ImageUpload) (*core.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadCurrentUserImage", ctx, upload)
+	ret0, _ := ret[0].(*core.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadCurrentUserImage indicates an expected call of LoadCurrentUserImage.
+func (mr *MockimageMockRecorder) LoadCurrentUserImage(ctx, upload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadCurrentUserImage", reflect.TypeOf((*Mockimage)(nil).LoadCurrentUserImage), ctx, upload)
+}
+
+// LoadDepartmentImage mocks base method.
+func (m *Mockimage) LoadDepartmentImage(ctx context.Context, departmentId int, upload *core.ImageUpload) (*core.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadDepartmentImage", ctx, departmentId, upload)
+	ret0, _ := ret[0].(*core.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadDepartmentImage indicates an expected call of LoadDepartmentImage.
+func (mr *MockimageMockRecorder) LoadDepartmentImage(ctx, departmentId, upload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadDepartmentImage", reflect.TypeOf((*Mockimage)(nil).LoadDepartmentImage), ctx, departmentId, upload)
+}
+
+// LoadThingImage mocks base method.
+func (m *Mockimage) LoadThingImage(ctx context.Context, thingId int, upload *core.ImageUpload) (*core.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadThingImage", ctx, thingId, upload)
+	ret0, _ := ret[0].(*core.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadThingImage indicates an expected call of LoadThingImage.
+func (mr *MockimageMockRecorder) LoadThingImage(ctx, thingId, upload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadThingImage", reflect.TypeOf((*Mockimage)(nil).LoadThingImage), ctx, thingId, upload)
+}
+
+// Mocktoken is a mock of token interface.
+type Mocktoken struct {
+	ctrl     *gomock.Controller
+	recorder *MocktokenMockRecorder
+}
+
+// MocktokenMockRecorder is the mock recorder for Mocktoken.
+type MocktokenMockRecorder struct {
+	mock *Mocktoken
+}
+
+// NewMocktoken creates a new mock instance.
+func NewMocktoken(ctrl *gomock.Controller) *Mocktoken {
+	mock := &Mocktoken{ctrl: ctrl}
+	mock.recorder = &MocktokenMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocktoken) EXPECT() *MocktokenMockRecorder {
+	return m.recorder
+}
+
+// ValidateToken mocks base method.
+func (m *Mocktoken) ValidateToken(token string) (int, []core.Credentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateToken", token)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]core.Credentials)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ValidateToken indicates an expected call of ValidateToken.
+func (mr *MocktokenMockRecorder) ValidateToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateToken", reflect.TypeOf((*Mocktoken)(nil).ValidateToken), token)
+}
+
+// MockcredentialsLoader is a mock of credentialsLoader interface.
+type MockcredentialsLoader struct {
+	ctrl     *gomock.Controller
+	recorder *MockcredentialsLoaderMockRecorder
+}
+
+// MockcredentialsLoaderMockRecorder is the mock recorder for MockcredentialsLoader.
+type MockcredentialsLoaderMockRecorder struct {
+	mock *MockcredentialsLoader
+}
+
+// NewMockcredentialsLoader creates a new mock instance.
+func NewMockcredentialsLoader(ctrl *gomock.Controller) *MockcredentialsLoader {
+	mock := &MockcredentialsLoader{ctrl: ctrl}
+	mock.recorder = &MockcredentialsLoaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcredentialsLoader) EXPECT() *MockcredentialsLoaderMockRecorder {
+	return m.recorder
+}
+
+// LoadUserCredential mocks base method.
+func (m *MockcredentialsLoader) LoadUserCredential(ctx context.Context, userId int) ([]core.Credentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadUserCredential", ctx, userId)
+	ret0, _ := ret[0].([]core.Credentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadUserCredential indicates an expected call of LoadUserCredential.
+func (mr *MockcredentialsLoaderMockRecorder) LoadUserCredential(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserCredential", reflect.TypeOf((*MockcredentialsLoader)(nil).LoadUserCredential), ctx, userId)
+}
+
+// MockuserDB is a mock of userDB interface.
+type MockuserDB struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserDBMockRecorder
+}
+
+// MockuserDBMockRecorder is the mock recorder for MockuserDB.
+type MockuserDBMockRecorder struct {
+	mock *MockuserDB
+}
+
+// NewMockuserDB creates a new mock instance.
+func NewMockuserDB(ctrl *gomock.Controller) *MockuserDB {
+	mock := &MockuserDB{ctrl: ctrl}
+	mock.recorder = &MockuserDBMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserDB) EXPECT() *MockuserDBMockRecorder {
+	return m.recorder
+}
+
+// GetUser mocks base method.
+func (m *MockuserDB) GetUser(ctx context.Context, userId int) (*core.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userId)
+	ret0, _ := ret[0].(*core.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockuserDBMockRecorder) GetUser(ctx, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignUp", reflect.TypeOf((*MockAuth)(nil).SignUp), authData)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockuserDB)(nil).GetUser), ctx, userId)
 }
@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+func notificationErrorResponse(c *gin.Context, err error) {
+	switch err {
+	case moduleErrors.ErrorServiceNotificationNotFound:
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+	case moduleErrors.ErrorServiceInvalidChannel,
+		moduleErrors.ErrorServiceInvalidWebhookURL:
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		userErrorResponse(c, err)
+	}
+}
+
+// @Summary Notifications
+// @Security ApiKeyAuth
+// @Tags notification
+// @Description This request for get notifications of current user, newest first. Next page is requested
+// @Description with id of last notification as before_id
+// @ID getNotifications
+// @Accept json
+// @Produces json
+// @Param unread query bool false "only unread notifications"
+// @Param before_id query int false "return notifications older than notification with this id"
+// @Success 200 {array} core.Notification
+// @Failure 400,401 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /user/notifications [get]
+func (H *Handler) getNotifications(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getNotifications",
+		"context":  *core.LogContext(c),
+	}
+
+	unreadOnly := c.Query("unread") == "true"
+
+	beforeId, err := getOptionalIntQuery(c, "before_id")
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	before := 0
+	if beforeId != nil {
+		before = *beforeId
+	}
+
+	notifications, err := H.notification.GetNotifications(c.Request.Context(), unreadOnly, before)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("get notifications error")
+		notificationErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, notifications)
+}
+
+// @Summary UnreadNotifications
+// @Security ApiKeyAuth
+// @Tags notification
+// @Description This request for get count of unread notifications of current user
+// @ID getUnreadNotificationsCount
+// @Accept json
+// @Produces json
+// @Success 200 {object} core.NotificationUnreadCount
+// @Failure 401 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /user/notifications/unread_count [get]
+func (H *Handler) getUnreadNotificationsCount(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getUnreadNotificationsCount",
+		"context":  *core.LogContext(c),
+	}
+
+	count, err := H.notification.GetUnreadCount(c.Request.Context())
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("get unread notifications count error")
+		notificationErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, count)
+}
+
+// @Summary ReadNotification
+// @Security ApiKeyAuth
+// @Tags notification
+// @Description This request for mark notification of current user as read
+// @ID readNotification
+// @Accept json
+// @Produces json
+// @Param notificationId path int true "notification id"
+// @Success 200 {string} string "ok"
+// @Failure 400,401,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /user/notifications/{notificationId}/read [post]
+func (H *Handler) readNotification(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "readNotification",
+		"context":  *core.LogContext(c),
+	}
+
+	notificationId, err := strconv.Atoi(c.Param("notification_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	if err = H.notification.MarkRead(c.Request.Context(), notificationId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":           logBase,
+			"notificationId": notificationId,
+			"error":          err.Error(),
+		}).Error("read notification error")
+		notificationErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, "ok")
+}
+
+// @Summary ReadAllNotifications
+// @Security ApiKeyAuth
+// @Tags notification
+// @Description This request for mark all notifications of current user as read
+// @ID readAllNotifications
+// @Accept json
+// @Produces json
+// @Success 200 {string} string "ok"
+// @Failure 401 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /user/notifications/read_all [post]
+func (H *Handler) readAllNotifications(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "readAllNotifications",
+		"context":  *core.LogContext(c),
+	}
+
+	if err := H.notification.MarkAllRead(c.Request.Context()); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("read all notifications error")
+		notificationErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, "ok")
+}
+
+// @Summary NotificationPreferences
+// @Security ApiKeyAuth
+// @Tags notification
+// @Description This request for get delivery channels of notifications of current user
+// @ID getNotificationPreferences
+// @Accept json
+// @Produces json
+// @Success 200 {array} core.NotificationPreference
+// @Failure 401 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /user/notification_preferences [get]
+func (H *Handler) getNotificationPreferences(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getNotificationPreferences",
+		"context":  *core.LogContext(c),
+	}
+
+	preferences, err := H.notification.GetPreferences(c.Request.Context())
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("get notification preferences error")
+		notificationErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, preferences)
+}
+
+// @Summary NotificationPreferences
+// @Security ApiKeyAuth
+// @Tags notification
+// @Description This request for enable or disable delivery channel of notifications of current user,
+// @Description webhook url is required for enabled webhook channel
+// @ID putNotificationPreference
+// @Accept json
+// @Produces json
+// @Param input body core.NotificationPreference true "preference info"
+// @Success 200 {string} string "ok"
+// @Failure 400,401 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /user/notification_preferences [put]
+func (H *Handler) putNotificationPreference(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "putNotificationPreference",
+		"context":  *core.LogContext(c),
+	}
+
+	var input core.NotificationPreference
+	if err := c.BindJSON(&input); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error parse request body")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := H.notification.SetPreference(c.Request.Context(), &input); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"input": input,
+			"error": err.Error(),
+		}).Error("set notification preference error")
+		notificationErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, "ok")
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"bytes"
+	mockhandler "github.com/Thing-repository/backend-server/internal/transport/rest/handler/mocks"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPutNotificationPreference(t *testing.T) {
+	type mockBehavior func(s *mockhandler.Mocknotification)
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"channel":"webhook","enabled":true,"webhook_url":"https://example.com/hook"}`,
+			mockBehavior: func(s *mockhandler.Mocknotification) {
+				s.EXPECT().SetPreference(gomock.Any(), &core.NotificationPreference{Channel: "webhook", Enabled: true,
+					WebhookURL: "https://example.com/hook"}).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `"ok"`,
+		},
+		{
+			name:                 "No channel",
+			inputBody:            `{"enabled":true}`,
+			mockBehavior:         func(s *mockhandler.Mocknotification) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Key: 'NotificationPreference.Channel' Error:Field validation for 'Channel' failed on the 'required' tag"}`,
+		},
+		{
+			name:      "Invalid channel",
+			inputBody: `{"channel":"sms","enabled":true}`,
+			mockBehavior: func(s *mockhandler.Mocknotification) {
+				s.EXPECT().SetPreference(gomock.Any(), &core.NotificationPreference{Channel: "sms", Enabled: true}).
+					Return(moduleErrors.ErrorServiceInvalidChannel)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"invalid notification channel"}`,
+		},
+		{
+			name:      "Invalid webhook url",
+			inputBody: `{"channel":"webhook","enabled":true}`,
+			mockBehavior: func(s *mockhandler.Mocknotification) {
+				s.EXPECT().SetPreference(gomock.Any(), &core.NotificationPreference{Channel: "webhook", Enabled: true}).
+					Return(moduleErrors.ErrorServiceInvalidWebhookURL)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"webhook url must be absolute http or https url of public host"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Init deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			notification := mockhandler.NewMocknotification(c)
+			testCase.mockBehavior(notification)
+
+			handler := &Handler{notification: notification}
+
+			r := gin.New()
+			r.PUT("/user/notification_preferences", handler.putNotificationPreference)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/user/notification_preferences",
+				bytes.NewBufferString(testCase.inputBody))
+
+			// Perform request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			if w.Code != testCase.expectedStatusCode {
+				t.Errorf("status code = %d, want %d", w.Code, testCase.expectedStatusCode)
+			}
+			if w.Body.String() != testCase.expectedResponseBody {
+				t.Errorf("response body = %s, want %s", w.Body.String(), testCase.expectedResponseBody)
+			}
+		})
+	}
+}
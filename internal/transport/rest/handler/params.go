@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"strconv"
+)
+
+// getOptionalIntQuery returns nil if query parameter is not set
+func getOptionalIntQuery(c *gin.Context, name string) (*int, error) {
+	value, ok := c.GetQuery(name)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	ret, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// getOptionalUnixTimeQuery returns 0 if query parameter is not set
+func getOptionalUnixTimeQuery(c *gin.Context, name string) (uint32, error) {
+	value, ok := c.GetQuery(name)
+	if !ok || value == "" {
+		return 0, nil
+	}
+	ret, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(ret), nil
+}
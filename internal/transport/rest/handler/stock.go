@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+func stockErrorResponse(c *gin.Context, err error) {
+	switch err {
+	case moduleErrors.ErrorServiceInvalidMovementType,
+		moduleErrors.ErrorServiceConsumedRequired:
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+	case moduleErrors.ErrorServiceInsufficientStock:
+		newErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		thingErrorResponse(c, err)
+	}
+}
+
+// @Summary Stock movement
+// @Security ApiKeyAuth
+// @Tags stock
+// @Description This request for add stock movement of consumable. Quantity is positive amount for receipt,
+// @Description consumption and write_off, for adjustment it is counted remainder
+// @ID addStockMovement
+// @Accept json
+// @Produces json
+// @Param id path int true "thing id"
+// @Param input body core.StockMovementAdd true "movement info"
+// @Success 200 {object} core.StockMovement
+// @Failure 400,401,403,404,409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /thing/{id}/stock [post]
+func (H *Handler) addStockMovement(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "addStockMovement",
+		"context":  *core.LogContext(c),
+	}
+
+	thingId, err := strconv.Atoi(c.Param("thing_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	var input core.StockMovementAdd
+	if err = c.BindJSON(&input); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error parse request body")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	movement, err := H.stock.AddMovement(c.Request.Context(), thingId, &input)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":    logBase,
+			"thingId": thingId,
+			"input":   input,
+			"error":   err.Error(),
+		}).Error("add stock movement error")
+		stockErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, movement)
+}
+
+// @Summary Stock movements
+// @Security ApiKeyAuth
+// @Tags stock
+// @Description This request for get stock ledger of consumable, newest movements first
+// @ID getStockMovements
+// @Accept json
+// @Produces json
+// @Param id path int true "thing id"
+// @Success 200 {array} core.StockMovement
+// @Failure 400,401,403,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /thing/{id}/stock [get]
+func (H *Handler) getStockMovements(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getStockMovements",
+		"context":  *core.LogContext(c),
+	}
+
+	thingId, err := strconv.Atoi(c.Param("thing_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	movements, err := H.stock.GetMovements(c.Request.Context(), thingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":    logBase,
+			"thingId": thingId,
+			"error":   err.Error(),
+		}).Error("get stock movements error")
+		stockErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, movements)
+}
+
+// @Summary Low stock
+// @Security ApiKeyAuth
+// @Tags stock
+// @Description This request for get consumables of company with remainder below min remainder
+// @ID getLowStock
+// @Accept json
+// @Produces json
+// @Param companyId path int true "company id"
+// @Success 200 {array} core.Thing
+// @Failure 400,401,403 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /company/{companyId}/low_stock [get]
+func (H *Handler) getLowStock(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getLowStock",
+		"context":  *core.LogContext(c),
+	}
+
+	companyId, err := strconv.Atoi(c.Param("company_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	things, err := H.stockAlert.GetLowStock(c.Request.Context(), companyId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":      logBase,
+			"companyId": companyId,
+			"error":     err.Error(),
+		}).Error("get low stock error")
+		stockErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, things)
+}
+
+// @Summary Stock alerts
+// @Security ApiKeyAuth
+// @Tags stock
+// @Description This request for get open low stock alerts of department, alerts are shown to department
+// @Description maintainers until stock is replenished
+// @ID getDepartmentStockAlerts
+// @Accept json
+// @Produces json
+// @Param departmentId path int true "department id"
+// @Success 200 {array} core.StockAlert
+// @Failure 400,401,403,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /department/{departmentId}/stock_alerts [get]
+func (H *Handler) getDepartmentStockAlerts(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getDepartmentStockAlerts",
+		"context":  *core.LogContext(c),
+	}
+
+	departmentId, err := strconv.Atoi(c.Param("department_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	alerts, err := H.stockAlert.GetDepartmentAlerts(c.Request.Context(), departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":         logBase,
+			"departmentId": departmentId,
+			"error":        err.Error(),
+		}).Error("get stock alerts error")
+		departmentErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, alerts)
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"bytes"
+	mockhandler "github.com/Thing-repository/backend-server/internal/transport/rest/handler/mocks"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAddStockMovement(t *testing.T) {
+	type mockBehavior func(s *mockhandler.Mockstock)
+
+	testTable := []struct {
+		name                 string
+		thingId              string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			thingId:   "1",
+			inputBody: `{"type":"receipt","quantity":5,"comment":"delivery"}`,
+			mockBehavior: func(s *mockhandler.Mockstock) {
+				s.EXPECT().AddMovement(gomock.Any(), 1,
+					&core.StockMovementAdd{Type: "receipt", Quantity: 5, Comment: "delivery"}).
+					Return(&core.StockMovement{Id: 2, ThingId: 1, Type: "receipt", Quantity: 5, Remainder: 7,
+						Comment: "delivery", CreatedTime: 1700000000}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"id":2,"thing_id":1,"type":"receipt","quantity":5,"remainder":7,` +
+				`"comment":"delivery","created_time":1700000000}`,
+		},
+		{
+			name:                 "No type",
+			thingId:              "1",
+			inputBody:            `{"quantity":5}`,
+			mockBehavior:         func(s *mockhandler.Mockstock) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"Key: 'StockMovementAdd.Type' Error:Field validation for 'Type' failed on the 'required' tag"}`,
+		},
+		{
+			name:                 "Invalid thing id",
+			thingId:              "thing",
+			inputBody:            `{"type":"receipt","quantity":5}`,
+			mockBehavior:         func(s *mockhandler.Mockstock) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"no required Fields in query"}`,
+		},
+		{
+			name:      "Insufficient stock",
+			thingId:   "1",
+			inputBody: `{"type":"write_off","quantity":10}`,
+			mockBehavior: func(s *mockhandler.Mockstock) {
+				s.EXPECT().AddMovement(gomock.Any(), 1, &core.StockMovementAdd{Type: "write_off", Quantity: 10}).
+					Return(nil, moduleErrors.ErrorServiceInsufficientStock)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"message":"not enough stock of consumable"}`,
+		},
+		{
+			name:      "Not consumable",
+			thingId:   "1",
+			inputBody: `{"type":"receipt","quantity":1}`,
+			mockBehavior: func(s *mockhandler.Mockstock) {
+				s.EXPECT().AddMovement(gomock.Any(), 1, &core.StockMovementAdd{Type: "receipt", Quantity: 1}).
+					Return(nil, moduleErrors.ErrorServiceThingNotConsumable)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"thing is not consumable"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Init deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			stock := mockhandler.NewMockstock(c)
+			testCase.mockBehavior(stock)
+
+			handler := &Handler{stock: stock}
+
+			r := gin.New()
+			r.POST("/thing/:thing_id/stock", handler.addStockMovement)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/thing/"+testCase.thingId+"/stock",
+				bytes.NewBufferString(testCase.inputBody))
+
+			// Perform request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			if w.Code != testCase.expectedStatusCode {
+				t.Errorf("status code = %d, want %d", w.Code, testCase.expectedStatusCode)
+			}
+			if w.Body.String() != testCase.expectedResponseBody {
+				t.Errorf("response body = %s, want %s", w.Body.String(), testCase.expectedResponseBody)
+			}
+		})
+	}
+}
@@ -1,11 +1,37 @@
 package handler
 
 import (
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 	"net/http"
+	"strconv"
 )
 
-// @Summary NOT IMPLEMENTED! Thing
+func thingErrorResponse(c *gin.Context, err error) {
+	switch err {
+	case moduleErrors.ErrorServiceInvalidContext:
+		newErrorResponse(c, http.StatusUnauthorized, moduleErrors.ErrorHandlerForbidden.Error())
+	case moduleErrors.ErrorServiceBadPermissions:
+		newErrorResponse(c, http.StatusForbidden, moduleErrors.ErrorServiceBadPermissions.Error())
+	case moduleErrors.ErrorServiceThingNotFound:
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+	case moduleErrors.ErrorServiceInvalidThingType,
+		moduleErrors.ErrorServiceInvalidRemainderType,
+		moduleErrors.ErrorServiceUserHasNotDepartment,
+		moduleErrors.ErrorServiceInvalidQuantity,
+		moduleErrors.ErrorServiceRemainderFromStock,
+		moduleErrors.ErrorServiceInvalidMinRemainder,
+		moduleErrors.ErrorServiceThingNotConsumable,
+		moduleErrors.ErrorAllNoFields:
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// @Summary Thing
 // @Security ApiKeyAuth
 // @Tags thing
 // @Description This request for creating thing
@@ -20,10 +46,48 @@ import (
 // @Failure default {object} errorResponse
 // @Router /thing [post]
 func (H *Handler) addThing(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "addThing",
+		"context":  *core.LogContext(c),
+	}
+
+	var thing core.ThingBase
+
+	if err := c.BindJSON(&thing); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("json parse error")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	departmentId, err := getOptionalIntQuery(c, "departmentId")
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error convert departmentId to int")
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	thingData, err := H.thing.AddThing(c.Request.Context(), &thing, departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"thing": thing,
+			"error": err.Error(),
+		}).Error("add thing error")
+		thingErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, thingData)
 }
 
-// @Summary NOT IMPLEMENTED! Thing
+// @Summary Thing
 // @Security ApiKeyAuth
 // @Tags thing
 // @Description This request for getting thing
@@ -32,15 +96,42 @@ func (H *Handler) addThing(c *gin.Context) {
 // @Produces json
 // @Param id path int true "thing id"
 // @Success 200 {object} core.Thing
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /thing/{id} [get]
 func (H *Handler) getThing(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getThing",
+		"context":  *core.LogContext(c),
+	}
+
+	thingId, err := strconv.Atoi(c.Param("thing_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	thingData, err := H.thing.GetThing(c.Request.Context(), thingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":    logBase,
+			"thingId": thingId,
+			"error":   err.Error(),
+		}).Error("get thing error")
+		thingErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, thingData)
 }
 
-// @Summary NOT IMPLEMENTED! All things
+// @Summary All things
 // @Security ApiKeyAuth
 // @Tags thing
 // @Description This request for getting all company or department things
@@ -55,10 +146,54 @@ func (H *Handler) getThing(c *gin.Context) {
 // @Failure default {object} errorResponse
 // @Router /things [get]
 func (H *Handler) getAllThings(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getAllThings",
+		"context":  *core.LogContext(c),
+	}
+
+	companyId, err := getOptionalIntQuery(c, "companyId")
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error convert companyId to int")
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	departmentId, err := getOptionalIntQuery(c, "departmentId")
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error convert departmentId to int")
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	if companyId == nil && departmentId == nil {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	things, err := H.thing.GetThings(c.Request.Context(), companyId, departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("get things error")
+		thingErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, things)
 }
 
-// @Summary NOT IMPLEMENTED! Thing
+// @Summary Thing
 // @Security ApiKeyAuth
 // @Tags thing
 // @Description This request for edit thing info
@@ -66,17 +201,56 @@ func (H *Handler) getAllThings(c *gin.Context) {
 // @Accept json
 // @Produces json
 // @Param id path int true "thing id"
-// @Param input body core.ThingBase true "thing info"
+// @Param input body core.ThingUpdate true "thing info"
 // @Success 200 {object} core.Thing
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /thing/{id} [patch]
 func (H *Handler) patchThing(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "patchThing",
+		"context":  *core.LogContext(c),
+	}
+
+	thingId, err := strconv.Atoi(c.Param("thing_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	var thing core.ThingUpdate
+
+	if err := c.BindJSON(&thing); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("json parse error")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	thingData, err := H.thing.UpdateThing(c.Request.Context(), &thing, thingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":    logBase,
+			"thingId": thingId,
+			"thing":   thing,
+			"error":   err.Error(),
+		}).Error("update thing error")
+		thingErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, thingData)
 }
 
-// @Summary NOT IMPLEMENTED! Thing
+// @Summary Thing
 // @Security ApiKeyAuth
 // @Tags thing
 // @Description This request for delete thing
@@ -85,10 +259,89 @@ func (H *Handler) patchThing(c *gin.Context) {
 // @Produces json
 // @Param id path int true "thing id"
 // @Success 200 {string} string "ok"
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /thing/{id} [delete]
 func (H *Handler) deleteThing(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "deleteThing",
+		"context":  *core.LogContext(c),
+	}
+
+	thingId, err := strconv.Atoi(c.Param("thing_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	err = H.thing.DeleteThing(c.Request.Context(), thingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":    logBase,
+			"thingId": thingId,
+			"error":   err.Error(),
+		}).Error("delete thing error")
+		thingErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, "ok")
+}
+
+// @Summary LoadThingImage
+// @Security ApiKeyAuth
+// @Tags thing
+// @Description This request for load thing image, image is sent in multipart field image. Jpeg, png and gif
+// @Description images are allowed, thumbnail is generated for every image
+// @ID loadThingImage
+// @Accept mpfd
+// @Produces json
+// @Param id path int true "thing id"
+// @Param image formData file true "image file"
+// @Success 200 {object} core.Image
+// @Failure 400,401,403,404,413,415 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /thing/{id}/image [post]
+func (H *Handler) loadThingImage(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "loadThingImage",
+		"context":  *core.LogContext(c),
+	}
+
+	thingId, err := strconv.Atoi(c.Param("thing_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	upload, err := readImageUpload(c)
+	if err != nil {
+		imageErrorResponse(c, err)
+		return
+	}
+
+	image, err := H.image.LoadThingImage(c.Request.Context(), thingId, upload)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":    logBase,
+			"thingId": thingId,
+			"error":   err.Error(),
+		}).Error("load thing image error")
+		imageErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, image)
 }
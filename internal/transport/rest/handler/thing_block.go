@@ -1,11 +1,26 @@
 package handler
 
 import (
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 	"net/http"
+	"strconv"
 )
 
-// @Summary NOT IMPLEMENTED! Thing block
+func thingBlockErrorResponse(c *gin.Context, err error) {
+	switch err {
+	case moduleErrors.ErrorServiceBlockNotFound:
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+	case moduleErrors.ErrorServiceBlockConflict:
+		newErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		thingUsageErrorResponse(c, err)
+	}
+}
+
+// @Summary Thing block
 // @Security ApiKeyAuth
 // @Tags thing block
 // @Description This request for get all block info in company or department
@@ -18,12 +33,56 @@ import (
 // @Failure 400,401,403 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
-// @Router /thing/block/ [get]
+// @Router /thing/block [get]
 func (H *Handler) getAllThingBlocking(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getAllThingBlocking",
+		"context":  *core.LogContext(c),
+	}
+
+	companyId, err := getOptionalIntQuery(c, "companyId")
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error convert companyId to int")
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	departmentId, err := getOptionalIntQuery(c, "departmentId")
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error convert departmentId to int")
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	if companyId == nil && departmentId == nil {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	blocks, err := H.thingBlock.GetBlocks(c.Request.Context(), companyId, departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("get blocks error")
+		thingBlockErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, blocks)
 }
 
-// @Summary NOT IMPLEMENTED! Thing block
+// @Summary Thing block
 // @Security ApiKeyAuth
 // @Tags thing block
 // @Description This request for blocking thing
@@ -31,16 +90,47 @@ func (H *Handler) getAllThingBlocking(c *gin.Context) {
 // @Accept json
 // @Produces json
 // @Param input body core.ThingBlockAdd true "thing bock info"
+// @Param force query bool false "cancel usages in block time"
 // @Success 200 {object} core.ThingBlock
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404,409 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /thing/block [post]
 func (H *Handler) addThingBlock(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "addThingBlock",
+		"context":  *core.LogContext(c),
+	}
+
+	var block core.ThingBlockAdd
+
+	if err := c.BindJSON(&block); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("json parse error")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	force := c.Query("force") == "true"
+
+	blockData, err := H.thingBlock.AddBlock(c.Request.Context(), &block, force)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"block": block,
+			"error": err.Error(),
+		}).Error("add block error")
+		thingBlockErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, blockData)
 }
 
-// @Summary NOT IMPLEMENTED! Thing block
+// @Summary Thing block
 // @Security ApiKeyAuth
 // @Tags thing block
 // @Description This request for edit block info
@@ -49,16 +139,58 @@ func (H *Handler) addThingBlock(c *gin.Context) {
 // @Produces json
 // @Param id path int true "block id"
 // @Param input body core.ThingBlockAdd true "thing bock info"
+// @Param force query bool false "cancel usages in block time"
 // @Success 200 {object} core.ThingBlock
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404,409 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /thing/block/{id} [patch]
 func (H *Handler) editThingBlock(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "editThingBlock",
+		"context":  *core.LogContext(c),
+	}
+
+	blockId, err := strconv.Atoi(c.Param("block_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	var block core.ThingBlockAdd
+
+	if err := c.BindJSON(&block); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("json parse error")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	force := c.Query("force") == "true"
+
+	blockData, err := H.thingBlock.UpdateBlock(c.Request.Context(), &block, blockId, force)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":    logBase,
+			"blockId": blockId,
+			"block":   block,
+			"error":   err.Error(),
+		}).Error("update block error")
+		thingBlockErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, blockData)
 }
 
-// @Summary NOT IMPLEMENTED! Thing block
+// @Summary Thing block
 // @Security ApiKeyAuth
 // @Tags thing block
 // @Description This request for get block info
@@ -67,27 +199,81 @@ func (H *Handler) editThingBlock(c *gin.Context) {
 // @Produces json
 // @Param id path int true "block id"
 // @Success 200 {object} core.ThingBlock
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /thing/block/{id} [get]
 func (H *Handler) getThingBlocking(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getThingBlocking",
+		"context":  *core.LogContext(c),
+	}
+
+	blockId, err := strconv.Atoi(c.Param("block_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	blockData, err := H.thingBlock.GetBlock(c.Request.Context(), blockId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":    logBase,
+			"blockId": blockId,
+			"error":   err.Error(),
+		}).Error("get block error")
+		thingBlockErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, blockData)
 }
 
-// @Summary NOT IMPLEMENTED! Thing block
+// @Summary Thing block
 // @Security ApiKeyAuth
 // @Tags thing block
 // @Description This request for delete block
-// @ID addThingBlocking
+// @ID deleteThingBlocking
 // @Accept json
 // @Produces json
 // @Param id path int true "block id"
 // @Success 200 {string} string "ok"
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /thing/block/{id} [delete]
 func (H *Handler) deleteThingBlocking(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "deleteThingBlocking",
+		"context":  *core.LogContext(c),
+	}
+
+	blockId, err := strconv.Atoi(c.Param("block_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	err = H.thingBlock.DeleteBlock(c.Request.Context(), blockId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":    logBase,
+			"blockId": blockId,
+			"error":   err.Error(),
+		}).Error("delete block error")
+		thingBlockErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, "ok")
 }
@@ -1,11 +1,30 @@
 package handler
 
 import (
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 	"net/http"
+	"strconv"
 )
 
-// @Summary NOT IMPLEMENTED! Thing usage
+func thingUsageErrorResponse(c *gin.Context, err error) {
+	switch err {
+	case moduleErrors.ErrorServiceUsageNotFound:
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+	case moduleErrors.ErrorServiceInvalidUsageTime:
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+	case moduleErrors.ErrorServiceUsageOverlap, moduleErrors.ErrorServiceInvalidUsageStatus,
+		moduleErrors.ErrorServiceUsageStatusChanged, moduleErrors.ErrorServiceThingBlocked,
+		moduleErrors.ErrorServiceUserOnVacation:
+		newErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		stockErrorResponse(c, err)
+	}
+}
+
+// @Summary Thing usage
 // @Security ApiKeyAuth
 // @Tags thing usage
 // @Description This request for get all usage info in company or department
@@ -20,25 +39,99 @@ import (
 // @Failure default {object} errorResponse
 // @Router /thing/usage [get]
 func (H *Handler) getAllThingUsage(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getAllThingUsage",
+		"context":  *core.LogContext(c),
+	}
+
+	companyId, err := getOptionalIntQuery(c, "companyId")
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error convert companyId to int")
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	departmentId, err := getOptionalIntQuery(c, "departmentId")
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error convert departmentId to int")
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	if companyId == nil && departmentId == nil {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	usages, err := H.thingUsage.GetUsages(c.Request.Context(), companyId, departmentId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("get usages error")
+		thingUsageErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, usages)
 }
 
-// @Summary NOT IMPLEMENTED! Thing usage
+// @Summary Thing usage
 // @Security ApiKeyAuth
 // @Tags thing usage
-// @Description This request for using thing
+// @Description This request for using thing, thing can't be booked for time when user is on vacation without force
 // @ID addThingUsage
 // @Accept json
 // @Produces json
-// @Param id query int true "thing id"
 // @Param input body core.ThingUsageAdd true "thing use info"
+// @Param force query bool false "book thing even if user is on vacation in usage time"
 // @Success 200 {object} core.ThingUsage
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404,409 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /thing/usage [post]
 func (H *Handler) addThingUsage(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "addThingUsage",
+		"context":  *core.LogContext(c),
+	}
+
+	var usage core.ThingUsageAdd
+
+	if err := c.BindJSON(&usage); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("json parse error")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	force := c.Query("force") == "true"
+
+	usageData, err := H.thingUsage.AddUsage(c.Request.Context(), &usage, force)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"usage": usage,
+			"error": err.Error(),
+		}).Error("add usage error")
+		thingUsageErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, usageData)
 }
 
 // @Summary NOT IMPLEMENTED! Thing usage
@@ -59,7 +152,7 @@ func (H *Handler) editThingUsage(c *gin.Context) {
 	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
 }
 
-// @Summary NOT IMPLEMENTED! Thing usage
+// @Summary Thing usage
 // @Security ApiKeyAuth
 // @Tags thing usage
 // @Description This request for get usage info
@@ -73,27 +166,81 @@ func (H *Handler) editThingUsage(c *gin.Context) {
 // @Failure default {object} errorResponse
 // @Router /thing/usage/{id} [get]
 func (H *Handler) getThingUsage(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getThingUsage",
+		"context":  *core.LogContext(c),
+	}
+
+	usageId, err := strconv.Atoi(c.Param("usage_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	usage, err := H.thingUsage.GetUsage(c.Request.Context(), usageId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":    logBase,
+			"usageId": usageId,
+			"error":   err.Error(),
+		}).Error("get usage error")
+		thingUsageErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, usage)
 }
 
-// @Summary NOT IMPLEMENTED! Thing usage
+// @Summary Thing usage
 // @Security ApiKeyAuth
 // @Tags thing usage
-// @Description This request for delete usage
+// @Description This request for cancel usage
 // @ID deleteThingUsage
 // @Accept json
 // @Produces json
 // @Param id path int true "usage id"
-// @Success 200 {string} string "ok"
-// @Failure 400,401,403 {object} errorResponse
+// @Success 200 {object} core.ThingUsage
+// @Failure 400,401,403,404,409 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /thing/usage/{id} [delete]
 func (H *Handler) deleteThingUsage(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "deleteThingUsage",
+		"context":  *core.LogContext(c),
+	}
+
+	usageId, err := strconv.Atoi(c.Param("usage_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	usage, err := H.thingUsage.CancelUsage(c.Request.Context(), usageId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":    logBase,
+			"usageId": usageId,
+			"error":   err.Error(),
+		}).Error("cancel usage error")
+		thingUsageErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, usage)
 }
 
-// @Summary NOT IMPLEMENTED! Thing usage get need approve
+// @Summary Thing usage get need approve
 // @Security ApiKeyAuth
 // @Tags thing usage
 // @Description This request for get all things need approved by me
@@ -106,10 +253,26 @@ func (H *Handler) deleteThingUsage(c *gin.Context) {
 // @Failure default {object} errorResponse
 // @Router /thing/usage/approve [get]
 func (H *Handler) getNeedApproveThingUsage(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getNeedApproveThingUsage",
+		"context":  *core.LogContext(c),
+	}
+
+	usages, err := H.thingUsage.GetUsagesForApprove(c.Request.Context())
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("get usages for approve error")
+		thingUsageErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, usages)
 }
 
-// @Summary NOT IMPLEMENTED! Thing usage approve
+// @Summary Thing usage approve
 // @Security ApiKeyAuth
 // @Tags thing usage
 // @Description This request for approve usage
@@ -118,15 +281,42 @@ func (H *Handler) getNeedApproveThingUsage(c *gin.Context) {
 // @Produces json
 // @Param id path int true "usage id"
 // @Success 200 {object} core.ThingUsage
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404,409 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /thing/usage/{id}/approve [post]
 func (H *Handler) approveThingUsage(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "approveThingUsage",
+		"context":  *core.LogContext(c),
+	}
+
+	usageId, err := strconv.Atoi(c.Param("usage_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	usage, err := H.thingUsage.ApproveUsage(c.Request.Context(), usageId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":    logBase,
+			"usageId": usageId,
+			"error":   err.Error(),
+		}).Error("approve usage error")
+		thingUsageErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, usage)
 }
 
-// @Summary NOT IMPLEMENTED! Get my usage
+// @Summary Get my usage
 // @Security ApiKeyAuth
 // @Tags thing usage
 // @Description This request for get all things usage by me
@@ -139,10 +329,26 @@ func (H *Handler) approveThingUsage(c *gin.Context) {
 // @Failure default {object} errorResponse
 // @Router /thing/usage/my [get]
 func (H *Handler) getMyThingUsage(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getMyThingUsage",
+		"context":  *core.LogContext(c),
+	}
+
+	usages, err := H.thingUsage.GetMyUsages(c.Request.Context())
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("get my usages error")
+		thingUsageErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, usages)
 }
 
-// @Summary NOT IMPLEMENTED! Thing usage take
+// @Summary Thing usage take
 // @Security ApiKeyAuth
 // @Tags thing usage
 // @Description This request for take thing
@@ -151,43 +357,140 @@ func (H *Handler) getMyThingUsage(c *gin.Context) {
 // @Produces json
 // @Param id path int true "usage id"
 // @Success 200 {object} core.ThingUsage
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404,409 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /thing/usage/{id}/take [post]
 func (H *Handler) takeThingUsage(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "takeThingUsage",
+		"context":  *core.LogContext(c),
+	}
+
+	usageId, err := strconv.Atoi(c.Param("usage_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	usage, err := H.thingUsage.TakeUsage(c.Request.Context(), usageId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":    logBase,
+			"usageId": usageId,
+			"error":   err.Error(),
+		}).Error("take usage error")
+		thingUsageErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, usage)
 }
 
-// @Summary NOT IMPLEMENTED! Thing usage return
+// @Summary Thing usage return
 // @Security ApiKeyAuth
 // @Tags thing usage
-// @Description This request for return thing
+// @Description This request for return thing, consumed quantity is required for consumables
 // @ID returnThingUsage
 // @Accept json
 // @Produces json
 // @Param id path int true "usage id"
+// @Param input body core.UsageReturn false "return info"
 // @Success 200 {object} core.ThingUsage
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404,409 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /thing/usage/{id}/return [post]
 func (H *Handler) returnThingUsage(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "returnThingUsage",
+		"context":  *core.LogContext(c),
+	}
+
+	usageId, err := strconv.Atoi(c.Param("usage_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	// body is optional, equipment is returned without it
+	var input core.UsageReturn
+	if c.Request.ContentLength != 0 {
+		if err = c.BindJSON(&input); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"base":  logBase,
+				"error": err.Error(),
+			}).Error("error parse request body")
+			newErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	usage, err := H.thingUsage.ReturnUsage(c.Request.Context(), usageId, &input)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":    logBase,
+			"usageId": usageId,
+			"input":   input,
+			"error":   err.Error(),
+		}).Error("return usage error")
+		thingUsageErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, usage)
 }
 
-// @Summary NOT IMPLEMENTED! Get usage by thing
+// @Summary Get usage by thing
 // @Security ApiKeyAuth
 // @Tags thing usage
 // @Description This request for get all usage by thing
 // @ID getThingUsageById
 // @Accept json
 // @Produces json
+// @Param id path int true "thing id"
 // @Success 200 {array} core.ThingUsage
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,403,404 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
-// @Router /thing/{id}/usage/ [get]
+// @Router /thing/{id}/usage [get]
 func (H *Handler) getThingUsageById(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getThingUsageById",
+		"context":  *core.LogContext(c),
+	}
+
+	thingId, err := strconv.Atoi(c.Param("thing_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	usages, err := H.thingUsage.GetThingUsages(c.Request.Context(), thingId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":    logBase,
+			"thingId": thingId,
+			"error":   err.Error(),
+		}).Error("get thing usages error")
+		thingUsageErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, usages)
 }
@@ -9,7 +9,20 @@ import (
 	"strconv"
 )
 
-// @Summary NOT IMPLEMENTED! CurrentUser
+func userErrorResponse(c *gin.Context, err error) {
+	switch err {
+	case moduleErrors.ErrorServiceUserHasTakenThings,
+		moduleErrors.ErrorServiceUserAlreadyHas:
+		newErrorResponse(c, http.StatusConflict, err.Error())
+	case moduleErrors.ErrorServiceInvalidCursor,
+		moduleErrors.ErrorServiceInvalidSort:
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		credentialsErrorResponse(c, err)
+	}
+}
+
+// @Summary CurrentUser
 // @Security ApiKeyAuth
 // @Tags user
 // @Description This request for get current user info
@@ -22,24 +35,67 @@ import (
 // @Failure default {object} errorResponse
 // @Router /user [get]
 func (H *Handler) getCurrentUser(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getCurrentUser",
+		"context":  *core.LogContext(c),
+	}
+
+	userData, err := H.profile.GetCurrentUser(c.Request.Context())
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("get current user error")
+		userErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, userData)
 }
 
-// @Summary NOT IMPLEMENTED! User
+// @Summary User
 // @Security ApiKeyAuth
 // @Tags user
-// @Description This request for get user info
+// @Description This request for get user info, only users from the same company are visible
 // @ID getUser
 // @Accept json
 // @Produces json
 // @Param id path int true "user id"
 // @Success 200 {object} core.User
-// @Failure 400,401,403 {object} errorResponse
+// @Failure 400,401,404 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /user/{id} [get]
 func (H *Handler) getUser(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getUser",
+		"context":  *core.LogContext(c),
+	}
+
+	userId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	userData, err := H.profile.GetUser(c.Request.Context(), userId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"userId": userId,
+			"error":  err.Error(),
+		}).Error("get user error")
+		userErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, userData)
 }
 
 // @Summary FindUsersForInvite
@@ -116,7 +172,7 @@ func (H *Handler) findUsersForInvite(c *gin.Context) {
 		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
 		return
 	}
-	users, err := H.user.FindUsersForInvite(c, filter, limitInt, offsetInt)
+	users, err := H.user.FindUsersForInvite(c.Request.Context(), filter, limitInt, offsetInt)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"base":      logBase,
@@ -131,78 +187,213 @@ func (H *Handler) findUsersForInvite(c *gin.Context) {
 	c.AbortWithStatusJSON(http.StatusOK, users)
 }
 
-// @Summary NOT IMPLEMENTED! Users
+// @Summary Users
 // @Security ApiKeyAuth
 // @Tags user
-// @Description This request for get all users in company or department
+// @Description This request for get directory of company users with their roles. Company of current user is used when company and department aren't set. Next page is requested with cursor from previous page
 // @ID getUsers
 // @Accept json
 // @Produces json
 // @Param companyId query int false "company id"
 // @Param departmentId query int false "department id"
-// @Success 200 {array} core.User
-// @Failure 400,401,403 {object} errorResponse
+// @Param role query string false "credential type of user in company"
+// @Param name query string false "beginning of user name"
+// @Param sort query string false "sort field: name (default), email or id"
+// @Param order query string false "sort order: asc (default) or desc"
+// @Param cursor query string false "next_cursor from previous page"
+// @Param limit query int false "page size, 20 by default, 100 max"
+// @Success 200 {object} core.UserDirectoryPage
+// @Failure 400,401,403,404 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /users [get]
 func (H *Handler) getUsers(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getUsers",
+		"context":  *core.LogContext(c),
+	}
+
+	filter := core.UserDirectoryFilter{
+		NamePrefix: c.Query("name"),
+		Sort:       c.Query("sort"),
+		Cursor:     c.Query("cursor"),
+	}
+
+	var err error
+	if filter.CompanyId, err = getOptionalIntQuery(c, "companyId"); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get company id from query")
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+	if filter.DepartmentId, err = getOptionalIntQuery(c, "departmentId"); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get department id from query")
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+	limit, err := getOptionalIntQuery(c, "limit")
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get limit from query")
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+	if limit != nil {
+		filter.Limit = *limit
+	}
+	if role, ok := c.GetQuery("role"); ok && role != "" {
+		filter.Role = &role
+	}
+	switch c.Query("order") {
+	case "", "asc":
+	case "desc":
+		filter.Descending = true
+	default:
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorServiceInvalidSort.Error())
+		return
+	}
+
+	page, err := H.user.GetCompanyUsers(c.Request.Context(), &filter)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"filter": filter,
+			"error":  err.Error(),
+		}).Error("get company users error")
+		userErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, page)
 }
 
-// @Summary NOT IMPLEMENTED! CurrentUser
+// @Summary CurrentUser
 // @Security ApiKeyAuth
 // @Tags user
-// @Description This request for edit current user info
+// @Description This request for edit current user info. Changed email must be verified again
 // @ID patchCurrentUser
 // @Accept json
 // @Produces json
-// @Param input body core.UserBaseData true "user info"
+// @Param input body core.UserUpdate true "user info"
 // @Success 200 {object} core.User
-// @Failure 400,401 {object} errorResponse
+// @Failure 400,401,409 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /user [patch]
 func (H *Handler) patchCurrentUser(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "patchCurrentUser",
+		"context":  *core.LogContext(c),
+	}
+
+	var input core.UserUpdate
+	if err := c.BindJSON(&input); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error parse request body")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userData, err := H.profile.UpdateCurrentUser(c.Request.Context(), &input)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"input": input,
+			"error": err.Error(),
+		}).Error("update current user error")
+		userErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, userData)
 }
 
-// @Summary NOT IMPLEMENTED! CurrentUser
+// @Summary CurrentUser
 // @Security ApiKeyAuth
 // @Tags user
-// @Description This request for delete current user info
+// @Description This request for delete current user account. The last company admin can't delete account
 // @ID deleteCurrentUser
 // @Accept json
 // @Produces json
 // @Success 200 {string} string "ok"
-// @Failure 400,401 {object} errorResponse
+// @Failure 400,401,409 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /user [delete]
 func (H *Handler) deleteCurrentUser(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "deleteCurrentUser",
+		"context":  *core.LogContext(c),
+	}
+
+	if err := H.profile.DeleteCurrentUser(c.Request.Context()); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("delete current user error")
+		userErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, "ok")
 }
 
-// @Summary NOT IMPLEMENTED! LoadCurrentUserImage
+// @Summary LoadCurrentUserImage
 // @Security ApiKeyAuth
 // @Tags user
-// @Description This request for load current user image
+// @Description This request for load current user image, image is sent in multipart field image. Jpeg, png and gif
+// @Description images are allowed, thumbnail is generated for every image
 // @ID loadCurrentUserImage
-// @Accept json
+// @Accept mpfd
 // @Produces json
-// @Param input body []byte true "image file"
-// @Success 200 {string} string "image_url"
-// @Failure 400,401 {object} errorResponse
+// @Param image formData file true "image file"
+// @Success 200 {object} core.Image
+// @Failure 400,401,403,404,413,415 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /user/image [post]
 func (H *Handler) loadCurrentUserImage(c *gin.Context) {
-	newErrorResponse(c, http.StatusInternalServerError, "method not implemented")
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "loadCurrentUserImage",
+		"context":  *core.LogContext(c),
+	}
+
+	upload, err := readImageUpload(c)
+	if err != nil {
+		imageErrorResponse(c, err)
+		return
+	}
+
+	image, err := H.image.LoadCurrentUserImage(c.Request.Context(), upload)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("load current user image error")
+		imageErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, image)
 }
 
 // @Summary AddUserToCompany
 // @Security ApiKeyAuth
 // @Tags user
-// @Description This request for get user info
+// @Description This request for add user without company to department without invitation. Only for company admins
 // @ID addUserToCompany
 // @Accept json
 // @Produces json
@@ -255,7 +446,7 @@ func (H *Handler) addUserToCompany(c *gin.Context) {
 		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
 	}
 
-	err = H.user.AddUserToCompany(c, addedUserIdInt, departmentIdInt)
+	err = H.user.AddUserToCompany(c.Request.Context(), addedUserIdInt, departmentIdInt)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"base":            logBase,
@@ -277,3 +468,132 @@ func (H *Handler) addUserToCompany(c *gin.Context) {
 
 	c.AbortWithStatusJSON(http.StatusOK, "ok")
 }
+
+// @Summary RemoveUserFromCompany
+// @Security ApiKeyAuth
+// @Tags user
+// @Description This request for remove user from company, all credentials of user in company are deleted and not taken usages are cancelled. Taken things must be returned first. Only for company admins
+// @ID removeUserFromCompany
+// @Accept json
+// @Produces json
+// @Param id path int true "user id"
+// @Success 200 {string} string "ok"
+// @Failure 400,401,403,404,409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /users/{id}/remove_from_company [post]
+func (H *Handler) removeUserFromCompany(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "removeUserFromCompany",
+		"context":  *core.LogContext(c),
+	}
+
+	userId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error("error get user_id from path")
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	if err = H.user.RemoveUserFromCompany(c.Request.Context(), userId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":   logBase,
+			"userId": userId,
+			"error":  err.Error(),
+		}).Error("remove user from company error")
+		userErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, "ok")
+}
+
+// @Summary MoveUserToDepartment
+// @Security ApiKeyAuth
+// @Tags user
+// @Description This request for move user to other department of the same company, credentials of user in old department are deleted. Things of old department taken by user must be returned first. Only for admins of both departments
+// @ID moveUserToDepartment
+// @Accept json
+// @Produces json
+// @Param id path int true "user id"
+// @Param department_id query int true "target department id"
+// @Success 200 {string} string "ok"
+// @Failure 400,401,403,404,409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /users/{id}/move_to_department [post]
+func (H *Handler) moveUserToDepartment(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "moveUserToDepartment",
+		"context":  *core.LogContext(c),
+	}
+
+	userId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error("error get user_id from path")
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	departmentId, err := strconv.Atoi(c.Query("department_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error("error get department_id from query")
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	if err = H.user.MoveUserToDepartment(c.Request.Context(), userId, departmentId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":         logBase,
+			"userId":       userId,
+			"departmentId": departmentId,
+			"error":        err.Error(),
+		}).Error("move user to department error")
+		userErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, "ok")
+}
+
+// @Summary LeaveCompany
+// @Security ApiKeyAuth
+// @Tags user
+// @Description This request for leave company by current user. The last company admin can't leave, taken things must be returned first
+// @ID leaveCompany
+// @Accept json
+// @Produces json
+// @Success 200 {string} string "ok"
+// @Failure 400,401,409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /user/leave_company [post]
+func (H *Handler) leaveCompany(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "leaveCompany",
+		"context":  *core.LogContext(c),
+	}
+
+	if err := H.user.LeaveCompany(c.Request.Context()); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("leave company error")
+		userErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, "ok")
+}
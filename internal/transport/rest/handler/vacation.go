@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+func vacationErrorResponse(c *gin.Context, err error) {
+	switch err {
+	case moduleErrors.ErrorServiceVacationNotFound:
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+	case moduleErrors.ErrorServiceInvalidVacationTime:
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+	case moduleErrors.ErrorServiceVacationOverlap:
+		newErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		userErrorResponse(c, err)
+	}
+}
+
+// @Summary Vacation
+// @Security ApiKeyAuth
+// @Tags vacation
+// @Description This request for add vacation of current user
+// @ID addVacation
+// @Accept json
+// @Produces json
+// @Param input body core.VacationAdd true "vacation info"
+// @Success 200 {object} core.Vacation
+// @Failure 400,401,409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /user/vacations [post]
+func (H *Handler) addVacation(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "addVacation",
+		"context":  *core.LogContext(c),
+	}
+
+	var input core.VacationAdd
+	if err := c.BindJSON(&input); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error parse request body")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	vacation, err := H.vacation.AddVacation(c.Request.Context(), &input)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"input": input,
+			"error": err.Error(),
+		}).Error("add vacation error")
+		vacationErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, vacation)
+}
+
+// @Summary MyVacations
+// @Security ApiKeyAuth
+// @Tags vacation
+// @Description This request for get all vacations of current user
+// @ID getMyVacations
+// @Accept json
+// @Produces json
+// @Success 200 {array} core.Vacation
+// @Failure 401 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /user/vacations [get]
+func (H *Handler) getMyVacations(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getMyVacations",
+		"context":  *core.LogContext(c),
+	}
+
+	vacations, err := H.vacation.GetMyVacations(c.Request.Context())
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("get vacations error")
+		vacationErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, vacations)
+}
+
+// @Summary Vacation
+// @Security ApiKeyAuth
+// @Tags vacation
+// @Description This request for change vacation of current user
+// @ID patchVacation
+// @Accept json
+// @Produces json
+// @Param vacationId path int true "vacation id"
+// @Param input body core.VacationAdd true "vacation info"
+// @Success 200 {object} core.Vacation
+// @Failure 400,401,404,409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /user/vacations/{vacationId} [patch]
+func (H *Handler) patchVacation(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "patchVacation",
+		"context":  *core.LogContext(c),
+	}
+
+	vacationId, err := strconv.Atoi(c.Param("vacation_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	var input core.VacationAdd
+	if err = c.BindJSON(&input); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error parse request body")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	vacation, err := H.vacation.UpdateVacation(c.Request.Context(), &input, vacationId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":       logBase,
+			"vacationId": vacationId,
+			"input":      input,
+			"error":      err.Error(),
+		}).Error("update vacation error")
+		vacationErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, vacation)
+}
+
+// @Summary Vacation
+// @Security ApiKeyAuth
+// @Tags vacation
+// @Description This request for delete vacation of current user
+// @ID deleteVacation
+// @Accept json
+// @Produces json
+// @Param vacationId path int true "vacation id"
+// @Success 200 {string} string "ok"
+// @Failure 400,401,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /user/vacations/{vacationId} [delete]
+func (H *Handler) deleteVacation(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "deleteVacation",
+		"context":  *core.LogContext(c),
+	}
+
+	vacationId, err := strconv.Atoi(c.Param("vacation_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	if err = H.vacation.DeleteVacation(c.Request.Context(), vacationId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":       logBase,
+			"vacationId": vacationId,
+			"error":      err.Error(),
+		}).Error("delete vacation error")
+		vacationErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, "ok")
+}
+
+// @Summary DepartmentVacations
+// @Security ApiKeyAuth
+// @Tags vacation
+// @Description This request for get calendar of department members vacations. Vacations which intersect with
+// @Description time interval are returned, interval starts now by default and has no end if end isn't set
+// @ID getDepartmentVacations
+// @Accept json
+// @Produces json
+// @Param departmentId path int true "department id"
+// @Param from query int false "start of interval, unix time"
+// @Param to query int false "end of interval, unix time"
+// @Success 200 {array} core.DepartmentVacation
+// @Failure 400,401,403,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /department/{departmentId}/vacations [get]
+func (H *Handler) getDepartmentVacations(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getDepartmentVacations",
+		"context":  *core.LogContext(c),
+	}
+
+	departmentId, err := strconv.Atoi(c.Param("department_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	startTime, err := getOptionalUnixTimeQuery(c, "from")
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+	endTime, err := getOptionalUnixTimeQuery(c, "to")
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	vacations, err := H.vacation.GetDepartmentVacations(c.Request.Context(), departmentId, startTime, endTime)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":         logBase,
+			"departmentId": departmentId,
+			"error":        err.Error(),
+		}).Error("get department vacations error")
+		vacationErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, vacations)
+}
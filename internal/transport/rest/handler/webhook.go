@@ -0,0 +1,367 @@
+package handler
+
+import (
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+func webhookErrorResponse(c *gin.Context, err error) {
+	switch err {
+	case moduleErrors.ErrorServiceWebhookNotFound,
+		moduleErrors.ErrorServiceWebhookDeliveryNotFound:
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+	case moduleErrors.ErrorServiceInvalidWebhookURL,
+		moduleErrors.ErrorServiceInvalidEventType:
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		departmentErrorResponse(c, err)
+	}
+}
+
+// @Summary Webhook
+// @Security ApiKeyAuth
+// @Tags webhook
+// @Description This request for subscribe company integration to events. Requests to webhook are signed by
+// @Description HMAC-SHA256 of "timestamp.body" with secret, secret is generated if it isn't set and is returned
+// @Description only in this response
+// @ID addWebhook
+// @Accept json
+// @Produces json
+// @Param companyId path int true "company id"
+// @Param input body core.WebhookAdd true "webhook info"
+// @Success 200 {object} core.Webhook
+// @Failure 400,401,403 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /company/{companyId}/webhooks [post]
+func (H *Handler) addWebhook(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "addWebhook",
+		"context":  *core.LogContext(c),
+	}
+
+	companyId, err := strconv.Atoi(c.Param("company_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	var input core.WebhookAdd
+	if err = c.BindJSON(&input); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error parse request body")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	webhook, err := H.webhook.AddWebhook(c.Request.Context(), companyId, &input)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":      logBase,
+			"companyId": companyId,
+			"url":       input.URL,
+			"error":     err.Error(),
+		}).Error("add webhook error")
+		webhookErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, webhook)
+}
+
+// @Summary Webhooks
+// @Security ApiKeyAuth
+// @Tags webhook
+// @Description This request for get webhooks of company
+// @ID getCompanyWebhooks
+// @Accept json
+// @Produces json
+// @Param companyId path int true "company id"
+// @Success 200 {array} core.Webhook
+// @Failure 400,401,403 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /company/{companyId}/webhooks [get]
+func (H *Handler) getCompanyWebhooks(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getCompanyWebhooks",
+		"context":  *core.LogContext(c),
+	}
+
+	companyId, err := strconv.Atoi(c.Param("company_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	webhooks, err := H.webhook.GetWebhooks(c.Request.Context(), companyId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":      logBase,
+			"companyId": companyId,
+			"error":     err.Error(),
+		}).Error("get webhooks error")
+		webhookErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, webhooks)
+}
+
+// @Summary Webhook
+// @Security ApiKeyAuth
+// @Tags webhook
+// @Description This request for get webhook
+// @ID getWebhook
+// @Accept json
+// @Produces json
+// @Param webhookId path int true "webhook id"
+// @Success 200 {object} core.Webhook
+// @Failure 400,401,403,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /webhook/{webhookId} [get]
+func (H *Handler) getWebhook(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getWebhook",
+		"context":  *core.LogContext(c),
+	}
+
+	webhookId, err := strconv.Atoi(c.Param("webhook_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	webhook, err := H.webhook.GetWebhook(c.Request.Context(), webhookId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":      logBase,
+			"webhookId": webhookId,
+			"error":     err.Error(),
+		}).Error("get webhook error")
+		webhookErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, webhook)
+}
+
+// @Summary Webhook
+// @Security ApiKeyAuth
+// @Tags webhook
+// @Description This request for change webhook, empty secret generates new secret which is returned in response
+// @ID patchWebhook
+// @Accept json
+// @Produces json
+// @Param webhookId path int true "webhook id"
+// @Param input body core.WebhookUpdate true "webhook info"
+// @Success 200 {object} core.Webhook
+// @Failure 400,401,403,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /webhook/{webhookId} [patch]
+func (H *Handler) patchWebhook(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "patchWebhook",
+		"context":  *core.LogContext(c),
+	}
+
+	webhookId, err := strconv.Atoi(c.Param("webhook_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	var input core.WebhookUpdate
+	if err = c.BindJSON(&input); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error parse request body")
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	webhook, err := H.webhook.UpdateWebhook(c.Request.Context(), webhookId, &input)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":      logBase,
+			"webhookId": webhookId,
+			"error":     err.Error(),
+		}).Error("update webhook error")
+		webhookErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, webhook)
+}
+
+// @Summary Webhook
+// @Security ApiKeyAuth
+// @Tags webhook
+// @Description This request for delete webhook with its deliveries
+// @ID deleteWebhook
+// @Accept json
+// @Produces json
+// @Param webhookId path int true "webhook id"
+// @Success 200 {string} string "ok"
+// @Failure 400,401,403,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /webhook/{webhookId} [delete]
+func (H *Handler) deleteWebhook(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "deleteWebhook",
+		"context":  *core.LogContext(c),
+	}
+
+	webhookId, err := strconv.Atoi(c.Param("webhook_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	if err = H.webhook.DeleteWebhook(c.Request.Context(), webhookId); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":      logBase,
+			"webhookId": webhookId,
+			"error":     err.Error(),
+		}).Error("delete webhook error")
+		webhookErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, "ok")
+}
+
+// @Summary WebhookDeliveries
+// @Security ApiKeyAuth
+// @Tags webhook
+// @Description This request for get delivery log of webhook, newest deliveries first
+// @ID getWebhookDeliveries
+// @Accept json
+// @Produces json
+// @Param webhookId path int true "webhook id"
+// @Success 200 {array} core.WebhookDelivery
+// @Failure 400,401,403,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /webhook/{webhookId}/deliveries [get]
+func (H *Handler) getWebhookDeliveries(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "getWebhookDeliveries",
+		"context":  *core.LogContext(c),
+	}
+
+	webhookId, err := strconv.Atoi(c.Param("webhook_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	deliveries, err := H.webhook.GetDeliveries(c.Request.Context(), webhookId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":      logBase,
+			"webhookId": webhookId,
+			"error":     err.Error(),
+		}).Error("get webhook deliveries error")
+		webhookErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, deliveries)
+}
+
+// @Summary RedeliverWebhook
+// @Security ApiKeyAuth
+// @Tags webhook
+// @Description This request for send delivery of webhook again, it is queued with reset attempts
+// @ID redeliverWebhookDelivery
+// @Accept json
+// @Produces json
+// @Param webhookId path int true "webhook id"
+// @Param deliveryId path int true "delivery id"
+// @Success 200 {object} core.WebhookDelivery
+// @Failure 400,401,403,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /webhook/{webhookId}/deliveries/{deliveryId}/redeliver [post]
+func (H *Handler) redeliverWebhookDelivery(c *gin.Context) {
+	logBase := logrus.Fields{
+		"module":   "handler",
+		"function": "redeliverWebhookDelivery",
+		"context":  *core.LogContext(c),
+	}
+
+	webhookId, err := strconv.Atoi(c.Param("webhook_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	deliveryId, err := strconv.Atoi(c.Param("delivery_id"))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error(moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		newErrorResponse(c, http.StatusBadRequest, moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error())
+		return
+	}
+
+	delivery, err := H.webhook.Redeliver(c.Request.Context(), webhookId, deliveryId)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":       logBase,
+			"webhookId":  webhookId,
+			"deliveryId": deliveryId,
+			"error":      err.Error(),
+		}).Error("redeliver webhook delivery error")
+		webhookErrorResponse(c, err)
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusOK, delivery)
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	mockhandler "github.com/Thing-repository/backend-server/internal/transport/rest/handler/mocks"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRedeliverWebhookDelivery(t *testing.T) {
+	type mockBehavior func(s *mockhandler.Mockwebhook)
+
+	testTable := []struct {
+		name                 string
+		path                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			path: "/webhook/2/deliveries/7/redeliver",
+			mockBehavior: func(s *mockhandler.Mockwebhook) {
+				s.EXPECT().Redeliver(gomock.Any(), 2, 7).Return(&core.WebhookDelivery{
+					Id:              7,
+					WebhookId:       2,
+					EventType:       core.WebhookEventThingBlocked,
+					Payload:         []byte(`{"type":"thing.blocked"}`),
+					Status:          core.WebhookDeliveryPending,
+					NextAttemptTime: 1700000000,
+					CreatedTime:     1690000000,
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"id":7,"webhook_id":2,"event_type":"thing.blocked","payload":{"type":"thing.blocked"},` +
+				`"status":"pending","attempts":0,"next_attempt_time":1700000000,"created_time":1690000000}`,
+		},
+		{
+			name:                 "Invalid delivery id",
+			path:                 "/webhook/2/deliveries/abc/redeliver",
+			mockBehavior:         func(s *mockhandler.Mockwebhook) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"` + moduleErrors.ErrorHandlerNoRequiredFieldsQuery.Error() + `"}`,
+		},
+		{
+			name: "Delivery not found",
+			path: "/webhook/2/deliveries/8/redeliver",
+			mockBehavior: func(s *mockhandler.Mockwebhook) {
+				s.EXPECT().Redeliver(gomock.Any(), 2, 8).Return(nil, moduleErrors.ErrorServiceWebhookDeliveryNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"webhook delivery not found"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Init deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			webhook := mockhandler.NewMockwebhook(c)
+			testCase.mockBehavior(webhook)
+
+			handler := &Handler{webhook: webhook}
+
+			r := gin.New()
+			r.POST("/webhook/:webhook_id/deliveries/:delivery_id/redeliver", handler.redeliverWebhookDelivery)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", testCase.path, nil)
+
+			// Perform request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			if w.Code != testCase.expectedStatusCode {
+				t.Errorf("status code = %d, want %d", w.Code, testCase.expectedStatusCode)
+			}
+			if w.Body.String() != testCase.expectedResponseBody {
+				t.Errorf("response body = %s, want %s", w.Body.String(), testCase.expectedResponseBody)
+			}
+		})
+	}
+}
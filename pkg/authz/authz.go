@@ -0,0 +1,234 @@
+package authz
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
+)
+
+type Action string
+
+const (
+	ActionRead   Action = "read"
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+	// ActionManageCredentials is grant and revoke of admin and maintainer credentials
+	ActionManageCredentials Action = "manage_credentials"
+	// ActionAddMember is inviting user to department, on company it is adding user without invitation
+	ActionAddMember Action = "add_member"
+	// ActionRemoveMember is removing user from company or department
+	ActionRemoveMember Action = "remove_member"
+	// ActionApprove is changing status of thing usage which belongs to other user
+	ActionApprove Action = "approve"
+	// ActionTransferOwnership is replacing all company admins by one user
+	ActionTransferOwnership Action = "transfer_ownership"
+	// ActionLock is lock and unlock of user account
+	ActionLock Action = "lock"
+)
+
+type ResourceType string
+
+const (
+	ResourceCompany    ResourceType = "company"
+	ResourceDepartment ResourceType = "department"
+	ResourceThing      ResourceType = "thing"
+	ResourceThingUsage ResourceType = "thing_usage"
+	ResourceThingBlock ResourceType = "thing_block"
+	ResourceThingStock ResourceType = "thing_stock"
+	ResourceWebhook    ResourceType = "webhook"
+	ResourceUser       ResourceType = "user"
+)
+
+// Resource is object of action, DepartmentId is 0 for company level resources
+type Resource struct {
+	Type         ResourceType
+	CompanyId    int
+	DepartmentId int
+}
+
+func Company(companyId int) Resource {
+	return Resource{Type: ResourceCompany, CompanyId: companyId}
+}
+
+func Department(companyId int, departmentId int) Resource {
+	return Resource{Type: ResourceDepartment, CompanyId: companyId, DepartmentId: departmentId}
+}
+
+func Thing(companyId int, departmentId int) Resource {
+	return Resource{Type: ResourceThing, CompanyId: companyId, DepartmentId: departmentId}
+}
+
+func ThingUsage(companyId int, departmentId int) Resource {
+	return Resource{Type: ResourceThingUsage, CompanyId: companyId, DepartmentId: departmentId}
+}
+
+func ThingBlock(companyId int, departmentId int) Resource {
+	return Resource{Type: ResourceThingBlock, CompanyId: companyId, DepartmentId: departmentId}
+}
+
+// ThingStock is stock movements ledger of consumable thing
+func ThingStock(companyId int, departmentId int) Resource {
+	return Resource{Type: ResourceThingStock, CompanyId: companyId, DepartmentId: departmentId}
+}
+
+// Webhook is subscription of company integration to events
+func Webhook(companyId int) Resource {
+	return Resource{Type: ResourceWebhook, CompanyId: companyId}
+}
+
+// User is user account, it doesn't belong to company for authorization
+func User() Resource {
+	return Resource{Type: ResourceUser}
+}
+
+// policies lists roles which are allowed to do action with resource, implied roles are added by userRoles
+var policies = map[ResourceType]map[Action][]string{
+	ResourceCompany: {
+		ActionRead:              {core.CredentialTypeCompanyUser},
+		ActionUpdate:            {core.CredentialTypeCompanyAdmin},
+		ActionDelete:            {core.CredentialTypeCompanyAdmin},
+		ActionManageCredentials: {core.CredentialTypeCompanyAdmin},
+		ActionTransferOwnership: {core.CredentialTypeServiceAdmin},
+		ActionAddMember:         {core.CredentialTypeCompanyAdmin},
+		ActionRemoveMember:      {core.CredentialTypeCompanyAdmin},
+	},
+	ResourceDepartment: {
+		ActionRead:              {core.CredentialTypeCompanyUser},
+		ActionCreate:            {core.CredentialTypeCompanyAdmin},
+		ActionUpdate:            {core.CredentialTypeDepartmentAdmin},
+		ActionDelete:            {core.CredentialTypeCompanyAdmin},
+		ActionManageCredentials: {core.CredentialTypeDepartmentAdmin},
+		ActionAddMember:         {core.CredentialTypeDepartmentAdmin},
+		ActionRemoveMember:      {core.CredentialTypeDepartmentAdmin},
+	},
+	ResourceThing: {
+		ActionRead:   {core.CredentialTypeCompanyUser},
+		ActionCreate: {core.CredentialTypeDepartmentMaintainer},
+		ActionUpdate: {core.CredentialTypeDepartmentMaintainer},
+		ActionDelete: {core.CredentialTypeDepartmentMaintainer},
+	},
+	ResourceThingUsage: {
+		ActionRead:    {core.CredentialTypeCompanyUser},
+		ActionCreate:  {core.CredentialTypeCompanyUser},
+		ActionApprove: {core.CredentialTypeDepartmentMaintainer},
+	},
+	ResourceThingBlock: {
+		ActionRead:   {core.CredentialTypeCompanyUser},
+		ActionCreate: {core.CredentialTypeDepartmentMaintainer},
+		ActionUpdate: {core.CredentialTypeDepartmentMaintainer},
+		ActionDelete: {core.CredentialTypeDepartmentMaintainer},
+	},
+	ResourceThingStock: {
+		ActionRead:   {core.CredentialTypeCompanyUser},
+		ActionCreate: {core.CredentialTypeDepartmentMaintainer},
+	},
+	ResourceWebhook: {
+		ActionRead:   {core.CredentialTypeCompanyAdmin},
+		ActionCreate: {core.CredentialTypeCompanyAdmin},
+		ActionUpdate: {core.CredentialTypeCompanyAdmin},
+		ActionDelete: {core.CredentialTypeCompanyAdmin},
+	},
+	ResourceUser: {
+		ActionLock: {core.CredentialTypeServiceAdmin},
+	},
+}
+
+// allRoles is list of roles of service admin on any resource
+var allRoles = []string{
+	core.CredentialTypeServiceAdmin,
+	core.CredentialTypeCompanyAdmin,
+	core.CredentialTypeCompanyUser,
+	core.CredentialTypeDepartmentAdmin,
+	core.CredentialTypeDepartmentMaintainer,
+	core.CredentialTypeDepartmentUser,
+}
+
+// userRoles returns roles of user on resource. Service admin has all roles on every resource.
+// Company admin has all department rights within its company, department admin has maintainer rights,
+// maintainer has department user rights.
+func userRoles(credentials []core.Credentials, resource Resource) []string {
+	if core.CheckCredential(credentials, core.CredentialTypeServiceAdmin, 0) {
+		return allRoles
+	}
+
+	roles := make([]string, 0)
+	addRole := func(role string) {
+		if slices.Index(roles, role) == -1 {
+			roles = append(roles, role)
+		}
+	}
+
+	if core.CheckCredential(credentials, core.CredentialTypeCompanyAdmin, resource.CompanyId) {
+		addRole(core.CredentialTypeCompanyAdmin)
+		addRole(core.CredentialTypeCompanyUser)
+	}
+	if core.CheckCredential(credentials, core.CredentialTypeCompanyUser, resource.CompanyId) {
+		addRole(core.CredentialTypeCompanyUser)
+	}
+
+	if resource.DepartmentId == 0 {
+		return roles
+	}
+
+	if slices.Index(roles, core.CredentialTypeCompanyAdmin) != -1 ||
+		core.CheckCredential(credentials, core.CredentialTypeDepartmentAdmin, resource.DepartmentId) {
+		addRole(core.CredentialTypeDepartmentAdmin)
+	}
+	if slices.Index(roles, core.CredentialTypeDepartmentAdmin) != -1 ||
+		core.CheckCredential(credentials, core.CredentialTypeDepartmentMaintainer, resource.DepartmentId) {
+		addRole(core.CredentialTypeDepartmentMaintainer)
+	}
+	if slices.Index(roles, core.CredentialTypeDepartmentMaintainer) != -1 ||
+		core.CheckCredential(credentials, core.CredentialTypeDepartmentUser, resource.DepartmentId) {
+		addRole(core.CredentialTypeDepartmentUser)
+	}
+
+	return roles
+}
+
+// Can checks that user with credentials can do action with resource, unknown actions are denied
+func Can(credentials []core.Credentials, action Action, resource Resource) bool {
+	allowedRoles, ok := policies[resource.Type][action]
+	if !ok {
+		return false
+	}
+
+	for _, role := range userRoles(credentials, resource) {
+		if slices.Index(allowedRoles, role) != -1 {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize checks that user from context can do action with resource
+func Authorize(ctx context.Context, action Action, resource Resource) error {
+	logBase := logrus.Fields{
+		"module":   "authz",
+		"function": "Authorize",
+		"action":   action,
+		"resource": resource,
+		"context":  *core.LogContext(ctx),
+	}
+
+	credentials, err := core.ContextGetUserCredentials(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err.Error(),
+		}).Error("error get user credentials from context")
+		return moduleErrors.ErrorServiceInvalidContext
+	}
+
+	if !Can(credentials, action, resource) {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error("user has not credentials")
+		return moduleErrors.ErrorServiceBadPermissions
+	}
+
+	return nil
+}
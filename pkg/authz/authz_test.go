@@ -0,0 +1,167 @@
+package authz
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"golang.org/x/exp/slices"
+	"testing"
+)
+
+const (
+	testCompanyId         = 1
+	testDepartmentId      = 10
+	testOtherCompanyId    = 2
+	testOtherDepartmentId = 20
+)
+
+var allCredentialTypes = []string{
+	core.CredentialTypeCompanyAdmin,
+	core.CredentialTypeCompanyUser,
+	core.CredentialTypeDepartmentAdmin,
+	core.CredentialTypeDepartmentMaintainer,
+	core.CredentialTypeDepartmentUser,
+	core.CredentialTypeServiceAdmin,
+}
+
+// credentialOn returns credential of type on test company or department, service admin credential is global
+func credentialOn(credentialType string, companyId int, departmentId int) core.Credentials {
+	if credentialType == core.CredentialTypeServiceAdmin {
+		return core.Credentials{CredentialType: credentialType}
+	}
+	if slices.Index(core.CompanyCredential, credentialType) != -1 {
+		return core.Credentials{CredentialType: credentialType, ObjectId: companyId}
+	}
+	return core.Credentials{CredentialType: credentialType, ObjectId: departmentId}
+}
+
+func TestCan(t *testing.T) {
+	serviceAdmins := []string{core.CredentialTypeServiceAdmin}
+	companyReaders := []string{core.CredentialTypeServiceAdmin, core.CredentialTypeCompanyAdmin,
+		core.CredentialTypeCompanyUser}
+	companyAdmins := []string{core.CredentialTypeServiceAdmin, core.CredentialTypeCompanyAdmin}
+	departmentAdmins := []string{core.CredentialTypeServiceAdmin, core.CredentialTypeCompanyAdmin,
+		core.CredentialTypeDepartmentAdmin}
+	departmentMaintainers := []string{core.CredentialTypeServiceAdmin, core.CredentialTypeCompanyAdmin,
+		core.CredentialTypeDepartmentAdmin, core.CredentialTypeDepartmentMaintainer}
+
+	testTable := []struct {
+		action       Action
+		resource     Resource
+		allowedRoles []string
+	}{
+		{ActionRead, Company(testCompanyId), companyReaders},
+		{ActionUpdate, Company(testCompanyId), companyAdmins},
+		{ActionDelete, Company(testCompanyId), companyAdmins},
+		{ActionManageCredentials, Company(testCompanyId), companyAdmins},
+		{ActionTransferOwnership, Company(testCompanyId), serviceAdmins},
+		{ActionAddMember, Company(testCompanyId), companyAdmins},
+		{ActionRemoveMember, Company(testCompanyId), companyAdmins},
+		{ActionCreate, Company(testCompanyId), nil},
+
+		{ActionRead, Department(testCompanyId, testDepartmentId), companyReaders},
+		{ActionCreate, Department(testCompanyId, 0), companyAdmins},
+		{ActionUpdate, Department(testCompanyId, testDepartmentId), departmentAdmins},
+		{ActionDelete, Department(testCompanyId, testDepartmentId), companyAdmins},
+		{ActionManageCredentials, Department(testCompanyId, testDepartmentId), departmentAdmins},
+		{ActionAddMember, Department(testCompanyId, testDepartmentId), departmentAdmins},
+		{ActionRemoveMember, Department(testCompanyId, testDepartmentId), departmentAdmins},
+
+		{ActionRead, Thing(testCompanyId, testDepartmentId), companyReaders},
+		{ActionRead, Thing(testCompanyId, 0), companyReaders},
+		{ActionCreate, Thing(testCompanyId, testDepartmentId), departmentMaintainers},
+		{ActionUpdate, Thing(testCompanyId, testDepartmentId), departmentMaintainers},
+		{ActionDelete, Thing(testCompanyId, testDepartmentId), departmentMaintainers},
+		{ActionApprove, Thing(testCompanyId, testDepartmentId), nil},
+
+		{ActionRead, ThingUsage(testCompanyId, testDepartmentId), companyReaders},
+		{ActionCreate, ThingUsage(testCompanyId, testDepartmentId), companyReaders},
+		{ActionApprove, ThingUsage(testCompanyId, testDepartmentId), departmentMaintainers},
+		{ActionDelete, ThingUsage(testCompanyId, testDepartmentId), nil},
+
+		{ActionRead, ThingBlock(testCompanyId, testDepartmentId), companyReaders},
+		{ActionCreate, ThingBlock(testCompanyId, testDepartmentId), departmentMaintainers},
+		{ActionUpdate, ThingBlock(testCompanyId, testDepartmentId), departmentMaintainers},
+		{ActionDelete, ThingBlock(testCompanyId, testDepartmentId), departmentMaintainers},
+
+		{ActionRead, ThingStock(testCompanyId, testDepartmentId), companyReaders},
+		{ActionCreate, ThingStock(testCompanyId, testDepartmentId), departmentMaintainers},
+		{ActionDelete, ThingStock(testCompanyId, testDepartmentId), nil},
+
+		{ActionRead, Webhook(testCompanyId), companyAdmins},
+		{ActionCreate, Webhook(testCompanyId), companyAdmins},
+		{ActionUpdate, Webhook(testCompanyId), companyAdmins},
+		{ActionDelete, Webhook(testCompanyId), companyAdmins},
+		{ActionApprove, Webhook(testCompanyId), nil},
+
+		{ActionLock, User(), serviceAdmins},
+		{ActionRead, User(), nil},
+	}
+
+	for _, testCase := range testTable {
+		for _, credentialType := range allCredentialTypes {
+			name := string(testCase.resource.Type) + "/" + string(testCase.action) + "/" + credentialType
+			t.Run(name, func(t *testing.T) {
+				// credential on resource object
+				credentials := []core.Credentials{credentialOn(credentialType, testCompanyId, testDepartmentId)}
+				expected := slices.Index(testCase.allowedRoles, credentialType) != -1
+				if got := Can(credentials, testCase.action, testCase.resource); got != expected {
+					t.Errorf("Can() = %v, want %v", got, expected)
+				}
+
+				// same credential on other company and department never gives rights
+				credentials = []core.Credentials{credentialOn(credentialType, testOtherCompanyId, testOtherDepartmentId)}
+				if credentialType != core.CredentialTypeServiceAdmin && Can(credentials, testCase.action, testCase.resource) {
+					t.Errorf("Can() with credential on other object = true, want false")
+				}
+			})
+		}
+	}
+}
+
+func TestCanWithoutCredentials(t *testing.T) {
+	if Can(nil, ActionRead, Company(testCompanyId)) {
+		t.Errorf("Can() without credentials = true, want false")
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	userCtx := core.ContextWithUser(context.Background(), 1, []core.Credentials{
+		{CredentialType: core.CredentialTypeCompanyUser, ObjectId: testCompanyId},
+	})
+
+	testTable := []struct {
+		name        string
+		ctx         context.Context
+		action      Action
+		expectedErr error
+	}{
+		{
+			name:        "Allowed",
+			ctx:         userCtx,
+			action:      ActionRead,
+			expectedErr: nil,
+		},
+		{
+			name:        "Denied",
+			ctx:         userCtx,
+			action:      ActionUpdate,
+			expectedErr: moduleErrors.ErrorServiceBadPermissions,
+		},
+		{
+			name:        "No credentials in context",
+			ctx:         context.Background(),
+			action:      ActionRead,
+			expectedErr: moduleErrors.ErrorServiceInvalidContext,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := Authorize(testCase.ctx, testCase.action, Company(testCompanyId))
+			if err != testCase.expectedErr {
+				t.Errorf("Authorize() = %v, want %v", err, testCase.expectedErr)
+			}
+		})
+	}
+}
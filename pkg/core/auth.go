@@ -8,6 +8,8 @@ type UserSignInData struct {
 type UserSignUpData struct {
 	UserBaseData
 	Password string `json:"password" binding:"required"`
+	// InvitationToken is token from invitation email, invitation with it is accepted on sign up
+	InvitationToken string `json:"invitation_token,omitempty"`
 }
 
 type Access struct {
@@ -24,5 +26,24 @@ type AuthValidationData struct {
 
 type SignInResponse struct {
 	User
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type VerifyEmailData struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ForgotPasswordData struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type ResetPasswordData struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type ChangePasswordData struct {
+	OldPassword string `json:"old_password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
 }
@@ -14,3 +14,9 @@ type Company struct {
 	CompanyUpdate
 	Id int `json:"id"`
 }
+
+// CompanySearchResult is company found by user which isn't its member, departments are listed for join request
+type CompanySearchResult struct {
+	Company
+	Departments []Department `json:"departments"`
+}
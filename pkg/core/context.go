@@ -6,13 +6,22 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// contextKey is type of context keys, it prevents collisions with keys of other packages
+type contextKey string
+
 const (
-	UserDataCtx    = "userData"
-	UserIdCtx      = "userId"
-	CredentialsCtx = "credentials"
+	UserDataCtx    contextKey = "userData"
+	UserIdCtx      contextKey = "userId"
+	CredentialsCtx contextKey = "credentials"
 )
 
-var allContextValues = []string{UserDataCtx, UserIdCtx, CredentialsCtx}
+var allContextValues = []contextKey{UserDataCtx, UserIdCtx, CredentialsCtx}
+
+// ContextWithUser returns copy of ctx with id and credentials of authenticated user
+func ContextWithUser(ctx context.Context, userId int, credentials []Credentials) context.Context {
+	ctx = context.WithValue(ctx, UserIdCtx, userId)
+	return context.WithValue(ctx, CredentialsCtx, credentials)
+}
 
 func ContextGetUserId(ctx context.Context) (int, error) {
 	userId := ctx.Value(UserIdCtx)
@@ -41,7 +50,7 @@ func ContextGetUserCredentials(ctx context.Context) ([]Credentials, error) {
 func LogContext(ctx context.Context) *logrus.Fields {
 	fields := make(logrus.Fields)
 	for _, val := range allContextValues {
-		fields[val] = ctx.Value(val)
+		fields[string(val)] = ctx.Value(val)
 	}
 	return &fields
 }
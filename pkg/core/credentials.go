@@ -10,11 +10,25 @@ const (
 	CredentialTypeDepartmentMaintainer = "department_maintainer"
 	CredentialTypeCompanyUser          = "company_user"
 	CredentialTypeDepartmentUser       = "department_user"
+	// CredentialTypeServiceAdmin is platform level credential, it is stored in service_admins table
+	// and has ObjectId 0
+	CredentialTypeServiceAdmin = "service_admin"
+)
+
+// sources of user credentials for access checks: claims of access token
+// or database on each request (changes take effect without waiting for token refresh)
+const (
+	CredentialsSourceToken    = "token"
+	CredentialsSourceDatabase = "database"
 )
 
 var CompanyCredential = []string{CredentialTypeCompanyAdmin, CredentialTypeCompanyUser}
 var DepartmentCredential = []string{CredentialTypeDepartmentAdmin, CredentialTypeDepartmentMaintainer, CredentialTypeDepartmentUser}
 
+// AllCredentialTypes are company and department credential types, service admin isn't included
+var AllCredentialTypes = []string{CredentialTypeCompanyAdmin, CredentialTypeCompanyUser, CredentialTypeDepartmentAdmin,
+	CredentialTypeDepartmentMaintainer, CredentialTypeDepartmentUser}
+
 type Credentials struct {
 	CredentialType string
 	ObjectId       int
@@ -10,3 +10,8 @@ type Department struct {
 	Id       *int    `json:"id"`
 	ImageURL *string `json:"image_url"`
 }
+
+type DepartmentUpdate struct {
+	DepartmentName *string `json:"department_name"`
+	ImageURL       *string `json:"-"`
+}
@@ -0,0 +1,12 @@
+package core
+
+// ImageUpload is uploaded file with content type declared by client
+type ImageUpload struct {
+	Data        []byte
+	ContentType string
+}
+
+type Image struct {
+	ImageURL     string `json:"image_url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
@@ -0,0 +1,26 @@
+package core
+
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusDeclined = "declined"
+)
+
+// InvitationAdd invites registered user by UserId or not registered user by Email
+type InvitationAdd struct {
+	DepartmentId int     `json:"department_id" binding:"required"`
+	UserId       *int    `json:"user_id,omitempty"`
+	Email        *string `json:"email,omitempty"`
+}
+
+type Invitation struct {
+	Id           int     `json:"id"`
+	DepartmentId int     `json:"department_id"`
+	CompanyId    int     `json:"company_id"`
+	UserId       *int    `json:"user_id,omitempty"`
+	Email        *string `json:"email,omitempty"`
+	InvitedBy    *int    `json:"invited_by,omitempty"`
+	Status       string  `json:"status"`
+	CreatedTime  uint32  `json:"created_time"`
+	ExpiresTime  uint32  `json:"expires_time"`
+}
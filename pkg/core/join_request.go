@@ -0,0 +1,24 @@
+package core
+
+const (
+	JoinRequestStatusPending   = "pending"
+	JoinRequestStatusApproved  = "approved"
+	JoinRequestStatusRejected  = "rejected"
+	JoinRequestStatusCancelled = "cancelled"
+)
+
+type JoinRequestAdd struct {
+	DepartmentId int    `json:"department_id" binding:"required"`
+	Message      string `json:"message"`
+}
+
+type JoinRequest struct {
+	JoinRequestAdd
+	Id           int    `json:"id"`
+	CompanyId    int    `json:"company_id"`
+	UserId       int    `json:"user_id"`
+	Status       string `json:"status"`
+	CreatedTime  uint32 `json:"created_time"`
+	AnsweredBy   *int   `json:"answered_by,omitempty"`
+	AnsweredTime uint32 `json:"answered_time,omitempty"`
+}
@@ -0,0 +1,7 @@
+package core
+
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
@@ -3,10 +3,28 @@ package moduleErrors
 import "errors"
 
 var (
-	ErrorDatabaseUserNotFound           = errors.New("user not found")
-	ErrorDataBaseInternal               = errors.New("internal data base error")
-	ErrorDataBaseUserAlreadyHas         = errors.New("there is already a user with this email")
-	ErrorDataBaseGetTransaction         = errors.New("error get transaction")
-	ErrorDataBaseHasNotDataToChange     = errors.New("error hasn't data to change")
-	ErrorDataBaseInvalidCredentialsType = errors.New("invalid credentials type")
+	ErrorDatabaseUserNotFound            = errors.New("user not found")
+	ErrorDataBaseInternal                = errors.New("internal data base error")
+	ErrorDataBaseUserAlreadyHas          = errors.New("there is already a user with this email")
+	ErrorDataBaseGetTransaction          = errors.New("error get transaction")
+	ErrorDataBaseHasNotDataToChange      = errors.New("error hasn't data to change")
+	ErrorDataBaseInvalidCredentialsType  = errors.New("invalid credentials type")
+	ErrorDatabaseThingNotFound           = errors.New("thing not found")
+	ErrorDatabaseUsageNotFound           = errors.New("thing usage not found")
+	ErrorDatabaseUsageStatusChanged      = errors.New("thing usage status was changed")
+	ErrorDatabaseBlockNotFound           = errors.New("thing block not found")
+	ErrorDatabaseDepartmentNotFound      = errors.New("department not found")
+	ErrorDatabaseDepartmentAlreadyExists = errors.New("department with this name already exists")
+	ErrorDatabaseCredentialNotFound      = errors.New("credential not found")
+	ErrorDatabaseCredentialAlreadyExists = errors.New("credential already exists")
+	ErrorDatabaseSessionNotFound         = errors.New("session not found")
+	ErrorDatabaseResetTokenNotFound      = errors.New("password reset token not found")
+	ErrorDatabaseInvitationNotFound      = errors.New("invitation not found")
+	ErrorDatabaseJoinRequestNotFound     = errors.New("join request not found")
+	ErrorDatabaseVacationNotFound        = errors.New("vacation not found")
+	ErrorDatabaseJoinRequestExists       = errors.New("join request already exists")
+	ErrorDatabaseNotificationNotFound    = errors.New("notification not found")
+	ErrorDatabaseWebhookNotFound         = errors.New("webhook not found")
+	ErrorDatabaseWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrorDatabaseInsufficientStock       = errors.New("stock remainder can't be negative")
 )
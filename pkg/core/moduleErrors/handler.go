@@ -7,4 +7,8 @@ var (
 	ErrorHandlerInvalidEmail              = errors.New("invalid email address")
 	ErrorHandlerNoRequiredFieldsQuery     = errors.New("no required Fields in query")
 	ErrorHandlerForbidden                 = errors.New("you have not access to this resource")
+	ErrorHandlerEmptyAuthorization        = errors.New("empty authorization header")
+	ErrorHandlerInvalidAuthorization      = errors.New("invalid authorization header")
+	ErrorHandlerInvalidCSRFToken          = errors.New("csrf token header doesn't match csrf token cookie")
+	ErrorHandlerNoImageFile               = errors.New("image file is required in multipart field image")
 )
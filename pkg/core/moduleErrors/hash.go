@@ -4,4 +4,5 @@ import "errors"
 
 var (
 	ErrorHashValidationPassword = errors.New("error validation password")
+	ErrorHashUnknownFormat      = errors.New("unknown password hash format")
 )
@@ -3,12 +3,72 @@ package moduleErrors
 import "errors"
 
 var (
-	ErrorServiceUserNotFound          = errors.New("user not found")
-	ErrorServiceInvalidPassword       = errors.New("invalid password")
-	ErrorServiceUserAlreadyHas        = errors.New("there is already a user with this email")
-	ErrorServiceGetUserData           = errors.New("error get user data")
-	ErrorServiceUserAlreadyHasCompany = errors.New("user already has company")
-	ErrorServiceBadPermissions        = errors.New("access denied")
-	ErrorServiceInvalidContext        = errors.New("invalid context")
-	ErrorAllNoFields                  = errors.New("nothing to change")
+	ErrorServiceUserNotFound            = errors.New("user not found")
+	ErrorServiceInvalidPassword         = errors.New("invalid password")
+	ErrorServiceUserAlreadyHas          = errors.New("there is already a user with this email")
+	ErrorServiceGetUserData             = errors.New("error get user data")
+	ErrorServiceUserAlreadyHasCompany   = errors.New("user already has company")
+	ErrorServiceBadPermissions          = errors.New("access denied")
+	ErrorServiceInvalidContext          = errors.New("invalid context")
+	ErrorAllNoFields                    = errors.New("nothing to change")
+	ErrorServiceThingNotFound           = errors.New("thing not found")
+	ErrorServiceInvalidThingType        = errors.New("invalid thing type")
+	ErrorServiceInvalidRemainderType    = errors.New("invalid remainder type")
+	ErrorServiceUserHasNotDepartment    = errors.New("user has not department")
+	ErrorServiceUsageNotFound           = errors.New("thing usage not found")
+	ErrorServiceInvalidUsageTime        = errors.New("invalid usage time")
+	ErrorServiceUsageOverlap            = errors.New("thing already booked for this time")
+	ErrorServiceInvalidUsageStatus      = errors.New("invalid thing usage status transition")
+	ErrorServiceUsageStatusChanged      = errors.New("thing usage status was changed by another request")
+	ErrorServiceBlockNotFound           = errors.New("thing block not found")
+	ErrorServiceBlockConflict           = errors.New("thing has usages in block time")
+	ErrorServiceThingBlocked            = errors.New("thing is blocked for this time")
+	ErrorServiceDepartmentNotFound      = errors.New("department not found")
+	ErrorServiceDepartmentAlreadyExists = errors.New("department with this name already exists")
+	ErrorServiceHeadDepartmentProtected = errors.New("head department can't be deleted or renamed")
+	ErrorServiceInvalidTargetDepartment = errors.New("invalid target department")
+	ErrorServiceCredentialNotFound      = errors.New("user has not this credential")
+	ErrorServiceCredentialAlreadyExists = errors.New("user already has this credential")
+	ErrorServiceInvalidCredentialType   = errors.New("invalid credential type")
+	ErrorServiceLastCompanyAdmin        = errors.New("company must have at least one admin")
+	ErrorServiceUserNotInCompany        = errors.New("user is not a member of company")
+	ErrorServiceInvalidRefreshToken     = errors.New("invalid refresh token")
+	ErrorServiceRefreshTokenReused      = errors.New("refresh token reused, session revoked")
+	ErrorServiceEmailAlreadyVerified    = errors.New("email already verified")
+	ErrorServiceEmailResendTooSoon      = errors.New("verification email was sent recently, try later")
+	ErrorServiceInvalidEmailToken       = errors.New("invalid or expired email verification token")
+	ErrorServiceEmailNotVerified        = errors.New("email is not verified")
+	ErrorServiceInvalidResetToken       = errors.New("invalid or expired password reset token")
+	ErrorServiceUserLocked              = errors.New("user is locked")
+	ErrorServiceSelfLock                = errors.New("user can't lock own account")
+	ErrorServiceUserHasTakenThings      = errors.New("user has taken things, they must be returned first")
+	ErrorServiceInvitationNotFound      = errors.New("invitation not found")
+	ErrorServiceInvitationNotActive     = errors.New("invitation is expired or already answered")
+	ErrorServiceInvalidInvitation       = errors.New("invitation must have either user id or email")
+	ErrorServiceJoinRequestNotFound     = errors.New("join request not found")
+	ErrorServiceJoinRequestNotActive    = errors.New("join request is already answered or cancelled")
+	ErrorServiceJoinRequestExists       = errors.New("join request to this department already exists")
+	ErrorServiceInvalidCursor           = errors.New("invalid cursor")
+	ErrorServiceInvalidSort             = errors.New("invalid sort field")
+	ErrorServiceImageTooLarge           = errors.New("image file is too large")
+	ErrorServiceUnsupportedImageType    = errors.New("unsupported image type, jpeg, png and gif are allowed")
+	ErrorServiceInvalidImage            = errors.New("file is not a valid image")
+	ErrorServiceFileNotFound            = errors.New("file not found")
+	ErrorServiceVacationNotFound        = errors.New("vacation not found")
+	ErrorServiceInvalidVacationTime     = errors.New("vacation must have start time before end time")
+	ErrorServiceVacationOverlap         = errors.New("vacation intersects with other vacation of user")
+	ErrorServiceUserOnVacation          = errors.New("user is on vacation in usage time, use force to book anyway")
+	ErrorServiceThingNotConsumable      = errors.New("thing is not consumable")
+	ErrorServiceInvalidMovementType     = errors.New("invalid stock movement type")
+	ErrorServiceInvalidQuantity         = errors.New("stock movement quantity must be positive")
+	ErrorServiceInsufficientStock       = errors.New("not enough stock of consumable")
+	ErrorServiceConsumedRequired        = errors.New("consumed quantity is required for return of consumable")
+	ErrorServiceInvalidMinRemainder     = errors.New("min remainder must be non-negative and match remainder type")
+	ErrorServiceNotificationNotFound    = errors.New("notification not found")
+	ErrorServiceInvalidChannel          = errors.New("invalid notification channel")
+	ErrorServiceInvalidWebhookURL       = errors.New("webhook url must be absolute http or https url of public host")
+	ErrorServiceWebhookNotFound         = errors.New("webhook not found")
+	ErrorServiceWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrorServiceInvalidEventType        = errors.New("invalid webhook event type")
+	ErrorServiceRemainderFromStock      = errors.New("remainder of consumable is changed only by stock movements")
 )
@@ -0,0 +1,8 @@
+package moduleErrors
+
+import "errors"
+
+var (
+	ErrorStorageFileNotFound = errors.New("file not found")
+	ErrorStorageInvalidKey   = errors.New("invalid file key")
+)
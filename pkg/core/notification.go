@@ -0,0 +1,50 @@
+package core
+
+const (
+	NotificationUsageApproved      = "usage.approved"
+	NotificationUsageCancelled     = "usage.cancelled"
+	NotificationUsageOverdue       = "usage.overdue"
+	NotificationThingBlocked       = "thing.blocked"
+	NotificationInvitationReceived = "invitation.received"
+)
+
+const (
+	NotificationChannelEmail   = "email"
+	NotificationChannelWebhook = "webhook"
+)
+
+var NotificationChannels = []string{NotificationChannelEmail, NotificationChannelWebhook}
+
+// NotificationAdd is notification from service to user, ObjectId is id of usage, block or invitation
+type NotificationAdd struct {
+	UserId   int    `json:"user_id"`
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Body     string `json:"body"`
+	ObjectId *int   `json:"object_id,omitempty"`
+}
+
+type Notification struct {
+	NotificationAdd
+	Id          int    `json:"id"`
+	IsRead      bool   `json:"is_read"`
+	CreatedTime uint32 `json:"created_time"`
+}
+
+type NotificationUnreadCount struct {
+	Count int `json:"count"`
+}
+
+// NotificationPreference enables delivery channel of user, WebhookURL is required for enabled webhook channel
+type NotificationPreference struct {
+	Channel    string `json:"channel" binding:"required"`
+	Enabled    bool   `json:"enabled"`
+	WebhookURL string `json:"webhook_url,omitempty"`
+}
+
+// NotificationRecipient is user contacts for delivery channels
+type NotificationRecipient struct {
+	UserId     int
+	Email      string
+	WebhookURL string
+}
@@ -0,0 +1,27 @@
+package core
+
+type SessionAdd struct {
+	UserId int
+	// FamilyId is set for rotated refresh tokens, new family is created if nil
+	FamilyId         *string
+	RefreshTokenHash string
+	ExpiresTime      uint32
+}
+
+type Session struct {
+	Id          int
+	UserId      int
+	FamilyId    string
+	ExpiresTime uint32
+	UsedTime    uint32
+	RevokedTime uint32
+}
+
+type RefreshTokenData struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type TokenResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
@@ -0,0 +1,50 @@
+package core
+
+const (
+	StockMovementReceipt     = "receipt"
+	StockMovementConsumption = "consumption"
+	StockMovementAdjustment  = "adjustment"
+	StockMovementWriteOff    = "write_off"
+)
+
+var StockMovementTypes = []string{StockMovementReceipt, StockMovementConsumption, StockMovementAdjustment,
+	StockMovementWriteOff}
+
+// StockMovementAdd is movement of consumable stock. Quantity is positive amount for receipt, consumption and
+// write-off, for adjustment it is counted remainder of thing.
+type StockMovementAdd struct {
+	Type     string  `json:"type" binding:"required"`
+	Quantity float32 `json:"quantity"`
+	Comment  string  `json:"comment"`
+}
+
+// StockMovement is ledger record, Quantity is signed change of remainder and Remainder is remainder after movement
+type StockMovement struct {
+	Id          int     `json:"id"`
+	ThingId     int     `json:"thing_id"`
+	Type        string  `json:"type"`
+	Quantity    float32 `json:"quantity"`
+	Remainder   float32 `json:"remainder"`
+	UsageId     *int    `json:"usage_id,omitempty"`
+	UserId      *int    `json:"user_id,omitempty"`
+	Comment     string  `json:"comment"`
+	CreatedTime uint32  `json:"created_time"`
+}
+
+// UsageReturn is info of returned usage, Consumed is required for consumables
+type UsageReturn struct {
+	Consumed *float32 `json:"consumed"`
+}
+
+// StockAlert is raised when remainder of consumable falls below its threshold and resolved when stock is
+// replenished, remainder and threshold are current values of thing
+type StockAlert struct {
+	Id            int     `json:"id"`
+	ThingId       int     `json:"thing_id"`
+	ThingName     string  `json:"thing_name"`
+	DepartmentId  int     `json:"department_id"`
+	Remainder     float32 `json:"remainder"`
+	MinRemainder  float32 `json:"min_remainder"`
+	RemainderType string  `json:"remainder_type"`
+	CreatedTime   uint32  `json:"created_time"`
+}
@@ -1,11 +1,45 @@
 package core
 
+const (
+	ThingTypeEquipment   = "equipment"
+	ThingTypeConsumables = "consumables"
+)
+
+const (
+	RemainderTypePcs      = "pcs"
+	RemainderTypeCapacity = "capacity"
+	RemainderTypePercents = "percents"
+)
+
+const (
+	UsageStatusRequested = "requested"
+	UsageStatusApproved  = "approved"
+	UsageStatusTaken     = "taken"
+	UsageStatusReturned  = "returned"
+	UsageStatusCancelled = "cancelled"
+)
+
+var ThingTypes = []string{ThingTypeEquipment, ThingTypeConsumables}
+var RemainderTypes = []string{RemainderTypePcs, RemainderTypeCapacity, RemainderTypePercents}
+
 type ThingBase struct {
 	Name              string  `json:"name" binding:"required"`
 	Type              string  `json:"type" binding:"required"`
 	Remainder         float32 `json:"remainder"`
 	RemainderType     string  `json:"remainder_type"`
 	NeedAdminApproval bool    `json:"need_admin_approval"`
+	// MinRemainder is low stock threshold of consumable in units of remainder type, 0 disables alerts
+	MinRemainder float32 `json:"min_remainder"`
+}
+
+type ThingUpdate struct {
+	Name              *string  `json:"name,omitempty"`
+	Type              *string  `json:"type,omitempty"`
+	Remainder         *float32 `json:"remainder,omitempty"`
+	RemainderType     *string  `json:"remainder_type,omitempty"`
+	NeedAdminApproval *bool    `json:"need_admin_approval,omitempty"`
+	MinRemainder      *float32 `json:"min_remainder,omitempty"`
+	ImageURL          *string  `json:"-"`
 }
 
 type Thing struct {
@@ -17,7 +51,7 @@ type Thing struct {
 }
 
 type ThingActionBase struct {
-	UserId    int    `json:"user_id" binding:"required"`
+	UserId    int    `json:"user_id"`
 	ThingId   int    `json:"thing_id" binding:"required"`
 	StartTime uint32 `json:"start_time" binding:"required"`
 	EndTime   uint32 `json:"end_time"`
@@ -39,7 +73,13 @@ type ThingUsageAdd struct {
 
 type ThingUsage struct {
 	ThingUsageAdd
-	Id         int  `json:"id"`
-	IsApproved bool `json:"is_approved"`
-	IsTaken    bool `json:"is_taken"`
+	Id         int    `json:"id"`
+	IsApproved bool   `json:"is_approved"`
+	IsTaken    bool   `json:"is_taken"`
+	Status     string `json:"status"`
+	// IsOverdue is set by background job for taken usage which end time passed
+	IsOverdue bool `json:"is_overdue"`
+	// ApproverAway is set for requested usage when all admins and maintainers of thing department
+	// are on vacation, so usage must be approved by company admin
+	ApproverAway bool `json:"approver_away,omitempty"`
 }
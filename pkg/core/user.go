@@ -6,6 +6,13 @@ type UserBaseData struct {
 	Email     *string `json:"email" binding:"required"`
 }
 
+// UserUpdate is profile change of current user, changed email must be verified again
+type UserUpdate struct {
+	FirstName *string `json:"first_name,omitempty"`
+	LastName  *string `json:"last_name,omitempty"`
+	Email     *string `json:"email,omitempty"`
+}
+
 type User struct {
 	UserBaseData
 	EmailIsValidated *bool   `json:"email_is_validated"`
@@ -19,9 +26,55 @@ type UserDB struct {
 	User
 	PasswordHash         *string `json:"password_hash"`
 	EmailValidationToken *string `json:"email_validation_token"`
+	// EmailValidationSentTime is unix time of last verification email
+	EmailValidationSentTime uint32 `json:"-"`
+	// LockedTime is unix time of user lock by service admin, 0 if user isn't locked
+	LockedTime uint32 `json:"-"`
 }
 
 type AddUserDB struct {
 	UserBaseData
 	PasswordHash string `json:"password_hash"`
 }
+
+// sort orders of company users directory
+const (
+	UserSortName  = "name"
+	UserSortEmail = "email"
+	UserSortId    = "id"
+)
+
+// UserDirectoryFilter is query of company users directory. Company is taken from department
+// or from current user when it isn't set, Cursor is returned with previous page.
+type UserDirectoryFilter struct {
+	CompanyId    *int
+	DepartmentId *int
+	Role         *string
+	NamePrefix   string
+	Sort         string
+	Descending   bool
+	Cursor       string
+	Limit        int
+}
+
+// UserDirectoryCursor is position of the last user of page, it is valid only for the same sort order
+type UserDirectoryCursor struct {
+	Sort       string `json:"s"`
+	Descending bool   `json:"d,omitempty"`
+	SortKey    string `json:"k"`
+	Id         int    `json:"i"`
+}
+
+// UserDirectoryEntry is user with roles in company
+type UserDirectoryEntry struct {
+	User
+	Roles []Credentials `json:"roles"`
+	// SortKey is value of sort column for cursor
+	SortKey string `json:"-"`
+}
+
+type UserDirectoryPage struct {
+	Users      []UserDirectoryEntry `json:"users"`
+	Total      int                  `json:"total"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,20 @@
+package core
+
+type VacationAdd struct {
+	StartTime uint32 `json:"start_time" binding:"required"`
+	EndTime   uint32 `json:"end_time" binding:"required"`
+	Comment   string `json:"comment"`
+}
+
+type Vacation struct {
+	VacationAdd
+	Id     int `json:"id"`
+	UserId int `json:"user_id"`
+}
+
+// DepartmentVacation is vacation of department member in department calendar
+type DepartmentVacation struct {
+	Vacation
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
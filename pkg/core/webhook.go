@@ -0,0 +1,97 @@
+package core
+
+import "encoding/json"
+
+const (
+	WebhookEventUsageApproved  = "usage.approved"
+	WebhookEventUsageCancelled = "usage.cancelled"
+	WebhookEventUsageTaken     = "usage.taken"
+	WebhookEventUsageReturned  = "usage.returned"
+	WebhookEventUsageOverdue   = "usage.overdue"
+	WebhookEventThingBlocked   = "thing.blocked"
+	WebhookEventUserJoined     = "user.joined"
+)
+
+var WebhookEventTypes = []string{WebhookEventUsageApproved, WebhookEventUsageCancelled, WebhookEventUsageTaken,
+	WebhookEventUsageReturned, WebhookEventUsageOverdue, WebhookEventThingBlocked, WebhookEventUserJoined}
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// WebhookAdd is subscription of company to events, secret is generated if it isn't set
+type WebhookAdd struct {
+	URL        string   `json:"url" binding:"required"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types" binding:"required"`
+}
+
+// WebhookUpdate changes set fields of webhook, empty Secret generates new secret
+type WebhookUpdate struct {
+	URL        *string  `json:"url,omitempty"`
+	Secret     *string  `json:"secret,omitempty"`
+	EventTypes []string `json:"event_types,omitempty"`
+	IsActive   *bool    `json:"is_active,omitempty"`
+}
+
+// Webhook is subscription of company, Secret is returned only when it is set
+type Webhook struct {
+	Id          int      `json:"id"`
+	CompanyId   int      `json:"company_id"`
+	URL         string   `json:"url"`
+	Secret      string   `json:"secret,omitempty"`
+	EventTypes  []string `json:"event_types"`
+	IsActive    bool     `json:"is_active"`
+	CreatedTime uint32   `json:"created_time"`
+}
+
+// WebhookEvent is body of webhook request
+type WebhookEvent struct {
+	Type        string      `json:"type"`
+	CompanyId   int         `json:"company_id"`
+	CreatedTime uint32      `json:"created_time"`
+	Data        interface{} `json:"data"`
+}
+
+// WebhookUserJoined is data of user.joined event
+type WebhookUserJoined struct {
+	UserId       int `json:"user_id"`
+	CompanyId    int `json:"company_id"`
+	DepartmentId int `json:"department_id"`
+}
+
+// WebhookDelivery is event queued for webhook with result of last attempt
+type WebhookDelivery struct {
+	Id              int             `json:"id"`
+	WebhookId       int             `json:"webhook_id"`
+	EventType       string          `json:"event_type"`
+	Payload         json.RawMessage `json:"payload"`
+	Status          string          `json:"status"`
+	Attempts        int             `json:"attempts"`
+	LastStatusCode  *int            `json:"last_status_code,omitempty"`
+	LastError       string          `json:"last_error,omitempty"`
+	NextAttemptTime uint32          `json:"next_attempt_time"`
+	DeliveredTime   uint32          `json:"delivered_time,omitempty"`
+	CreatedTime     uint32          `json:"created_time"`
+}
+
+// WebhookDispatch is pending delivery with address and secret of its webhook
+type WebhookDispatch struct {
+	DeliveryId int
+	EventType  string
+	Payload    []byte
+	Attempts   int
+	URL        string
+	Secret     string
+}
+
+// WebhookDeliveryResult is result of delivery attempt, StatusCode is 0 if response wasn't received
+type WebhookDeliveryResult struct {
+	Status     string
+	StatusCode int
+	Error      string
+	// RetryAfter is delay of next attempt in seconds for pending delivery
+	RetryAfter int
+}
@@ -1,6 +1,10 @@
 package generateToken
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"github.com/Thing-repository/backend-server/pkg/core"
 	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
 	"github.com/golang-jwt/jwt"
@@ -9,21 +13,27 @@ import (
 )
 
 const (
-	tokenTTL = time.Hour * 24 * 30
+	// access token is short-lived, client gets new one by refresh token
+	tokenTTL = time.Minute * 15
+
+	randomTokenLength = 32
 )
 
 type tokenClaims struct {
 	jwt.StandardClaims
 	UserId      int                `json:"user_id"`
-	Credentials []core.Credentials `json:"credentials"`
+	Credentials []core.Credentials `json:"credentials,omitempty"`
 }
 
 type token struct {
 	signingKey []byte
+	// embedCredentials puts credentials to claims, without it token carries only user id
+	// and credentials are loaded from database on each request
+	embedCredentials bool
 }
 
-func NewToken(signingKey []byte) *token {
-	return &token{signingKey: signingKey}
+func NewToken(signingKey []byte, embedCredentials bool) *token {
+	return &token{signingKey: signingKey, embedCredentials: embedCredentials}
 }
 
 func (t *token) GenerateToken(userId int, credentials []core.Credentials) (string, error) {
@@ -32,6 +42,10 @@ func (t *token) GenerateToken(userId int, credentials []core.Credentials) (strin
 		"function": "GenerateToken",
 	}
 
+	if !t.embedCredentials {
+		credentials = nil
+	}
+
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &tokenClaims{
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: time.Now().Add(tokenTTL).Unix(),
@@ -97,3 +111,22 @@ func (t *token) ValidateToken(token string) (int, []core.Credentials, error) {
 
 	return claims.UserId, claims.Credentials, nil
 }
+
+// GenerateRandomToken returns opaque random token (refresh or email token), only its hash is stored in database
+func (t *token) GenerateRandomToken() (string, error) {
+	buf := make([]byte, randomTokenLength)
+	if _, err := rand.Read(buf); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"module":   "generateToken",
+			"function": "GenerateRandomToken",
+			"error":    err,
+		}).Error("error generate random token")
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func (t *token) HashRandomToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
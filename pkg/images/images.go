@@ -0,0 +1,161 @@
+package images
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+const (
+	ContentTypeJPEG = "image/jpeg"
+	ContentTypePNG  = "image/png"
+	ContentTypeGIF  = "image/gif"
+
+	// maxPixels limits decoded image size, small files can declare huge dimensions
+	maxPixels   = 40 * 1000 * 1000
+	jpegQuality = 85
+)
+
+var (
+	ErrorUnsupportedType = errors.New("unsupported image type")
+	ErrorInvalidImage    = errors.New("file is not a valid image")
+	ErrorImageTooLarge   = errors.New("image dimensions are too large")
+)
+
+// extensions of supported content types
+var extensions = map[string]string{
+	ContentTypeJPEG: ".jpg",
+	ContentTypePNG:  ".png",
+	ContentTypeGIF:  ".gif",
+}
+
+// Supported reports whether content type is one of supported image types
+func Supported(contentType string) bool {
+	_, ok := extensions[contentType]
+	return ok
+}
+
+// Image is decoded upload with content type detected from its data
+type Image struct {
+	image.Image
+	ContentType string
+}
+
+// Extension returns file extension of image content type
+func (I *Image) Extension() string {
+	return extensions[I.ContentType]
+}
+
+// Decode checks content type by data signature and decodes image, declared content type of upload isn't trusted
+func Decode(data []byte) (*Image, error) {
+	contentType := http.DetectContentType(data)
+	if !Supported(contentType) {
+		return nil, ErrorUnsupportedType
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrorInvalidImage
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, ErrorInvalidImage
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, ErrorImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrorInvalidImage
+	}
+
+	return &Image{Image: img, ContentType: contentType}, nil
+}
+
+// Thumbnail scales image down to fit into size x size square keeping proportions,
+// smaller images are returned unchanged. Pixels are averaged over covered area of source.
+func Thumbnail(img *Image, size int) *Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return img
+	}
+
+	newWidth, newHeight := size, size
+	if width > height {
+		newHeight = maxInt(1, height*size/width)
+	} else {
+		newWidth = maxInt(1, width*size/height)
+	}
+
+	dst := image.NewRGBA64(image.Rect(0, 0, newWidth, newHeight))
+	for y := 0; y < newHeight; y++ {
+		srcY0 := bounds.Min.Y + y*height/newHeight
+		srcY1 := maxInt(srcY0+1, bounds.Min.Y+(y+1)*height/newHeight)
+		for x := 0; x < newWidth; x++ {
+			srcX0 := bounds.Min.X + x*width/newWidth
+			srcX1 := maxInt(srcX0+1, bounds.Min.X+(x+1)*width/newWidth)
+
+			var r, g, b, a, count uint64
+			for sy := srcY0; sy < srcY1; sy++ {
+				for sx := srcX0; sx < srcX1; sx++ {
+					// colors are alpha premultiplied, so transparent pixels don't change color
+					cr, cg, cb, ca := img.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					b += uint64(cb)
+					a += uint64(ca)
+					count++
+				}
+			}
+			dst.SetRGBA64(x, y, color.RGBA64{
+				R: uint16(r / count),
+				G: uint16(g / count),
+				B: uint16(b / count),
+				A: uint16(a / count),
+			})
+		}
+	}
+
+	contentType := img.ContentType
+	// animation isn't kept in thumbnail
+	if contentType == ContentTypeGIF {
+		contentType = ContentTypePNG
+	}
+
+	return &Image{Image: dst, ContentType: contentType}
+}
+
+// Encode encodes image in its content type
+func Encode(img *Image) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+
+	switch img.ContentType {
+	case ContentTypeJPEG:
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	case ContentTypePNG:
+		err = png.Encode(&buf, img)
+	case ContentTypeGIF:
+		err = gif.Encode(&buf, img, nil)
+	default:
+		return nil, ErrorUnsupportedType
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,75 @@
+package images
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func testPNG(t *testing.T, width int, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("error encode test image: %s", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecode(t *testing.T) {
+	valid := testPNG(t, 4, 2)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{name: "PNG", data: valid},
+		{name: "Text", data: []byte("hello, this is not an image"), wantErr: ErrorUnsupportedType},
+		{name: "Broken PNG", data: valid[:len(valid)/2], wantErr: ErrorInvalidImage},
+		{name: "Too large", data: testPNG(t, 8000, 6000), wantErr: ErrorImageTooLarge},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			img, err := Decode(test.data)
+			if err != test.wantErr {
+				t.Fatalf("error = %v, want %v", err, test.wantErr)
+			}
+			if err == nil && img.ContentType != ContentTypePNG {
+				t.Errorf("content type = %s, want %s", img.ContentType, ContentTypePNG)
+			}
+		})
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	img, err := Decode(testPNG(t, 300, 100))
+	if err != nil {
+		t.Fatalf("error decode: %s", err)
+	}
+
+	thumbnail := Thumbnail(img, 60)
+	if size := thumbnail.Bounds().Size(); size != image.Pt(60, 20) {
+		t.Errorf("thumbnail size = %v, want (60,20)", size)
+	}
+	r, g, b, a := thumbnail.At(10, 10).RGBA()
+	if r>>8 != 200 || g>>8 != 100 || b>>8 != 50 || a>>8 != 255 {
+		t.Errorf("thumbnail color = %d %d %d %d, want 200 100 50 255", r>>8, g>>8, b>>8, a>>8)
+	}
+
+	if _, err = Encode(thumbnail); err != nil {
+		t.Errorf("error encode thumbnail: %s", err)
+	}
+
+	if small := Thumbnail(img, 500); small != img {
+		t.Error("small image is scaled")
+	}
+}
@@ -0,0 +1,60 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/sirupsen/logrus"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Log doesn't send emails, it writes them to log and to files in dir if dir is set.
+// It's used for local development and tests.
+type Log struct {
+	dir string
+}
+
+func NewLog(dir string) *Log {
+	return &Log{dir: dir}
+}
+
+func (l *Log) Send(_ context.Context, message *core.MailMessage) error {
+	logBase := logrus.Fields{
+		"module":   "mailer",
+		"function": "Send",
+		"to":       message.To,
+		"subject":  message.Subject,
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"base": logBase,
+		"body": message.Body,
+	}).Info("email sent to log")
+
+	if l.dir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(l.dir, 0o755); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error("error create mail dir")
+		return err
+	}
+
+	name := filepath.Join(l.dir, fmt.Sprintf("%d_%s.eml", time.Now().UnixNano(), filepath.Base(message.To)))
+	content := fmt.Sprintf("To: %s\nSubject: %s\n\n%s\n", message.To, message.Subject, message.Body)
+
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error("error write mail file")
+		return err
+	}
+
+	return nil
+}
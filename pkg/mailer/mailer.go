@@ -0,0 +1,11 @@
+package mailer
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/core"
+)
+
+// Mailer sends emails to users
+type Mailer interface {
+	Send(ctx context.Context, message *core.MailMessage) error
+}
@@ -0,0 +1,67 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/sirupsen/logrus"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type SMTP struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+func NewSMTP(cfg SMTPConfig) *SMTP {
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return &SMTP{
+		addr: net.JoinHostPort(cfg.Host, cfg.Port),
+		auth: auth,
+		from: cfg.From,
+	}
+}
+
+func (s *SMTP) Send(_ context.Context, message *core.MailMessage) error {
+	logBase := logrus.Fields{
+		"module":   "mailer",
+		"function": "Send",
+		"to":       message.To,
+		"subject":  message.Subject,
+	}
+
+	if strings.ContainsAny(message.To, "\r\n") || strings.ContainsAny(message.Subject, "\r\n") {
+		logrus.WithFields(logrus.Fields{
+			"base": logBase,
+		}).Error("invalid message headers")
+		return fmt.Errorf("invalid message headers")
+	}
+
+	body := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n"+
+		"MIME-Version: 1.0\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\n%s",
+		s.from, message.To, message.Subject, message.Body)
+
+	if err := smtp.SendMail(s.addr, s.auth, s.from, []string{message.To}, []byte(body)); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"base":  logBase,
+			"error": err,
+		}).Error("error send email")
+		return err
+	}
+
+	return nil
+}
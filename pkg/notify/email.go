@@ -0,0 +1,28 @@
+package notify
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/mailer"
+)
+
+// Email delivers notifications by mailer to user email
+type Email struct {
+	mailer mailer.Mailer
+}
+
+func NewEmail(mailer mailer.Mailer) *Email {
+	return &Email{mailer: mailer}
+}
+
+func (e *Email) Name() string {
+	return core.NotificationChannelEmail
+}
+
+func (e *Email) Deliver(ctx context.Context, recipient *core.NotificationRecipient, notification *core.Notification) error {
+	return e.mailer.Send(ctx, &core.MailMessage{
+		To:      recipient.Email,
+		Subject: notification.Title,
+		Body:    notification.Body,
+	})
+}
@@ -0,0 +1,13 @@
+package notify
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/core"
+)
+
+// Channel delivers notification to user outside of in-app inbox
+type Channel interface {
+	// Name is one of core.NotificationChannels
+	Name() string
+	Deliver(ctx context.Context, recipient *core.NotificationRecipient, notification *core.Notification) error
+}
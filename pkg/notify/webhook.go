@@ -0,0 +1,53 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/utils"
+	"net/http"
+	"time"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// Webhook delivers notifications as json POST request to webhook url of user,
+// url is set by user, so requests are sent only to public addresses
+type Webhook struct {
+	client *http.Client
+}
+
+func NewWebhook() *Webhook {
+	return &Webhook{client: utils.NewPublicHTTPClient(webhookTimeout)}
+}
+
+func (w *Webhook) Name() string {
+	return core.NotificationChannelWebhook
+}
+
+func (w *Webhook) Deliver(ctx context.Context, recipient *core.NotificationRecipient, notification *core.Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, recipient.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookDeliver(t *testing.T) {
+	notification := &core.Notification{
+		NotificationAdd: core.NotificationAdd{
+			UserId: 1,
+			Type:   core.NotificationUsageApproved,
+			Title:  "Usage approved",
+			Body:   "Your booking of drill is approved",
+		},
+		Id: 7,
+	}
+
+	testTable := []struct {
+		name       string
+		statusCode int
+		wantError  bool
+	}{
+		{name: "Ok", statusCode: http.StatusNoContent},
+		{name: "Receiver error", statusCode: http.StatusInternalServerError, wantError: true},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			var received core.Notification
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+					t.Errorf("request = %s %s, want json POST", r.Method, r.Header.Get("Content-Type"))
+				}
+				if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+					t.Errorf("error decode body: %s", err)
+				}
+				w.WriteHeader(testCase.statusCode)
+			}))
+			defer server.Close()
+
+			// test receiver listens on loopback address, which is refused by client of NewWebhook
+			webhook := &Webhook{client: server.Client()}
+			err := webhook.Deliver(context.Background(), &core.NotificationRecipient{WebhookURL: server.URL},
+				notification)
+			if (err != nil) != testCase.wantError {
+				t.Fatalf("error = %v, want error %t", err, testCase.wantError)
+			}
+			if received != *notification {
+				t.Errorf("received = %+v, want %+v", received, *notification)
+			}
+		})
+	}
+}
+
+func TestWebhookDeliverInternalAddress(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	err := NewWebhook().Deliver(context.Background(), &core.NotificationRecipient{WebhookURL: server.URL},
+		&core.Notification{Id: 1})
+	if err == nil || requests != 0 {
+		t.Errorf("webhook on loopback address is delivered, error = %v, requests = %d", err, requests)
+	}
+}
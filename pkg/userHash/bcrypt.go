@@ -0,0 +1,51 @@
+package userHash
+
+import (
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+)
+
+// Bcrypt stores hashes in modular crypt format: $2a$<cost>$<salt and hash>
+type Bcrypt struct {
+	cost int
+}
+
+func NewBcrypt(cost int) *Bcrypt {
+	if cost < bcrypt.MinCost {
+		cost = bcrypt.DefaultCost
+	}
+	return &Bcrypt{cost: cost}
+}
+
+func (b *Bcrypt) GenerateHash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (b *Bcrypt) ValidateHash(hash string, password string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	switch err {
+	case nil:
+		return nil
+	case bcrypt.ErrMismatchedHashAndPassword:
+		return moduleErrors.ErrorHashValidationPassword
+	default:
+		return moduleErrors.ErrorHashUnknownFormat
+	}
+}
+
+func (b *Bcrypt) Match(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+func (b *Bcrypt) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return true
+	}
+	return cost != b.cost
+}
@@ -1,53 +1,68 @@
 package userHash
 
 import (
-	"crypto/sha1"
-	"fmt"
 	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
 	"github.com/sirupsen/logrus"
 )
 
+// Hasher generates and validates password hashes of one algorithm
+type Hasher interface {
+	GenerateHash(password string) (string, error)
+	ValidateHash(hash string, password string) error
+	// Match reports whether hash was generated by this algorithm
+	Match(hash string) bool
+	// NeedsRehash reports whether hash was generated with outdated parameters
+	NeedsRehash(hash string) bool
+}
+
+// Hash generates new hashes with current hasher and validates hashes
+// of current and legacy hashers, so users can sign in with old hashes until rehash
 type Hash struct {
-	salt string
+	current Hasher
+	legacy  []Hasher
 }
 
-func NewHash(salt string) *Hash {
-	return &Hash{salt: salt}
+func NewHash(current Hasher, legacy ...Hasher) *Hash {
+	return &Hash{current: current, legacy: legacy}
 }
 
 func (h *Hash) GenerateHash(password string) (string, error) {
+	return h.current.GenerateHash(password)
+}
+
+func (h *Hash) ValidateHash(hash string, password string) error {
 	logBase := logrus.Fields{
 		"module":   "userHash",
-		"function": "GenerateHash",
+		"function": "ValidateHash",
 	}
-	hash := sha1.New()
 
-	if _, err := hash.Write([]byte(password)); err != nil {
+	hasher := h.hasher(hash)
+	if hasher == nil {
 		logrus.WithFields(logrus.Fields{
-			"base":  logBase,
-			"error": err,
-		}).Error("error generate hash")
-		return "", err
+			"base": logBase,
+		}).Error("unknown hash format")
+		return moduleErrors.ErrorHashUnknownFormat
 	}
-	return fmt.Sprintf("%x", hash.Sum([]byte(h.salt))), nil
+
+	return hasher.ValidateHash(hash, password)
 }
 
-func (h *Hash) ValidateHash(hash string, password string) error {
-	logBase := logrus.Fields{
-		"module":   "userHash",
-		"function": "ValidateHash",
+// NeedsRehash reports whether hash must be replaced with hash of current hasher
+func (h *Hash) NeedsRehash(hash string) bool {
+	if !h.current.Match(hash) {
+		return true
 	}
-	calculatedHash, err := h.GenerateHash(password)
-	if err != nil {
-		return err
+	return h.current.NeedsRehash(hash)
+}
+
+func (h *Hash) hasher(hash string) Hasher {
+	if h.current.Match(hash) {
+		return h.current
 	}
-	if hash != calculatedHash {
-		logrus.WithFields(logrus.Fields{
-			"base":           logBase,
-			"calculatedHash": calculatedHash,
-			"hash":           hash,
-		}).Warning("hashes doesn't match'")
-		return moduleErrors.ErrorHashValidationPassword
+	for _, hasher := range h.legacy {
+		if hasher.Match(hash) {
+			return hasher
+		}
 	}
 	return nil
 }
@@ -0,0 +1,150 @@
+package userHash
+
+import (
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"testing"
+)
+
+const (
+	testPassword = "correct horse battery staple"
+	testSalt     = "salt"
+	// testLegacyHash is hex of "salt" followed by sha1 of "password"
+	testLegacyHash = "73616c74" + "5baa61e4c9b93f3f0682250b6cf8331b7ee68fd8"
+)
+
+// testArgon2idParams are cheap parameters, so tests don't spend time and memory on hashing
+var testArgon2idParams = Argon2idParams{
+	Memory:      1024,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func TestHashRoundTrip(t *testing.T) {
+	testTable := []struct {
+		name   string
+		hasher Hasher
+	}{
+		{name: "argon2id", hasher: NewArgon2id(testArgon2idParams)},
+		{name: "bcrypt", hasher: NewBcrypt(4)},
+		{name: "legacy sha1", hasher: NewLegacySHA1(testSalt)},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			hash, err := testCase.hasher.GenerateHash(testPassword)
+			if err != nil {
+				t.Fatalf("error generate hash: %s", err)
+			}
+			if !testCase.hasher.Match(hash) {
+				t.Errorf("hasher doesn't match own hash %s", hash)
+			}
+			if err = testCase.hasher.ValidateHash(hash, testPassword); err != nil {
+				t.Errorf("error validate correct password: %s", err)
+			}
+			if err = testCase.hasher.ValidateHash(hash, "wrong"); err != moduleErrors.ErrorHashValidationPassword {
+				t.Errorf("error = %v, want %v", err, moduleErrors.ErrorHashValidationPassword)
+			}
+		})
+	}
+}
+
+func TestHashSaltIsRandom(t *testing.T) {
+	for _, hasher := range []Hasher{NewArgon2id(testArgon2idParams), NewBcrypt(4)} {
+		first, _ := hasher.GenerateHash(testPassword)
+		second, _ := hasher.GenerateHash(testPassword)
+		if first == second {
+			t.Errorf("hashes of same password are equal: %s", first)
+		}
+	}
+}
+
+func TestValidateMalformedHash(t *testing.T) {
+	argon2id := NewArgon2id(testArgon2idParams)
+	bcrypt := NewBcrypt(4)
+
+	testTable := []struct {
+		name   string
+		hasher interface {
+			ValidateHash(hash string, password string) error
+		}
+		hash string
+	}{
+		{name: "argon2id parts", hasher: argon2id, hash: "$argon2id$v=19$m=1024,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA"},
+		{name: "argon2id other algorithm", hasher: argon2id,
+			hash: "$argon2i$v=19$m=1024,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5"},
+		{name: "argon2id version", hasher: argon2id,
+			hash: "$argon2id$v=16$m=1024,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5"},
+		{name: "argon2id params", hasher: argon2id, hash: "$argon2id$v=19$m=x,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5"},
+		{name: "argon2id salt", hasher: argon2id, hash: "$argon2id$v=19$m=1024,t=1,p=1$!!!$a2V5"},
+		{name: "argon2id key", hasher: argon2id, hash: "$argon2id$v=19$m=1024,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$!!!"},
+		{name: "bcrypt short", hasher: bcrypt, hash: "$2a$10$short"},
+		{name: "bcrypt cost", hasher: bcrypt, hash: "$2a$xx$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy"},
+		{name: "unknown format", hasher: NewHash(argon2id, bcrypt, NewLegacySHA1(testSalt)), hash: "plain password"},
+		{name: "legacy without salt", hasher: NewHash(argon2id, bcrypt), hash: testLegacyHash},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.hasher.ValidateHash(testCase.hash, testPassword)
+			if err != moduleErrors.ErrorHashUnknownFormat {
+				t.Errorf("error = %v, want %v", err, moduleErrors.ErrorHashUnknownFormat)
+			}
+		})
+	}
+}
+
+func TestLegacySHA1(t *testing.T) {
+	hash := NewHash(NewArgon2id(testArgon2idParams), NewBcrypt(4), NewLegacySHA1(testSalt))
+
+	if err := hash.ValidateHash(testLegacyHash, "password"); err != nil {
+		t.Errorf("error validate legacy hash: %s", err)
+	}
+	if err := hash.ValidateHash(testLegacyHash, "Password"); err != moduleErrors.ErrorHashValidationPassword {
+		t.Errorf("error = %v, want %v", err, moduleErrors.ErrorHashValidationPassword)
+	}
+	if !hash.NeedsRehash(testLegacyHash) {
+		t.Errorf("legacy hash doesn't need rehash")
+	}
+
+	// other salt is other hash format
+	if NewLegacySHA1("pepper").Match(testLegacyHash) {
+		t.Errorf("legacy hasher matches hash with other salt")
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	argon2id := NewArgon2id(testArgon2idParams)
+	bcrypt := NewBcrypt(4)
+
+	weakerParams := testArgon2idParams
+	weakerParams.Iterations = 2
+	oldArgon2idHash, _ := NewArgon2id(weakerParams).GenerateHash(testPassword)
+	argon2idHash, _ := argon2id.GenerateHash(testPassword)
+	bcryptHash, _ := bcrypt.GenerateHash(testPassword)
+	oldBcryptHash, _ := NewBcrypt(5).GenerateHash(testPassword)
+
+	testTable := []struct {
+		name string
+		hash *Hash
+		in   string
+		want bool
+	}{
+		{name: "argon2id current", hash: NewHash(argon2id, bcrypt), in: argon2idHash, want: false},
+		{name: "argon2id other params", hash: NewHash(argon2id, bcrypt), in: oldArgon2idHash, want: true},
+		{name: "argon2id malformed", hash: NewHash(argon2id, bcrypt), in: "$argon2id$broken", want: true},
+		{name: "bcrypt legacy", hash: NewHash(argon2id, bcrypt), in: bcryptHash, want: true},
+		{name: "bcrypt current", hash: NewHash(bcrypt, argon2id), in: bcryptHash, want: false},
+		{name: "bcrypt other cost", hash: NewHash(bcrypt, argon2id), in: oldBcryptHash, want: true},
+		{name: "argon2id legacy", hash: NewHash(bcrypt, argon2id), in: argon2idHash, want: true},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := testCase.hash.NeedsRehash(testCase.in); got != testCase.want {
+				t.Errorf("NeedsRehash = %t, want %t", got, testCase.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package userHash
+
+import (
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/hex"
+	"github.com/Thing-repository/backend-server/pkg/core/moduleErrors"
+	"strings"
+)
+
+// LegacySHA1 validates hashes generated by first version of service:
+// hex of global salt bytes followed by hex of unsalted sha1 of password.
+// It's used only for validation before rehash on sign in.
+type LegacySHA1 struct {
+	saltHex string
+}
+
+func NewLegacySHA1(salt string) *LegacySHA1 {
+	return &LegacySHA1{saltHex: hex.EncodeToString([]byte(salt))}
+}
+
+func (l *LegacySHA1) GenerateHash(password string) (string, error) {
+	sum := sha1.Sum([]byte(password))
+	return l.saltHex + hex.EncodeToString(sum[:]), nil
+}
+
+func (l *LegacySHA1) ValidateHash(hash string, password string) error {
+	calculatedHash, err := l.GenerateHash(password)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(calculatedHash)) != 1 {
+		return moduleErrors.ErrorHashValidationPassword
+	}
+	return nil
+}
+
+func (l *LegacySHA1) Match(hash string) bool {
+	return len(hash) == len(l.saltHex)+sha1.Size*2 && strings.HasPrefix(hash, l.saltHex)
+}
+
+func (l *LegacySHA1) NeedsRehash(_ string) bool {
+	return true
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	testTable := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "93.184.216.34", want: true},
+		{ip: "2606:2800:220:1:248:1893:25c8:1946", want: true},
+		{ip: "127.0.0.1", want: false},
+		{ip: "::1", want: false},
+		{ip: "10.1.2.3", want: false},
+		{ip: "172.16.0.1", want: false},
+		{ip: "192.168.1.1", want: false},
+		{ip: "169.254.169.254", want: false},
+		{ip: "fe80::1", want: false},
+		{ip: "fd00::1", want: false},
+		{ip: "100.64.0.1", want: false},
+		{ip: "0.0.0.0", want: false},
+		{ip: "::ffff:127.0.0.1", want: false},
+		{ip: "::ffff:10.0.0.1", want: false},
+		{ip: "224.0.0.1", want: false},
+		{ip: "255.255.255.255", want: false},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.ip, func(t *testing.T) {
+			if got := IsPublicIP(net.ParseIP(testCase.ip)); got != testCase.want {
+				t.Errorf("IsPublicIP(%s) = %t, want %t", testCase.ip, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestPublicHTTPClient(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	// httptest server listens on loopback address
+	_, err := NewPublicHTTPClient(time.Second).Post(server.URL, "application/json", nil)
+	if !errors.Is(err, ErrorNotPublicAddress) {
+		t.Errorf("error = %v, want %v", err, ErrorNotPublicAddress)
+	}
+	if requests != 0 {
+		t.Errorf("server received %d requests", requests)
+	}
+}
+
+func TestPublicHTTPClientRedirect(t *testing.T) {
+	// redirect check doesn't depend on transport, so test client dials loopback receiver
+	client := NewPublicHTTPClient(time.Second)
+	client.Transport = http.DefaultTransport
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusFound)
+	}))
+	defer server.Close()
+
+	_, err := client.Post(server.URL, "application/json", nil)
+	if !errors.Is(err, ErrorRedirect) {
+		t.Errorf("error = %v, want %v", err, ErrorRedirect)
+	}
+}
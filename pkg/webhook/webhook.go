@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"github.com/Thing-repository/backend-server/pkg/utils"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	sendTimeout = 10 * time.Second
+	// maxErrorBodySize is part of response body of failed delivery which is kept in delivery log
+	maxErrorBodySize = 512
+)
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Sign returns signature of webhook request, it is hex HMAC-SHA256 of "timestamp.body" with webhook secret.
+// Receiver checks it and rejects old timestamps, so request can't be replayed.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sender sends signed event payloads to webhooks of companies
+type Sender struct {
+	client *http.Client
+}
+
+// NewSender returns sender which connects only to public addresses without following redirects,
+// because webhook urls are set by companies
+func NewSender() *Sender {
+	return &Sender{client: utils.NewPublicHTTPClient(sendTimeout)}
+}
+
+// Send posts payload of delivery to webhook url, status code is 0 if response wasn't received.
+// Response without 2xx status is error.
+func (s *Sender) Send(ctx context.Context, dispatch *core.WebhookDispatch) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dispatch.URL, bytes.NewReader(dispatch.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, dispatch.EventType)
+	req.Header.Set(HeaderDelivery, strconv.Itoa(dispatch.DeliveryId))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(dispatch.Secret, timestamp, dispatch.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d: %s", resp.StatusCode, body)
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"context"
+	"github.com/Thing-repository/backend-server/pkg/core"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestSend(t *testing.T) {
+	const secret = "test-secret"
+	payload := []byte(`{"type":"usage.approved","company_id":1,"created_time":1700000000,"data":{"id":3}}`)
+
+	testTable := []struct {
+		name           string
+		statusCode     int
+		wantStatusCode int
+		wantError      bool
+	}{
+		{name: "Ok", statusCode: http.StatusOK, wantStatusCode: http.StatusOK},
+		{name: "Receiver error", statusCode: http.StatusServiceUnavailable,
+			wantStatusCode: http.StatusServiceUnavailable, wantError: true},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Errorf("error read body: %s", err)
+				}
+				if string(body) != string(payload) {
+					t.Errorf("body = %s, want %s", body, payload)
+				}
+				if r.Header.Get(HeaderEvent) != core.WebhookEventUsageApproved || r.Header.Get(HeaderDelivery) != "5" {
+					t.Errorf("event = %s, delivery = %s", r.Header.Get(HeaderEvent), r.Header.Get(HeaderDelivery))
+				}
+
+				// receiver verifies signature with shared secret
+				timestamp, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+				if err != nil {
+					t.Errorf("invalid timestamp: %s", err)
+				}
+				if r.Header.Get(HeaderSignature) != Sign(secret, timestamp, body) {
+					t.Errorf("signature = %s, want %s", r.Header.Get(HeaderSignature), Sign(secret, timestamp, body))
+				}
+
+				w.WriteHeader(testCase.statusCode)
+			}))
+			defer server.Close()
+
+			// test server listens on loopback address, which isn't allowed by client of NewSender
+			sender := &Sender{client: server.Client()}
+			statusCode, err := sender.Send(context.Background(), &core.WebhookDispatch{
+				DeliveryId: 5,
+				EventType:  core.WebhookEventUsageApproved,
+				Payload:    payload,
+				URL:        server.URL,
+				Secret:     secret,
+			})
+			if (err != nil) != testCase.wantError {
+				t.Fatalf("error = %v, want error %t", err, testCase.wantError)
+			}
+			if statusCode != testCase.wantStatusCode {
+				t.Errorf("status code = %d, want %d", statusCode, testCase.wantStatusCode)
+			}
+		})
+	}
+}
+
+func TestSendInternalAddress(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	statusCode, err := NewSender().Send(context.Background(), &core.WebhookDispatch{
+		DeliveryId: 5,
+		EventType:  core.WebhookEventUsageApproved,
+		Payload:    []byte(`{}`),
+		URL:        server.URL,
+		Secret:     "test-secret",
+	})
+	if err == nil || statusCode != 0 || requests != 0 {
+		t.Errorf("delivery to loopback address is sent, error = %v, requests = %d", err, requests)
+	}
+}
+
+func TestSign(t *testing.T) {
+	signature := Sign("secret", 1700000000, []byte(`{}`))
+	if signature != Sign("secret", 1700000000, []byte(`{}`)) {
+		t.Errorf("signature isn't stable")
+	}
+	if signature == Sign("other", 1700000000, []byte(`{}`)) {
+		t.Errorf("signature doesn't depend on secret")
+	}
+	if signature == Sign("secret", 1700000001, []byte(`{}`)) {
+		t.Errorf("signature doesn't depend on timestamp")
+	}
+}
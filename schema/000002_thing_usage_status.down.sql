@@ -0,0 +1,4 @@
+DROP INDEX using_things_thing_id_status_idx;
+ALTER TABLE using_things
+    DROP COLUMN status;
+DROP TYPE usage_statuses;
@@ -0,0 +1,13 @@
+CREATE TYPE usage_statuses as enum ('requested', 'approved', 'taken', 'returned', 'cancelled');
+
+ALTER TABLE using_things
+    ADD COLUMN status usage_statuses default 'requested' not null;
+
+UPDATE using_things
+SET status = CASE
+                 WHEN is_taken THEN 'taken'::usage_statuses
+                 WHEN is_approve THEN 'approved'::usage_statuses
+                 ELSE 'requested'::usage_statuses
+    END;
+
+CREATE INDEX using_things_thing_id_status_idx ON using_things (thing_id, status);
@@ -0,0 +1,2 @@
+DROP TABLE usage_cancellations;
+DROP INDEX blocking_things_thing_id_idx;
@@ -0,0 +1,11 @@
+CREATE INDEX blocking_things_thing_id_idx ON blocking_things (thing_id);
+
+CREATE TABLE usage_cancellations
+(
+    id           serial primary key,
+    usage_id     int references using_things (id) on delete cascade    not null,
+    user_id      int references users (id) on delete cascade           not null,
+    block_id     int references blocking_things (id) on delete set null,
+    reason       varchar(255),
+    created_time timestamp default (now() at time zone 'utc')          not null
+);
@@ -0,0 +1,14 @@
+CREATE TABLE sessions
+(
+    id                 serial primary key,
+    user_id            int references users (id) on delete cascade not null,
+    family_id          uuid                                        not null,
+    refresh_token_hash varchar(64)                                 not null unique,
+    created_time       timestamp default (now() at time zone 'utc') not null,
+    expires_time       timestamp                                   not null,
+    used_time          timestamp,
+    revoked_time       timestamp
+);
+
+CREATE INDEX sessions_family_id_idx ON sessions (family_id);
+CREATE INDEX sessions_user_id_idx ON sessions (user_id);
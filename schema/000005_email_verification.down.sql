@@ -0,0 +1,4 @@
+DROP INDEX users_email_validation_token_idx;
+
+ALTER TABLE users
+    DROP COLUMN email_validation_sent_time;
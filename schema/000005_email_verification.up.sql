@@ -0,0 +1,4 @@
+ALTER TABLE users
+    ADD COLUMN email_validation_sent_time timestamp;
+
+CREATE INDEX users_email_validation_token_idx ON users (email_validation_token);
@@ -0,0 +1,11 @@
+CREATE TABLE password_resets
+(
+    id           serial primary key,
+    user_id      int references users (id) on delete cascade not null,
+    token_hash   varchar(64)                                 not null unique,
+    created_time timestamp default (now() at time zone 'utc') not null,
+    expires_time timestamp                                   not null,
+    used_time    timestamp
+);
+
+CREATE INDEX password_resets_user_id_idx ON password_resets (user_id);
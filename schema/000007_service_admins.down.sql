@@ -0,0 +1,4 @@
+ALTER TABLE users
+    DROP COLUMN locked_time;
+
+DROP TABLE service_admins;
@@ -0,0 +1,9 @@
+CREATE TABLE service_admins
+(
+    id           serial primary key,
+    user_id      int references users (id) on delete cascade   not null unique,
+    created_time timestamp default (now() at time zone 'utc') not null
+);
+
+ALTER TABLE users
+    ADD COLUMN locked_time timestamp;
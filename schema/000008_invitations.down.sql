@@ -0,0 +1,3 @@
+DROP TABLE invitations;
+
+DROP TYPE invitation_statuses;
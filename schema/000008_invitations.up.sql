@@ -0,0 +1,18 @@
+CREATE TYPE invitation_statuses as enum ('pending', 'accepted', 'declined');
+
+CREATE TABLE invitations
+(
+    id            serial primary key,
+    department_id int references departments (id) on delete cascade not null,
+    -- user_id is null for invitation of not registered user, it is set on sign up with invited email
+    user_id       int references users (id) on delete cascade,
+    email         varchar(255),
+    invited_by    int references users (id) on delete set null,
+    status        invitation_statuses default 'pending'              not null,
+    created_time  timestamp default (now() at time zone 'utc')       not null,
+    expires_time  timestamp                                          not null,
+    CHECK (user_id IS NOT NULL OR email IS NOT NULL)
+);
+
+CREATE INDEX invitations_user_id_idx ON invitations (user_id);
+CREATE INDEX invitations_email_idx ON invitations (lower(email));
@@ -0,0 +1,3 @@
+DROP TABLE join_requests;
+
+DROP TYPE join_request_statuses;
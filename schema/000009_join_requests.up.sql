@@ -0,0 +1,17 @@
+CREATE TYPE join_request_statuses as enum ('pending', 'approved', 'rejected', 'cancelled');
+
+CREATE TABLE join_requests
+(
+    id            serial primary key,
+    user_id       int references users (id) on delete cascade       not null,
+    department_id int references departments (id) on delete cascade not null,
+    message       varchar(255),
+    status        join_request_statuses default 'pending'           not null,
+    created_time  timestamp default (now() at time zone 'utc')      not null,
+    answered_by   int                                               references users (id) on delete set null,
+    answered_time timestamp
+);
+
+-- user can have only one pending request to department
+CREATE UNIQUE INDEX join_requests_pending_idx ON join_requests (user_id, department_id) WHERE status = 'pending';
+CREATE INDEX join_requests_department_id_idx ON join_requests (department_id);
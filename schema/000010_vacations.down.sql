@@ -0,0 +1,7 @@
+DROP INDEX vacations_user_id_idx;
+
+ALTER TABLE vacations
+    DROP CONSTRAINT vacations_time_check,
+    DROP COLUMN comment,
+    ALTER COLUMN vacation_time_start DROP NOT NULL,
+    ALTER COLUMN vacation_time_end DROP NOT NULL;
@@ -0,0 +1,14 @@
+-- vacations weren't used before, rows without time can't be shown in calendar
+DELETE
+FROM vacations
+WHERE vacation_time_start IS NULL
+   OR vacation_time_end IS NULL
+   OR vacation_time_end <= vacation_time_start;
+
+ALTER TABLE vacations
+    ALTER COLUMN vacation_time_start SET NOT NULL,
+    ALTER COLUMN vacation_time_end SET NOT NULL,
+    ADD COLUMN comment varchar(255),
+    ADD CONSTRAINT vacations_time_check CHECK (vacation_time_end > vacation_time_start);
+
+CREATE INDEX vacations_user_id_idx ON vacations (user_id, vacation_time_start);
@@ -0,0 +1,3 @@
+DROP TABLE stock_movements;
+
+DROP TYPE stock_movement_types;
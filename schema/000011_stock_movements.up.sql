@@ -0,0 +1,33 @@
+CREATE TYPE stock_movement_types as enum ('receipt', 'consumption', 'adjustment', 'write_off');
+
+-- ledger of consumables stock, things.thing_remainder is remainder after last movement
+CREATE TABLE stock_movements
+(
+    id            serial primary key,
+    thing_id      int references things (id) on delete cascade not null,
+    movement_type stock_movement_types                         not null,
+    quantity      real                                         not null,
+    remainder     real                                         not null,
+    usage_id      int                                          references using_things (id) on delete set null,
+    user_id       int                                          references users (id) on delete set null,
+    comment       varchar(255),
+    created_time  timestamp default (now() at time zone 'utc') not null,
+    CHECK (remainder >= 0)
+);
+
+CREATE INDEX stock_movements_thing_id_idx ON stock_movements (thing_id, id);
+-- consumption is recorded once on usage return
+CREATE UNIQUE INDEX stock_movements_usage_id_idx ON stock_movements (usage_id) WHERE movement_type = 'consumption';
+
+UPDATE things
+SET thing_remainder = 0
+WHERE thing_type = 'consumables'
+  AND (thing_remainder IS NULL OR thing_remainder < 0);
+
+-- opening balance of existing consumables
+INSERT INTO stock_movements
+    (thing_id, movement_type, quantity, remainder, comment)
+SELECT id, 'adjustment', thing_remainder, thing_remainder, 'opening balance'
+FROM things
+WHERE thing_type = 'consumables'
+  AND thing_remainder > 0;
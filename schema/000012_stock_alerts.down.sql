@@ -0,0 +1,5 @@
+DROP TABLE stock_alerts;
+
+ALTER TABLE things
+    DROP CONSTRAINT things_min_remainder_check,
+    DROP COLUMN min_remainder;
@@ -0,0 +1,16 @@
+-- low stock threshold of consumable in units of remainder type, 0 disables alerts
+ALTER TABLE things
+    ADD COLUMN min_remainder real default 0 not null,
+    ADD CONSTRAINT things_min_remainder_check CHECK (min_remainder >= 0);
+
+-- alert is open while remainder of consumable is below threshold
+CREATE TABLE stock_alerts
+(
+    id            serial primary key,
+    thing_id      int references things (id) on delete cascade not null,
+    created_time  timestamp default (now() at time zone 'utc') not null,
+    resolved_time timestamp
+);
+
+-- thing can have only one open alert
+CREATE UNIQUE INDEX stock_alerts_open_idx ON stock_alerts (thing_id) WHERE resolved_time IS NULL;
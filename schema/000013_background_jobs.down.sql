@@ -0,0 +1,6 @@
+DROP INDEX using_things_status_end_time_idx;
+
+ALTER TABLE using_things
+    DROP COLUMN is_overdue;
+
+DROP TABLE job_runs;
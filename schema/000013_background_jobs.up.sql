@@ -0,0 +1,12 @@
+-- last successful run of background job, replicas claim run of job under advisory lock
+CREATE TABLE job_runs
+(
+    job_name      varchar(255) primary key,
+    last_run_time timestamp not null
+);
+
+-- taken usage is overdue when end time passed without return
+ALTER TABLE using_things
+    ADD COLUMN is_overdue boolean default false not null;
+
+CREATE INDEX using_things_status_end_time_idx ON using_things (status, end_time);
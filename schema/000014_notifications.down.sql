@@ -0,0 +1,5 @@
+DROP TABLE notification_preferences;
+
+DROP TYPE notification_channels;
+
+DROP TABLE notifications;
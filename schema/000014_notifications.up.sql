@@ -0,0 +1,26 @@
+CREATE TABLE notifications
+(
+    id                serial primary key,
+    user_id           int references users (id) on delete cascade  not null,
+    notification_type varchar(64)                                  not null,
+    title             varchar(255)                                 not null,
+    body              text                                         not null,
+    object_id         int,
+    is_read           boolean default false                        not null,
+    created_time      timestamp default (now() at time zone 'utc') not null
+);
+
+CREATE INDEX notifications_user_id_idx ON notifications (user_id, id);
+CREATE INDEX notifications_unread_idx ON notifications (user_id) WHERE NOT is_read;
+
+-- in-app inbox is always on, other channels are delivered only if enabled by user
+CREATE TYPE notification_channels as enum ('email', 'webhook');
+
+CREATE TABLE notification_preferences
+(
+    user_id     int references users (id) on delete cascade not null,
+    channel     notification_channels                       not null,
+    enabled     boolean                                     not null,
+    webhook_url varchar(2048),
+    primary key (user_id, channel)
+);
@@ -0,0 +1,33 @@
+CREATE TABLE webhooks
+(
+    id           serial primary key,
+    company_id   int references companies (id) on delete cascade not null,
+    url          varchar(2048)                                   not null,
+    secret       varchar(255)                                    not null,
+    event_types  varchar(64)[]                                   not null,
+    is_active    boolean   default true                          not null,
+    created_time timestamp default (now() at time zone 'utc')    not null
+);
+
+CREATE INDEX webhooks_company_id_idx ON webhooks (company_id);
+
+CREATE TYPE webhook_delivery_statuses as enum ('pending', 'delivered', 'failed');
+
+-- delivery queue and log, pending deliveries are sent by background job until success or last attempt
+CREATE TABLE webhook_deliveries
+(
+    id                serial primary key,
+    webhook_id        int references webhooks (id) on delete cascade not null,
+    event_type        varchar(64)                                   not null,
+    payload           jsonb                                         not null,
+    status            webhook_delivery_statuses default 'pending'   not null,
+    attempts          int                       default 0           not null,
+    last_status_code  int,
+    last_error        text,
+    next_attempt_time timestamp default (now() at time zone 'utc')  not null,
+    delivered_time    timestamp,
+    created_time      timestamp default (now() at time zone 'utc')  not null
+);
+
+CREATE INDEX webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id, id);
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_time) WHERE status = 'pending';
@@ -0,0 +1,3 @@
+-- hash of secret token from invitation email, invitation by email is accepted on sign up only with this token
+ALTER TABLE invitations
+    ADD COLUMN token_hash varchar(64) unique;